@@ -239,7 +239,8 @@ public class KubeHoundTraversalSourceDsl extends GraphTraversalSource {
 
     /**
      * Starts a traversal that finds all vertices representing volume host mounts
-     * and optionally allows filtering of those
+     * (including persistent volume claims bound to a hostPath or local persistent
+     * volume) and optionally allows filtering of those
      * vertices on the "sourcePath" property.
      *
      * @param sourcePaths list of host source paths to filter on
@@ -249,7 +250,9 @@ public class KubeHoundTraversalSourceDsl extends GraphTraversalSource {
 
         traversal = traversal
                 .has("class", "Volume")
-                .has("type", "HostPath");
+                .or(
+                        __.has("type", "HostPath"),
+                        __.has("type", "PersistentVolumeClaim").has("backingType", P.within("HostPath", "Local")));
 
         if (sourcePaths.length > 0) {
             traversal = traversal.has("sourcePath", P.within(sourcePaths));
@@ -20,6 +20,11 @@ pod = mgmt.makeVertexLabel('Pod').make();
 permissionSet = mgmt.makeVertexLabel('PermissionSet').make();
 volume = mgmt.makeVertexLabel('Volume').make();
 endpoint = mgmt.makeVertexLabel('Endpoint').make();
+secret = mgmt.makeVertexLabel('Secret').make();
+workload = mgmt.makeVertexLabel('Workload').make();
+webhook = mgmt.makeVertexLabel('Webhook').make();
+cloudIdentity = mgmt.makeVertexLabel('CloudIdentity').make();
+namespaceVertex = mgmt.makeVertexLabel('Namespace').make();
 
 // Create our edge labels and connections
 permissionDiscover = mgmt.makeEdgeLabel('PERMISSION_DISCOVER').multiplicity(MULTI).make();
@@ -28,6 +33,9 @@ mgmt.addConnection(permissionDiscover, identity, permissionSet);
 volumeDiscover = mgmt.makeEdgeLabel('VOLUME_DISCOVER').multiplicity(MULTI).make();
 mgmt.addConnection(volumeDiscover, container, volume);
 
+namespaceDiscover = mgmt.makeEdgeLabel('NAMESPACE_DISCOVER').multiplicity(MULTI).make();
+mgmt.addConnection(namespaceDiscover, pod, namespaceVertex);
+
 volumeAccess = mgmt.makeEdgeLabel('VOLUME_ACCESS').multiplicity(MULTI).make();
 mgmt.addConnection(volumeAccess, node, volume);
 
@@ -43,6 +51,9 @@ mgmt.addConnection(hostTraverse, volume, volume);
 sharedPsNamespace = mgmt.makeEdgeLabel('SHARE_PS_NAMESPACE').multiplicity(MULTI).make();
 mgmt.addConnection(sharedPsNamespace, container, container);
 
+volumeShareWrite = mgmt.makeEdgeLabel('VOLUME_SHARE_WRITE').multiplicity(MULTI).make();
+mgmt.addConnection(volumeShareWrite, container, container);
+
 containerAttach = mgmt.makeEdgeLabel('CONTAINER_ATTACH').multiplicity(ONE2MANY).make();
 mgmt.addConnection(containerAttach, pod, container);
 
@@ -50,12 +61,19 @@ idAssume = mgmt.makeEdgeLabel('IDENTITY_ASSUME').multiplicity(MANY2ONE).make();
 mgmt.addConnection(idAssume, container, identity);
 mgmt.addConnection(idAssume, node, identity);
 
-idImpersonate = mgmt.makeEdgeLabel('IDENTITY_IMPERSONATE').multiplicity(MANY2ONE).make();
+idImpersonate = mgmt.makeEdgeLabel('IDENTITY_IMPERSONATE').multiplicity(MULTI).make();
 mgmt.addConnection(idImpersonate, permissionSet, identity);
 
+idFederate = mgmt.makeEdgeLabel('IDENTITY_FEDERATE').multiplicity(MULTI).make();
+mgmt.addConnection(idFederate, identity, cloudIdentity);
+mgmt.addConnection(idFederate, node, cloudIdentity);
+
 roleBind = mgmt.makeEdgeLabel('ROLE_BIND').multiplicity(MULTI).make();
 mgmt.addConnection(roleBind, permissionSet, permissionSet);
 
+roleEscalate = mgmt.makeEdgeLabel('ROLE_ESCALATE').multiplicity(MULTI).make();
+mgmt.addConnection(roleEscalate, permissionSet, permissionSet);
+
 podAttach = mgmt.makeEdgeLabel('POD_ATTACH').multiplicity(ONE2MANY).make();
 mgmt.addConnection(podAttach, node, pod);
 
@@ -72,6 +90,13 @@ podExec = mgmt.makeEdgeLabel('POD_EXEC').multiplicity(MULTI).make();
 mgmt.addConnection(podExec, permissionSet, pod);
 mgmt.addConnection(podExec, permissionSet, permissionSet); // self-referencing for large cluster optimizations
 
+podDebug = mgmt.makeEdgeLabel('POD_DEBUG').multiplicity(MULTI).make();
+mgmt.addConnection(podDebug, permissionSet, pod);
+mgmt.addConnection(podDebug, permissionSet, permissionSet); // self-referencing for large cluster optimizations
+
+nodeProxyExec = mgmt.makeEdgeLabel('NODE_PROXY_EXEC').multiplicity(MULTI).make();
+mgmt.addConnection(nodeProxyExec, permissionSet, node);
+
 tokenSteal = mgmt.makeEdgeLabel('TOKEN_STEAL').multiplicity(MULTI).make();
 mgmt.addConnection(tokenSteal, volume, identity);
 
@@ -81,6 +106,9 @@ mgmt.addConnection(tokenBruteforce, permissionSet, identity);
 tokenList = mgmt.makeEdgeLabel('TOKEN_LIST').multiplicity(MULTI).make();
 mgmt.addConnection(tokenList, permissionSet, identity);
 
+csrIssue = mgmt.makeEdgeLabel('CSR_ISSUE').multiplicity(MULTI).make();
+mgmt.addConnection(csrIssue, permissionSet, identity);
+
 nsenter = mgmt.makeEdgeLabel('CE_NSENTER').multiplicity(MANY2ONE).make();
 mgmt.addConnection(nsenter, container, node);
 
@@ -93,15 +121,57 @@ mgmt.addConnection(umhCorePattern, container, node);
 privMount = mgmt.makeEdgeLabel('CE_PRIV_MOUNT').multiplicity(MANY2ONE).make();
 mgmt.addConnection(privMount, container, node);
 
+runtimeSocket = mgmt.makeEdgeLabel('CE_RUNTIME_SOCKET').multiplicity(MANY2ONE).make();
+mgmt.addConnection(runtimeSocket, container, node);
+
 sysPtrace = mgmt.makeEdgeLabel('CE_SYS_PTRACE').multiplicity(MANY2ONE).make();
 mgmt.addConnection(sysPtrace, container, node);
 
+dacReadSearch = mgmt.makeEdgeLabel('CE_DAC_READ_SEARCH').multiplicity(MANY2ONE).make();
+mgmt.addConnection(dacReadSearch, container, node);
+
+cgroupReleaseAgent = mgmt.makeEdgeLabel('CE_CGROUP_RELEASE_AGENT').multiplicity(MANY2ONE).make();
+mgmt.addConnection(cgroupReleaseAgent, container, node);
+
+bpf = mgmt.makeEdgeLabel('CE_BPF').multiplicity(MANY2ONE).make();
+mgmt.addConnection(bpf, container, node);
+
+sysRawio = mgmt.makeEdgeLabel('CE_SYS_RAWIO').multiplicity(MANY2ONE).make();
+mgmt.addConnection(sysRawio, container, node);
+
 varLogSymLink = mgmt.makeEdgeLabel('CE_VAR_LOG_SYMLINK').multiplicity(MULTI).make();
 mgmt.addConnection(varLogSymLink, container, node);
 
 endpointExploit = mgmt.makeEdgeLabel('ENDPOINT_EXPLOIT').multiplicity(MULTI).make();
 mgmt.addConnection(endpointExploit, endpoint, container);
 
+secretMount = mgmt.makeEdgeLabel('SECRET_MOUNT').multiplicity(MANY2ONE).make();
+mgmt.addConnection(secretMount, volume, secret);
+
+secretRead = mgmt.makeEdgeLabel('SECRET_READ').multiplicity(MULTI).make();
+mgmt.addConnection(secretRead, permissionSet, secret);
+
+workloadSpawn = mgmt.makeEdgeLabel('WORKLOAD_SPAWN').multiplicity(ONE2MANY).make();
+mgmt.addConnection(workloadSpawn, workload, pod);
+mgmt.addConnection(workloadSpawn, workload, workload);
+
+workloadCreate = mgmt.makeEdgeLabel('WORKLOAD_CREATE').multiplicity(MULTI).make();
+mgmt.addConnection(workloadCreate, permissionSet, node);
+mgmt.addConnection(workloadCreate, permissionSet, permissionSet); // self-referencing for large cluster optimizations
+
+workloadPatch = mgmt.makeEdgeLabel('WORKLOAD_PATCH').multiplicity(MULTI).make();
+mgmt.addConnection(workloadPatch, permissionSet, workload);
+
+webhookInject = mgmt.makeEdgeLabel('WEBHOOK_INJECT').multiplicity(MULTI).make();
+mgmt.addConnection(webhookInject, permissionSet, pod);
+mgmt.addConnection(webhookInject, webhook, pod);
+
+webhookHijack = mgmt.makeEdgeLabel('WEBHOOK_HIJACK').multiplicity(MULTI).make();
+mgmt.addConnection(webhookHijack, endpoint, webhook);
+
+namespacePatch = mgmt.makeEdgeLabel('NAMESPACE_PATCH').multiplicity(MULTI).make();
+mgmt.addConnection(namespacePatch, permissionSet, namespaceVertex);
+
 // All properties we will index on
 cls = mgmt.makePropertyKey('class').dataType(String.class).cardinality(Cardinality.SINGLE).make();
 cluster = mgmt.makePropertyKey('cluster').dataType(String.class).cardinality(Cardinality.SINGLE).make();
@@ -114,6 +184,7 @@ name = mgmt.makePropertyKey('name').dataType(String.class).cardinality(Cardinali
 namespace = mgmt.makePropertyKey('namespace').dataType(String.class).cardinality(Cardinality.SINGLE).make();
 type = mgmt.makePropertyKey('type').dataType(String.class).cardinality(Cardinality.SINGLE).make();
 critical = mgmt.makePropertyKey('critical').dataType(Boolean.class).cardinality(Cardinality.SINGLE).make();
+criticalRule = mgmt.makePropertyKey('criticalRule').dataType(String.class).cardinality(Cardinality.SINGLE).make();
 port = mgmt.makePropertyKey('port').dataType(Integer.class).cardinality(Cardinality.SINGLE).make();
 portName = mgmt.makePropertyKey('portName').dataType(String.class).cardinality(Cardinality.SINGLE).make();
 serviceEndpoint = mgmt.makePropertyKey('serviceEndpoint').dataType(String.class).cardinality(Cardinality.SINGLE).make();
@@ -126,6 +197,9 @@ compromised = mgmt.makePropertyKey('compromised').dataType(Integer.class).cardin
 sourcePath = mgmt.makePropertyKey('sourcePath').dataType(String.class).cardinality(Cardinality.SINGLE).make();
 mountPath = mgmt.makePropertyKey('mountPath').dataType(String.class).cardinality(Cardinality.SINGLE).make();
 readonly = mgmt.makePropertyKey('readonly').dataType(Boolean.class).cardinality(Cardinality.SINGLE).make();
+mountReason = mgmt.makePropertyKey('mountReason').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+claimName = mgmt.makePropertyKey('claimName').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+backingType = mgmt.makePropertyKey('backingType').dataType(String.class).cardinality(Cardinality.SINGLE).make();
 nodeName = mgmt.makePropertyKey('node').dataType(String.class).cardinality(Cardinality.SINGLE).make();
 sharedPs = mgmt.makePropertyKey('shareProcessNamespace').dataType(Boolean.class).cardinality(Cardinality.SINGLE).make();
 serviceAccount = mgmt.makePropertyKey('serviceAccount').dataType(String.class).cardinality(Cardinality.SINGLE).make();
@@ -137,7 +211,10 @@ hostIpc = mgmt.makePropertyKey('hostIpc').dataType(Boolean.class).cardinality(Ca
 privesc = mgmt.makePropertyKey('privesc').dataType(Boolean.class).cardinality(Cardinality.SINGLE).make();
 privileged = mgmt.makePropertyKey('privileged').dataType(Boolean.class).cardinality(Cardinality.SINGLE).make();
 runAsUser = mgmt.makePropertyKey('runAsUser').dataType(Long.class).cardinality(Cardinality.SINGLE).make();
+initContainer = mgmt.makePropertyKey('init').dataType(Boolean.class).cardinality(Cardinality.SINGLE).make();
+ephemeral = mgmt.makePropertyKey('ephemeral').dataType(Boolean.class).cardinality(Cardinality.SINGLE).make();
 rules = mgmt.makePropertyKey('rules').dataType(String.class).cardinality(Cardinality.LIST).make();
+aggregatedFrom = mgmt.makePropertyKey('aggregatedFrom').dataType(String.class).cardinality(Cardinality.LIST).make();
 command = mgmt.makePropertyKey('command').dataType(String.class).cardinality(Cardinality.LIST).make();
 args = mgmt.makePropertyKey('args').dataType(String.class).cardinality(Cardinality.LIST).make();
 capabilities = mgmt.makePropertyKey('capabilities').dataType(String.class).cardinality(Cardinality.LIST).make();
@@ -148,46 +225,91 @@ addresses = mgmt.makePropertyKey('addresses').dataType(String.class).cardinality
 protocol = mgmt.makePropertyKey('protocol').dataType(String.class).cardinality(Cardinality.SINGLE).make();
 role = mgmt.makePropertyKey('role').dataType(String.class).cardinality(Cardinality.SINGLE).make();
 roleBinding = mgmt.makePropertyKey('roleBinding').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+automount = mgmt.makePropertyKey('automount').dataType(Boolean.class).cardinality(Cardinality.SINGLE).make();
+imagePullSecrets = mgmt.makePropertyKey('imagePullSecrets').dataType(String.class).cardinality(Cardinality.LIST).make();
+tokenSecrets = mgmt.makePropertyKey('tokenSecrets').dataType(String.class).cardinality(Cardinality.LIST).make();
+kind = mgmt.makePropertyKey('kind').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+configuration = mgmt.makePropertyKey('configuration').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+failurePolicy = mgmt.makePropertyKey('failurePolicy').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+serviceNamespace = mgmt.makePropertyKey('serviceNamespace').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+serviceName = mgmt.makePropertyKey('serviceName').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+url = mgmt.makePropertyKey('url').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+resources = mgmt.makePropertyKey('resources').dataType(String.class).cardinality(Cardinality.LIST).make();
+operations = mgmt.makePropertyKey('operations').dataType(String.class).cardinality(Cardinality.LIST).make();
+provider = mgmt.makePropertyKey('provider').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+account = mgmt.makePropertyKey('account').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+psaEnforce = mgmt.makePropertyKey('psaEnforce').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+psaEnforceVersion = mgmt.makePropertyKey('psaEnforceVersion').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+psaAudit = mgmt.makePropertyKey('psaAudit').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+psaWarn = mgmt.makePropertyKey('psaWarn').dataType(String.class).cardinality(Cardinality.SINGLE).make();
 
 // All edge properties
 attckTechniqueID = mgmt.makePropertyKey('attckTechniqueID').dataType(String.class).cardinality(Cardinality.SINGLE).make();
 attckTacticID = mgmt.makePropertyKey('attckTacticID').dataType(String.class).cardinality(Cardinality.SINGLE).make();
+resourceScoped = mgmt.makePropertyKey('resourceScoped').dataType(Boolean.class).cardinality(Cardinality.SINGLE).make();
+networkPolicyBlocked = mgmt.makePropertyKey('networkPolicyBlocked').dataType(Boolean.class).cardinality(Cardinality.SINGLE).make();
+signerName = mgmt.makePropertyKey('signerName').dataType(String.class).cardinality(Cardinality.SINGLE).make();
 
 // Define properties for each vertex 
-mgmt.addProperties(container, cls, cluster, runID, storeID, app, team, service, isNamespaced, namespace, name, image, privileged, privesc, hostPid, hostIpc, hostNetwork, runAsUser, podName, nodeName, compromised, command, args, capabilities, ports);
-mgmt.addProperties(identity, cls, cluster, runID, storeID, app, team, service, name, isNamespaced, namespace, type, critical);
-mgmt.addProperties(node, cls, cluster, runID, storeID, app, team, service, name, isNamespaced, namespace, compromised, critical);
-mgmt.addProperties(pod, cls, cluster, runID, storeID, app, team, service, name, isNamespaced, namespace, sharedPs, serviceAccount, nodeName, compromised, critical);
-mgmt.addProperties(permissionSet, cls, cluster, runID, storeID, app, team, service, name, isNamespaced, namespace, role, roleBinding, rules, critical);
-mgmt.addProperties(volume, cls, cluster, runID, storeID, app, team, service, name, isNamespaced, namespace, type, sourcePath, mountPath, readonly);
+mgmt.addProperties(container, cls, cluster, runID, storeID, app, team, service, isNamespaced, namespace, name, image, privileged, privesc, hostPid, hostIpc, hostNetwork, runAsUser, podName, nodeName, initContainer, ephemeral, compromised, command, args, capabilities, ports);
+mgmt.addProperties(identity, cls, cluster, runID, storeID, app, team, service, name, isNamespaced, namespace, type, critical, criticalRule, automount, imagePullSecrets, tokenSecrets);
+mgmt.addProperties(node, cls, cluster, runID, storeID, app, team, service, name, isNamespaced, namespace, compromised, critical, criticalRule);
+mgmt.addProperties(pod, cls, cluster, runID, storeID, app, team, service, name, isNamespaced, namespace, sharedPs, serviceAccount, nodeName, compromised, critical, criticalRule);
+mgmt.addProperties(permissionSet, cls, cluster, runID, storeID, app, team, service, name, isNamespaced, namespace, role, roleBinding, rules, aggregatedFrom, critical, criticalRule);
+mgmt.addProperties(volume, cls, cluster, runID, storeID, app, team, service, name, isNamespaced, namespace, type, sourcePath, mountPath, readonly, mountReason, claimName, backingType);
 mgmt.addProperties(endpoint, cls, cluster, runID, storeID, app, team, service, name, isNamespaced, namespace, serviceEndpoint, serviceDns, addressType, addresses, port, portName, protocol, exposure, compromised);
+mgmt.addProperties(secret, cls, cluster, runID, storeID, app, team, service, name, isNamespaced, namespace, type, serviceAccount);
+mgmt.addProperties(workload, cls, cluster, runID, storeID, app, team, service, name, isNamespaced, namespace, kind, serviceAccount);
+mgmt.addProperties(webhook, cls, cluster, runID, storeID, app, team, service, name, isNamespaced, configuration, type, failurePolicy, serviceNamespace, serviceName, url, resources, operations);
+mgmt.addProperties(cloudIdentity, cls, cluster, runID, storeID, app, team, service, name, isNamespaced, provider, type, account);
+mgmt.addProperties(namespaceVertex, cls, cluster, runID, storeID, app, team, service, name, isNamespaced, psaEnforce, psaEnforceVersion, psaAudit, psaWarn);
 
 // Define properties for each edge
 mgmt.addProperties(permissionDiscover, runID, attckTechniqueID, attckTacticID);
 mgmt.addProperties(volumeDiscover, runID, attckTechniqueID, attckTacticID);
+mgmt.addProperties(namespaceDiscover, runID, attckTechniqueID, attckTacticID);
 mgmt.addProperties(volumeAccess, runID, attckTechniqueID, attckTacticID);
 mgmt.addProperties(hostWrite, runID, attckTechniqueID, attckTacticID);
-mgmt.addProperties(hostRead, runID, attckTechniqueID, attckTacticID);
+mgmt.addProperties(hostRead, runID, attckTechniqueID, attckTacticID, mountReason);
 mgmt.addProperties(hostTraverse, runID, attckTechniqueID, attckTacticID);
 mgmt.addProperties(sharedPsNamespace, runID, attckTechniqueID, attckTacticID);
+mgmt.addProperties(volumeShareWrite, runID, attckTechniqueID, attckTacticID);
 mgmt.addProperties(containerAttach, runID, attckTechniqueID, attckTacticID);
 mgmt.addProperties(idAssume, runID, attckTechniqueID, attckTacticID);
-mgmt.addProperties(idImpersonate, runID, attckTechniqueID, attckTacticID);
+mgmt.addProperties(idImpersonate, runID, attckTechniqueID, attckTacticID, resourceScoped);
+mgmt.addProperties(idFederate, runID, attckTechniqueID, attckTacticID);
 mgmt.addProperties(roleBind, runID, attckTechniqueID, attckTacticID);
+mgmt.addProperties(roleEscalate, runID, attckTechniqueID, attckTacticID);
 mgmt.addProperties(podAttach, runID, attckTechniqueID, attckTacticID);
 mgmt.addProperties(podCreate, runID, attckTechniqueID, attckTacticID);
-mgmt.addProperties(podPatch, runID, attckTechniqueID, attckTacticID);
-mgmt.addProperties(podExec, runID, attckTechniqueID, attckTacticID);
+mgmt.addProperties(podPatch, runID, attckTechniqueID, attckTacticID, resourceScoped, psaEnforce);
+mgmt.addProperties(podExec, runID, attckTechniqueID, attckTacticID, resourceScoped);
+mgmt.addProperties(podDebug, runID, attckTechniqueID, attckTacticID, resourceScoped);
+mgmt.addProperties(nodeProxyExec, runID, attckTechniqueID, attckTacticID, resourceScoped);
 mgmt.addProperties(tokenSteal, runID, attckTechniqueID, attckTacticID);
-mgmt.addProperties(tokenBruteforce, runID, attckTechniqueID, attckTacticID);
-mgmt.addProperties(tokenList, runID, attckTechniqueID, attckTacticID);
+mgmt.addProperties(tokenBruteforce, runID, attckTechniqueID, attckTacticID, resourceScoped);
+mgmt.addProperties(tokenList, runID, attckTechniqueID, attckTacticID, resourceScoped);
+mgmt.addProperties(csrIssue, runID, attckTechniqueID, attckTacticID, signerName);
 mgmt.addProperties(nsenter, runID, attckTechniqueID, attckTacticID);
 mgmt.addProperties(moduleLoad, runID, attckTechniqueID, attckTacticID);
 mgmt.addProperties(umhCorePattern, runID, attckTechniqueID, attckTacticID);
 mgmt.addProperties(privMount, runID, attckTechniqueID, attckTacticID);
+mgmt.addProperties(runtimeSocket, runID, attckTechniqueID, attckTacticID, mountReason);
 mgmt.addProperties(sysPtrace, runID, attckTechniqueID, attckTacticID);
-mgmt.addProperties(varLogSymLink, runID, attckTechniqueID, attckTacticID);
-mgmt.addProperties(endpointExploit, runID, attckTechniqueID, attckTacticID);
+mgmt.addProperties(dacReadSearch, runID, attckTechniqueID, attckTacticID);
+mgmt.addProperties(cgroupReleaseAgent, runID, attckTechniqueID, attckTacticID);
+mgmt.addProperties(bpf, runID, attckTechniqueID, attckTacticID);
+mgmt.addProperties(sysRawio, runID, attckTechniqueID, attckTacticID);
+mgmt.addProperties(varLogSymLink, runID, attckTechniqueID, attckTacticID, resourceScoped);
+mgmt.addProperties(endpointExploit, runID, attckTechniqueID, attckTacticID, networkPolicyBlocked);
+mgmt.addProperties(secretMount, runID, attckTechniqueID, attckTacticID);
+mgmt.addProperties(secretRead, runID, attckTechniqueID, attckTacticID, resourceScoped);
+mgmt.addProperties(workloadSpawn, runID, attckTechniqueID, attckTacticID);
+mgmt.addProperties(workloadCreate, runID, attckTechniqueID, attckTacticID);
+mgmt.addProperties(workloadPatch, runID, attckTechniqueID, attckTacticID, resourceScoped);
+mgmt.addProperties(webhookInject, runID, attckTechniqueID, attckTacticID, resourceScoped);
+mgmt.addProperties(webhookHijack, runID, attckTechniqueID, attckTacticID);
+mgmt.addProperties(namespacePatch, runID, attckTechniqueID, attckTacticID, resourceScoped);
 
 // Create the indexes on vertex properties
 // NOTE: labels cannot be indexed so we create the class property to mirror the vertex label and allow indexing
@@ -22,12 +22,14 @@ require (
 	github.com/docker/docker v28.5.1+incompatible
 	github.com/go-playground/validator/v10 v10.25.0
 	github.com/hashicorp/go-multierror v1.1.1
+	github.com/neo4j/neo4j-go-driver/v5 v5.28.4
 	github.com/oklog/ulid/v2 v2.1.0
 	github.com/pkg/errors v0.9.1
 	github.com/spf13/afero v1.12.0
 	github.com/spf13/cobra v1.10.1
 	github.com/spf13/viper v1.19.0
 	github.com/stretchr/testify v1.11.1
+	go.etcd.io/bbolt v1.4.3
 	go.mongodb.org/mongo-driver v1.17.3
 	go.uber.org/ratelimit v0.3.1
 	go.uber.org/zap v1.27.0
@@ -9,6 +9,7 @@ import (
 	"github.com/DataDog/KubeHound/pkg/globals/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/services"
 	"github.com/DataDog/KubeHound/pkg/telemetry/tag"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // Generic interface to allow an ingestor to consume stream inputs from a collector.
@@ -20,6 +21,13 @@ type GenericIngestor interface {
 	RoleBindingIngestor
 	ClusterRoleBindingIngestor
 	EndpointIngestor
+	SecretIngestor
+	ServiceAccountIngestor
+	WorkloadIngestor
+	NetworkPolicyIngestor
+	PersistentVolumeIngestor
+	WebhookIngestor
+	NamespaceIngestor
 }
 
 // NodeIngestor defines the interface to allow an ingestor to consume node inputs from a collector.
@@ -78,6 +86,73 @@ type EndpointIngestor interface {
 	Complete(context.Context) error
 }
 
+// SecretIngestor defines the interface to allow an ingestor to consume secret inputs from a collector.
+// NOTE: collectors only ever provide the secret metadata and type, secret data is never collected.
+//
+//go:generate mockery --name SecretIngestor --output mockingest --case underscore --filename secret_ingestor.go --with-expecter
+type SecretIngestor interface {
+	IngestSecret(context.Context, types.SecretType) error
+	Complete(context.Context) error
+}
+
+// ServiceAccountIngestor defines the interface to allow an ingestor to consume service account inputs from a collector.
+//
+//go:generate mockery --name ServiceAccountIngestor --output mockingest --case underscore --filename service_account_ingestor.go --with-expecter
+type ServiceAccountIngestor interface {
+	IngestServiceAccount(context.Context, types.ServiceAccountType) error
+	Complete(context.Context) error
+}
+
+// WorkloadIngestor defines the interface to allow an ingestor to consume workload controller inputs from a collector.
+// Workload controllers (deployments, daemon sets, stateful sets, jobs and cron jobs) are streamed together as they all
+// end up as workload vertices in the graph.
+//
+//go:generate mockery --name WorkloadIngestor --output mockingest --case underscore --filename workload_ingestor.go --with-expecter
+type WorkloadIngestor interface {
+	IngestDeployment(context.Context, types.DeploymentType) error
+	IngestDaemonSet(context.Context, types.DaemonSetType) error
+	IngestStatefulSet(context.Context, types.StatefulSetType) error
+	IngestJob(context.Context, types.JobType) error
+	IngestCronJob(context.Context, types.CronJobType) error
+	Complete(context.Context) error
+}
+
+// NetworkPolicyIngestor defines the interface to allow an ingestor to consume network policy inputs from a collector.
+//
+//go:generate mockery --name NetworkPolicyIngestor --output mockingest --case underscore --filename network_policy_ingestor.go --with-expecter
+type NetworkPolicyIngestor interface {
+	IngestNetworkPolicy(context.Context, types.NetworkPolicyType) error
+	Complete(context.Context) error
+}
+
+// PersistentVolumeIngestor defines the interface to allow an ingestor to consume persistent volume and persistent volume claim
+// inputs from a collector. Both are streamed together as claims are only meaningful once resolved to their bound volume.
+//
+//go:generate mockery --name PersistentVolumeIngestor --output mockingest --case underscore --filename persistent_volume_ingestor.go --with-expecter
+type PersistentVolumeIngestor interface {
+	IngestPersistentVolume(context.Context, types.PersistentVolumeType) error
+	IngestPersistentVolumeClaim(context.Context, types.PersistentVolumeClaimType) error
+	Complete(context.Context) error
+}
+
+// WebhookIngestor defines the interface to allow an ingestor to consume mutating and validating webhook configuration
+// inputs from a collector. Both are streamed together as they all end up as webhook vertices in the graph.
+//
+//go:generate mockery --name WebhookIngestor --output mockingest --case underscore --filename webhook_ingestor.go --with-expecter
+type WebhookIngestor interface {
+	IngestMutatingWebhookConfiguration(context.Context, types.MutatingWebhookConfigurationType) error
+	IngestValidatingWebhookConfiguration(context.Context, types.ValidatingWebhookConfigurationType) error
+	Complete(context.Context) error
+}
+
+// NamespaceIngestor defines the interface to allow an ingestor to consume namespace inputs from a collector.
+//
+//go:generate mockery --name NamespaceIngestor --output mockingest --case underscore --filename namespace_ingestor.go --with-expecter
+type NamespaceIngestor interface {
+	IngestNamespace(context.Context, types.NamespaceType) error
+	Complete(context.Context) error
+}
+
 // MetadataIngestor defines the interface to allow an ingestor to computed metrics and metadata from a collector.
 type MetadataIngestor interface {
 	DumpMetadata(context.Context, Metadata) error
@@ -121,6 +196,38 @@ type CollectorClient interface { //nolint: interfacebloat
 	// Once all the EndpointType objects have been exhausted the ingestor.Complete method will be invoked to signal the end of the stream.
 	StreamEndpoints(ctx context.Context, ingestor EndpointIngestor) error
 
+	// StreamSecrets will iterate through all SecretType objects collected by the collector and invoke the ingestor.IngestSecret method on each.
+	// Only the secret metadata and type are provided, secret data is stripped before the object reaches the ingestor.
+	// Once all the SecretType objects have been exhausted the ingestor.Complete method will be invoked to signal the end of the stream.
+	StreamSecrets(ctx context.Context, ingestor SecretIngestor) error
+
+	// StreamServiceAccounts will iterate through all ServiceAccountType objects collected by the collector and invoke the ingestor.IngestServiceAccount method on each.
+	// Once all the ServiceAccountType objects have been exhausted the ingestor.Complete method will be invoked to signal the end of the stream.
+	StreamServiceAccounts(ctx context.Context, ingestor ServiceAccountIngestor) error
+
+	// StreamWorkloads will iterate through all the workload controller objects (DeploymentType, DaemonSetType, StatefulSetType, JobType and CronJobType)
+	// collected by the collector and invoke the matching ingestor.IngestXXX method on each.
+	// Once all the workload objects have been exhausted the ingestor.Complete method will be invoked to signal the end of the stream.
+	StreamWorkloads(ctx context.Context, ingestor WorkloadIngestor) error
+
+	// StreamNetworkPolicies will iterate through all NetworkPolicyType objects collected by the collector and invoke the ingestor.IngestNetworkPolicy method on each.
+	// Once all the NetworkPolicyType objects have been exhausted the ingestor.Complete method will be invoked to signal the end of the stream.
+	StreamNetworkPolicies(ctx context.Context, ingestor NetworkPolicyIngestor) error
+
+	// StreamPersistentVolumes will iterate through all the PersistentVolumeType and PersistentVolumeClaimType objects collected by the collector
+	// and invoke the matching ingestor.IngestXXX method on each.
+	// Once all the objects have been exhausted the ingestor.Complete method will be invoked to signal the end of the stream.
+	StreamPersistentVolumes(ctx context.Context, ingestor PersistentVolumeIngestor) error
+
+	// StreamWebhooks will iterate through all the MutatingWebhookConfigurationType and ValidatingWebhookConfigurationType objects collected
+	// by the collector and invoke the matching ingestor.IngestXXX method on each.
+	// Once all the objects have been exhausted the ingestor.Complete method will be invoked to signal the end of the stream.
+	StreamWebhooks(ctx context.Context, ingestor WebhookIngestor) error
+
+	// StreamNamespaces will iterate through all NamespaceType objects collected by the collector and invoke the ingestor.IngestNamespace method on each.
+	// Once all the namespaces have been exhausted the ingestor.Complete method will be invoked to signal the end of the stream.
+	StreamNamespaces(ctx context.Context, ingestor NamespaceIngestor) error
+
 	// Close cleans up any resources used by the collector client implementation. Client cannot be reused after this call.
 	Close(ctx context.Context) error
 }
@@ -137,11 +244,36 @@ func ClientFactory(ctx context.Context, cfg *config.KubehoundConfig) (CollectorC
 	}
 }
 
+// secretMetadata returns a copy of the provided secret stripped of any sensitive content. Only the object metadata and
+// the secret type are retained. The last applied configuration annotation is also dropped as it can embed the secret data.
+func secretMetadata(secret *corev1.Secret) *corev1.Secret {
+	stripped := &corev1.Secret{
+		TypeMeta:   secret.TypeMeta,
+		ObjectMeta: *secret.ObjectMeta.DeepCopy(),
+		Type:       secret.Type,
+		Immutable:  secret.Immutable,
+	}
+
+	delete(stripped.Annotations, corev1.LastAppliedConfigAnnotation)
+
+	// Managed fields can also reference the secret keys and are of no use to KubeHound
+	stripped.ManagedFields = nil
+
+	return stripped
+}
+
 type collectorTags struct {
 	pod                []string
 	role               []string
 	rolebinding        []string
 	endpoint           []string
+	secret             []string
+	serviceaccount     []string
+	workload           []string
+	networkpolicy      []string
+	persistentvolume   []string
+	webhook            []string
+	namespace          []string
 	node               []string
 	clusterrole        []string
 	clusterrolebinding []string
@@ -154,6 +286,13 @@ func newCollectorTags() collectorTags {
 		role:               tag.GetBaseTagsWith(tag.Collector(FileCollectorName), tag.Entity(tag.EntityRoles)),
 		rolebinding:        tag.GetBaseTagsWith(tag.Collector(FileCollectorName), tag.Entity(tag.EntityRolebindings)),
 		endpoint:           tag.GetBaseTagsWith(tag.Collector(FileCollectorName), tag.Entity(tag.EntityEndpoints)),
+		secret:             tag.GetBaseTagsWith(tag.Collector(FileCollectorName), tag.Entity(tag.EntitySecrets)),
+		serviceaccount:     tag.GetBaseTagsWith(tag.Collector(FileCollectorName), tag.Entity(tag.EntityServiceAccounts)),
+		workload:           tag.GetBaseTagsWith(tag.Collector(FileCollectorName), tag.Entity(tag.EntityWorkloads)),
+		networkpolicy:      tag.GetBaseTagsWith(tag.Collector(FileCollectorName), tag.Entity(tag.EntityNetworkPolicies)),
+		persistentvolume:   tag.GetBaseTagsWith(tag.Collector(FileCollectorName), tag.Entity(tag.EntityPersistentVolumes)),
+		webhook:            tag.GetBaseTagsWith(tag.Collector(FileCollectorName), tag.Entity(tag.EntityWebhooks)),
+		namespace:          tag.GetBaseTagsWith(tag.Collector(FileCollectorName), tag.Entity(tag.EntityNamespaces)),
 		node:               tag.GetBaseTagsWith(tag.Collector(FileCollectorName), tag.Entity(tag.EntityNodes)),
 		clusterrole:        tag.GetBaseTagsWith(tag.Collector(FileCollectorName), tag.Entity(tag.EntityClusterRoles)),
 		clusterrolebinding: tag.GetBaseTagsWith(tag.Collector(FileCollectorName), tag.Entity(tag.EntityClusterRolebindings)),
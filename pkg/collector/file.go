@@ -17,8 +17,12 @@ import (
 	"github.com/DataDog/KubeHound/pkg/telemetry/statsd"
 	"github.com/DataDog/KubeHound/pkg/telemetry/tag"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
+	netv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 )
 
@@ -28,23 +32,58 @@ import (
 // | |____pods.json
 // | |____endpointslices.discovery.k8s.io.json
 // | |____roles.rbac.authorization.k8s.io.json
+// | |____secrets.json
+// | |____serviceaccounts.json
+// | |____deployments.apps.json
+// | |____daemonsets.apps.json
+// | |____statefulsets.apps.json
+// | |____jobs.batch.json
+// | |____cronjobs.batch.json
+// | |____networkpolicies.networking.k8s.io.json
+// | |____persistentvolumeclaims.json
 // |____<namespace>
 // | |____rolebindings.rbac.authorization.k8s.io.json
 // | |____pods.json
 // | |____endpointslices.discovery.k8s.io.json
 // | |____roles.rbac.authorization.k8s.io.json
+// | |____secrets.json
+// | |____serviceaccounts.json
+// | |____deployments.apps.json
+// | |____daemonsets.apps.json
+// | |____statefulsets.apps.json
+// | |____jobs.batch.json
+// | |____cronjobs.batch.json
+// | |____networkpolicies.networking.k8s.io.json
+// | |____persistentvolumeclaims.json
 // |____nodes.json
 // |____clusterroles.rbac.authorization.k8s.io.json
 // |____clusterrolebindings.rbac.authorization.k8s.io.json
+// |____persistentvolumes.json
+// |____mutatingwebhookconfigurations.admissionregistration.k8s.io.json
+// |____validatingwebhookconfigurations.admissionregistration.k8s.io.json
+// |____namespaces.json
 const (
-	NodePath                = "nodes.json"
-	EndpointPath            = "endpointslices.discovery.k8s.io.json"
-	ClusterRolesPath        = "clusterroles.rbac.authorization.k8s.io.json"
-	ClusterRoleBindingsPath = "clusterrolebindings.rbac.authorization.k8s.io.json"
-	PodPath                 = "pods.json"
-	RolesPath               = "roles.rbac.authorization.k8s.io.json"
-	RoleBindingsPath        = "rolebindings.rbac.authorization.k8s.io.json"
-	MetadataPath            = "metadata.json"
+	NodePath                  = "nodes.json"
+	EndpointPath              = "endpointslices.discovery.k8s.io.json"
+	ClusterRolesPath          = "clusterroles.rbac.authorization.k8s.io.json"
+	ClusterRoleBindingsPath   = "clusterrolebindings.rbac.authorization.k8s.io.json"
+	PodPath                   = "pods.json"
+	RolesPath                 = "roles.rbac.authorization.k8s.io.json"
+	RoleBindingsPath          = "rolebindings.rbac.authorization.k8s.io.json"
+	SecretPath                = "secrets.json"
+	ServiceAccountPath        = "serviceaccounts.json"
+	DeploymentPath            = "deployments.apps.json"
+	DaemonSetPath             = "daemonsets.apps.json"
+	StatefulSetPath           = "statefulsets.apps.json"
+	JobPath                   = "jobs.batch.json"
+	CronJobPath               = "cronjobs.batch.json"
+	NetworkPolicyPath         = "networkpolicies.networking.k8s.io.json"
+	PersistentVolumePath      = "persistentvolumes.json"
+	PersistentVolumeClaimPath = "persistentvolumeclaims.json"
+	MutatingWebhookPath       = "mutatingwebhookconfigurations.admissionregistration.k8s.io.json"
+	ValidatingWebhookPath     = "validatingwebhookconfigurations.admissionregistration.k8s.io.json"
+	NamespacePath             = "namespaces.json"
+	MetadataPath              = "metadata.json"
 )
 
 const (
@@ -325,6 +364,466 @@ func (c *FileCollector) StreamEndpoints(ctx context.Context, ingestor EndpointIn
 	return ingestor.Complete(ctx)
 }
 
+// streamSecretsNamespace streams the secrets in a single file, corresponding to a cluster namespace.
+func (c *FileCollector) streamSecretsNamespace(ctx context.Context, fp string, ingestor SecretIngestor) error {
+	list, err := readList[corev1.SecretList](ctx, fp)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range list.Items {
+		_ = statsd.Incr(ctx, metric.CollectorCount, c.tags.secret, 1)
+		i := item
+		// Files generated outside of KubeHound (e.g via kubectl) may contain the secret data
+		err = ingestor.IngestSecret(ctx, secretMetadata(&i))
+		if err != nil {
+			return fmt.Errorf("processing K8s secret %s: %w", i.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *FileCollector) StreamSecrets(ctx context.Context, ingestor SecretIngestor) error {
+	span, ctx := span.SpanRunFromContext(ctx, span.CollectorStream)
+	span.SetTag(tag.EntityTag, tag.EntitySecrets)
+	l := log.Trace(ctx)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	err = filepath.WalkDir(c.cfg.Directory, func(path string, d fs.DirEntry, err error) error {
+		if path == c.cfg.Directory || !d.IsDir() {
+			// Skip files
+			return nil
+		}
+
+		fp := filepath.Join(path, SecretPath)
+
+		// Check if the file exists
+		if _, err := os.Stat(fp); os.IsNotExist(err) {
+			// Skipping streaming as file does not exist (k8s type not necessary required in a namespace, for instance, an namespace can have no secrets)
+			return nil
+		}
+		l.Debug("Streaming secrets from file", log.String(log.FieldPathKey, fp), log.String(log.FieldEntityKey, tag.EntitySecrets))
+
+		return c.streamSecretsNamespace(ctx, fp, ingestor)
+	})
+
+	if err != nil {
+		return fmt.Errorf("file collector stream secrets: %w", err)
+	}
+
+	return ingestor.Complete(ctx)
+}
+
+// streamServiceAccountsNamespace streams the service accounts in a single file, corresponding to a cluster namespace.
+func (c *FileCollector) streamServiceAccountsNamespace(ctx context.Context, fp string, ingestor ServiceAccountIngestor) error {
+	list, err := readList[corev1.ServiceAccountList](ctx, fp)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range list.Items {
+		_ = statsd.Incr(ctx, metric.CollectorCount, c.tags.serviceaccount, 1)
+		i := item
+		err = ingestor.IngestServiceAccount(ctx, &i)
+		if err != nil {
+			return fmt.Errorf("processing K8s service account %s: %w", i.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *FileCollector) StreamServiceAccounts(ctx context.Context, ingestor ServiceAccountIngestor) error {
+	span, ctx := span.SpanRunFromContext(ctx, span.CollectorStream)
+	span.SetTag(tag.EntityTag, tag.EntityServiceAccounts)
+	l := log.Trace(ctx)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	err = filepath.WalkDir(c.cfg.Directory, func(path string, d fs.DirEntry, err error) error {
+		if path == c.cfg.Directory || !d.IsDir() {
+			// Skip files
+			return nil
+		}
+
+		fp := filepath.Join(path, ServiceAccountPath)
+
+		// Check if the file exists
+		if _, err := os.Stat(fp); os.IsNotExist(err) {
+			// Skipping streaming as file does not exist (k8s type not necessary required in a namespace)
+			return nil
+		}
+		l.Debug("Streaming service accounts from file", log.String(log.FieldPathKey, fp), log.String(log.FieldEntityKey, tag.EntityServiceAccounts))
+
+		return c.streamServiceAccountsNamespace(ctx, fp, ingestor)
+	})
+
+	if err != nil {
+		return fmt.Errorf("file collector stream service accounts: %w", err)
+	}
+
+	return ingestor.Complete(ctx)
+}
+
+// streamNetworkPoliciesNamespace streams the network policies in a single file, corresponding to a cluster namespace.
+func (c *FileCollector) streamNetworkPoliciesNamespace(ctx context.Context, fp string, ingestor NetworkPolicyIngestor) error {
+	list, err := readList[netv1.NetworkPolicyList](ctx, fp)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range list.Items {
+		_ = statsd.Incr(ctx, metric.CollectorCount, c.tags.networkpolicy, 1)
+		i := item
+		err = ingestor.IngestNetworkPolicy(ctx, &i)
+		if err != nil {
+			return fmt.Errorf("processing K8s network policy %s: %w", i.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *FileCollector) StreamNetworkPolicies(ctx context.Context, ingestor NetworkPolicyIngestor) error {
+	span, ctx := span.SpanRunFromContext(ctx, span.CollectorStream)
+	span.SetTag(tag.EntityTag, tag.EntityNetworkPolicies)
+	l := log.Trace(ctx)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	err = filepath.WalkDir(c.cfg.Directory, func(path string, d fs.DirEntry, err error) error {
+		if path == c.cfg.Directory || !d.IsDir() {
+			// Skip files
+			return nil
+		}
+
+		fp := filepath.Join(path, NetworkPolicyPath)
+
+		// Check if the file exists
+		if _, err := os.Stat(fp); os.IsNotExist(err) {
+			// Skipping streaming as file does not exist (k8s type not necessary required in a namespace)
+			return nil
+		}
+		l.Debug("Streaming network policies from file", log.String(log.FieldPathKey, fp), log.String(log.FieldEntityKey, tag.EntityNetworkPolicies))
+
+		return c.streamNetworkPoliciesNamespace(ctx, fp, ingestor)
+	})
+
+	if err != nil {
+		return fmt.Errorf("file collector stream network policies: %w", err)
+	}
+
+	return ingestor.Complete(ctx)
+}
+
+// streamPersistentVolumeClaimsNamespace streams the persistent volume claims in a single file, corresponding to a cluster namespace.
+func (c *FileCollector) streamPersistentVolumeClaimsNamespace(ctx context.Context, fp string, ingestor PersistentVolumeIngestor) error {
+	list, err := readList[corev1.PersistentVolumeClaimList](ctx, fp)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range list.Items {
+		_ = statsd.Incr(ctx, metric.CollectorCount, c.tags.persistentvolume, 1)
+		i := item
+		err = ingestor.IngestPersistentVolumeClaim(ctx, &i)
+		if err != nil {
+			return fmt.Errorf("processing K8s persistent volume claim %s: %w", i.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// streamPersistentVolumes streams the cluster persistent volumes from the root directory file.
+func (c *FileCollector) streamPersistentVolumes(ctx context.Context, ingestor PersistentVolumeIngestor) error {
+	fp := filepath.Join(c.cfg.Directory, PersistentVolumePath)
+
+	// Check if the file exists
+	if _, err := os.Stat(fp); os.IsNotExist(err) {
+		// Skipping streaming as file does not exist (dumps generated by older versions do not include persistent volumes)
+		return nil
+	}
+
+	list, err := readList[corev1.PersistentVolumeList](ctx, fp)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range list.Items {
+		_ = statsd.Incr(ctx, metric.CollectorCount, c.tags.persistentvolume, 1)
+		i := item
+		err = ingestor.IngestPersistentVolume(ctx, &i)
+		if err != nil {
+			return fmt.Errorf("processing K8s persistent volume %s: %w", i.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *FileCollector) StreamPersistentVolumes(ctx context.Context, ingestor PersistentVolumeIngestor) error {
+	span, ctx := span.SpanRunFromContext(ctx, span.CollectorStream)
+	span.SetTag(tag.EntityTag, tag.EntityPersistentVolumes)
+	l := log.Trace(ctx)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	err = c.streamPersistentVolumes(ctx, ingestor)
+	if err != nil {
+		return fmt.Errorf("file collector stream persistent volumes: %w", err)
+	}
+
+	err = filepath.WalkDir(c.cfg.Directory, func(path string, d fs.DirEntry, err error) error {
+		if path == c.cfg.Directory || !d.IsDir() {
+			// Skip files
+			return nil
+		}
+
+		fp := filepath.Join(path, PersistentVolumeClaimPath)
+
+		// Check if the file exists
+		if _, err := os.Stat(fp); os.IsNotExist(err) {
+			// Skipping streaming as file does not exist (k8s type not necessary required in a namespace)
+			return nil
+		}
+		l.Debug("Streaming persistent volume claims from file", log.String(log.FieldPathKey, fp), log.String(log.FieldEntityKey, tag.EntityPersistentVolumes))
+
+		return c.streamPersistentVolumeClaimsNamespace(ctx, fp, ingestor)
+	})
+
+	if err != nil {
+		return fmt.Errorf("file collector stream persistent volume claims: %w", err)
+	}
+
+	return ingestor.Complete(ctx)
+}
+
+// streamWebhookFile streams the webhook configurations of a single kind from the root directory file.
+func streamWebhookFile[Tl types.ListInputType, T any](ctx context.Context, c *FileCollector, fp string,
+	items func(list *Tl) []T, ingest func(item *T) error) error {
+
+	// Check if the file exists
+	if _, err := os.Stat(fp); os.IsNotExist(err) {
+		// Skipping streaming as file does not exist (dumps generated by older versions do not include webhook configurations)
+		return nil
+	}
+
+	list, err := readList[Tl](ctx, fp)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items(&list) {
+		_ = statsd.Incr(ctx, metric.CollectorCount, c.tags.webhook, 1)
+		i := item
+		err = ingest(&i)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *FileCollector) StreamWebhooks(ctx context.Context, ingestor WebhookIngestor) error {
+	span, ctx := span.SpanRunFromContext(ctx, span.CollectorStream)
+	span.SetTag(tag.EntityTag, tag.EntityWebhooks)
+	l := log.Trace(ctx)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	fp := filepath.Join(c.cfg.Directory, MutatingWebhookPath)
+	l.Debug("Streaming mutating webhook configurations from file", log.String(log.FieldPathKey, fp), log.String(log.FieldEntityKey, tag.EntityWebhooks))
+
+	err = streamWebhookFile(ctx, c, fp,
+		func(l *admissionregistrationv1.MutatingWebhookConfigurationList) []admissionregistrationv1.MutatingWebhookConfiguration {
+			return l.Items
+		},
+		func(i *admissionregistrationv1.MutatingWebhookConfiguration) error {
+			if err := ingestor.IngestMutatingWebhookConfiguration(ctx, i); err != nil {
+				return fmt.Errorf("processing K8s mutating webhook configuration %s: %w", i.Name, err)
+			}
+
+			return nil
+		})
+	if err != nil {
+		return fmt.Errorf("file collector stream mutating webhook configurations: %w", err)
+	}
+
+	fp = filepath.Join(c.cfg.Directory, ValidatingWebhookPath)
+	l.Debug("Streaming validating webhook configurations from file", log.String(log.FieldPathKey, fp), log.String(log.FieldEntityKey, tag.EntityWebhooks))
+
+	err = streamWebhookFile(ctx, c, fp,
+		func(l *admissionregistrationv1.ValidatingWebhookConfigurationList) []admissionregistrationv1.ValidatingWebhookConfiguration {
+			return l.Items
+		},
+		func(i *admissionregistrationv1.ValidatingWebhookConfiguration) error {
+			if err := ingestor.IngestValidatingWebhookConfiguration(ctx, i); err != nil {
+				return fmt.Errorf("processing K8s validating webhook configuration %s: %w", i.Name, err)
+			}
+
+			return nil
+		})
+	if err != nil {
+		return fmt.Errorf("file collector stream validating webhook configurations: %w", err)
+	}
+
+	return ingestor.Complete(ctx)
+}
+
+func (c *FileCollector) StreamNamespaces(ctx context.Context, ingestor NamespaceIngestor) error {
+	span, ctx := span.SpanRunFromContext(ctx, span.CollectorStream)
+	span.SetTag(tag.EntityTag, tag.EntityNamespaces)
+	l := log.Trace(ctx)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	fp := filepath.Join(c.cfg.Directory, NamespacePath)
+	l.Debug("Streaming namespaces from file", log.String(log.FieldPathKey, fp), log.String(log.FieldEntityKey, tag.EntityNamespaces))
+
+	// Check if the file exists
+	if _, err := os.Stat(fp); os.IsNotExist(err) {
+		// Skipping streaming as file does not exist (dumps generated by older versions do not include namespaces)
+		return ingestor.Complete(ctx)
+	}
+
+	list, err := readList[corev1.NamespaceList](ctx, fp)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range list.Items {
+		_ = statsd.Incr(ctx, metric.CollectorCount, c.tags.namespace, 1)
+		i := item
+		err = ingestor.IngestNamespace(ctx, &i)
+		if err != nil {
+			return fmt.Errorf("processing K8s namespace %s: %w", i.Name, err)
+		}
+	}
+
+	return ingestor.Complete(ctx)
+}
+
+// streamWorkloadFile streams the workload controllers of a single kind from a file, corresponding to a cluster namespace.
+func streamWorkloadFile[Tl types.ListInputType, T any](ctx context.Context, c *FileCollector, fp string,
+	items func(list *Tl) []T, ingest func(item *T) error) error {
+
+	// Check if the file exists
+	if _, err := os.Stat(fp); os.IsNotExist(err) {
+		// Skipping streaming as file does not exist (a namespace does not necessarily hold every kind of workload)
+		return nil
+	}
+
+	list, err := readList[Tl](ctx, fp)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items(&list) {
+		_ = statsd.Incr(ctx, metric.CollectorCount, c.tags.workload, 1)
+		i := item
+		err = ingest(&i)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// streamWorkloadsNamespace streams the workload controllers in a namespace directory, one file per workload kind.
+func (c *FileCollector) streamWorkloadsNamespace(ctx context.Context, path string, ingestor WorkloadIngestor) error {
+	err := streamWorkloadFile(ctx, c, filepath.Join(path, DeploymentPath),
+		func(list *appsv1.DeploymentList) []appsv1.Deployment { return list.Items },
+		func(item *appsv1.Deployment) error {
+			if err := ingestor.IngestDeployment(ctx, item); err != nil {
+				return fmt.Errorf("processing K8s deployment %s: %w", item.Name, err)
+			}
+
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	err = streamWorkloadFile(ctx, c, filepath.Join(path, DaemonSetPath),
+		func(list *appsv1.DaemonSetList) []appsv1.DaemonSet { return list.Items },
+		func(item *appsv1.DaemonSet) error {
+			if err := ingestor.IngestDaemonSet(ctx, item); err != nil {
+				return fmt.Errorf("processing K8s daemon set %s: %w", item.Name, err)
+			}
+
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	err = streamWorkloadFile(ctx, c, filepath.Join(path, StatefulSetPath),
+		func(list *appsv1.StatefulSetList) []appsv1.StatefulSet { return list.Items },
+		func(item *appsv1.StatefulSet) error {
+			if err := ingestor.IngestStatefulSet(ctx, item); err != nil {
+				return fmt.Errorf("processing K8s stateful set %s: %w", item.Name, err)
+			}
+
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	err = streamWorkloadFile(ctx, c, filepath.Join(path, JobPath),
+		func(list *batchv1.JobList) []batchv1.Job { return list.Items },
+		func(item *batchv1.Job) error {
+			if err := ingestor.IngestJob(ctx, item); err != nil {
+				return fmt.Errorf("processing K8s job %s: %w", item.Name, err)
+			}
+
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	return streamWorkloadFile(ctx, c, filepath.Join(path, CronJobPath),
+		func(list *batchv1.CronJobList) []batchv1.CronJob { return list.Items },
+		func(item *batchv1.CronJob) error {
+			if err := ingestor.IngestCronJob(ctx, item); err != nil {
+				return fmt.Errorf("processing K8s cron job %s: %w", item.Name, err)
+			}
+
+			return nil
+		})
+}
+
+func (c *FileCollector) StreamWorkloads(ctx context.Context, ingestor WorkloadIngestor) error {
+	span, ctx := span.SpanRunFromContext(ctx, span.CollectorStream)
+	span.SetTag(tag.EntityTag, tag.EntityWorkloads)
+	l := log.Trace(ctx)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	err = filepath.WalkDir(c.cfg.Directory, func(path string, d fs.DirEntry, err error) error {
+		if path == c.cfg.Directory || !d.IsDir() {
+			// Skip files
+			return nil
+		}
+
+		l.Debug("Streaming workloads from directory", log.String(log.FieldPathKey, path), log.String(log.FieldEntityKey, tag.EntityWorkloads))
+
+		return c.streamWorkloadsNamespace(ctx, path, ingestor)
+	})
+
+	if err != nil {
+		return fmt.Errorf("file collector stream workloads: %w", err)
+	}
+
+	return ingestor.Complete(ctx)
+}
+
 func (c *FileCollector) StreamNodes(ctx context.Context, ingestor NodeIngestor) error {
 	span, ctx := span.SpanRunFromContext(ctx, span.CollectorStream)
 	span.SetTag(tag.EntityTag, tag.EntityNodes)
@@ -2,13 +2,16 @@
 package collector
 
 import (
+	"context"
 	"testing"
 
 	mocks "github.com/DataDog/KubeHound/pkg/collector/mockingest"
 	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestFileCollector_Constructor(t *testing.T) {
@@ -182,3 +185,111 @@ func TestFileCollector_StreamEndpoints(t *testing.T) {
 	err := c.StreamEndpoints(ctx, i)
 	assert.NoError(t, err)
 }
+
+func TestFileCollector_StreamSecrets(t *testing.T) {
+	t.Parallel()
+
+	c := NewTestFileCollector(t)
+	ctx := t.Context()
+	i := mocks.NewSecretIngestor(t)
+
+	i.EXPECT().IngestSecret(mock.Anything, mock.AnythingOfType("types.SecretType")).RunAndReturn(func(_ context.Context, secret types.SecretType) error {
+		assert.Empty(t, secret.Data)
+		assert.Empty(t, secret.StringData)
+		assert.NotContains(t, secret.Annotations, corev1.LastAppliedConfigAnnotation)
+
+		return nil
+	}).Twice()
+	i.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+	err := c.StreamSecrets(ctx, i)
+	assert.NoError(t, err)
+}
+
+func TestFileCollector_StreamServiceAccounts(t *testing.T) {
+	t.Parallel()
+
+	c := NewTestFileCollector(t)
+	ctx := t.Context()
+	i := mocks.NewServiceAccountIngestor(t)
+
+	i.EXPECT().IngestServiceAccount(mock.Anything, mock.AnythingOfType("types.ServiceAccountType")).Return(nil).Twice()
+	i.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+	err := c.StreamServiceAccounts(ctx, i)
+	assert.NoError(t, err)
+}
+
+func TestFileCollector_StreamWorkloads(t *testing.T) {
+	t.Parallel()
+
+	c := NewTestFileCollector(t)
+	ctx := t.Context()
+	i := mocks.NewWorkloadIngestor(t)
+
+	i.EXPECT().IngestDeployment(mock.Anything, mock.AnythingOfType("types.DeploymentType")).Return(nil).Once()
+	i.EXPECT().IngestJob(mock.Anything, mock.AnythingOfType("types.JobType")).Return(nil).Once()
+	i.EXPECT().IngestCronJob(mock.Anything, mock.AnythingOfType("types.CronJobType")).Return(nil).Once()
+	i.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+	err := c.StreamWorkloads(ctx, i)
+	assert.NoError(t, err)
+}
+
+func TestFileCollector_StreamNetworkPolicies(t *testing.T) {
+	t.Parallel()
+
+	c := NewTestFileCollector(t)
+	ctx := t.Context()
+	i := mocks.NewNetworkPolicyIngestor(t)
+
+	i.EXPECT().IngestNetworkPolicy(mock.Anything, mock.AnythingOfType("types.NetworkPolicyType")).Return(nil).Twice()
+	i.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+	err := c.StreamNetworkPolicies(ctx, i)
+	assert.NoError(t, err)
+}
+
+func TestFileCollector_StreamPersistentVolumes(t *testing.T) {
+	t.Parallel()
+
+	c := NewTestFileCollector(t)
+	ctx := t.Context()
+	i := mocks.NewPersistentVolumeIngestor(t)
+
+	i.EXPECT().IngestPersistentVolume(mock.Anything, mock.AnythingOfType("types.PersistentVolumeType")).Return(nil).Once()
+	i.EXPECT().IngestPersistentVolumeClaim(mock.Anything, mock.AnythingOfType("types.PersistentVolumeClaimType")).Return(nil).Once()
+	i.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+	err := c.StreamPersistentVolumes(ctx, i)
+	assert.NoError(t, err)
+}
+
+func TestFileCollector_StreamWebhooks(t *testing.T) {
+	t.Parallel()
+
+	c := NewTestFileCollector(t)
+	ctx := t.Context()
+	i := mocks.NewWebhookIngestor(t)
+
+	i.EXPECT().IngestMutatingWebhookConfiguration(mock.Anything, mock.AnythingOfType("types.MutatingWebhookConfigurationType")).Return(nil).Once()
+	i.EXPECT().IngestValidatingWebhookConfiguration(mock.Anything, mock.AnythingOfType("types.ValidatingWebhookConfigurationType")).Return(nil).Once()
+	i.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+	err := c.StreamWebhooks(ctx, i)
+	assert.NoError(t, err)
+}
+
+func TestFileCollector_StreamNamespaces(t *testing.T) {
+	t.Parallel()
+
+	c := NewTestFileCollector(t)
+	ctx := t.Context()
+	i := mocks.NewNamespaceIngestor(t)
+
+	i.EXPECT().IngestNamespace(mock.Anything, mock.AnythingOfType("types.NamespaceType")).Return(nil).Twice()
+	i.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+	err := c.StreamNamespaces(ctx, i)
+	assert.NoError(t, err)
+}
@@ -18,8 +18,12 @@ import (
 	"go.uber.org/ratelimit"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
+	netv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -656,3 +660,523 @@ func (c *k8sAPICollector) StreamClusterRoleBindings(ctx context.Context, ingesto
 
 	return ingestor.Complete(ctx)
 }
+
+// streamSecretsNamespace streams the secret objects corresponding to a cluster namespace.
+func (c *k8sAPICollector) streamSecretsNamespace(ctx context.Context, namespace string, ingestor SecretIngestor) error {
+	entity := tag.EntitySecrets
+	err := c.checkNamespaceExists(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	opts := tunedListOptions()
+	pager := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+		entries, err := c.clientset.CoreV1().Secrets(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("getting K8s secrets for namespace %s: %w", namespace, err)
+		}
+
+		return entries, err
+	}))
+
+	c.setPagerConfig(pager)
+
+	return pager.EachListItem(ctx, opts, func(obj runtime.Object) error {
+		_ = statsd.Incr(ctx, metric.CollectorCount, c.tags.secret, 1)
+		c.wait(ctx, entity, c.tags.secret)
+		item, ok := obj.(*corev1.Secret)
+		if !ok {
+			return fmt.Errorf("secret stream type conversion error: %T", obj)
+		}
+
+		err := ingestor.IngestSecret(ctx, secretMetadata(item))
+		if err != nil {
+			return fmt.Errorf("processing K8s secret %s for namespace %s: %w", item.Name, namespace, err)
+		}
+
+		return nil
+	})
+}
+
+func (c *k8sAPICollector) StreamSecrets(ctx context.Context, ingestor SecretIngestor) error {
+	entity := tag.EntitySecrets
+	span, ctx := span.SpanRunFromContext(ctx, span.CollectorStream)
+	span.SetTag(tag.EntityTag, tag.EntitySecrets)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	// passing an empty namespace will collect all namespaces
+	err = c.streamSecretsNamespace(ctx, "", ingestor)
+	if err != nil {
+		return err
+	}
+
+	c.waitTimeByResource(ctx, entity, span)
+
+	return ingestor.Complete(ctx)
+}
+
+// streamServiceAccountsNamespace streams the service account objects corresponding to a cluster namespace.
+func (c *k8sAPICollector) streamServiceAccountsNamespace(ctx context.Context, namespace string, ingestor ServiceAccountIngestor) error {
+	entity := tag.EntityServiceAccounts
+	err := c.checkNamespaceExists(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	opts := tunedListOptions()
+	pager := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+		entries, err := c.clientset.CoreV1().ServiceAccounts(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("getting K8s service accounts for namespace %s: %w", namespace, err)
+		}
+
+		return entries, err
+	}))
+
+	c.setPagerConfig(pager)
+
+	return pager.EachListItem(ctx, opts, func(obj runtime.Object) error {
+		_ = statsd.Incr(ctx, metric.CollectorCount, c.tags.serviceaccount, 1)
+		c.wait(ctx, entity, c.tags.serviceaccount)
+		item, ok := obj.(*corev1.ServiceAccount)
+		if !ok {
+			return fmt.Errorf("service account stream type conversion error: %T", obj)
+		}
+
+		err := ingestor.IngestServiceAccount(ctx, item)
+		if err != nil {
+			return fmt.Errorf("processing K8s service account %s for namespace %s: %w", item.Name, namespace, err)
+		}
+
+		return nil
+	})
+}
+
+func (c *k8sAPICollector) StreamServiceAccounts(ctx context.Context, ingestor ServiceAccountIngestor) error {
+	entity := tag.EntityServiceAccounts
+	span, ctx := span.SpanRunFromContext(ctx, span.CollectorStream)
+	span.SetTag(tag.EntityTag, tag.EntityServiceAccounts)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	// passing an empty namespace will collect all namespaces
+	err = c.streamServiceAccountsNamespace(ctx, "", ingestor)
+	if err != nil {
+		return err
+	}
+
+	c.waitTimeByResource(ctx, entity, span)
+
+	return ingestor.Complete(ctx)
+}
+
+// streamNetworkPoliciesNamespace streams the network policy objects corresponding to a cluster namespace.
+func (c *k8sAPICollector) streamNetworkPoliciesNamespace(ctx context.Context, namespace string, ingestor NetworkPolicyIngestor) error {
+	entity := tag.EntityNetworkPolicies
+	err := c.checkNamespaceExists(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	opts := tunedListOptions()
+	pager := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+		entries, err := c.clientset.NetworkingV1().NetworkPolicies(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("getting K8s network policies for namespace %s: %w", namespace, err)
+		}
+
+		return entries, err
+	}))
+
+	c.setPagerConfig(pager)
+
+	return pager.EachListItem(ctx, opts, func(obj runtime.Object) error {
+		_ = statsd.Incr(ctx, metric.CollectorCount, c.tags.networkpolicy, 1)
+		c.wait(ctx, entity, c.tags.networkpolicy)
+		item, ok := obj.(*netv1.NetworkPolicy)
+		if !ok {
+			return fmt.Errorf("network policy stream type conversion error: %T", obj)
+		}
+
+		err := ingestor.IngestNetworkPolicy(ctx, item)
+		if err != nil {
+			return fmt.Errorf("processing K8s network policy %s for namespace %s: %w", item.Name, namespace, err)
+		}
+
+		return nil
+	})
+}
+
+func (c *k8sAPICollector) StreamNetworkPolicies(ctx context.Context, ingestor NetworkPolicyIngestor) error {
+	entity := tag.EntityNetworkPolicies
+	span, ctx := span.SpanRunFromContext(ctx, span.CollectorStream)
+	span.SetTag(tag.EntityTag, tag.EntityNetworkPolicies)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	// passing an empty namespace will collect all namespaces
+	err = c.streamNetworkPoliciesNamespace(ctx, "", ingestor)
+	if err != nil {
+		return err
+	}
+
+	c.waitTimeByResource(ctx, entity, span)
+
+	return ingestor.Complete(ctx)
+}
+
+// streamPersistentVolumeClaimsNamespace streams the persistent volume claim objects corresponding to a cluster namespace.
+func (c *k8sAPICollector) streamPersistentVolumeClaimsNamespace(ctx context.Context, namespace string, ingestor PersistentVolumeIngestor) error {
+	entity := tag.EntityPersistentVolumes
+	err := c.checkNamespaceExists(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	opts := tunedListOptions()
+	pager := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+		entries, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("getting K8s persistent volume claims for namespace %s: %w", namespace, err)
+		}
+
+		return entries, err
+	}))
+
+	c.setPagerConfig(pager)
+
+	return pager.EachListItem(ctx, opts, func(obj runtime.Object) error {
+		_ = statsd.Incr(ctx, metric.CollectorCount, c.tags.persistentvolume, 1)
+		c.wait(ctx, entity, c.tags.persistentvolume)
+		item, ok := obj.(*corev1.PersistentVolumeClaim)
+		if !ok {
+			return fmt.Errorf("persistent volume claim stream type conversion error: %T", obj)
+		}
+
+		err := ingestor.IngestPersistentVolumeClaim(ctx, item)
+		if err != nil {
+			return fmt.Errorf("processing K8s persistent volume claim %s for namespace %s: %w", item.Name, namespace, err)
+		}
+
+		return nil
+	})
+}
+
+// streamPersistentVolumes streams the cluster persistent volume objects.
+func (c *k8sAPICollector) streamPersistentVolumes(ctx context.Context, ingestor PersistentVolumeIngestor) error {
+	entity := tag.EntityPersistentVolumes
+	opts := tunedListOptions()
+	pager := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+		entries, err := c.clientset.CoreV1().PersistentVolumes().List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("getting K8s persistent volumes: %w", err)
+		}
+
+		return entries, err
+	}))
+
+	c.setPagerConfig(pager)
+
+	return pager.EachListItem(ctx, opts, func(obj runtime.Object) error {
+		_ = statsd.Incr(ctx, metric.CollectorCount, c.tags.persistentvolume, 1)
+		c.wait(ctx, entity, c.tags.persistentvolume)
+		item, ok := obj.(*corev1.PersistentVolume)
+		if !ok {
+			return fmt.Errorf("persistent volume stream type conversion error: %T", obj)
+		}
+
+		err := ingestor.IngestPersistentVolume(ctx, item)
+		if err != nil {
+			return fmt.Errorf("processing K8s persistent volume %s: %w", item.Name, err)
+		}
+
+		return nil
+	})
+}
+
+func (c *k8sAPICollector) StreamPersistentVolumes(ctx context.Context, ingestor PersistentVolumeIngestor) error {
+	entity := tag.EntityPersistentVolumes
+	span, ctx := span.SpanRunFromContext(ctx, span.CollectorStream)
+	span.SetTag(tag.EntityTag, tag.EntityPersistentVolumes)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	err = c.streamPersistentVolumes(ctx, ingestor)
+	if err != nil {
+		return err
+	}
+
+	// passing an empty namespace will collect all namespaces
+	err = c.streamPersistentVolumeClaimsNamespace(ctx, "", ingestor)
+	if err != nil {
+		return err
+	}
+
+	c.waitTimeByResource(ctx, entity, span)
+
+	return ingestor.Complete(ctx)
+}
+
+// streamWorkloadKind streams all the objects of a single workload controller kind corresponding to a cluster namespace.
+func (c *k8sAPICollector) streamWorkloadKind(ctx context.Context, namespace string, kind string,
+	list func(opts metav1.ListOptions) (runtime.Object, error), ingest func(obj runtime.Object) error) error {
+
+	entity := tag.EntityWorkloads
+	pager := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+		entries, err := list(opts)
+		if err != nil {
+			return nil, fmt.Errorf("getting K8s %s for namespace %s: %w", kind, namespace, err)
+		}
+
+		return entries, err
+	}))
+
+	c.setPagerConfig(pager)
+
+	return pager.EachListItem(ctx, tunedListOptions(), func(obj runtime.Object) error {
+		_ = statsd.Incr(ctx, metric.CollectorCount, c.tags.workload, 1)
+		c.wait(ctx, entity, c.tags.workload)
+
+		return ingest(obj)
+	})
+}
+
+// streamWorkloadsNamespace streams the workload controller objects corresponding to a cluster namespace.
+func (c *k8sAPICollector) streamWorkloadsNamespace(ctx context.Context, namespace string, ingestor WorkloadIngestor) error {
+	err := c.checkNamespaceExists(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	err = c.streamWorkloadKind(ctx, namespace, "deployments", func(opts metav1.ListOptions) (runtime.Object, error) {
+		return c.clientset.AppsV1().Deployments(namespace).List(ctx, opts)
+	}, func(obj runtime.Object) error {
+		item, ok := obj.(*appsv1.Deployment)
+		if !ok {
+			return fmt.Errorf("deployment stream type conversion error: %T", obj)
+		}
+
+		err := ingestor.IngestDeployment(ctx, item)
+		if err != nil {
+			return fmt.Errorf("processing K8s deployment %s for namespace %s: %w", item.Name, namespace, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.streamWorkloadKind(ctx, namespace, "daemonsets", func(opts metav1.ListOptions) (runtime.Object, error) {
+		return c.clientset.AppsV1().DaemonSets(namespace).List(ctx, opts)
+	}, func(obj runtime.Object) error {
+		item, ok := obj.(*appsv1.DaemonSet)
+		if !ok {
+			return fmt.Errorf("daemon set stream type conversion error: %T", obj)
+		}
+
+		err := ingestor.IngestDaemonSet(ctx, item)
+		if err != nil {
+			return fmt.Errorf("processing K8s daemon set %s for namespace %s: %w", item.Name, namespace, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.streamWorkloadKind(ctx, namespace, "statefulsets", func(opts metav1.ListOptions) (runtime.Object, error) {
+		return c.clientset.AppsV1().StatefulSets(namespace).List(ctx, opts)
+	}, func(obj runtime.Object) error {
+		item, ok := obj.(*appsv1.StatefulSet)
+		if !ok {
+			return fmt.Errorf("stateful set stream type conversion error: %T", obj)
+		}
+
+		err := ingestor.IngestStatefulSet(ctx, item)
+		if err != nil {
+			return fmt.Errorf("processing K8s stateful set %s for namespace %s: %w", item.Name, namespace, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.streamWorkloadKind(ctx, namespace, "jobs", func(opts metav1.ListOptions) (runtime.Object, error) {
+		return c.clientset.BatchV1().Jobs(namespace).List(ctx, opts)
+	}, func(obj runtime.Object) error {
+		item, ok := obj.(*batchv1.Job)
+		if !ok {
+			return fmt.Errorf("job stream type conversion error: %T", obj)
+		}
+
+		err := ingestor.IngestJob(ctx, item)
+		if err != nil {
+			return fmt.Errorf("processing K8s job %s for namespace %s: %w", item.Name, namespace, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.streamWorkloadKind(ctx, namespace, "cronjobs", func(opts metav1.ListOptions) (runtime.Object, error) {
+		return c.clientset.BatchV1().CronJobs(namespace).List(ctx, opts)
+	}, func(obj runtime.Object) error {
+		item, ok := obj.(*batchv1.CronJob)
+		if !ok {
+			return fmt.Errorf("cron job stream type conversion error: %T", obj)
+		}
+
+		err := ingestor.IngestCronJob(ctx, item)
+		if err != nil {
+			return fmt.Errorf("processing K8s cron job %s for namespace %s: %w", item.Name, namespace, err)
+		}
+
+		return nil
+	})
+}
+
+func (c *k8sAPICollector) StreamWorkloads(ctx context.Context, ingestor WorkloadIngestor) error {
+	entity := tag.EntityWorkloads
+	span, ctx := span.SpanRunFromContext(ctx, span.CollectorStream)
+	span.SetTag(tag.EntityTag, tag.EntityWorkloads)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	// passing an empty namespace will collect all namespaces
+	err = c.streamWorkloadsNamespace(ctx, "", ingestor)
+	if err != nil {
+		return err
+	}
+
+	c.waitTimeByResource(ctx, entity, span)
+
+	return ingestor.Complete(ctx)
+}
+
+// streamWebhookKind streams all the webhook configurations of a single kind (mutating or validating).
+func (c *k8sAPICollector) streamWebhookKind(ctx context.Context, kind string,
+	list func(opts metav1.ListOptions) (runtime.Object, error), ingest func(obj runtime.Object) error) error {
+
+	entity := tag.EntityWebhooks
+	pager := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+		entries, err := list(opts)
+		if err != nil {
+			return nil, fmt.Errorf("getting K8s %s: %w", kind, err)
+		}
+
+		return entries, err
+	}))
+
+	c.setPagerConfig(pager)
+
+	return pager.EachListItem(ctx, tunedListOptions(), func(obj runtime.Object) error {
+		_ = statsd.Incr(ctx, metric.CollectorCount, c.tags.webhook, 1)
+		c.wait(ctx, entity, c.tags.webhook)
+
+		return ingest(obj)
+	})
+}
+
+func (c *k8sAPICollector) StreamWebhooks(ctx context.Context, ingestor WebhookIngestor) error {
+	entity := tag.EntityWebhooks
+	span, ctx := span.SpanRunFromContext(ctx, span.CollectorStream)
+	span.SetTag(tag.EntityTag, tag.EntityWebhooks)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	admission := c.clientset.AdmissionregistrationV1()
+
+	err = c.streamWebhookKind(ctx, "mutating webhook configurations",
+		func(opts metav1.ListOptions) (runtime.Object, error) {
+			return admission.MutatingWebhookConfigurations().List(ctx, opts)
+		},
+		func(obj runtime.Object) error {
+			item, ok := obj.(*admissionregistrationv1.MutatingWebhookConfiguration)
+			if !ok {
+				return fmt.Errorf("mutating webhook configuration stream type conversion error: %T", obj)
+			}
+
+			if err := ingestor.IngestMutatingWebhookConfiguration(ctx, item); err != nil {
+				return fmt.Errorf("processing K8s mutating webhook configuration %s: %w", item.Name, err)
+			}
+
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	err = c.streamWebhookKind(ctx, "validating webhook configurations",
+		func(opts metav1.ListOptions) (runtime.Object, error) {
+			return admission.ValidatingWebhookConfigurations().List(ctx, opts)
+		},
+		func(obj runtime.Object) error {
+			item, ok := obj.(*admissionregistrationv1.ValidatingWebhookConfiguration)
+			if !ok {
+				return fmt.Errorf("validating webhook configuration stream type conversion error: %T", obj)
+			}
+
+			if err := ingestor.IngestValidatingWebhookConfiguration(ctx, item); err != nil {
+				return fmt.Errorf("processing K8s validating webhook configuration %s: %w", item.Name, err)
+			}
+
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	c.waitTimeByResource(ctx, entity, span)
+
+	return ingestor.Complete(ctx)
+}
+
+func (c *k8sAPICollector) StreamNamespaces(ctx context.Context, ingestor NamespaceIngestor) error {
+	entity := tag.EntityNamespaces
+	span, ctx := span.SpanRunFromContext(ctx, span.CollectorStream)
+	span.SetTag(tag.EntityTag, entity)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	opts := tunedListOptions()
+	pager := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+		entries, err := c.clientset.CoreV1().Namespaces().List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("getting K8s namespaces: %w", err)
+		}
+
+		return entries, err
+	}))
+
+	c.setPagerConfig(pager)
+
+	err = pager.EachListItem(ctx, opts, func(obj runtime.Object) error {
+		_ = statsd.Incr(ctx, metric.CollectorCount, c.tags.namespace, 1)
+		c.wait(ctx, entity, c.tags.namespace)
+		item, ok := obj.(*corev1.Namespace)
+		if !ok {
+			return fmt.Errorf("namespace stream type conversion error: %T", obj)
+		}
+
+		err := ingestor.IngestNamespace(ctx, item)
+		if err != nil {
+			return fmt.Errorf("processing K8s namespace %s: %w", item.Name, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.waitTimeByResource(ctx, entity, span)
+
+	return ingestor.Complete(ctx)
+}
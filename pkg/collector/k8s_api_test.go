@@ -7,9 +7,11 @@ import (
 
 	mocks "github.com/DataDog/KubeHound/pkg/collector/mockingest"
 	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 )
@@ -574,3 +576,496 @@ func Test_k8sAPICollector_StreamEndpoints(t *testing.T) {
 		})
 	}
 }
+
+func Test_k8sAPICollector_StreamSecrets(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	// 0 secrets found
+	test1 := func(t *testing.T) (*fake.Clientset, *mocks.SecretIngestor) {
+		t.Helper()
+		clientset := fake.NewSimpleClientset()
+		m := mocks.NewSecretIngestor(t)
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+		return clientset, m
+	}
+
+	// Listing all the secrets from all namespaces
+	test2 := func(t *testing.T) (*fake.Clientset, *mocks.SecretIngestor) {
+		t.Helper()
+		clienset := fake.NewSimpleClientset(
+			[]runtime.Object{
+				FakeSecret("namespace1", "name1", corev1.SecretTypeServiceAccountToken),
+				FakeSecret("namespace2", "name2", corev1.SecretTypeOpaque),
+			}...,
+		)
+		m := mocks.NewSecretIngestor(t)
+		m.EXPECT().IngestSecret(mock.Anything, mock.AnythingOfType("types.SecretType")).RunAndReturn(func(_ context.Context, secret types.SecretType) error {
+			// Secret data must never be collected
+			assert.Empty(t, secret.Data)
+
+			return nil
+		}).Twice()
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+		return clienset, m
+	}
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		testfct func(t *testing.T) (*fake.Clientset, *mocks.SecretIngestor)
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "no entry",
+			testfct: test1,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "all namespace",
+			testfct: test2,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clientset, mock := tt.testfct(t)
+			c := NewTestK8sAPICollector(tt.args.ctx, clientset)
+			if err := c.StreamSecrets(tt.args.ctx, mock); (err != nil) != tt.wantErr {
+				t.Errorf("k8sAPICollector.StreamSecrets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_k8sAPICollector_StreamServiceAccounts(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	// 0 service accounts found
+	test1 := func(t *testing.T) (*fake.Clientset, *mocks.ServiceAccountIngestor) {
+		t.Helper()
+		clientset := fake.NewSimpleClientset()
+		m := mocks.NewServiceAccountIngestor(t)
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+		return clientset, m
+	}
+
+	// Listing all the service accounts from all namespaces
+	test2 := func(t *testing.T) (*fake.Clientset, *mocks.ServiceAccountIngestor) {
+		t.Helper()
+		clienset := fake.NewSimpleClientset(
+			[]runtime.Object{
+				FakeServiceAccount("namespace1", "name1"),
+				FakeServiceAccount("namespace2", "name2"),
+			}...,
+		)
+		m := mocks.NewServiceAccountIngestor(t)
+		m.EXPECT().IngestServiceAccount(mock.Anything, mock.AnythingOfType("types.ServiceAccountType")).Return(nil).Twice()
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+		return clienset, m
+	}
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		testfct func(t *testing.T) (*fake.Clientset, *mocks.ServiceAccountIngestor)
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "no entry",
+			testfct: test1,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "all namespace",
+			testfct: test2,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clientset, mock := tt.testfct(t)
+			c := NewTestK8sAPICollector(tt.args.ctx, clientset)
+			if err := c.StreamServiceAccounts(tt.args.ctx, mock); (err != nil) != tt.wantErr {
+				t.Errorf("k8sAPICollector.StreamServiceAccounts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_k8sAPICollector_StreamWorkloads(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	// 0 workloads found
+	test1 := func(t *testing.T) (*fake.Clientset, *mocks.WorkloadIngestor) {
+		t.Helper()
+		clientset := fake.NewSimpleClientset()
+		m := mocks.NewWorkloadIngestor(t)
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+		return clientset, m
+	}
+
+	// Listing all the workload controllers from all namespaces
+	test2 := func(t *testing.T) (*fake.Clientset, *mocks.WorkloadIngestor) {
+		t.Helper()
+		clienset := fake.NewSimpleClientset(
+			[]runtime.Object{
+				FakeDeployment("namespace1", "name1"),
+				FakeDeployment("namespace2", "name2"),
+				FakeDaemonSet("namespace1", "name3"),
+				FakeStatefulSet("namespace2", "name4"),
+				FakeJob("namespace1", "name5"),
+				FakeCronJob("namespace2", "name6"),
+			}...,
+		)
+		m := mocks.NewWorkloadIngestor(t)
+		m.EXPECT().IngestDeployment(mock.Anything, mock.AnythingOfType("types.DeploymentType")).Return(nil).Twice()
+		m.EXPECT().IngestDaemonSet(mock.Anything, mock.AnythingOfType("types.DaemonSetType")).Return(nil).Once()
+		m.EXPECT().IngestStatefulSet(mock.Anything, mock.AnythingOfType("types.StatefulSetType")).Return(nil).Once()
+		m.EXPECT().IngestJob(mock.Anything, mock.AnythingOfType("types.JobType")).Return(nil).Once()
+		m.EXPECT().IngestCronJob(mock.Anything, mock.AnythingOfType("types.CronJobType")).Return(nil).Once()
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+		return clienset, m
+	}
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		testfct func(t *testing.T) (*fake.Clientset, *mocks.WorkloadIngestor)
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "no entry",
+			testfct: test1,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "all namespace",
+			testfct: test2,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clientset, mock := tt.testfct(t)
+			c := NewTestK8sAPICollector(tt.args.ctx, clientset)
+			if err := c.StreamWorkloads(tt.args.ctx, mock); (err != nil) != tt.wantErr {
+				t.Errorf("k8sAPICollector.StreamWorkloads() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_k8sAPICollector_StreamNetworkPolicies(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	// 0 network policies found
+	test1 := func(t *testing.T) (*fake.Clientset, *mocks.NetworkPolicyIngestor) {
+		t.Helper()
+		clientset := fake.NewSimpleClientset()
+		m := mocks.NewNetworkPolicyIngestor(t)
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+		return clientset, m
+	}
+
+	// Listing all the network policies from all namespaces
+	test2 := func(t *testing.T) (*fake.Clientset, *mocks.NetworkPolicyIngestor) {
+		t.Helper()
+		clienset := fake.NewSimpleClientset(
+			[]runtime.Object{
+				FakeNetworkPolicy("namespace1", "name1"),
+				FakeNetworkPolicy("namespace2", "name2"),
+			}...,
+		)
+		m := mocks.NewNetworkPolicyIngestor(t)
+		m.EXPECT().IngestNetworkPolicy(mock.Anything, mock.AnythingOfType("types.NetworkPolicyType")).Return(nil).Twice()
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+		return clienset, m
+	}
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		testfct func(t *testing.T) (*fake.Clientset, *mocks.NetworkPolicyIngestor)
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "no entry",
+			testfct: test1,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "all namespace",
+			testfct: test2,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clientset, mock := tt.testfct(t)
+			c := NewTestK8sAPICollector(tt.args.ctx, clientset)
+			if err := c.StreamNetworkPolicies(tt.args.ctx, mock); (err != nil) != tt.wantErr {
+				t.Errorf("k8sAPICollector.StreamNetworkPolicies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_k8sAPICollector_StreamPersistentVolumes(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	// 0 persistent volumes found
+	test1 := func(t *testing.T) (*fake.Clientset, *mocks.PersistentVolumeIngestor) {
+		t.Helper()
+		clientset := fake.NewSimpleClientset()
+		m := mocks.NewPersistentVolumeIngestor(t)
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+		return clientset, m
+	}
+
+	// Listing all the persistent volumes and the claims from all namespaces
+	test2 := func(t *testing.T) (*fake.Clientset, *mocks.PersistentVolumeIngestor) {
+		t.Helper()
+		clienset := fake.NewSimpleClientset(
+			[]runtime.Object{
+				FakePersistentVolume("name1", "/var/lib/name1"),
+				FakePersistentVolumeClaim("namespace1", "name1", "name1"),
+				FakePersistentVolumeClaim("namespace2", "name2", ""),
+			}...,
+		)
+		m := mocks.NewPersistentVolumeIngestor(t)
+		m.EXPECT().IngestPersistentVolume(mock.Anything, mock.AnythingOfType("types.PersistentVolumeType")).Return(nil).Once()
+		m.EXPECT().IngestPersistentVolumeClaim(mock.Anything, mock.AnythingOfType("types.PersistentVolumeClaimType")).Return(nil).Twice()
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+		return clienset, m
+	}
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		testfct func(t *testing.T) (*fake.Clientset, *mocks.PersistentVolumeIngestor)
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "no entry",
+			testfct: test1,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "all namespace",
+			testfct: test2,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clientset, mock := tt.testfct(t)
+			c := NewTestK8sAPICollector(tt.args.ctx, clientset)
+			if err := c.StreamPersistentVolumes(tt.args.ctx, mock); (err != nil) != tt.wantErr {
+				t.Errorf("k8sAPICollector.StreamPersistentVolumes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_k8sAPICollector_StreamWebhooks(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	// 0 webhook configurations found
+	test1 := func(t *testing.T) (*fake.Clientset, *mocks.WebhookIngestor) {
+		t.Helper()
+		clientset := fake.NewSimpleClientset()
+		m := mocks.NewWebhookIngestor(t)
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+		return clientset, m
+	}
+
+	// Listing all the mutating and validating webhook configurations
+	test2 := func(t *testing.T) (*fake.Clientset, *mocks.WebhookIngestor) {
+		t.Helper()
+		clienset := fake.NewSimpleClientset(
+			[]runtime.Object{
+				FakeMutatingWebhookConfiguration("name1", "namespace1", "service1"),
+				FakeMutatingWebhookConfiguration("name2", "namespace2", "service2"),
+				FakeValidatingWebhookConfiguration("name3", "https://webhook.example.com/validate"),
+			}...,
+		)
+		m := mocks.NewWebhookIngestor(t)
+		m.EXPECT().IngestMutatingWebhookConfiguration(mock.Anything, mock.AnythingOfType("types.MutatingWebhookConfigurationType")).Return(nil).Twice()
+		m.EXPECT().IngestValidatingWebhookConfiguration(mock.Anything, mock.AnythingOfType("types.ValidatingWebhookConfigurationType")).Return(nil).Once()
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+		return clienset, m
+	}
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		testfct func(t *testing.T) (*fake.Clientset, *mocks.WebhookIngestor)
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "no entry",
+			testfct: test1,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "all webhooks",
+			testfct: test2,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clientset, mock := tt.testfct(t)
+			c := NewTestK8sAPICollector(tt.args.ctx, clientset)
+			if err := c.StreamWebhooks(tt.args.ctx, mock); (err != nil) != tt.wantErr {
+				t.Errorf("k8sAPICollector.StreamWebhooks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_k8sAPICollector_StreamNamespaces(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	// 0 namespaces found
+	test1 := func(t *testing.T) (*fake.Clientset, *mocks.NamespaceIngestor) {
+		t.Helper()
+		clientset := fake.NewSimpleClientset()
+		m := mocks.NewNamespaceIngestor(t)
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+		return clientset, m
+	}
+
+	// Listing all the namespaces
+	test2 := func(t *testing.T) (*fake.Clientset, *mocks.NamespaceIngestor) {
+		t.Helper()
+		clienset := fake.NewSimpleClientset(
+			[]runtime.Object{
+				FakeNamespace("namespace1", nil),
+				FakeNamespace("namespace2", map[string]string{"pod-security.kubernetes.io/enforce": "restricted"}),
+			}...,
+		)
+		m := mocks.NewNamespaceIngestor(t)
+		m.EXPECT().IngestNamespace(mock.Anything, mock.AnythingOfType("types.NamespaceType")).Return(nil).Twice()
+		m.EXPECT().Complete(mock.Anything).Return(nil).Once()
+
+		return clienset, m
+	}
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		testfct func(t *testing.T) (*fake.Clientset, *mocks.NamespaceIngestor)
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "no entry",
+			testfct: test1,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "all namespaces",
+			testfct: test2,
+			args: args{
+				ctx: ctx,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clientset, mock := tt.testfct(t)
+			c := NewTestK8sAPICollector(tt.args.ctx, clientset)
+			if err := c.StreamNamespaces(tt.args.ctx, mock); (err != nil) != tt.wantErr {
+				t.Errorf("k8sAPICollector.StreamNamespaces() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
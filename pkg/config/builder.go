@@ -30,6 +30,35 @@ type EdgeBuilderConfig struct {
 	BatchSize                 int  `mapstructure:"batch_size"`                // Batch size for inserts
 	BatchSizeSmall            int  `mapstructure:"batch_size_small"`          // Batch size for expensive inserts
 	BatchSizeClusterImpact    int  `mapstructure:"batch_size_cluster_impact"` // Batch size for inserts impacting entire cluster e.g POD_PATCH
+
+	HostMounts HostMountConfig `mapstructure:"host_mounts"` // Host mount lists used by the EXPLOIT_HOST_* and CE_RUNTIME_SOCKET edges
+}
+
+// HostMountConfig configures the host mount lists used to compute the EXPLOIT_HOST_READ, EXPLOIT_HOST_WRITE and
+// CE_RUNTIME_SOCKET edges.
+type HostMountConfig struct {
+	DisableDefaults bool                     `mapstructure:"disable_defaults"`                          // Do not apply the built-in mount lists
+	SafeWrite       []HostMountRule          `mapstructure:"safe_write" validate:"omitempty,dive"`      // Writable host mounts deemed not exploitable
+	UnsafeRead      []HostMountRule          `mapstructure:"unsafe_read" validate:"omitempty,dive"`     // Read-only host mounts granting execution on the host
+	RuntimeSockets  []HostMountRule          `mapstructure:"runtime_sockets" validate:"omitempty,dive"` // Host mounts exposing a container runtime socket
+	Clusters        []HostMountClusterConfig `mapstructure:"clusters" validate:"omitempty,dive"`        // Per cluster overrides
+}
+
+// HostMountRule matches a host mount source path, either exactly or using a regular expression.
+type HostMountRule struct {
+	Path   string `mapstructure:"path" validate:"required_without=Regex,excluded_with=Regex"` // Exact source path (without trailing slash)
+	Regex  string `mapstructure:"regex" validate:"required_without=Path"`                     // Regular expression matched against the source path
+	Reason string `mapstructure:"reason"`                                                     // Reason recorded in the mountReason property of the graph
+}
+
+// HostMountClusterConfig overrides the host mount lists for a single cluster. The cluster rules are evaluated before
+// the global rules.
+type HostMountClusterConfig struct {
+	Name            string          `mapstructure:"name" validate:"required"`                  // Name of the cluster
+	DisableDefaults bool            `mapstructure:"disable_defaults"`                          // Do not apply the built-in mount lists to this cluster
+	SafeWrite       []HostMountRule `mapstructure:"safe_write" validate:"omitempty,dive"`      // Additional writable host mounts deemed not exploitable
+	UnsafeRead      []HostMountRule `mapstructure:"unsafe_read" validate:"omitempty,dive"`     // Additional read-only host mounts granting execution on the host
+	RuntimeSockets  []HostMountRule `mapstructure:"runtime_sockets" validate:"omitempty,dive"` // Additional host mounts exposing a container runtime socket
 }
 
 type BuilderConfig struct {
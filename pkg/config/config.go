@@ -32,15 +32,20 @@ const (
 
 // KubehoundConfig defines the top-level application configuration for KubeHound.
 type KubehoundConfig struct {
-	Debug      bool             `mapstructure:"debug"`      // Debug mode
-	Collector  CollectorConfig  `mapstructure:"collector"`  // Collector configuration
-	MongoDB    MongoDBConfig    `mapstructure:"mongodb"`    // MongoDB configuration
-	JanusGraph JanusGraphConfig `mapstructure:"janusgraph"` // JanusGraph configuration
-	Storage    StorageConfig    `mapstructure:"storage"`    // Global param for all storage provider
-	Telemetry  TelemetryConfig  `mapstructure:"telemetry"`  // telemetry configuration, contains statsd and other sub structures
-	Builder    BuilderConfig    `mapstructure:"builder"`    // Graph builder  configuration
-	Ingestor   IngestorConfig   `mapstructure:"ingestor"`   // Ingestor configuration
-	Dynamic    DynamicConfig    `mapstructure:"dynamic"`    // Dynamic (i.e runtime generated) configuration
+	Debug         bool                `mapstructure:"debug"`          // Debug mode
+	Collector     CollectorConfig     `mapstructure:"collector"`      // Collector configuration
+	MongoDB       MongoDBConfig       `mapstructure:"mongodb"`        // MongoDB configuration
+	EmbeddedStore EmbeddedStoreConfig `mapstructure:"embedded_store"` // Embedded store configuration
+	DiskCache     DiskCacheConfig     `mapstructure:"disk_cache"`     // Disk cache configuration
+	JanusGraph    JanusGraphConfig    `mapstructure:"janusgraph"`     // JanusGraph configuration
+	Embedded      EmbeddedGraphConfig `mapstructure:"embedded_graph"` // Embedded graph configuration
+	Neo4j         Neo4jConfig         `mapstructure:"neo4j"`          // Neo4j configuration
+	Storage       StorageConfig       `mapstructure:"storage"`        // Global param for all storage provider
+	Telemetry     TelemetryConfig     `mapstructure:"telemetry"`      // telemetry configuration, contains statsd and other sub structures
+	Builder       BuilderConfig       `mapstructure:"builder"`        // Graph builder  configuration
+	Ingestor      IngestorConfig      `mapstructure:"ingestor"`       // Ingestor configuration
+	Risk          RiskConfig          `mapstructure:"risk"`           // Risk engine configuration
+	Dynamic       DynamicConfig       `mapstructure:"dynamic"`        // Dynamic (i.e runtime generated) configuration
 }
 
 // MustLoadEmbedConfig loads the embedded default application configuration, treating all errors as fatal.
@@ -109,6 +114,9 @@ func SetDefaultValues(ctx context.Context, v *viper.Viper) {
 	v.SetDefault("storage.wipe", true)
 	v.SetDefault("storage.retry", DefaultRetry)
 	v.SetDefault("storage.retry_delay", DefaultRetryDelay)
+	v.SetDefault(StorageGraphProvider, DefaultGraphProvider)
+	v.SetDefault(StorageStoreProvider, DefaultStoreProvider)
+	v.SetDefault(StorageCacheProvider, DefaultCacheProvider)
 
 	// Disable Datadog telemetry by default
 	v.SetDefault(TelemetryEnabled, false)
@@ -125,6 +133,12 @@ func SetDefaultValues(ctx context.Context, v *viper.Viper) {
 	v.SetDefault(JanusGraphWriterMaxRetry, defaultJanusGraphWriterMaxRetry)
 	v.SetDefault(JanusGraphWriterWorkerCount, defaultJanusGraphWriterWorkerCount)
 
+	// Defaults values for Neo4j
+	v.SetDefault(Neo4jUrl, DefaultNeo4jUrl)
+	v.SetDefault(Neo4jTimeout, DefaultConnectionTimeout)
+	v.SetDefault(Neo4jWriterTimeout, defaultNeo4jWriterTimeout)
+	v.SetDefault(Neo4jWriterWorkerCount, defaultNeo4jWriterWorkerCount)
+
 	// Profiler values
 	v.SetDefault(TelemetryProfilerPeriod, DefaultProfilerPeriod)
 	v.SetDefault(TelemetryProfilerCPUDuration, DefaultProfilerCPUDuration)
@@ -147,6 +161,9 @@ func SetDefaultValues(ctx context.Context, v *viper.Viper) {
 	v.SetDefault(IngestorMaxArchiveSize, DefaultMaxArchiveSize)
 	v.SetDefault(IngestorArchiveName, DefaultArchiveName)
 
+	// Default values for the risk engine
+	v.SetDefault(RiskDisableDefaultRules, DefaultRiskDisableDefaultRules)
+
 	SetLocalConfig(ctx, v)
 }
 
@@ -161,10 +178,20 @@ func SetEnvOverrides(ctx context.Context, c *viper.Viper) {
 	res = multierror.Append(res, c.BindEnv("collector.file.cluster", "KH_COLLECTOR_TARGET"))
 
 	res = multierror.Append(res, c.BindEnv(MongoUrl, "KH_MONGODB_URL"))
+	res = multierror.Append(res, c.BindEnv(StorageStoreProvider, "KH_STORE_PROVIDER"))
+	res = multierror.Append(res, c.BindEnv(EmbeddedStorePath, "KH_EMBEDDED_STORE_PATH"))
+	res = multierror.Append(res, c.BindEnv(StorageCacheProvider, "KH_CACHE_PROVIDER"))
+	res = multierror.Append(res, c.BindEnv(DiskCachePath, "KH_DISK_CACHE_PATH"))
 	res = multierror.Append(res, c.BindEnv(JanusGraphUrl, "KH_JANUSGRAPH_URL"))
 	res = multierror.Append(res, c.BindEnv(JanusGraphWriterMaxRetry, "KH_JANUSGRAPH_WRITER_MAX_RETRY"))
 	res = multierror.Append(res, c.BindEnv(JanusGraphWriterTimeout, "KH_JANUSGRAPH_WRITER_TIMEOUT"))
 	res = multierror.Append(res, c.BindEnv(JanusGraphWriterWorkerCount, "KH_JANUSGRAPH_WRITER_WORKER_COUNT"))
+	res = multierror.Append(res, c.BindEnv(StorageGraphProvider, "KH_GRAPH_PROVIDER"))
+	res = multierror.Append(res, c.BindEnv(EmbeddedGraphPath, "KH_EMBEDDED_GRAPH_PATH"))
+	res = multierror.Append(res, c.BindEnv(Neo4jUrl, "KH_NEO4J_URL"))
+	res = multierror.Append(res, c.BindEnv(Neo4jUsername, "KH_NEO4J_USERNAME"))
+	res = multierror.Append(res, c.BindEnv(Neo4jPassword, "KH_NEO4J_PASSWORD"))
+	res = multierror.Append(res, c.BindEnv(Neo4jDatabase, "KH_NEO4J_DATABASE"))
 
 	res = multierror.Append(res, c.BindEnv(IngestorAPIEndpoint, "KH_INGESTOR_API_ENDPOINT"))
 	res = multierror.Append(res, c.BindEnv(IngestorAPIInsecure, "KH_INGESTOR_API_INSECURE"))
@@ -179,6 +206,8 @@ func SetEnvOverrides(ctx context.Context, c *viper.Viper) {
 	res = multierror.Append(res, c.BindEnv("builder.edge.batch_size", "KH_BUILDER_EDGE_BATCH_SIZE"))
 	res = multierror.Append(res, c.BindEnv("builder.edge.batch_size_small", "KH_BUILDER_EDGE_BATCH_SIZE_SMALL"))
 
+	res = multierror.Append(res, c.BindEnv(RiskRulesFile, "KH_RISK_RULES_FILE"))
+
 	res = multierror.Append(res, c.BindEnv(TelemetryStatsdUrl, "STATSD_URL"))
 	res = multierror.Append(res, c.BindEnv(TelemetryTracerUrl, "TRACE_AGENT_URL"))
 
@@ -25,9 +25,12 @@ func TestMustLoadConfig(t *testing.T) {
 			},
 			want: &KubehoundConfig{
 				Storage: StorageConfig{
-					RetryDelay: DefaultRetryDelay,
-					Retry:      DefaultRetry,
-					Wipe:       true,
+					RetryDelay:    DefaultRetryDelay,
+					Retry:         DefaultRetry,
+					Wipe:          true,
+					GraphProvider: GraphProviderJanusGraph,
+					StoreProvider: StoreProviderMongoDB,
+					CacheProvider: CacheProviderMemCache,
 				},
 				Collector: CollectorConfig{
 					Type: CollectorTypeFile,
@@ -55,6 +58,12 @@ func TestMustLoadConfig(t *testing.T) {
 					WriterMaxRetry:    defaultJanusGraphWriterMaxRetry,
 					WriterWorkerCount: defaultJanusGraphWriterWorkerCount,
 				},
+				Neo4j: Neo4jConfig{
+					URL:               DefaultNeo4jUrl,
+					ConnectionTimeout: DefaultConnectionTimeout,
+					WriterTimeout:     defaultNeo4jWriterTimeout,
+					WriterWorkerCount: defaultNeo4jWriterWorkerCount,
+				},
 				Telemetry: TelemetryConfig{
 					Statsd: StatsdConfig{
 						URL: "127.0.0.1:8125",
@@ -106,9 +115,12 @@ func TestMustLoadConfig(t *testing.T) {
 			},
 			want: &KubehoundConfig{
 				Storage: StorageConfig{
-					RetryDelay: DefaultRetryDelay,
-					Retry:      DefaultRetry,
-					Wipe:       true,
+					RetryDelay:    DefaultRetryDelay,
+					Retry:         DefaultRetry,
+					Wipe:          true,
+					GraphProvider: GraphProviderJanusGraph,
+					StoreProvider: StoreProviderMongoDB,
+					CacheProvider: CacheProviderMemCache,
 				},
 				Collector: CollectorConfig{
 					Type: CollectorTypeK8sAPI,
@@ -134,6 +146,12 @@ func TestMustLoadConfig(t *testing.T) {
 					WriterMaxRetry:    defaultJanusGraphWriterMaxRetry,
 					WriterWorkerCount: defaultJanusGraphWriterWorkerCount,
 				},
+				Neo4j: Neo4jConfig{
+					URL:               DefaultNeo4jUrl,
+					ConnectionTimeout: DefaultConnectionTimeout,
+					WriterTimeout:     defaultNeo4jWriterTimeout,
+					WriterWorkerCount: defaultNeo4jWriterWorkerCount,
+				},
 				Telemetry: TelemetryConfig{
 					Statsd: StatsdConfig{
 						URL: "127.0.0.1:8125",
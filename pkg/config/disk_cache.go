@@ -0,0 +1,11 @@
+package config
+
+const (
+	DiskCachePath = "disk_cache.path"
+)
+
+// DiskCacheConfig configures the disk-backed cache provider.
+type DiskCacheConfig struct {
+	// Path of the cache file. A temporary file, removed on close, is used if empty.
+	Path string `mapstructure:"path"`
+}
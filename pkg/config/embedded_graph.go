@@ -0,0 +1,11 @@
+package config
+
+const (
+	EmbeddedGraphPath = "embedded_graph.path"
+)
+
+// EmbeddedGraphConfig configures the embedded in-process graph database.
+type EmbeddedGraphConfig struct {
+	// Path of the file used to persist the graph across runs. The graph is kept in memory only if empty.
+	Path string `mapstructure:"path"`
+}
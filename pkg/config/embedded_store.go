@@ -0,0 +1,11 @@
+package config
+
+const (
+	EmbeddedStorePath = "embedded_store.path"
+)
+
+// EmbeddedStoreConfig configures the embedded in-process store database.
+type EmbeddedStoreConfig struct {
+	// Path of the database file. A temporary file, removed on close, is used if empty.
+	Path string `mapstructure:"path"`
+}
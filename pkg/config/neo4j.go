@@ -0,0 +1,33 @@
+package config
+
+import (
+	"time"
+)
+
+const (
+	DefaultNeo4jUrl = "bolt://localhost:7687"
+
+	defaultNeo4jWriterTimeout     = 60 * time.Second
+	defaultNeo4jWriterWorkerCount = 10
+
+	Neo4jUrl               = "neo4j.url"
+	Neo4jUsername          = "neo4j.username"
+	Neo4jPassword          = "neo4j.password" //nolint:gosec // configuration key, not a credential
+	Neo4jDatabase          = "neo4j.database"
+	Neo4jTimeout           = "neo4j.connection_timeout"
+	Neo4jWriterTimeout     = "neo4j.writer_timeout"
+	Neo4jWriterWorkerCount = "neo4j.writer_worker_count"
+)
+
+// Neo4jConfig configures the Bolt protocol graph provider (Neo4j, Memgraph or any openCypher database speaking Bolt).
+type Neo4jConfig struct {
+	URL               string        `mapstructure:"url"`      // Bolt URL of the database (bolt:// or neo4j://)
+	Username          string        `mapstructure:"username"` // Basic auth username (no auth if empty)
+	Password          string        `mapstructure:"password"` // Basic auth password
+	Database          string        `mapstructure:"database"` // Database name (server default database if empty)
+	ConnectionTimeout time.Duration `mapstructure:"connection_timeout"`
+
+	// Neo4j vertex/edge writer configuration
+	WriterTimeout     time.Duration `mapstructure:"writer_timeout"`
+	WriterWorkerCount int           `mapstructure:"writer_worker_count"`
+}
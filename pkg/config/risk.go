@@ -0,0 +1,43 @@
+package config
+
+const (
+	RiskRulesFile           = "risk.rules_file"
+	RiskDisableDefaultRules = "risk.disable_default_rules"
+
+	DefaultRiskDisableDefaultRules = false
+)
+
+// Supported risk rule targets.
+const (
+	RiskTargetRole     = "role"
+	RiskTargetIdentity = "identity"
+	RiskTargetNode     = "node"
+	RiskTargetPod      = "pod"
+)
+
+// RiskConfig configures the rules used by the risk engine to flag critical assets.
+type RiskConfig struct {
+	RulesFile           string     `mapstructure:"rules_file"`                      // Optional YAML file holding additional rules under a top level "rules" key
+	DisableDefaultRules bool       `mapstructure:"disable_default_rules"`           // Do not apply the built-in critical role list
+	Rules               []RiskRule `mapstructure:"rules" validate:"omitempty,dive"` // Inline rules, evaluated before the rules file and the defaults
+}
+
+// RiskRule defines a single critical asset rule. All the populated matchers must match for the rule to fire.
+type RiskRule struct {
+	Name         string            `mapstructure:"name" validate:"required"`                                // Name of the rule, recorded on matching vertices
+	Target       string            `mapstructure:"target" validate:"required,oneof=role identity node pod"` // Type of asset the rule applies to
+	Names        []string          `mapstructure:"names"`                                                   // Glob patterns matched against the asset (or role) name
+	Namespaces   []string          `mapstructure:"namespaces"`                                              // Glob patterns matched against the asset namespace
+	Namespaced   *bool             `mapstructure:"namespaced"`                                              // Restrict the rule to namespaced (true) or cluster-wide (false) assets
+	Selector     string            `mapstructure:"selector"`                                                // Label selector matched against node or pod labels
+	IdentityType string            `mapstructure:"identity_type"`                                           // Identity type (ServiceAccount, User or Group)
+	RBAC         []RiskRBACMatcher `mapstructure:"rbac" validate:"omitempty,dive"`                          // RBAC rules granted by a role, any matcher firing is sufficient
+}
+
+// RiskRBACMatcher matches a role granting every listed verb on every listed resource and API group.
+// A wildcard ("*") in the matcher only matches a wildcard in the role, whereas a wildcard in the role grants any value.
+type RiskRBACMatcher struct {
+	APIGroups []string `mapstructure:"api_groups"`
+	Resources []string `mapstructure:"resources"`
+	Verbs     []string `mapstructure:"verbs"`
+}
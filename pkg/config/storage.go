@@ -8,10 +8,36 @@ const (
 	DefaultRetry             int           = 10 // number of tries before failing
 	DefaultRetryDelay        time.Duration = 10 * time.Second
 	DefaultConnectionTimeout time.Duration = 30 * time.Second
+
+	GraphProviderJanusGraph = "janusgraph" // Remote JanusGraph instance over websocket
+	GraphProviderEmbedded   = "embedded"   // In-process graph, optionally persisted to disk
+	GraphProviderNeo4j      = "neo4j"      // Remote Neo4j/Memgraph instance over Bolt
+	DefaultGraphProvider    = GraphProviderJanusGraph
+
+	StoreProviderMongoDB  = "mongodb"  // Remote MongoDB instance
+	StoreProviderEmbedded = "embedded" // In-process store backed by a local file
+	DefaultStoreProvider  = StoreProviderMongoDB
+
+	CacheProviderMemCache = "memcache" // In-memory map
+	CacheProviderDisk     = "disk"     // On-disk key value store backed by a local file
+	DefaultCacheProvider  = CacheProviderMemCache
+
+	StorageGraphProvider = "storage.graph_provider"
+	StorageStoreProvider = "storage.store_provider"
+	StorageCacheProvider = "storage.cache_provider"
 )
 
 type StorageConfig struct {
 	Retry      int           `mapstructure:"retry"`
 	RetryDelay time.Duration `mapstructure:"retry_delay"`
 	Wipe       bool          `mapstructure:"wipe"`
+
+	// Graph database provider used to store the attack graph
+	GraphProvider string `mapstructure:"graph_provider" validate:"omitempty,oneof=janusgraph embedded neo4j"`
+
+	// Store database provider used to store the ingested resources
+	StoreProvider string `mapstructure:"store_provider" validate:"omitempty,oneof=mongodb embedded"`
+
+	// Cache provider used for the intermediate K8s relationship data
+	CacheProvider string `mapstructure:"cache_provider" validate:"omitempty,oneof=memcache disk"`
 }
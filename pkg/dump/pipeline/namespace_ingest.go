@@ -0,0 +1,38 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	"github.com/DataDog/KubeHound/pkg/dump/writer"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+type NamespaceIngestor struct {
+	buffer map[string]*corev1.NamespaceList
+	writer writer.DumperWriter
+}
+
+func NewNamespaceIngestor(ctx context.Context, dumpWriter writer.DumperWriter) *NamespaceIngestor {
+	return &NamespaceIngestor{
+		buffer: make(map[string]*corev1.NamespaceList),
+		writer: dumpWriter,
+	}
+}
+
+func (d *NamespaceIngestor) IngestNamespace(ctx context.Context, namespace types.NamespaceType) error {
+	if ok, err := preflight.CheckNamespace(namespace); !ok {
+		return err
+	}
+
+	return bufferObject[corev1.NamespaceList, types.NamespaceType](ctx, collector.NamespacePath, d.buffer, namespace)
+}
+
+// Complete() is invoked by the collector when all k8s assets have been streamed.
+// The function flushes all writers and waits for completion.
+func (d *NamespaceIngestor) Complete(ctx context.Context) error {
+	return dumpObj[*corev1.NamespaceList](ctx, d.buffer, d.writer)
+}
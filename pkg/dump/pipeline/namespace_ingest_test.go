@@ -0,0 +1,92 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	mockwriter "github.com/DataDog/KubeHound/pkg/dump/writer/mockwriter"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDumpIngestor_IngestNamespace(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	// no ingestion
+	noIngest := func(t *testing.T, _ []types.NamespaceType) *NamespaceIngestor {
+		t.Helper()
+		mDumpWriter := mockwriter.NewDumperWriter(t)
+		ingestor := NewNamespaceIngestor(ctx, mDumpWriter)
+
+		return ingestor
+	}
+
+	// ingesting n entries
+	nIngest := func(t *testing.T, namespaces []types.NamespaceType) *NamespaceIngestor {
+		t.Helper()
+		mDumpWriter := mockwriter.NewDumperWriter(t)
+		ingestor := NewNamespaceIngestor(ctx, mDumpWriter)
+
+		buffer := &corev1.NamespaceList{}
+
+		for _, namespace := range namespaces {
+			buffer.Items = append(buffer.Items, *namespace)
+		}
+		rawBuffer, err := json.Marshal(buffer)
+		if err != nil {
+			t.Fatalf("failed to marshal Kubernetes object: %v", err)
+		}
+		mDumpWriter.EXPECT().Write(mock.Anything, rawBuffer, collector.NamespacePath).Return(nil).Once()
+
+		return ingestor
+	}
+
+	type args struct {
+		namespaces []types.NamespaceType
+	}
+	tests := []struct {
+		name    string
+		testfct func(t *testing.T, namespaces []types.NamespaceType) *NamespaceIngestor
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "no entry",
+			testfct: noIngest,
+			args: args{
+				namespaces: []types.NamespaceType{
+					nil,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "entries found",
+			testfct: nIngest,
+			args: args{
+				namespaces: []types.NamespaceType{
+					collector.FakeNamespace("name1", nil),
+					collector.FakeNamespace("name2", map[string]string{"pod-security.kubernetes.io/enforce": "restricted"}),
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ingestor := tt.testfct(t, tt.args.namespaces)
+			for _, namespace := range tt.args.namespaces {
+				if err := ingestor.IngestNamespace(ctx, namespace); (err != nil) != tt.wantErr {
+					t.Errorf("Dumper.IngestNamespace() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+			if err := ingestor.Complete(ctx); err != nil {
+				t.Errorf("Dumper.IngestNamespace() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package pipeline
+
+import (
+	"context"
+	"path"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	"github.com/DataDog/KubeHound/pkg/dump/writer"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	netv1 "k8s.io/api/networking/v1"
+)
+
+type NetworkPolicyIngestor struct {
+	buffer map[string]*netv1.NetworkPolicyList
+	writer writer.DumperWriter
+}
+
+func ingestNetworkPolicyPath(networkPolicy types.NetworkPolicyType) string {
+	return path.Join(networkPolicy.Namespace, collector.NetworkPolicyPath)
+}
+
+func NewNetworkPolicyIngestor(ctx context.Context, dumpWriter writer.DumperWriter) *NetworkPolicyIngestor {
+	return &NetworkPolicyIngestor{
+		buffer: make(map[string]*netv1.NetworkPolicyList),
+		writer: dumpWriter,
+	}
+}
+
+func (d *NetworkPolicyIngestor) IngestNetworkPolicy(ctx context.Context, networkPolicy types.NetworkPolicyType) error {
+	if ok, err := preflight.CheckNetworkPolicy(networkPolicy); !ok {
+		return err
+	}
+
+	networkPolicyPath := ingestNetworkPolicyPath(networkPolicy)
+
+	return bufferObject[netv1.NetworkPolicyList, types.NetworkPolicyType](ctx, networkPolicyPath, d.buffer, networkPolicy)
+}
+
+// Complete() is invoked by the collector when all k8s assets have been streamed.
+// The function flushes all writers and waits for completion.
+func (d *NetworkPolicyIngestor) Complete(ctx context.Context) error {
+	return dumpObj[*netv1.NetworkPolicyList](ctx, d.buffer, d.writer)
+}
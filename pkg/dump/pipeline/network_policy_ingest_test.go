@@ -0,0 +1,97 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	mockwriter "github.com/DataDog/KubeHound/pkg/dump/writer/mockwriter"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	netv1 "k8s.io/api/networking/v1"
+)
+
+func TestDumpIngestor_IngestNetworkPolicy(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	// no ingestion
+	noIngest := func(t *testing.T, _ []*netv1.NetworkPolicy) *NetworkPolicyIngestor {
+		t.Helper()
+		mDumpWriter := mockwriter.NewDumperWriter(t)
+		ingestor := NewNetworkPolicyIngestor(ctx, mDumpWriter)
+
+		return ingestor
+	}
+
+	// ingesting n entries
+	nIngest := func(t *testing.T, networkPolicies []*netv1.NetworkPolicy) *NetworkPolicyIngestor {
+		t.Helper()
+		mDumpWriter := mockwriter.NewDumperWriter(t)
+		ingestor := NewNetworkPolicyIngestor(ctx, mDumpWriter)
+
+		buffer := make(map[string]*netv1.NetworkPolicyList)
+		for _, networkPolicy := range networkPolicies {
+			err := bufferObject[netv1.NetworkPolicyList, types.NetworkPolicyType](ctx, ingestNetworkPolicyPath(networkPolicy), buffer, networkPolicy)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for path, networkPolicyListNamespaced := range buffer {
+			rawBuffer, err := json.Marshal(networkPolicyListNamespaced)
+			if err != nil {
+				t.Fatalf("failed to marshal Kubernetes object: %v", err)
+			}
+			mDumpWriter.EXPECT().Write(ctx, rawBuffer, path).Return(nil).Once()
+		}
+
+		return ingestor
+	}
+
+	type args struct {
+		networkPolicies []*netv1.NetworkPolicy
+	}
+	tests := []struct {
+		name     string
+		ingestor *NetworkPolicyIngestor
+		testfct  func(t *testing.T, networkPolicies []*netv1.NetworkPolicy) *NetworkPolicyIngestor
+		args     args
+		wantErr  bool
+	}{
+		{
+			name:    "no entry",
+			testfct: noIngest,
+			args: args{
+				networkPolicies: []*netv1.NetworkPolicy{
+					nil,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "entries found",
+			testfct: nIngest,
+			args: args{
+				networkPolicies: []*netv1.NetworkPolicy{
+					collector.FakeNetworkPolicy("namespace1", "name1"),
+					collector.FakeNetworkPolicy("namespace2", "name2"),
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ingestor := tt.testfct(t, tt.args.networkPolicies)
+			for _, networkPolicy := range tt.args.networkPolicies {
+				if err := ingestor.IngestNetworkPolicy(ctx, networkPolicy); (err != nil) != tt.wantErr {
+					t.Errorf("Dumper.IngestNetworkPolicy() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+			if err := ingestor.Complete(ctx); err != nil {
+				t.Errorf("Dumper.IngestNetworkPolicy() error = %v", err)
+			}
+		})
+	}
+}
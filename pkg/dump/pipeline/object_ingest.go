@@ -9,8 +9,12 @@ import (
 	"github.com/DataDog/KubeHound/pkg/dump/writer"
 	"github.com/DataDog/KubeHound/pkg/globals/types"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
+	netv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 )
 
@@ -47,6 +51,84 @@ func addK8sObjToTypeList(_ context.Context, object any, objectList any) error {
 			return fmt.Errorf("failed to cast object to EndpointType: %s", reflect.TypeOf(object).String())
 		}
 		o.Items = append(o.Items, *val)
+	case *corev1.SecretList:
+		val, ok := object.(types.SecretType)
+		if !ok {
+			return fmt.Errorf("failed to cast object to SecretType: %s", reflect.TypeOf(object).String())
+		}
+		o.Items = append(o.Items, *val)
+	case *corev1.ServiceAccountList:
+		val, ok := object.(types.ServiceAccountType)
+		if !ok {
+			return fmt.Errorf("failed to cast object to ServiceAccountType: %s", reflect.TypeOf(object).String())
+		}
+		o.Items = append(o.Items, *val)
+	case *appsv1.DeploymentList:
+		val, ok := object.(types.DeploymentType)
+		if !ok {
+			return fmt.Errorf("failed to cast object to DeploymentType: %s", reflect.TypeOf(object).String())
+		}
+		o.Items = append(o.Items, *val)
+	case *appsv1.DaemonSetList:
+		val, ok := object.(types.DaemonSetType)
+		if !ok {
+			return fmt.Errorf("failed to cast object to DaemonSetType: %s", reflect.TypeOf(object).String())
+		}
+		o.Items = append(o.Items, *val)
+	case *appsv1.StatefulSetList:
+		val, ok := object.(types.StatefulSetType)
+		if !ok {
+			return fmt.Errorf("failed to cast object to StatefulSetType: %s", reflect.TypeOf(object).String())
+		}
+		o.Items = append(o.Items, *val)
+	case *batchv1.JobList:
+		val, ok := object.(types.JobType)
+		if !ok {
+			return fmt.Errorf("failed to cast object to JobType: %s", reflect.TypeOf(object).String())
+		}
+		o.Items = append(o.Items, *val)
+	case *batchv1.CronJobList:
+		val, ok := object.(types.CronJobType)
+		if !ok {
+			return fmt.Errorf("failed to cast object to CronJobType: %s", reflect.TypeOf(object).String())
+		}
+		o.Items = append(o.Items, *val)
+	case *netv1.NetworkPolicyList:
+		val, ok := object.(types.NetworkPolicyType)
+		if !ok {
+			return fmt.Errorf("failed to cast object to NetworkPolicyType: %s", reflect.TypeOf(object).String())
+		}
+		o.Items = append(o.Items, *val)
+	case *corev1.PersistentVolumeList:
+		val, ok := object.(types.PersistentVolumeType)
+		if !ok {
+			return fmt.Errorf("failed to cast object to PersistentVolumeType: %s", reflect.TypeOf(object).String())
+		}
+		o.Items = append(o.Items, *val)
+	case *corev1.PersistentVolumeClaimList:
+		val, ok := object.(types.PersistentVolumeClaimType)
+		if !ok {
+			return fmt.Errorf("failed to cast object to PersistentVolumeClaimType: %s", reflect.TypeOf(object).String())
+		}
+		o.Items = append(o.Items, *val)
+	case *admissionregistrationv1.MutatingWebhookConfigurationList:
+		val, ok := object.(types.MutatingWebhookConfigurationType)
+		if !ok {
+			return fmt.Errorf("failed to cast object to MutatingWebhookConfigurationType: %s", reflect.TypeOf(object).String())
+		}
+		o.Items = append(o.Items, *val)
+	case *admissionregistrationv1.ValidatingWebhookConfigurationList:
+		val, ok := object.(types.ValidatingWebhookConfigurationType)
+		if !ok {
+			return fmt.Errorf("failed to cast object to ValidatingWebhookConfigurationType: %s", reflect.TypeOf(object).String())
+		}
+		o.Items = append(o.Items, *val)
+	case *corev1.NamespaceList:
+		val, ok := object.(types.NamespaceType)
+		if !ok {
+			return fmt.Errorf("failed to cast object to NamespaceType: %s", reflect.TypeOf(object).String())
+		}
+		o.Items = append(o.Items, *val)
 	case *corev1.PodList:
 		val, ok := object.(types.PodType)
 		if !ok {
@@ -0,0 +1,60 @@
+package pipeline
+
+import (
+	"context"
+	"path"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	"github.com/DataDog/KubeHound/pkg/dump/writer"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PersistentVolumeIngestor dumps the cluster persistent volumes in a root file and the persistent volume claims
+// in a dedicated file in each namespace.
+type PersistentVolumeIngestor struct {
+	volumes map[string]*corev1.PersistentVolumeList
+	claims  map[string]*corev1.PersistentVolumeClaimList
+	writer  writer.DumperWriter
+}
+
+func ingestPersistentVolumeClaimPath(claim types.PersistentVolumeClaimType) string {
+	return path.Join(claim.Namespace, collector.PersistentVolumeClaimPath)
+}
+
+func NewPersistentVolumeIngestor(ctx context.Context, dumpWriter writer.DumperWriter) *PersistentVolumeIngestor {
+	return &PersistentVolumeIngestor{
+		volumes: make(map[string]*corev1.PersistentVolumeList),
+		claims:  make(map[string]*corev1.PersistentVolumeClaimList),
+		writer:  dumpWriter,
+	}
+}
+
+func (d *PersistentVolumeIngestor) IngestPersistentVolume(ctx context.Context, volume types.PersistentVolumeType) error {
+	if ok, err := preflight.CheckPersistentVolume(volume); !ok {
+		return err
+	}
+
+	return bufferObject[corev1.PersistentVolumeList, types.PersistentVolumeType](ctx, collector.PersistentVolumePath, d.volumes, volume)
+}
+
+func (d *PersistentVolumeIngestor) IngestPersistentVolumeClaim(ctx context.Context, claim types.PersistentVolumeClaimType) error {
+	if ok, err := preflight.CheckPersistentVolumeClaim(claim); !ok {
+		return err
+	}
+
+	claimPath := ingestPersistentVolumeClaimPath(claim)
+
+	return bufferObject[corev1.PersistentVolumeClaimList, types.PersistentVolumeClaimType](ctx, claimPath, d.claims, claim)
+}
+
+// Complete() is invoked by the collector when all k8s assets have been streamed.
+// The function flushes all writers and waits for completion.
+func (d *PersistentVolumeIngestor) Complete(ctx context.Context) error {
+	if err := dumpObj[*corev1.PersistentVolumeList](ctx, d.volumes, d.writer); err != nil {
+		return err
+	}
+
+	return dumpObj[*corev1.PersistentVolumeClaimList](ctx, d.claims, d.writer)
+}
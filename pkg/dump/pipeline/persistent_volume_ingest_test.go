@@ -0,0 +1,127 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	mockwriter "github.com/DataDog/KubeHound/pkg/dump/writer/mockwriter"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDumpIngestor_IngestPersistentVolume(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	// no ingestion
+	noIngest := func(t *testing.T, _ []*corev1.PersistentVolume, _ []*corev1.PersistentVolumeClaim) *PersistentVolumeIngestor {
+		t.Helper()
+		mDumpWriter := mockwriter.NewDumperWriter(t)
+		ingestor := NewPersistentVolumeIngestor(ctx, mDumpWriter)
+
+		return ingestor
+	}
+
+	// ingesting n entries, volumes are dumped to a single root file and claims to a file per namespace
+	nIngest := func(t *testing.T, volumes []*corev1.PersistentVolume, claims []*corev1.PersistentVolumeClaim) *PersistentVolumeIngestor {
+		t.Helper()
+		mDumpWriter := mockwriter.NewDumperWriter(t)
+		ingestor := NewPersistentVolumeIngestor(ctx, mDumpWriter)
+
+		volumeBuffer := make(map[string]*corev1.PersistentVolumeList)
+		for _, volume := range volumes {
+			err := bufferObject[corev1.PersistentVolumeList, types.PersistentVolumeType](ctx,
+				collector.PersistentVolumePath, volumeBuffer, volume)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for path, volumeList := range volumeBuffer {
+			rawBuffer, err := json.Marshal(volumeList)
+			if err != nil {
+				t.Fatalf("failed to marshal Kubernetes object: %v", err)
+			}
+			mDumpWriter.EXPECT().Write(ctx, rawBuffer, path).Return(nil).Once()
+		}
+
+		claimBuffer := make(map[string]*corev1.PersistentVolumeClaimList)
+		for _, claim := range claims {
+			err := bufferObject[corev1.PersistentVolumeClaimList, types.PersistentVolumeClaimType](ctx,
+				ingestPersistentVolumeClaimPath(claim), claimBuffer, claim)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for path, claimListNamespaced := range claimBuffer {
+			rawBuffer, err := json.Marshal(claimListNamespaced)
+			if err != nil {
+				t.Fatalf("failed to marshal Kubernetes object: %v", err)
+			}
+			mDumpWriter.EXPECT().Write(ctx, rawBuffer, path).Return(nil).Once()
+		}
+
+		return ingestor
+	}
+
+	type args struct {
+		volumes []*corev1.PersistentVolume
+		claims  []*corev1.PersistentVolumeClaim
+	}
+	tests := []struct {
+		name    string
+		testfct func(t *testing.T, volumes []*corev1.PersistentVolume, claims []*corev1.PersistentVolumeClaim) *PersistentVolumeIngestor
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "no entry",
+			testfct: noIngest,
+			args: args{
+				volumes: []*corev1.PersistentVolume{
+					nil,
+				},
+				claims: []*corev1.PersistentVolumeClaim{
+					nil,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "entries found",
+			testfct: nIngest,
+			args: args{
+				volumes: []*corev1.PersistentVolume{
+					collector.FakePersistentVolume("name1", "/var/lib/name1"),
+					collector.FakePersistentVolume("name2", "/var/lib/name2"),
+				},
+				claims: []*corev1.PersistentVolumeClaim{
+					collector.FakePersistentVolumeClaim("namespace1", "name1", "name1"),
+					collector.FakePersistentVolumeClaim("namespace2", "name2", "name2"),
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ingestor := tt.testfct(t, tt.args.volumes, tt.args.claims)
+			for _, volume := range tt.args.volumes {
+				if err := ingestor.IngestPersistentVolume(ctx, volume); (err != nil) != tt.wantErr {
+					t.Errorf("Dumper.IngestPersistentVolume() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+			for _, claim := range tt.args.claims {
+				if err := ingestor.IngestPersistentVolumeClaim(ctx, claim); (err != nil) != tt.wantErr {
+					t.Errorf("Dumper.IngestPersistentVolumeClaim() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+			if err := ingestor.Complete(ctx); err != nil {
+				t.Errorf("Dumper.IngestPersistentVolume() error = %v", err)
+			}
+		})
+	}
+}
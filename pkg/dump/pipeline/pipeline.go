@@ -74,6 +74,55 @@ func dumpIngestorSequence(collector collector.CollectorClient, writer writer.Dum
 				return collector.StreamEndpoints(ctx, NewEndpointIngestor(ctx, writer))
 			},
 		},
+		{
+			operationName: span.DumperSecrets,
+			entity:        tag.EntitySecrets,
+			streamFunc: func(ctx context.Context) error {
+				return collector.StreamSecrets(ctx, NewSecretIngestor(ctx, writer))
+			},
+		},
+		{
+			operationName: span.DumperServiceAccounts,
+			entity:        tag.EntityServiceAccounts,
+			streamFunc: func(ctx context.Context) error {
+				return collector.StreamServiceAccounts(ctx, NewServiceAccountIngestor(ctx, writer))
+			},
+		},
+		{
+			operationName: span.DumperWorkloads,
+			entity:        tag.EntityWorkloads,
+			streamFunc: func(ctx context.Context) error {
+				return collector.StreamWorkloads(ctx, NewWorkloadIngestor(ctx, writer))
+			},
+		},
+		{
+			operationName: span.DumperNetworkPolicies,
+			entity:        tag.EntityNetworkPolicies,
+			streamFunc: func(ctx context.Context) error {
+				return collector.StreamNetworkPolicies(ctx, NewNetworkPolicyIngestor(ctx, writer))
+			},
+		},
+		{
+			operationName: span.DumperPersistentVolumes,
+			entity:        tag.EntityPersistentVolumes,
+			streamFunc: func(ctx context.Context) error {
+				return collector.StreamPersistentVolumes(ctx, NewPersistentVolumeIngestor(ctx, writer))
+			},
+		},
+		{
+			operationName: span.DumperWebhooks,
+			entity:        tag.EntityWebhooks,
+			streamFunc: func(ctx context.Context) error {
+				return collector.StreamWebhooks(ctx, NewWebhookIngestor(ctx, writer))
+			},
+		},
+		{
+			operationName: span.DumperNamespaces,
+			entity:        tag.EntityNamespaces,
+			streamFunc: func(ctx context.Context) error {
+				return collector.StreamNamespaces(ctx, NewNamespaceIngestor(ctx, writer))
+			},
+		},
 	}
 }
 
@@ -13,8 +13,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
+	netv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 )
 
@@ -65,6 +69,56 @@ func PipelineLiveTest(ctx context.Context, t *testing.T, workerNum int) (*mockwr
 			}
 			path := fmt.Sprintf("%s/%s", k8sObj.Namespace, collector.EndpointPath)
 			countK8sObjectsByFile[path]++
+		case reflect.TypeOf(&corev1.Secret{}):
+			k8sObj, ok := rObj.(*corev1.Secret)
+			if !ok {
+				t.Fatalf("failed to cast object to SecretType: %s", reflectType.String())
+			}
+			path := fmt.Sprintf("%s/%s", k8sObj.Namespace, collector.SecretPath)
+			countK8sObjectsByFile[path]++
+		case reflect.TypeOf(&corev1.ServiceAccount{}):
+			k8sObj, ok := rObj.(*corev1.ServiceAccount)
+			if !ok {
+				t.Fatalf("failed to cast object to ServiceAccountType: %s", reflectType.String())
+			}
+			path := fmt.Sprintf("%s/%s", k8sObj.Namespace, collector.ServiceAccountPath)
+			countK8sObjectsByFile[path]++
+		case reflect.TypeOf(&appsv1.Deployment{}):
+			k8sObj, ok := rObj.(*appsv1.Deployment)
+			if !ok {
+				t.Fatalf("failed to cast object to DeploymentType: %s", reflectType.String())
+			}
+			path := fmt.Sprintf("%s/%s", k8sObj.Namespace, collector.DeploymentPath)
+			countK8sObjectsByFile[path]++
+		case reflect.TypeOf(&batchv1.CronJob{}):
+			k8sObj, ok := rObj.(*batchv1.CronJob)
+			if !ok {
+				t.Fatalf("failed to cast object to CronJobType: %s", reflectType.String())
+			}
+			path := fmt.Sprintf("%s/%s", k8sObj.Namespace, collector.CronJobPath)
+			countK8sObjectsByFile[path]++
+		case reflect.TypeOf(&netv1.NetworkPolicy{}):
+			k8sObj, ok := rObj.(*netv1.NetworkPolicy)
+			if !ok {
+				t.Fatalf("failed to cast object to NetworkPolicyType: %s", reflectType.String())
+			}
+			path := fmt.Sprintf("%s/%s", k8sObj.Namespace, collector.NetworkPolicyPath)
+			countK8sObjectsByFile[path]++
+		case reflect.TypeOf(&corev1.PersistentVolume{}):
+			countK8sObjectsByFile[collector.PersistentVolumePath]++
+		case reflect.TypeOf(&corev1.PersistentVolumeClaim{}):
+			k8sObj, ok := rObj.(*corev1.PersistentVolumeClaim)
+			if !ok {
+				t.Fatalf("failed to cast object to PersistentVolumeClaimType: %s", reflectType.String())
+			}
+			path := fmt.Sprintf("%s/%s", k8sObj.Namespace, collector.PersistentVolumeClaimPath)
+			countK8sObjectsByFile[path]++
+		case reflect.TypeOf(&admissionregistrationv1.MutatingWebhookConfiguration{}):
+			countK8sObjectsByFile[collector.MutatingWebhookPath]++
+		case reflect.TypeOf(&admissionregistrationv1.ValidatingWebhookConfiguration{}):
+			countK8sObjectsByFile[collector.ValidatingWebhookPath]++
+		case reflect.TypeOf(&corev1.Namespace{}):
+			countK8sObjectsByFile[collector.NamespacePath]++
 		default:
 			t.Fatalf("unknown object type to cast: %s", reflectType.String())
 		}
@@ -117,6 +171,20 @@ func GenK8sObjects() []runtime.Object {
 		collector.FakeEndpoint("namespace1", "name12", []int32{80}),
 		collector.FakeEndpoint("namespace2", "name21", []int32{80}),
 		collector.FakeEndpoint("namespace2", "name22", []int32{80}),
+		collector.FakeSecret("namespace1", "name11", corev1.SecretTypeServiceAccountToken),
+		collector.FakeSecret("namespace2", "name21", corev1.SecretTypeOpaque),
+		collector.FakeServiceAccount("namespace1", "name11"),
+		collector.FakeServiceAccount("namespace2", "name21"),
+		collector.FakeDeployment("namespace1", "name11"),
+		collector.FakeCronJob("namespace2", "name21"),
+		collector.FakeNetworkPolicy("namespace1", "name11"),
+		collector.FakeNetworkPolicy("namespace2", "name21"),
+		collector.FakePersistentVolume("name11", "/var/lib/name11"),
+		collector.FakePersistentVolumeClaim("namespace1", "name11", "name11"),
+		collector.FakeMutatingWebhookConfiguration("name11", "namespace1", "name11"),
+		collector.FakeValidatingWebhookConfiguration("name21", "https://name21.example.com"),
+		collector.FakeNamespace("namespace1", nil),
+		collector.FakeNamespace("namespace2", map[string]string{"pod-security.kubernetes.io/enforce": "restricted"}),
 	}
 
 	return k8sOjb
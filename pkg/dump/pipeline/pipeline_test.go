@@ -58,7 +58,7 @@ func TestPipelineDumpIngestor_Run(t *testing.T) {
 		sequence := dumpIngestorSequence(mCollectorClient, mDumpWriter)
 
 		mDumpWriter.EXPECT().WorkerNumber().Return(1)
-		var mStreamNodes, mStreamPods, mStreamRoles, mStreamClusterRoles, mStreamRoleBindings, mStreamClusteRoleBindings *mock.Call
+		var mStreamNodes, mStreamPods, mStreamRoles, mStreamClusterRoles, mStreamRoleBindings, mStreamClusteRoleBindings, mStreamEndpoints, mStreamSecrets, mStreamServiceAccounts, mStreamWorkloads, mStreamNetworkPolicies, mStreamPersistentVolumes, mStreamWebhooks *mock.Call
 
 		for _, step := range sequence {
 			switch step.entity {
@@ -75,7 +75,21 @@ func TestPipelineDumpIngestor_Run(t *testing.T) {
 			case tag.EntityClusterRolebindings:
 				mStreamClusteRoleBindings = mCollectorClient.EXPECT().StreamClusterRoleBindings(mock.Anything, NewClusterRoleBindingIngestor(ctx, mDumpWriter)).Return(nil).Once().NotBefore(mStreamRoleBindings)
 			case tag.EntityEndpoints:
-				mCollectorClient.EXPECT().StreamEndpoints(mock.Anything, NewEndpointIngestor(ctx, mDumpWriter)).Return(nil).Once().NotBefore(mStreamClusteRoleBindings)
+				mStreamEndpoints = mCollectorClient.EXPECT().StreamEndpoints(mock.Anything, NewEndpointIngestor(ctx, mDumpWriter)).Return(nil).Once().NotBefore(mStreamClusteRoleBindings)
+			case tag.EntitySecrets:
+				mStreamSecrets = mCollectorClient.EXPECT().StreamSecrets(mock.Anything, NewSecretIngestor(ctx, mDumpWriter)).Return(nil).Once().NotBefore(mStreamEndpoints)
+			case tag.EntityServiceAccounts:
+				mStreamServiceAccounts = mCollectorClient.EXPECT().StreamServiceAccounts(mock.Anything, NewServiceAccountIngestor(ctx, mDumpWriter)).Return(nil).Once().NotBefore(mStreamSecrets)
+			case tag.EntityWorkloads:
+				mStreamWorkloads = mCollectorClient.EXPECT().StreamWorkloads(mock.Anything, NewWorkloadIngestor(ctx, mDumpWriter)).Return(nil).Once().NotBefore(mStreamServiceAccounts)
+			case tag.EntityNetworkPolicies:
+				mStreamNetworkPolicies = mCollectorClient.EXPECT().StreamNetworkPolicies(mock.Anything, NewNetworkPolicyIngestor(ctx, mDumpWriter)).Return(nil).Once().NotBefore(mStreamWorkloads)
+			case tag.EntityPersistentVolumes:
+				mStreamPersistentVolumes = mCollectorClient.EXPECT().StreamPersistentVolumes(mock.Anything, NewPersistentVolumeIngestor(ctx, mDumpWriter)).Return(nil).Once().NotBefore(mStreamNetworkPolicies)
+			case tag.EntityWebhooks:
+				mStreamWebhooks = mCollectorClient.EXPECT().StreamWebhooks(mock.Anything, NewWebhookIngestor(ctx, mDumpWriter)).Return(nil).Once().NotBefore(mStreamPersistentVolumes)
+			case tag.EntityNamespaces:
+				mCollectorClient.EXPECT().StreamNamespaces(mock.Anything, NewNamespaceIngestor(ctx, mDumpWriter)).Return(nil).Once().NotBefore(mStreamWebhooks)
 			}
 		}
 
@@ -112,6 +126,20 @@ func TestPipelineDumpIngestor_Run(t *testing.T) {
 				mCollectorClient.EXPECT().StreamClusterRoleBindings(mock.Anything, NewClusterRoleBindingIngestor(ctx, mDumpWriter)).Return(nil).Once()
 			case tag.EntityEndpoints:
 				mCollectorClient.EXPECT().StreamEndpoints(mock.Anything, NewEndpointIngestor(ctx, mDumpWriter)).Return(nil).Once()
+			case tag.EntitySecrets:
+				mCollectorClient.EXPECT().StreamSecrets(mock.Anything, NewSecretIngestor(ctx, mDumpWriter)).Return(nil).Once()
+			case tag.EntityServiceAccounts:
+				mCollectorClient.EXPECT().StreamServiceAccounts(mock.Anything, NewServiceAccountIngestor(ctx, mDumpWriter)).Return(nil).Once()
+			case tag.EntityWorkloads:
+				mCollectorClient.EXPECT().StreamWorkloads(mock.Anything, NewWorkloadIngestor(ctx, mDumpWriter)).Return(nil).Once()
+			case tag.EntityNetworkPolicies:
+				mCollectorClient.EXPECT().StreamNetworkPolicies(mock.Anything, NewNetworkPolicyIngestor(ctx, mDumpWriter)).Return(nil).Once()
+			case tag.EntityPersistentVolumes:
+				mCollectorClient.EXPECT().StreamPersistentVolumes(mock.Anything, NewPersistentVolumeIngestor(ctx, mDumpWriter)).Return(nil).Once()
+			case tag.EntityWebhooks:
+				mCollectorClient.EXPECT().StreamWebhooks(mock.Anything, NewWebhookIngestor(ctx, mDumpWriter)).Return(nil).Once()
+			case tag.EntityNamespaces:
+				mCollectorClient.EXPECT().StreamNamespaces(mock.Anything, NewNamespaceIngestor(ctx, mDumpWriter)).Return(nil).Once()
 			}
 		}
 
@@ -0,0 +1,44 @@
+package pipeline
+
+import (
+	"context"
+	"path"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	"github.com/DataDog/KubeHound/pkg/dump/writer"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type SecretIngestor struct {
+	buffer map[string]*corev1.SecretList
+	writer writer.DumperWriter
+}
+
+func ingestSecretPath(secret types.SecretType) string {
+	return path.Join(secret.Namespace, collector.SecretPath)
+}
+
+func NewSecretIngestor(ctx context.Context, dumpWriter writer.DumperWriter) *SecretIngestor {
+	return &SecretIngestor{
+		buffer: make(map[string]*corev1.SecretList),
+		writer: dumpWriter,
+	}
+}
+
+func (d *SecretIngestor) IngestSecret(ctx context.Context, secret types.SecretType) error {
+	if ok, err := preflight.CheckSecret(secret); !ok {
+		return err
+	}
+
+	secretPath := ingestSecretPath(secret)
+
+	return bufferObject[corev1.SecretList, types.SecretType](ctx, secretPath, d.buffer, secret)
+}
+
+// Complete() is invoked by the collector when all k8s assets have been streamed.
+// The function flushes all writers and waits for completion.
+func (d *SecretIngestor) Complete(ctx context.Context) error {
+	return dumpObj[*corev1.SecretList](ctx, d.buffer, d.writer)
+}
@@ -0,0 +1,97 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	mockwriter "github.com/DataDog/KubeHound/pkg/dump/writer/mockwriter"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDumpIngestor_IngestSecret(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	// no ingestion
+	noIngest := func(t *testing.T, _ []*corev1.Secret) *SecretIngestor {
+		t.Helper()
+		mDumpWriter := mockwriter.NewDumperWriter(t)
+		ingestor := NewSecretIngestor(ctx, mDumpWriter)
+
+		return ingestor
+	}
+
+	// ingesting n entries
+	nIngest := func(t *testing.T, secrets []*corev1.Secret) *SecretIngestor {
+		t.Helper()
+		mDumpWriter := mockwriter.NewDumperWriter(t)
+		ingestor := NewSecretIngestor(ctx, mDumpWriter)
+
+		buffer := make(map[string]*corev1.SecretList)
+		for _, secret := range secrets {
+			err := bufferObject[corev1.SecretList, types.SecretType](ctx, ingestSecretPath(secret), buffer, secret)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for path, secretListNamespaced := range buffer {
+			rawBuffer, err := json.Marshal(secretListNamespaced)
+			if err != nil {
+				t.Fatalf("failed to marshal Kubernetes object: %v", err)
+			}
+			mDumpWriter.EXPECT().Write(ctx, rawBuffer, path).Return(nil).Once()
+		}
+
+		return ingestor
+	}
+
+	type args struct {
+		secrets []*corev1.Secret
+	}
+	tests := []struct {
+		name     string
+		ingestor *SecretIngestor
+		testfct  func(t *testing.T, secrets []*corev1.Secret) *SecretIngestor
+		args     args
+		wantErr  bool
+	}{
+		{
+			name:    "no entry",
+			testfct: noIngest,
+			args: args{
+				secrets: []*corev1.Secret{
+					nil,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "entries found",
+			testfct: nIngest,
+			args: args{
+				secrets: []*corev1.Secret{
+					collector.FakeSecret("namespace1", "name1", corev1.SecretTypeServiceAccountToken),
+					collector.FakeSecret("namespace2", "name2", corev1.SecretTypeOpaque),
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ingestor := tt.testfct(t, tt.args.secrets)
+			for _, secret := range tt.args.secrets {
+				if err := ingestor.IngestSecret(ctx, secret); (err != nil) != tt.wantErr {
+					t.Errorf("Dumper.IngestSecret() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+			if err := ingestor.Complete(ctx); err != nil {
+				t.Errorf("Dumper.IngestSecret() error = %v", err)
+			}
+		})
+	}
+}
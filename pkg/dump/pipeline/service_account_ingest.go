@@ -0,0 +1,44 @@
+package pipeline
+
+import (
+	"context"
+	"path"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	"github.com/DataDog/KubeHound/pkg/dump/writer"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type ServiceAccountIngestor struct {
+	buffer map[string]*corev1.ServiceAccountList
+	writer writer.DumperWriter
+}
+
+func ingestServiceAccountPath(serviceAccount types.ServiceAccountType) string {
+	return path.Join(serviceAccount.Namespace, collector.ServiceAccountPath)
+}
+
+func NewServiceAccountIngestor(ctx context.Context, dumpWriter writer.DumperWriter) *ServiceAccountIngestor {
+	return &ServiceAccountIngestor{
+		buffer: make(map[string]*corev1.ServiceAccountList),
+		writer: dumpWriter,
+	}
+}
+
+func (d *ServiceAccountIngestor) IngestServiceAccount(ctx context.Context, serviceAccount types.ServiceAccountType) error {
+	if ok, err := preflight.CheckServiceAccount(serviceAccount); !ok {
+		return err
+	}
+
+	serviceAccountPath := ingestServiceAccountPath(serviceAccount)
+
+	return bufferObject[corev1.ServiceAccountList, types.ServiceAccountType](ctx, serviceAccountPath, d.buffer, serviceAccount)
+}
+
+// Complete() is invoked by the collector when all k8s assets have been streamed.
+// The function flushes all writers and waits for completion.
+func (d *ServiceAccountIngestor) Complete(ctx context.Context) error {
+	return dumpObj[*corev1.ServiceAccountList](ctx, d.buffer, d.writer)
+}
@@ -0,0 +1,97 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	mockwriter "github.com/DataDog/KubeHound/pkg/dump/writer/mockwriter"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDumpIngestor_IngestServiceAccount(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	// no ingestion
+	noIngest := func(t *testing.T, _ []*corev1.ServiceAccount) *ServiceAccountIngestor {
+		t.Helper()
+		mDumpWriter := mockwriter.NewDumperWriter(t)
+		ingestor := NewServiceAccountIngestor(ctx, mDumpWriter)
+
+		return ingestor
+	}
+
+	// ingesting n entries
+	nIngest := func(t *testing.T, serviceAccounts []*corev1.ServiceAccount) *ServiceAccountIngestor {
+		t.Helper()
+		mDumpWriter := mockwriter.NewDumperWriter(t)
+		ingestor := NewServiceAccountIngestor(ctx, mDumpWriter)
+
+		buffer := make(map[string]*corev1.ServiceAccountList)
+		for _, serviceAccount := range serviceAccounts {
+			err := bufferObject[corev1.ServiceAccountList, types.ServiceAccountType](ctx, ingestServiceAccountPath(serviceAccount), buffer, serviceAccount)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for path, serviceAccountListNamespaced := range buffer {
+			rawBuffer, err := json.Marshal(serviceAccountListNamespaced)
+			if err != nil {
+				t.Fatalf("failed to marshal Kubernetes object: %v", err)
+			}
+			mDumpWriter.EXPECT().Write(ctx, rawBuffer, path).Return(nil).Once()
+		}
+
+		return ingestor
+	}
+
+	type args struct {
+		serviceAccounts []*corev1.ServiceAccount
+	}
+	tests := []struct {
+		name     string
+		ingestor *ServiceAccountIngestor
+		testfct  func(t *testing.T, serviceAccounts []*corev1.ServiceAccount) *ServiceAccountIngestor
+		args     args
+		wantErr  bool
+	}{
+		{
+			name:    "no entry",
+			testfct: noIngest,
+			args: args{
+				serviceAccounts: []*corev1.ServiceAccount{
+					nil,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "entries found",
+			testfct: nIngest,
+			args: args{
+				serviceAccounts: []*corev1.ServiceAccount{
+					collector.FakeServiceAccount("namespace1", "name1"),
+					collector.FakeServiceAccount("namespace2", "name2"),
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ingestor := tt.testfct(t, tt.args.serviceAccounts)
+			for _, serviceAccount := range tt.args.serviceAccounts {
+				if err := ingestor.IngestServiceAccount(ctx, serviceAccount); (err != nil) != tt.wantErr {
+					t.Errorf("Dumper.IngestServiceAccount() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+			if err := ingestor.Complete(ctx); err != nil {
+				t.Errorf("Dumper.IngestServiceAccount() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	"github.com/DataDog/KubeHound/pkg/dump/writer"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+)
+
+// WebhookIngestor dumps the cluster mutating and validating webhook configurations in dedicated root files.
+type WebhookIngestor struct {
+	mutating   map[string]*admissionregistrationv1.MutatingWebhookConfigurationList
+	validating map[string]*admissionregistrationv1.ValidatingWebhookConfigurationList
+	writer     writer.DumperWriter
+}
+
+func NewWebhookIngestor(ctx context.Context, dumpWriter writer.DumperWriter) *WebhookIngestor {
+	return &WebhookIngestor{
+		mutating:   make(map[string]*admissionregistrationv1.MutatingWebhookConfigurationList),
+		validating: make(map[string]*admissionregistrationv1.ValidatingWebhookConfigurationList),
+		writer:     dumpWriter,
+	}
+}
+
+func (d *WebhookIngestor) IngestMutatingWebhookConfiguration(ctx context.Context, config types.MutatingWebhookConfigurationType) error {
+	if ok, err := preflight.CheckMutatingWebhookConfiguration(config); !ok {
+		return err
+	}
+
+	return bufferObject[admissionregistrationv1.MutatingWebhookConfigurationList, types.MutatingWebhookConfigurationType](ctx,
+		collector.MutatingWebhookPath, d.mutating, config)
+}
+
+func (d *WebhookIngestor) IngestValidatingWebhookConfiguration(ctx context.Context, config types.ValidatingWebhookConfigurationType) error {
+	if ok, err := preflight.CheckValidatingWebhookConfiguration(config); !ok {
+		return err
+	}
+
+	return bufferObject[admissionregistrationv1.ValidatingWebhookConfigurationList, types.ValidatingWebhookConfigurationType](ctx,
+		collector.ValidatingWebhookPath, d.validating, config)
+}
+
+// Complete() is invoked by the collector when all k8s assets have been streamed.
+// The function flushes all writers and waits for completion.
+func (d *WebhookIngestor) Complete(ctx context.Context) error {
+	if err := dumpObj[*admissionregistrationv1.MutatingWebhookConfigurationList](ctx, d.mutating, d.writer); err != nil {
+		return err
+	}
+
+	return dumpObj[*admissionregistrationv1.ValidatingWebhookConfigurationList](ctx, d.validating, d.writer)
+}
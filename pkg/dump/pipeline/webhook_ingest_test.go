@@ -0,0 +1,126 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	mockwriter "github.com/DataDog/KubeHound/pkg/dump/writer/mockwriter"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+)
+
+func TestDumpIngestor_IngestMutatingWebhookConfiguration(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	// no ingestion
+	noIngest := func(t *testing.T, _ []*admissionregistrationv1.MutatingWebhookConfiguration, _ []*admissionregistrationv1.ValidatingWebhookConfiguration) *WebhookIngestor {
+		t.Helper()
+		mDumpWriter := mockwriter.NewDumperWriter(t)
+		ingestor := NewWebhookIngestor(ctx, mDumpWriter)
+
+		return ingestor
+	}
+
+	// ingesting n entries, each kind of webhook configuration is dumped to a single root file
+	nIngest := func(t *testing.T, mutating []*admissionregistrationv1.MutatingWebhookConfiguration, validating []*admissionregistrationv1.ValidatingWebhookConfiguration) *WebhookIngestor {
+		t.Helper()
+		mDumpWriter := mockwriter.NewDumperWriter(t)
+		ingestor := NewWebhookIngestor(ctx, mDumpWriter)
+
+		mutatingBuffer := make(map[string]*admissionregistrationv1.MutatingWebhookConfigurationList)
+		for _, config := range mutating {
+			err := bufferObject[admissionregistrationv1.MutatingWebhookConfigurationList, types.MutatingWebhookConfigurationType](ctx,
+				collector.MutatingWebhookPath, mutatingBuffer, config)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for path, configList := range mutatingBuffer {
+			rawBuffer, err := json.Marshal(configList)
+			if err != nil {
+				t.Fatalf("failed to marshal Kubernetes object: %v", err)
+			}
+			mDumpWriter.EXPECT().Write(ctx, rawBuffer, path).Return(nil).Once()
+		}
+
+		validatingBuffer := make(map[string]*admissionregistrationv1.ValidatingWebhookConfigurationList)
+		for _, config := range validating {
+			err := bufferObject[admissionregistrationv1.ValidatingWebhookConfigurationList, types.ValidatingWebhookConfigurationType](ctx,
+				collector.ValidatingWebhookPath, validatingBuffer, config)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for path, configList := range validatingBuffer {
+			rawBuffer, err := json.Marshal(configList)
+			if err != nil {
+				t.Fatalf("failed to marshal Kubernetes object: %v", err)
+			}
+			mDumpWriter.EXPECT().Write(ctx, rawBuffer, path).Return(nil).Once()
+		}
+
+		return ingestor
+	}
+
+	type args struct {
+		mutating   []*admissionregistrationv1.MutatingWebhookConfiguration
+		validating []*admissionregistrationv1.ValidatingWebhookConfiguration
+	}
+	tests := []struct {
+		name    string
+		testfct func(t *testing.T, mutating []*admissionregistrationv1.MutatingWebhookConfiguration, validating []*admissionregistrationv1.ValidatingWebhookConfiguration) *WebhookIngestor
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "no entry",
+			testfct: noIngest,
+			args: args{
+				mutating: []*admissionregistrationv1.MutatingWebhookConfiguration{
+					nil,
+				},
+				validating: []*admissionregistrationv1.ValidatingWebhookConfiguration{
+					nil,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "entries found",
+			testfct: nIngest,
+			args: args{
+				mutating: []*admissionregistrationv1.MutatingWebhookConfiguration{
+					collector.FakeMutatingWebhookConfiguration("name1", "namespace1", "service1"),
+					collector.FakeMutatingWebhookConfiguration("name2", "namespace2", "service2"),
+				},
+				validating: []*admissionregistrationv1.ValidatingWebhookConfiguration{
+					collector.FakeValidatingWebhookConfiguration("name3", "https://webhook.example.com/validate"),
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ingestor := tt.testfct(t, tt.args.mutating, tt.args.validating)
+			for _, config := range tt.args.mutating {
+				if err := ingestor.IngestMutatingWebhookConfiguration(ctx, config); (err != nil) != tt.wantErr {
+					t.Errorf("Dumper.IngestMutatingWebhookConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+			for _, config := range tt.args.validating {
+				if err := ingestor.IngestValidatingWebhookConfiguration(ctx, config); (err != nil) != tt.wantErr {
+					t.Errorf("Dumper.IngestValidatingWebhookConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+			if err := ingestor.Complete(ctx); err != nil {
+				t.Errorf("Dumper.IngestMutatingWebhookConfiguration() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package pipeline
+
+import (
+	"context"
+	"path"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	"github.com/DataDog/KubeHound/pkg/dump/writer"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+// WorkloadIngestor dumps the workload controllers, using a dedicated file per kind of workload in each namespace.
+type WorkloadIngestor struct {
+	deployments  map[string]*appsv1.DeploymentList
+	daemonSets   map[string]*appsv1.DaemonSetList
+	statefulSets map[string]*appsv1.StatefulSetList
+	jobs         map[string]*batchv1.JobList
+	cronJobs     map[string]*batchv1.CronJobList
+	writer       writer.DumperWriter
+}
+
+func ingestWorkloadPath(namespace string, kindPath string) string {
+	return path.Join(namespace, kindPath)
+}
+
+func NewWorkloadIngestor(ctx context.Context, dumpWriter writer.DumperWriter) *WorkloadIngestor {
+	return &WorkloadIngestor{
+		deployments:  make(map[string]*appsv1.DeploymentList),
+		daemonSets:   make(map[string]*appsv1.DaemonSetList),
+		statefulSets: make(map[string]*appsv1.StatefulSetList),
+		jobs:         make(map[string]*batchv1.JobList),
+		cronJobs:     make(map[string]*batchv1.CronJobList),
+		writer:       dumpWriter,
+	}
+}
+
+func (d *WorkloadIngestor) IngestDeployment(ctx context.Context, deployment types.DeploymentType) error {
+	if ok, err := preflight.CheckDeployment(deployment); !ok {
+		return err
+	}
+
+	deploymentPath := ingestWorkloadPath(deployment.Namespace, collector.DeploymentPath)
+
+	return bufferObject[appsv1.DeploymentList, types.DeploymentType](ctx, deploymentPath, d.deployments, deployment)
+}
+
+func (d *WorkloadIngestor) IngestDaemonSet(ctx context.Context, daemonSet types.DaemonSetType) error {
+	if ok, err := preflight.CheckDaemonSet(daemonSet); !ok {
+		return err
+	}
+
+	daemonSetPath := ingestWorkloadPath(daemonSet.Namespace, collector.DaemonSetPath)
+
+	return bufferObject[appsv1.DaemonSetList, types.DaemonSetType](ctx, daemonSetPath, d.daemonSets, daemonSet)
+}
+
+func (d *WorkloadIngestor) IngestStatefulSet(ctx context.Context, statefulSet types.StatefulSetType) error {
+	if ok, err := preflight.CheckStatefulSet(statefulSet); !ok {
+		return err
+	}
+
+	statefulSetPath := ingestWorkloadPath(statefulSet.Namespace, collector.StatefulSetPath)
+
+	return bufferObject[appsv1.StatefulSetList, types.StatefulSetType](ctx, statefulSetPath, d.statefulSets, statefulSet)
+}
+
+func (d *WorkloadIngestor) IngestJob(ctx context.Context, job types.JobType) error {
+	if ok, err := preflight.CheckJob(job); !ok {
+		return err
+	}
+
+	jobPath := ingestWorkloadPath(job.Namespace, collector.JobPath)
+
+	return bufferObject[batchv1.JobList, types.JobType](ctx, jobPath, d.jobs, job)
+}
+
+func (d *WorkloadIngestor) IngestCronJob(ctx context.Context, cronJob types.CronJobType) error {
+	if ok, err := preflight.CheckCronJob(cronJob); !ok {
+		return err
+	}
+
+	cronJobPath := ingestWorkloadPath(cronJob.Namespace, collector.CronJobPath)
+
+	return bufferObject[batchv1.CronJobList, types.CronJobType](ctx, cronJobPath, d.cronJobs, cronJob)
+}
+
+// Complete() is invoked by the collector when all k8s assets have been streamed.
+// The function flushes all writers and waits for completion.
+func (d *WorkloadIngestor) Complete(ctx context.Context) error {
+	if err := dumpObj[*appsv1.DeploymentList](ctx, d.deployments, d.writer); err != nil {
+		return err
+	}
+
+	if err := dumpObj[*appsv1.DaemonSetList](ctx, d.daemonSets, d.writer); err != nil {
+		return err
+	}
+
+	if err := dumpObj[*appsv1.StatefulSetList](ctx, d.statefulSets, d.writer); err != nil {
+		return err
+	}
+
+	if err := dumpObj[*batchv1.JobList](ctx, d.jobs, d.writer); err != nil {
+		return err
+	}
+
+	return dumpObj[*batchv1.CronJobList](ctx, d.cronJobs, d.writer)
+}
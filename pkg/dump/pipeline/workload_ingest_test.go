@@ -0,0 +1,127 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	mockwriter "github.com/DataDog/KubeHound/pkg/dump/writer/mockwriter"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+func TestDumpIngestor_IngestWorkload(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	// no ingestion
+	noIngest := func(t *testing.T, _ []*appsv1.Deployment, _ []*batchv1.CronJob) *WorkloadIngestor {
+		t.Helper()
+		mDumpWriter := mockwriter.NewDumperWriter(t)
+		ingestor := NewWorkloadIngestor(ctx, mDumpWriter)
+
+		return ingestor
+	}
+
+	// ingesting n entries, each kind of workload is dumped to its own file
+	nIngest := func(t *testing.T, deployments []*appsv1.Deployment, cronJobs []*batchv1.CronJob) *WorkloadIngestor {
+		t.Helper()
+		mDumpWriter := mockwriter.NewDumperWriter(t)
+		ingestor := NewWorkloadIngestor(ctx, mDumpWriter)
+
+		deploymentBuffer := make(map[string]*appsv1.DeploymentList)
+		for _, deployment := range deployments {
+			err := bufferObject[appsv1.DeploymentList, types.DeploymentType](ctx,
+				ingestWorkloadPath(deployment.Namespace, collector.DeploymentPath), deploymentBuffer, deployment)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for path, deploymentListNamespaced := range deploymentBuffer {
+			rawBuffer, err := json.Marshal(deploymentListNamespaced)
+			if err != nil {
+				t.Fatalf("failed to marshal Kubernetes object: %v", err)
+			}
+			mDumpWriter.EXPECT().Write(ctx, rawBuffer, path).Return(nil).Once()
+		}
+
+		cronJobBuffer := make(map[string]*batchv1.CronJobList)
+		for _, cronJob := range cronJobs {
+			err := bufferObject[batchv1.CronJobList, types.CronJobType](ctx,
+				ingestWorkloadPath(cronJob.Namespace, collector.CronJobPath), cronJobBuffer, cronJob)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for path, cronJobListNamespaced := range cronJobBuffer {
+			rawBuffer, err := json.Marshal(cronJobListNamespaced)
+			if err != nil {
+				t.Fatalf("failed to marshal Kubernetes object: %v", err)
+			}
+			mDumpWriter.EXPECT().Write(ctx, rawBuffer, path).Return(nil).Once()
+		}
+
+		return ingestor
+	}
+
+	type args struct {
+		deployments []*appsv1.Deployment
+		cronJobs    []*batchv1.CronJob
+	}
+	tests := []struct {
+		name    string
+		testfct func(t *testing.T, deployments []*appsv1.Deployment, cronJobs []*batchv1.CronJob) *WorkloadIngestor
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "no entry",
+			testfct: noIngest,
+			args: args{
+				deployments: []*appsv1.Deployment{
+					nil,
+				},
+				cronJobs: []*batchv1.CronJob{
+					nil,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "entries found",
+			testfct: nIngest,
+			args: args{
+				deployments: []*appsv1.Deployment{
+					collector.FakeDeployment("namespace1", "name1"),
+					collector.FakeDeployment("namespace2", "name2"),
+				},
+				cronJobs: []*batchv1.CronJob{
+					collector.FakeCronJob("namespace1", "name3"),
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ingestor := tt.testfct(t, tt.args.deployments, tt.args.cronJobs)
+			for _, deployment := range tt.args.deployments {
+				if err := ingestor.IngestDeployment(ctx, deployment); (err != nil) != tt.wantErr {
+					t.Errorf("Dumper.IngestDeployment() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+			for _, cronJob := range tt.args.cronJobs {
+				if err := ingestor.IngestCronJob(ctx, cronJob); (err != nil) != tt.wantErr {
+					t.Errorf("Dumper.IngestCronJob() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+			if err := ingestor.Complete(ctx); err != nil {
+				t.Errorf("Dumper.IngestWorkload() error = %v", err)
+			}
+		})
+	}
+}
@@ -1,25 +1,50 @@
 package types
 
 import (
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
+	netv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 type PodType *corev1.Pod
 type NodeType *corev1.Node
 type ContainerType *corev1.Container
+type EphemeralContainerType *corev1.EphemeralContainer
 type VolumeMountType *corev1.VolumeMount
 type RoleType *rbacv1.Role
 type RoleBindingType *rbacv1.RoleBinding
 type ClusterRoleType *rbacv1.ClusterRole
 type ClusterRoleBindingType *rbacv1.ClusterRoleBinding
 type EndpointType *discoveryv1.EndpointSlice
+type SecretType *corev1.Secret
+type ServiceAccountType *corev1.ServiceAccount
+type DeploymentType *appsv1.Deployment
+type DaemonSetType *appsv1.DaemonSet
+type StatefulSetType *appsv1.StatefulSet
+type JobType *batchv1.Job
+type CronJobType *batchv1.CronJob
+type NetworkPolicyType *netv1.NetworkPolicy
+type PersistentVolumeType *corev1.PersistentVolume
+type PersistentVolumeClaimType *corev1.PersistentVolumeClaim
+type MutatingWebhookConfigurationType *admissionregistrationv1.MutatingWebhookConfiguration
+type ValidatingWebhookConfigurationType *admissionregistrationv1.ValidatingWebhookConfiguration
+type MutatingWebhookType *admissionregistrationv1.MutatingWebhook
+type ValidatingWebhookType *admissionregistrationv1.ValidatingWebhook
+type NamespaceType *corev1.Namespace
 
 type InputType interface {
-	PodType | NodeType | ContainerType | VolumeMountType | RoleType | RoleBindingType | ClusterRoleType | ClusterRoleBindingType | EndpointType
+	PodType | NodeType | ContainerType | VolumeMountType | RoleType | RoleBindingType | ClusterRoleType | ClusterRoleBindingType | EndpointType | SecretType | ServiceAccountType |
+		DeploymentType | DaemonSetType | StatefulSetType | JobType | CronJobType | NetworkPolicyType | PersistentVolumeType | PersistentVolumeClaimType |
+		MutatingWebhookConfigurationType | ValidatingWebhookConfigurationType | NamespaceType
 }
 
 type ListInputType interface {
-	corev1.PodList | corev1.NodeList | rbacv1.RoleList | rbacv1.RoleBindingList | rbacv1.ClusterRoleList | rbacv1.ClusterRoleBindingList | discoveryv1.EndpointSliceList
+	corev1.PodList | corev1.NodeList | rbacv1.RoleList | rbacv1.RoleBindingList | rbacv1.ClusterRoleList | rbacv1.ClusterRoleBindingList | discoveryv1.EndpointSliceList | corev1.SecretList | corev1.ServiceAccountList |
+		appsv1.DeploymentList | appsv1.DaemonSetList | appsv1.StatefulSetList | batchv1.JobList | batchv1.CronJobList | netv1.NetworkPolicyList |
+		corev1.PersistentVolumeList | corev1.PersistentVolumeClaimList |
+		admissionregistrationv1.MutatingWebhookConfigurationList | admissionregistrationv1.ValidatingWebhookConfigurationList | corev1.NamespaceList
 }
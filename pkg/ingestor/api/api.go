@@ -15,14 +15,15 @@ import (
 	grpc "github.com/DataDog/KubeHound/pkg/ingestor/api/grpc/pb"
 	"github.com/DataDog/KubeHound/pkg/ingestor/notifier"
 	"github.com/DataDog/KubeHound/pkg/ingestor/puller"
-	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/providers"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/graphdb"
 	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
 	"github.com/DataDog/KubeHound/pkg/telemetry/events"
 	"github.com/DataDog/KubeHound/pkg/telemetry/log"
 	"github.com/DataDog/KubeHound/pkg/telemetry/span"
 	gremlingo "github.com/apache/tinkerpop/gremlin-go/v3/driver"
-	"go.mongodb.org/mongo-driver/bson"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
@@ -256,8 +257,28 @@ func (g *IngestorAPI) Ingest(ctx context.Context, path string) error { //nolint:
 	return err
 }
 
-func (g *IngestorAPI) isAlreadyIngestedInGraph(_ context.Context, clusterName string, runID string) (bool, error) {
+func (g *IngestorAPI) isAlreadyIngestedInGraph(ctx context.Context, clusterName string, runID string) (bool, error) {
 	var err error
+	if eg, ok := g.providers.GraphProvider.Raw().(*graphdb.EmbeddedGraph); ok {
+		res := eg.Vertices(types.VertexFilter{
+			Properties: map[string]any{"runID": runID, "cluster": clusterName},
+		})
+
+		return len(res) != 0, nil
+	}
+
+	if driver, ok := g.providers.GraphProvider.Raw().(neo4j.DriverWithContext); ok {
+		res, err := neo4j.ExecuteQuery(ctx, driver,
+			"MATCH (n) WHERE n.runID = $runID AND n.cluster = $cluster RETURN n.storeID LIMIT 1",
+			map[string]any{"runID": runID, "cluster": clusterName},
+			neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase(g.Cfg.Neo4j.Database))
+		if err != nil {
+			return false, fmt.Errorf("getting nodes for %s/%s: %w", runID, clusterName, err)
+		}
+
+		return len(res.Records) != 0, nil
+	}
+
 	gClient, ok := g.providers.GraphProvider.Raw().(*gremlingo.DriverRemoteConnection)
 	if !ok {
 		return false, fmt.Errorf("assert gClient as *gremlingo.DriverRemoteConnection")
@@ -287,17 +308,7 @@ func (g *IngestorAPI) isAlreadyIngestedInDB(ctx context.Context, clusterName str
 	var resNum int64
 	var err error
 	for _, collection := range collections.GetCollections() {
-		mdb := adapter.MongoDB(ctx, g.providers.StoreProvider)
-		db := mdb.Collection(collection)
-		filter := bson.M{
-			"runtime": bson.M{
-				"runID": runID,
-				"cluster": bson.M{
-					"name": clusterName,
-				},
-			},
-		}
-		resNum, err = db.CountDocuments(ctx, filter, nil)
+		resNum, err = g.providers.StoreProvider.Count(ctx, collection, runID, clusterName)
 		if err != nil {
 			return false, fmt.Errorf("error counting documents in collection %s: %w", collection, err)
 		}
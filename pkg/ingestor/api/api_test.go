@@ -1,7 +1,6 @@
 package api
 
 import (
-	"fmt"
 	"testing"
 
 	"github.com/DataDog/KubeHound/pkg/config"
@@ -15,9 +14,6 @@ import (
 	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"github.com/stretchr/testify/require"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 )
 
@@ -29,21 +25,8 @@ func foundPreviousScan(mt *mtest.T, g *IngestorAPI) {
 		mt.Fatalf("failed to cast store provider to mock")
 	}
 
-	defer func() {
-		mt.Client = nil
-	}()
-	mt.AddMockResponses(mtest.CreateSuccessResponse())
-
 	// Return X documents to emulate a previous scan
-	store.On("Reader", mock.Anything).Return(mt.DB).Once()
-	mt.AddMockResponses(mtest.CreateCursorResponse(1, "test.nodes", mtest.FirstBatch, bson.D{{Key: "n", Value: 123}}))
-
-	// For count to work, mongo needs an index. So we need to create that. Index view should contains a key. Value does not matter
-	indexView := mt.Coll.Indexes()
-	_, err := indexView.CreateOne(mt.Context(), mongo.IndexModel{
-		Keys: bson.D{{Key: "n", Value: 321}},
-	})
-	require.Nil(mt, err, "CreateOne error for index: %v", err)
+	store.EXPECT().Count(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(123, nil).Once()
 }
 
 func noPreviousScan(mt *mtest.T, g *IngestorAPI) {
@@ -54,23 +37,9 @@ func noPreviousScan(mt *mtest.T, g *IngestorAPI) {
 		mt.Fatalf("failed to cast store provider to mock")
 	}
 
-	defer func() {
-		mt.Client = nil
-	}()
-	mt.AddMockResponses(mtest.CreateSuccessResponse())
-
-	// For count to work, mongo needs an index. So we need to create that. Index view should contains a key. Value does not matter
-	indexView := mt.Coll.Indexes()
-	_, err := indexView.CreateOne(mt.Context(), mongo.IndexModel{
-		Keys: bson.D{{Key: "n", Value: 321}},
-	})
-	require.Nil(mt, err, "CreateOne error for index: %v", err)
-
 	// Iterate over all collections without findings any element
 	for _, collection := range collections.GetCollections() {
-		store.On("Reader", mock.Anything).Return(mt.DB)
-		mt.AddMockResponses(mtest.CreateCursorResponse(1, fmt.Sprintf("test.%s", collection), mtest.FirstBatch, bson.D{{Key: "n", Value: 0}}))
-
+		store.EXPECT().Count(mock.Anything, collection, mock.Anything, mock.Anything).Return(0, nil)
 	}
 }
 
@@ -0,0 +1,74 @@
+package adapter
+
+import (
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+)
+
+// DefaultVertexWrites returns the backend-neutral equivalent of the default vertex traversal, i.e one vertex with
+// the provided label per map created by GremlinVertexProcessor.
+func DefaultVertexWrites(label string) types.VertexWrites {
+	return func(inserts []any) ([]types.VertexWrite, error) {
+		writes := make([]types.VertexWrite, 0, len(inserts))
+		for _, i := range inserts {
+			props, ok := i.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s vertex insert type: %T", label, i)
+			}
+
+			// labels are not indexed - use a mirror property
+			withClass := make(map[string]any, len(props)+1)
+			for k, v := range props {
+				withClass[k] = v
+			}
+			withClass["class"] = label
+
+			writes = append(writes, types.VertexWrite{
+				Label:      label,
+				Properties: withClass,
+			})
+		}
+
+		return writes, nil
+	}
+}
+
+// DefaultEdgeWrites returns the backend-neutral equivalent of the default edge traversal, i.e one merged edge per
+// map created by GremlinEdgeProcessor.
+func DefaultEdgeWrites() types.EdgeWrites {
+	return func(inserts []any) ([]types.EdgeWrite, error) {
+		writes := make([]types.EdgeWrite, 0, len(inserts))
+		for _, i := range inserts {
+			processed, ok := i.(map[any]any)
+			if !ok {
+				return nil, fmt.Errorf("invalid edge insert type: %T", i)
+			}
+
+			label, lOk := processed[gremlin.T.Label].(string)
+			vidOut, oOk := processed[gremlin.Direction.Out].(int64)
+			vidIn, iOk := processed[gremlin.Direction.In].(int64)
+			if !lOk || !oOk || !iOk {
+				return nil, fmt.Errorf("invalid edge insert: %v", processed)
+			}
+
+			props := make(map[string]any, len(processed))
+			for k, v := range processed {
+				if key, ok := k.(string); ok {
+					props[key] = v
+				}
+			}
+
+			writes = append(writes, types.EdgeWrite{
+				Label:      label,
+				Out:        types.VertexFilter{IDs: []int64{vidOut}},
+				In:         &types.VertexFilter{IDs: []int64{vidIn}},
+				Properties: props,
+				Merge:      true,
+			})
+		}
+
+		return writes, nil
+	}
+}
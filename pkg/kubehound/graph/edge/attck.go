@@ -14,6 +14,8 @@ var (
 	AttckTacticPersistence AttckTacticID = "TA0003"
 	// AttckTacticPrivilegeEscalation is the ATT&CK tactic for privilege escalation (TA0004).
 	AttckTacticPrivilegeEscalation AttckTacticID = "TA0004"
+	// AttckTacticDefenseEvasion is the ATT&CK tactic for defense evasion (TA0005).
+	AttckTacticDefenseEvasion AttckTacticID = "TA0005"
 	// AttckTacticCredentialAccess is the ATT&CK tactic for credential access (TA0006).
 	AttckTacticCredentialAccess AttckTacticID = "TA0006"
 	// AttckTacticDiscovery is the ATT&CK tactic for discovery (TA0007).
@@ -32,6 +34,8 @@ var (
 	AttckTechniquePermissionGroupsDiscovery AttckTechniqueID = "T1069"
 	// AttckTechniqueValidAccounts is the ATT&CK technique for valid accounts (T1078).
 	AttckTechniqueValidAccounts AttckTechniqueID = "T1078"
+	// AttckTechniqueAccountManipulation is the ATT&CK technique for account manipulation (T1098).
+	AttckTechniqueAccountManipulation AttckTechniqueID = "T1098"
 	// AttckTechniqueTaintedSharedContent is the ATT&CK technique for tainted shared content (T1080).
 	AttckTechniqueTaintedSharedContent AttckTechniqueID = "T1080"
 	// AttckTechniqueExploitationOfRemoteServices is the ATT&CK technique for exploitation of remote services (T1210).
@@ -40,6 +44,8 @@ var (
 	AttckTechniqueStealApplicationAccessTokens AttckTechniqueID = "T1528"
 	// AttckTechniqueUnsecuredCredentials is the ATT&CK technique for unsecured credentials (T1552).
 	AttckTechniqueUnsecuredCredentials AttckTechniqueID = "T1552"
+	// AttckTechniqueImpairDefenses is the ATT&CK technique for impairing defenses (T1562).
+	AttckTechniqueImpairDefenses AttckTechniqueID = "T1562"
 	// AttckTechniqueContainerAdministrationCommand is the ATT&CK technique for container administration command (T1609).
 	AttckTechniqueContainerAdministrationCommand AttckTechniqueID = "T1609"
 	// AttckTechniqueDeployContainer is the ATT&CK technique for deploying a container (T1610).
@@ -25,3 +25,7 @@ func (e *BaseEdge) BatchSize() int {
 func (e *BaseEdge) Traversal() types.EdgeTraversal {
 	return adapter.DefaultEdgeTraversal()
 }
+
+func (e *BaseEdge) Writes() types.EdgeWrites {
+	return adapter.DefaultEdgeWrites()
+}
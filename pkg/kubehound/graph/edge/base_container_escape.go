@@ -2,14 +2,26 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/DataDog/KubeHound/pkg/config"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// BaseContainerEscape is the base of the container escape edges, linking a container to its node. Unless stated
+// otherwise, capability based escapes are technically true for privileged containers, but in this case the
+// CE_PRIV_MOUNT attack is also possible and easier to execute, so privileged containers are excluded.
 type BaseContainerEscape struct {
 	BaseEdge
 }
@@ -27,6 +39,166 @@ func containerEscapeProcessor(ctx context.Context, oic *converter.ObjectIDConver
 
 	return adapter.GremlinEdgeProcessor(ctx, oic, edgeLabel, typed.Container, typed.Node, attributes)
 }
+
+// boolRef returns a pointer to the provided value, to set the optional predicates of a container query.
+func boolRef(v bool) *bool {
+	return &v
+}
+
+// streamContainerEscapes streams a container escape entry to the callback for each container matching the query.
+func streamContainerEscapes(ctx context.Context, sdb storedb.Provider, runtime *config.DynamicConfig,
+	q storedb.ContainerQuery, callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	err := sdb.Containers(ctx, runtime, q, func(ctx context.Context, c *store.Container) error {
+		return callback(ctx, &containerEscapeGroup{Node: c.NodeId, Container: c.Id})
+	})
+
+	return errors.Join(complete(ctx), err)
+}
+
+// streamContainerEscapesFunc streams a container escape entry to the callback for each container matching the query
+// that is also kept by the provided predicate.
+func streamContainerEscapesFunc(ctx context.Context, sdb storedb.Provider, runtime *config.DynamicConfig,
+	q storedb.ContainerQuery, keep func(c *store.Container) bool,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	err := sdb.Containers(ctx, runtime, q, func(ctx context.Context, c *store.Container) error {
+		if !keep(c) {
+			return nil
+		}
+
+		return callback(ctx, &containerEscapeGroup{Node: c.NodeId, Container: c.Id})
+	})
+
+	return errors.Join(complete(ctx), err)
+}
+
+// hostMounts returns the host volumes mounting one of the provided node paths.
+func hostMounts(ctx context.Context, sdb storedb.Provider, runtime *config.DynamicConfig,
+	sources []string) ([]*store.Volume, error) {
+
+	query := storedb.VolumeQuery{
+		Types: []string{shared.VolumeTypeHost},
+	}
+
+	var mounts []*store.Volume
+	err := sdb.Volumes(ctx, runtime, query, func(_ context.Context, v *store.Volume) error {
+		if slices.Contains(sources, v.SourcePath) {
+			mounts = append(mounts, v)
+		}
+
+		return nil
+	})
+
+	return mounts, err
+}
+
+// streamNodeContainerEscapes streams a container escape entry to the callback for each container matching the query
+// that is also kept by the provided predicate, evaluated against the node of the container. Containers scheduled on an
+// unknown node are dropped.
+func streamNodeContainerEscapes(ctx context.Context, sdb storedb.Provider, runtime *config.DynamicConfig,
+	q storedb.ContainerQuery, keep func(c *store.Container, n *store.Node) bool,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	nodes := make(map[primitive.ObjectID]*store.Node)
+	err := sdb.Nodes(ctx, runtime, func(_ context.Context, n *store.Node) error {
+		nodes[n.Id] = n
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(complete(ctx), err)
+	}
+
+	return streamContainerEscapesFunc(ctx, sdb, runtime, q, func(c *store.Container) bool {
+		n, ok := nodes[c.NodeId]
+
+		return ok && keep(c, n)
+	}, callback, complete)
+}
+
+// versionRegex matches the major and optional minor version numbers of a version string.
+var versionRegex = regexp.MustCompile(`(\d+)(?:\.(\d+))?`)
+
+// versionAtLeast returns whether the first version number found in the provided string (e.g "5.15.0-1051-azure" or
+// "Debian GNU/Linux 12 (bookworm)") is greater or equal to major.minor. A missing minor version is considered to be 0.
+// Versions that cannot be parsed are NOT considered recent enough.
+func versionAtLeast(version string, major int, minor int) bool {
+	match := versionRegex.FindStringSubmatch(version)
+	if match == nil {
+		return false
+	}
+
+	vmajor, err := strconv.Atoi(match[1])
+	if err != nil {
+		return false
+	}
+
+	vminor := 0
+	if match[2] != "" {
+		vminor, err = strconv.Atoi(match[2])
+		if err != nil {
+			return false
+		}
+	}
+
+	return vmajor > major || (vmajor == major && vminor >= minor)
+}
+
+// linuxNode returns whether the node runs Linux.
+func linuxNode(n *store.Node) bool {
+	return n.K8.Status.NodeInfo.OperatingSystem == "linux"
+}
+
+// cgroupV2OSImages lists the node OS images mounting the cgroup v2 unified hierarchy by default, starting from the
+// provided version of the image. Managed node images that do not report their version in the OS image name switched
+// to cgroup v2 along with a K8s release, in which case the version is compared to the kubelet version instead.
+var cgroupV2OSImages = []struct {
+	prefix  string
+	major   int
+	minor   int
+	kubelet bool
+}{
+	{prefix: "Ubuntu ", major: 21, minor: 10},
+	{prefix: "Debian GNU/Linux ", major: 11},
+	{prefix: "Fedora ", major: 31},
+	{prefix: "Red Hat Enterprise Linux ", major: 9},
+	{prefix: "Amazon Linux ", major: 2023},
+	{prefix: "Bottlerocket OS ", major: 1, minor: 13},
+	{prefix: "Flatcar Container Linux ", major: 2983},
+	{prefix: "Container-Optimized OS", major: 1, minor: 26, kubelet: true}, // COS 97+ on GKE 1.26+
+	{prefix: "CBL-Mariner", major: 1, minor: 25, kubelet: true},            // Mariner 2.0 on AKS 1.25+
+	{prefix: "Microsoft Azure Linux ", major: 2},
+}
+
+// cgroupV1Node returns whether the node mounts the legacy cgroup v1 hierarchies. The cgroup version is not exposed by
+// the K8s API, so it is derived from the kernel version and the default of the OS image of the node. Nodes running an
+// unknown OS image are assumed to use cgroup v1.
+func cgroupV1Node(n *store.Node) bool {
+	info := n.K8.Status.NodeInfo
+	if !linuxNode(n) {
+		return false
+	}
+
+	// The cgroup v2 unified hierarchy is only available since Linux 4.5
+	if !versionAtLeast(info.KernelVersion, 4, 5) {
+		return true
+	}
+
+	for _, image := range cgroupV2OSImages {
+		version, ok := strings.CutPrefix(info.OSImage, image.prefix)
+		if image.kubelet {
+			version = info.KubeletVersion
+		}
+
+		if ok && versionAtLeast(version, image.major, image.minor) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (e *BaseContainerEscape) Traversal() types.EdgeTraversal {
 	return adapter.DefaultEdgeTraversal()
 }
@@ -40,6 +40,9 @@ type Builder interface {
 	// Traversal returns a graph traversal function that enables creating edges from an input array of TraversalInput objects.
 	Traversal() types.EdgeTraversal
 
+	// Writes returns a function creating backend-neutral edge writes from an input array of TraversalInput objects.
+	Writes() types.EdgeWrites
+
 	// Processor transforms an object queued for writing to a format suitable for consumption by the Traversal function.
 	Processor(context.Context, *converter.ObjectIDConverter, any) (any, error)
 
@@ -2,17 +2,16 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func init() {
@@ -60,24 +59,13 @@ func (e *ContainerAttach) Traversal() types.EdgeTraversal {
 	return adapter.DefaultEdgeTraversal()
 }
 
-func (e *ContainerAttach) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+func (e *ContainerAttach) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	containers := adapter.MongoDB(ctx, store).Collection(collections.ContainerName)
-
 	// We just need a 1:1 mapping of the container and pod to create this edge
-	projection := bson.M{"_id": 1, "pod_id": 1}
-
-	filter := bson.M{
-		"runtime.runID":        e.runtime.RunID.String(),
-		"runtime.cluster.name": e.runtime.Cluster.Name,
-	}
-
-	cur, err := containers.Find(ctx, filter, options.Find().SetProjection(projection))
-	if err != nil {
-		return err
-	}
-	defer cur.Close(ctx)
+	err := sdb.Containers(ctx, e.runtime, storedb.ContainerQuery{}, func(ctx context.Context, c *store.Container) error {
+		return callback(ctx, &containerAttachGroup{Pod: c.PodId, Container: c.Id})
+	})
 
-	return adapter.MongoCursorHandler[containerAttachGroup](ctx, cur, callback, complete)
+	return errors.Join(complete(ctx), err)
 }
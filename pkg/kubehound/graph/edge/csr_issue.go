@@ -0,0 +1,191 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const (
+	// Built-in signers automatically issuing certificates for approved requests.
+	// See reference for details: https://kubernetes.io/docs/reference/access-authn-authz/certificate-signing-requests/#kubernetes-signers
+	csrSignerClient        = "kubernetes.io/kube-apiserver-client"
+	csrSignerClientKubelet = "kubernetes.io/kube-apiserver-client-kubelet"
+	csrSignerWildcard      = "kubernetes.io/*"
+
+	// Identities of the nodes, as issued by the kube-apiserver-client-kubelet signer.
+	csrNodeGroup      = "system:nodes"
+	csrNodeUserPrefix = "system:node:"
+
+	// Identity targeted by the kube-apiserver-client signer under large cluster optimizations.
+	csrOptimizedTarget = "system:masters"
+)
+
+func init() {
+	Register(&CertificateSigningRequestIssue{}, RegisterDefault)
+}
+
+// CertificateSigningRequestIssue handles the CSR_ISSUE edges generated by cluster roles allowing to create and approve
+// certificate signing requests for a built-in client certificate signer.
+type CertificateSigningRequestIssue struct {
+	BaseEdge
+}
+
+type csrIssueGroup struct {
+	Role       primitive.ObjectID `bson:"_id" json:"role"`
+	Identity   primitive.ObjectID `bson:"identity" json:"identity"`
+	SignerName string             `bson:"signer_name" json:"signer_name"`
+}
+
+var (
+	// csrCreateRule matches the policy rules granting the creation of certificate signing requests.
+	csrCreateRule = storedb.RuleMatch{
+		APIGroups: []string{"certificates.k8s.io"},
+		Resources: []string{"certificatesigningrequests"},
+		Verbs:     []string{"create"},
+	}
+
+	// csrApprovalRule matches the policy rules granting the approval of certificate signing requests.
+	csrApprovalRule = storedb.RuleMatch{
+		APIGroups: []string{"certificates.k8s.io"},
+		Resources: []string{"certificatesigningrequests/approval"},
+		Verbs:     []string{"update", "patch"},
+	}
+
+	// csrSignerRule matches the policy rules granting the approval of certificates for a signer.
+	csrSignerRule = storedb.RuleMatch{
+		APIGroups: []string{"certificates.k8s.io"},
+		Resources: []string{"signers"},
+		Verbs:     []string{"approve"},
+	}
+)
+
+func (e *CertificateSigningRequestIssue) Label() string {
+	return "CSR_ISSUE"
+}
+
+func (e *CertificateSigningRequestIssue) Name() string {
+	return "CertificateSigningRequestIssue"
+}
+
+func (e *CertificateSigningRequestIssue) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueValidAccounts
+}
+
+func (e *CertificateSigningRequestIssue) AttckTacticID() AttckTacticID {
+	return AttckTacticPrivilegeEscalation
+}
+
+func (e *CertificateSigningRequestIssue) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*csrIssueGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Identity, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+		"signerName":       typed.SignerName,
+	})
+}
+
+// csrSignerApproved returns whether any policy rule grants the approve verb on the provided signer. Rules restricted via
+// resourceNames must name the signer or its domain wildcard.
+func csrSignerApproved(rules []rbacv1.PolicyRule, signer string) bool {
+	if csrSignerRule.Unscoped(rules) {
+		return true
+	}
+
+	names := csrSignerRule.ResourceNames(rules)
+
+	return slices.Contains(names, signer) || slices.Contains(names, csrSignerWildcard)
+}
+
+// csrClientTarget returns whether the identity can be issued a client certificate by the kube-apiserver-client signer.
+func (e *CertificateSigningRequestIssue) csrClientTarget(i *store.Identity) bool {
+	if e.cfg.LargeClusterOptimizations {
+		// For larger clusters simply target the system:masters group to reduce redundant attack paths
+		return i.Type == shared.IdentityTypeGroup && i.Name == csrOptimizedTarget
+	}
+
+	return i.Type == shared.IdentityTypeUser || i.Type == shared.IdentityTypeGroup
+}
+
+// csrNodeTarget returns whether the identity can be issued a client certificate by the kube-apiserver-client-kubelet
+// signer.
+func csrNodeTarget(i *store.Identity) bool {
+	return (i.Type == shared.IdentityTypeGroup && i.Name == csrNodeGroup) ||
+		(i.Type == shared.IdentityTypeUser && strings.HasPrefix(i.Name, csrNodeUserPrefix))
+}
+
+// Stream finds all roles that are NOT namespaced and allow to create certificate signing requests and approve them for
+// one of the built-in client certificate signers, and the identities a certificate can be issued for.
+//   - The kube-apiserver-client signer issues certificates for any user or group (including system:masters).
+//   - The kube-apiserver-client-kubelet signer issues certificates for the system:node:<name> users in the system:nodes group.
+func (e *CertificateSigningRequestIssue) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	type csrApprover struct {
+		role          primitive.ObjectID
+		client        bool
+		clientKubelet bool
+	}
+
+	var approvers []csrApprover
+	query := storedb.PermissionSetQuery{
+		Scope:    storedb.ClusterPermissionSets,
+		AllRules: []storedb.RuleMatch{csrCreateRule, csrApprovalRule, csrSignerRule},
+	}
+
+	err := sdb.PermissionSets(ctx, e.runtime, query, func(_ context.Context, ps *store.PermissionSet) error {
+		approvers = append(approvers, csrApprover{
+			role:          ps.Id,
+			client:        csrSignerApproved(ps.Rules, csrSignerClient),
+			clientKubelet: csrSignerApproved(ps.Rules, csrSignerClientKubelet),
+		})
+
+		return nil
+	})
+	if err != nil || len(approvers) == 0 {
+		return errors.Join(complete(ctx), err)
+	}
+
+	identities := storedb.IdentityQuery{
+		Types: []string{shared.IdentityTypeUser, shared.IdentityTypeGroup},
+	}
+
+	err = sdb.Identities(ctx, e.runtime, identities, func(ctx context.Context, i *store.Identity) error {
+		client, node := e.csrClientTarget(i), csrNodeTarget(i)
+		for _, a := range approvers {
+			var signer string
+			switch {
+			case a.client && client:
+				signer = csrSignerClient
+			case a.clientKubelet && node:
+				signer = csrSignerClientKubelet
+			default:
+				continue
+			}
+
+			if err := callback(ctx, &csrIssueGroup{Role: a.role, Identity: i.Id, SignerName: signer}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return errors.Join(complete(ctx), err)
+}
@@ -2,17 +2,19 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo/options"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
 )
 
 func init() {
@@ -24,10 +26,12 @@ type EndpointExploitInternal struct {
 }
 
 type containerEndpointGroup struct {
-	Endpoint  primitive.ObjectID `bson:"_id" json:"endpoint_id"`
-	Container primitive.ObjectID `bson:"container_id" json:"container_id"`
+	Endpoint             primitive.ObjectID `bson:"_id" json:"endpoint_id"`
+	Container            primitive.ObjectID `bson:"container_id" json:"container_id"`
+	NetworkPolicyBlocked bool               `bson:"-" json:"network_policy_blocked"`
 }
 
+// privateEndpoint holds the details of a private endpoint required to evaluate the network policies applying to its pod.
 func (e *EndpointExploitInternal) Label() string {
 	return "ENDPOINT_EXPLOIT"
 }
@@ -51,32 +55,99 @@ func (e *EndpointExploitInternal) Processor(ctx context.Context, oic *converter.
 	}
 
 	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Endpoint, typed.Container, map[string]any{
-		"attckTechniqueID": string(e.AttckTechniqueID()),
-		"attckTacticID":    string(e.AttckTacticID()),
+		"attckTechniqueID":     string(e.AttckTechniqueID()),
+		"attckTacticID":        string(e.AttckTacticID()),
+		"networkPolicyBlocked": typed.NetworkPolicyBlocked,
 	})
 }
 
-func (e *EndpointExploitInternal) Stream(ctx context.Context, store storedb.Provider, c cache.CacheReader,
+func (e *EndpointExploitInternal) Stream(ctx context.Context, sdb storedb.Provider, c cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	endpoints := adapter.MongoDB(ctx, store).Collection(collections.EndpointName)
+	policies, err := e.networkPolicies(ctx, sdb)
+	if err != nil {
+		return errors.Join(complete(ctx), err)
+	}
+
+	labels, sources, err := e.podSources(ctx, sdb, len(policies) != 0)
+	if err != nil {
+		return errors.Join(complete(ctx), err)
+	}
 
 	// Collect the endpoints with no associated slice. These are directly created from a container port in the
-	// pod ingest pipeline and so already have an associated container ID we can use directly.
-	filter := bson.M{
-		"has_slice":            false,
-		"runtime.runID":        e.runtime.RunID.String(),
-		"runtime.cluster.name": e.runtime.Cluster.Name,
+	// pod ingest pipeline and so already have an associated container ID we can use directly. The labels of the
+	// endpoint pod are retrieved to evaluate the network policies of the pod namespace against the collected pods.
+	hasSlice := false
+	query := storedb.EndpointQuery{
+		HasSlice: &hasSlice,
 	}
 
-	// We just need a 1:1 mapping of the (private) endpoint and container to create this edge
-	projection := bson.M{"_id": 1, "container_id": 1}
+	// We just need a 1:1 mapping of the (private) endpoint and container to create this edge, tagged with whether
+	// ingress traffic to the endpoint is denied by the network policies.
+	err = sdb.Endpoints(ctx, e.runtime, query, func(ctx context.Context, ep *store.Endpoint) error {
+		podLabels := labels[podRef{namespace: ep.PodNamespace, name: ep.PodName}]
+
+		return callback(ctx, &containerEndpointGroup{
+			Endpoint:  ep.Id,
+			Container: ep.ContainerId,
+			NetworkPolicyBlocked: libkube.NetworkPolicyIngressBlocked(policies[ep.PodNamespace], podLabels,
+				ep.SafePort(), ep.SafePortName(), corev1.Protocol(ep.SafeProtocol()), sources),
+		})
+	})
+
+	return errors.Join(complete(ctx), err)
+}
+
+// podRef identifies a pod by namespace and name.
+type podRef struct {
+	namespace string
+	name      string
+}
+
+// podSources returns the labels of the pods collected for the current run, by pod, along with the pods (and the labels
+// of their namespace) that can be the source of ingress traffic allowed by the network policy peers.
+func (e *EndpointExploitInternal) podSources(ctx context.Context, sdb storedb.Provider,
+	hasPolicies bool) (map[podRef]map[string]string, []libkube.NetworkPolicySource, error) {
 
-	cur, err := endpoints.Find(ctx, filter, options.Find().SetProjection(projection))
+	labels := make(map[podRef]map[string]string)
+	if !hasPolicies {
+		// Pod labels are only needed to evaluate network policies
+		return labels, nil, nil
+	}
+
+	namespaceLabels := make(map[string]map[string]string)
+	err := sdb.Namespaces(ctx, e.runtime, func(_ context.Context, ns *store.Namespace) error {
+		namespaceLabels[ns.K8.Name] = ns.K8.Labels
+
+		return nil
+	})
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[containerEndpointGroup](ctx, cur, callback, complete)
+	var sources []libkube.NetworkPolicySource
+	err = sdb.Pods(ctx, e.runtime, storedb.PodQuery{}, func(_ context.Context, p *store.Pod) error {
+		labels[podRef{namespace: p.K8.Namespace, name: p.K8.Name}] = p.K8.Labels
+		sources = append(sources, libkube.NetworkPolicySource{
+			Namespace:       p.K8.Namespace,
+			NamespaceLabels: namespaceLabels[p.K8.Namespace],
+			PodLabels:       p.K8.Labels,
+		})
+
+		return nil
+	})
+
+	return labels, sources, err
+}
+
+// networkPolicies returns the network policies collected for the current run, grouped by namespace.
+func (e *EndpointExploitInternal) networkPolicies(ctx context.Context, sdb storedb.Provider) (map[string][]netv1.NetworkPolicy, error) {
+	byNamespace := make(map[string][]netv1.NetworkPolicy)
+	err := sdb.NetworkPolicies(ctx, e.runtime, func(_ context.Context, policy *store.NetworkPolicy) error {
+		byNamespace[policy.Namespace] = append(byNamespace[policy.Namespace], policy.K8)
+
+		return nil
+	})
+
+	return byNamespace, err
 }
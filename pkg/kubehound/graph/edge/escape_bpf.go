@@ -0,0 +1,69 @@
+package edge
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+)
+
+const (
+	// CAP_BPF and CAP_PERFMON were split from CAP_SYS_ADMIN in Linux 5.8
+	BpfCapabilityKernelMajorVersion = 5
+	BpfCapabilityKernelMinorVersion = 8
+)
+
+func init() {
+	Register(&EscapeBpf{}, RegisterDefault)
+}
+
+type EscapeBpf struct {
+	BaseContainerEscape
+}
+
+func (e *EscapeBpf) Label() string {
+	return "CE_BPF"
+}
+
+func (e *EscapeBpf) Name() string {
+	return "ContainerEscapeBpf"
+}
+
+func (e *EscapeBpf) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueEscapeToHost
+}
+
+func (e *EscapeBpf) AttckTacticID() AttckTacticID {
+	return AttckTacticPrivilegeEscalation
+}
+
+// Processor delegates the processing tasks to the generic containerEscapeProcessor.
+func (e *EscapeBpf) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	return containerEscapeProcessor(ctx, oic, e.Label(), entry, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+	})
+}
+
+// Stream finds all containers allowed to load eBPF tracing programs. Tracing programs can read the memory of any
+// process or of the kernel of the node, exposing the credentials of the host (SSH keys, kubelet credentials, etc).
+//   - CAP_SYS_ADMIN grants the loading of tracing programs on any kernel version.
+//   - CAP_BPF and CAP_PERFMON are both required for tracing programs, and only exist since Linux 5.8.
+func (e *EscapeBpf) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	// CAP_SYS_ADMIN grants the BPF operations on any kernel, CAP_BPF and CAP_PERFMON only on kernels splitting them
+	sysAdmin := storedb.ContainerQuery{Capabilities: []string{"SYS_ADMIN"}}
+	query := storedb.ContainerQuery{
+		Privileged:      boolRef(false),
+		AnyCapabilities: [][]string{sysAdmin.Capabilities, {"BPF", "PERFMON"}},
+	}
+
+	return streamNodeContainerEscapes(ctx, sdb, e.runtime, query, func(c *store.Container, n *store.Node) bool {
+		return sysAdmin.Matches(c) || versionAtLeast(n.K8.Status.NodeInfo.KernelVersion,
+			BpfCapabilityKernelMajorVersion, BpfCapabilityKernelMinorVersion)
+	}, callback, complete)
+}
@@ -0,0 +1,60 @@
+package edge
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+)
+
+func init() {
+	Register(&EscapeCgroupReleaseAgent{}, RegisterDefault)
+}
+
+type EscapeCgroupReleaseAgent struct {
+	BaseContainerEscape
+}
+
+func (e *EscapeCgroupReleaseAgent) Label() string {
+	return "CE_CGROUP_RELEASE_AGENT"
+}
+
+func (e *EscapeCgroupReleaseAgent) Name() string {
+	return "ContainerEscapeCgroupReleaseAgent"
+}
+
+func (e *EscapeCgroupReleaseAgent) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueEscapeToHost
+}
+
+func (e *EscapeCgroupReleaseAgent) AttckTacticID() AttckTacticID {
+	return AttckTacticPrivilegeEscalation
+}
+
+// Processor delegates the processing tasks to the generic containerEscapeProcessor.
+func (e *EscapeCgroupReleaseAgent) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	return containerEscapeProcessor(ctx, oic, e.Label(), entry, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+	})
+}
+
+// Stream finds all containers with CAP_SYS_ADMIN running on a cgroup v1 node. The container can mount a cgroup v1
+// hierarchy and abuse its release_agent file, executed by the kernel in the host namespaces when the last process of a
+// cgroup exits. The mount syscall is denied by the default AppArmor profile.
+func (e *EscapeCgroupReleaseAgent) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	query := storedb.ContainerQuery{
+		Privileged:         boolRef(false),
+		Capabilities:       []string{"SYS_ADMIN"},
+		AppArmorUnconfined: true,
+	}
+
+	return streamNodeContainerEscapes(ctx, sdb, e.runtime, query, func(_ *store.Container, n *store.Node) bool {
+		return cgroupV1Node(n)
+	}, callback, complete)
+}
@@ -0,0 +1,60 @@
+package edge
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+)
+
+func init() {
+	Register(&EscapeDacReadSearch{}, RegisterDefault)
+}
+
+type EscapeDacReadSearch struct {
+	BaseContainerEscape
+}
+
+func (e *EscapeDacReadSearch) Label() string {
+	return "CE_DAC_READ_SEARCH"
+}
+
+func (e *EscapeDacReadSearch) Name() string {
+	return "ContainerEscapeDacReadSearch"
+}
+
+func (e *EscapeDacReadSearch) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueEscapeToHost
+}
+
+func (e *EscapeDacReadSearch) AttckTacticID() AttckTacticID {
+	return AttckTacticPrivilegeEscalation
+}
+
+// Processor delegates the processing tasks to the generic containerEscapeProcessor.
+func (e *EscapeDacReadSearch) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	return containerEscapeProcessor(ctx, oic, e.Label(), entry, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+	})
+}
+
+// Stream finds all containers with CAP_DAC_READ_SEARCH running on a Linux node. The open_by_handle_at syscall
+// ("shocker" exploit) allows to open any file of a host filesystem bind mounted in the container (e.g /etc/hosts) by
+// brute forcing its handle. The syscall exists since Linux 2.6.39, older than any kernel supported by K8s, so the
+// kernel version of the node is not checked.
+func (e *EscapeDacReadSearch) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	query := storedb.ContainerQuery{
+		Privileged:   boolRef(false),
+		Capabilities: []string{"DAC_READ_SEARCH"},
+	}
+
+	return streamNodeContainerEscapes(ctx, sdb, e.runtime, query, func(_ *store.Container, n *store.Node) bool {
+		return linuxNode(n)
+	}, callback, complete)
+}
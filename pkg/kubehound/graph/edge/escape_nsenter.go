@@ -3,14 +3,10 @@ package edge
 import (
 	"context"
 
-	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func init() {
@@ -45,27 +41,14 @@ func (e *EscapeNsenter) Processor(ctx context.Context, oic *converter.ObjectIDCo
 	})
 }
 
-func (e *EscapeNsenter) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+func (e *EscapeNsenter) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	containers := adapter.MongoDB(ctx, store).Collection(collections.ContainerName)
-
 	// Escape is possible with privileged containers that share the PID namespace
-	filter := bson.M{
-		"k8.securitycontext.privileged": true,
-		"inherited.host_pid":            true,
-		"runtime.runID":                 e.runtime.RunID.String(),
-		"runtime.cluster.name":          e.runtime.Cluster.Name,
-	}
-
-	// We just need a 1:1 mapping of the node and container to create this edge
-	projection := bson.M{"_id": 1, "node_id": 1}
-
-	cur, err := containers.Find(ctx, filter, options.Find().SetProjection(projection))
-	if err != nil {
-		return err
+	query := storedb.ContainerQuery{
+		Privileged: boolRef(true),
+		HostPID:    boolRef(true),
 	}
-	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[containerEscapeGroup](ctx, cur, callback, complete)
+	return streamContainerEscapes(ctx, sdb, e.runtime, query, callback, complete)
 }
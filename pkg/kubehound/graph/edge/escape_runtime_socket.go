@@ -0,0 +1,103 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/hostmount"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&EscapeRuntimeSocket{}, RegisterDefault)
+}
+
+type EscapeRuntimeSocket struct {
+	BaseContainerEscape
+}
+
+type escapeRuntimeSocketGroup struct {
+	Container primitive.ObjectID `bson:"_id" json:"container"`
+	Node      primitive.ObjectID `bson:"node_id" json:"node"`
+	Source    string             `bson:"source" json:"source"`
+}
+
+func (e *EscapeRuntimeSocket) Label() string {
+	return "CE_RUNTIME_SOCKET"
+}
+
+func (e *EscapeRuntimeSocket) Name() string {
+	return "ContainerEscapeRuntimeSocket"
+}
+
+func (e *EscapeRuntimeSocket) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueEscapeToHost
+}
+
+func (e *EscapeRuntimeSocket) AttckTacticID() AttckTacticID {
+	return AttckTacticPrivilegeEscalation
+}
+
+func (e *EscapeRuntimeSocket) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*escapeRuntimeSocketGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	// Record which runtime socket is exposed
+	reason, _ := hostmount.Instance().RuntimeSockets(e.runtime.Cluster.Name).Match(typed.Source)
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Container, typed.Node, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+		"mountReason":      reason,
+	})
+}
+
+// Stream finds all containers with a host mount exposing a container runtime socket (docker, containerd, cri-o, etc).
+// The runtime API allows to create a privileged container on the host, regardless of the readonly flag of the mount
+// as the read-only semantics do not apply to connecting to a unix socket. Known sockets are configurable via the
+// builder.edge.host_mounts.runtime_sockets setting.
+func (e *EscapeRuntimeSocket) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	query := storedb.VolumeQuery{
+		Types: []string{shared.VolumeTypeHost},
+	}
+
+	// A single edge per container, even if multiple sockets are mounted (the first socket in path order is recorded)
+	sockets := hostmount.Instance().RuntimeSockets(e.runtime.Cluster.Name)
+	groups := make(map[primitive.ObjectID]*escapeRuntimeSocketGroup)
+	err := sdb.Volumes(ctx, e.runtime, query, func(_ context.Context, v *store.Volume) error {
+		if _, ok := sockets.Match(v.SourcePath); !ok {
+			return nil
+		}
+
+		if g, ok := groups[v.ContainerId]; ok && g.Source <= v.SourcePath {
+			return nil
+		}
+
+		groups[v.ContainerId] = &escapeRuntimeSocketGroup{Container: v.ContainerId, Node: v.NodeId, Source: v.SourcePath}
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(complete(ctx), err)
+	}
+
+	for _, g := range groups {
+		if err := callback(ctx, g); err != nil {
+			return errors.Join(complete(ctx), err)
+		}
+	}
+
+	return complete(ctx)
+}
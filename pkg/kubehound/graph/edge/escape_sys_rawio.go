@@ -0,0 +1,80 @@
+package edge
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// KcoreMountList represents the host mounts exposing the /proc/kcore kernel memory image.
+var KcoreMountList = []string{
+	"/",
+	"/proc",
+}
+
+func init() {
+	Register(&EscapeSysRawio{}, RegisterDefault)
+}
+
+type EscapeSysRawio struct {
+	BaseContainerEscape
+}
+
+func (e *EscapeSysRawio) Label() string {
+	return "CE_SYS_RAWIO"
+}
+
+func (e *EscapeSysRawio) Name() string {
+	return "ContainerEscapeSysRawio"
+}
+
+func (e *EscapeSysRawio) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueEscapeToHost
+}
+
+func (e *EscapeSysRawio) AttckTacticID() AttckTacticID {
+	return AttckTacticPrivilegeEscalation
+}
+
+// Processor delegates the processing tasks to the generic containerEscapeProcessor.
+func (e *EscapeSysRawio) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	return containerEscapeProcessor(ctx, oic, e.Label(), entry, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+	})
+}
+
+// Stream finds all containers with CAP_SYS_RAWIO and the host /proc mounted. CAP_SYS_RAWIO grants read access to the
+// /proc/kcore image of the kernel memory, exposing the memory of every process of the node. The /proc/kcore file of the
+// container procfs is masked by the container runtime and the devices cgroup denies access to /dev/mem, hence a host
+// mount is required. /proc/kcore is only exposed by Linux nodes.
+func (e *EscapeSysRawio) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	mounts, err := hostMounts(ctx, sdb, e.runtime, KcoreMountList)
+	if err != nil || len(mounts) == 0 {
+		return errors.Join(complete(ctx), err)
+	}
+
+	kcore := make(map[primitive.ObjectID]struct{}, len(mounts))
+	for _, v := range mounts {
+		kcore[v.ContainerId] = struct{}{}
+	}
+
+	query := storedb.ContainerQuery{
+		Privileged:   boolRef(false),
+		Capabilities: []string{"SYS_RAWIO"},
+	}
+
+	return streamNodeContainerEscapes(ctx, sdb, e.runtime, query, func(c *store.Container, n *store.Node) bool {
+		_, ok := kcore[c.Id]
+
+		return ok && linuxNode(n)
+	}, callback, complete)
+}
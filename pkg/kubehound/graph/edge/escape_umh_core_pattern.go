@@ -2,18 +2,17 @@ package edge
 
 import (
 	"context"
+	"errors"
 
-	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
-	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
-	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-var ProcMountList = bson.A{
+var ProcMountList = []string{
 	"/",
 	"/proc",
 	"/proc/sys",
@@ -51,59 +50,28 @@ func (e *EscapeCorePattern) Processor(ctx context.Context, oic *converter.Object
 	})
 }
 
-func (e *EscapeCorePattern) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+func (e *EscapeCorePattern) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
-	containers := adapter.MongoDB(ctx, store).Collection(collections.ContainerName)
-
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"k8.securitycontext.runasuser": 0,
-				"runtime.runID":                e.runtime.RunID.String(),
-				"runtime.cluster.name":         e.runtime.Cluster.Name,
-			},
-		},
-		{
-			"$lookup": bson.M{
-				"as":           "procMountContainers",
-				"from":         "volumes",
-				"foreignField": "pod_id",
-				"localField":   "pod_id",
-				"pipeline": []bson.M{
-					{
-						"$match": bson.M{
-							"$and": bson.A{
-								bson.M{"type": shared.VolumeTypeHost},
-								bson.M{"source": bson.M{
-									"$in": ProcMountList,
-								}},
-								bson.M{"runtime.runID": e.runtime.RunID.String()},
-								bson.M{"runtime.cluster.name": e.runtime.Cluster.Name},
-							},
-						},
-					},
-				},
-			},
-		},
-		{
-			"$unwind": bson.M{
-				"path":                       "$procMountContainers",
-				"preserveNullAndEmptyArrays": false,
-			},
-		},
-		{
-			"$project": bson.M{
-				"_id":     1,
-				"node_id": 1,
-			},
-		},
+
+	mounts, err := hostMounts(ctx, sdb, e.runtime, ProcMountList)
+	if err != nil || len(mounts) == 0 {
+		return errors.Join(complete(ctx), err)
+	}
+
+	// Any root container of a pod mounting the proc filesystem
+	procMounts := make(map[primitive.ObjectID]struct{}, len(mounts))
+	for _, v := range mounts {
+		procMounts[v.PodId] = struct{}{}
 	}
 
-	cur, err := containers.Aggregate(ctx, pipeline)
-	if err != nil {
-		return err
+	var root int64
+	query := storedb.ContainerQuery{
+		RunAsUser: &root,
 	}
-	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[containerEscapeGroup](ctx, cur, callback, complete)
+	return streamContainerEscapesFunc(ctx, sdb, e.runtime, query, func(c *store.Container) bool {
+		_, ok := procMounts[c.PodId]
+
+		return ok
+	}, callback, complete)
 }
@@ -2,17 +2,16 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
 	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -86,55 +85,46 @@ func (e *EscapeVarLogSymlink) Traversal() types.EdgeTraversal {
 			AddE("CE_VAR_LOG_SYMLINK").From("c").To("n").
 			Property("attckTechniqueID", string(e.AttckTechniqueID())).
 			Property("attckTacticID", string(e.AttckTacticID())).
+			Property("resourceScoped", false).
 			Barrier().Limit(0)
 
 		return g
 	}
 }
 
-func (e *EscapeVarLogSymlink) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+func (e *EscapeVarLogSymlink) Writes() types.EdgeWrites {
+	return func(inserts []any) ([]types.EdgeWrite, error) {
+		ids := make([]int64, 0, len(inserts))
+		for _, i := range inserts {
+			id, ok := i.(int64)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s edge insert type: %T", e.Label(), i)
+			}
+			ids = append(ids, id)
+		}
+
+		return []types.EdgeWrite{
+			varLogSymlinkWrite(e.Label(), ids, nil, nil, map[string]any{
+				"attckTechniqueID": string(e.AttckTechniqueID()),
+				"attckTacticID":    string(e.AttckTacticID()),
+				"resourceScoped":   false,
+			}),
+		}, nil
+	}
+}
+
+func (e *EscapeVarLogSymlink) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback,
 ) error {
-	permissionSets := adapter.MongoDB(ctx, store).Collection(collections.PermissionSetName)
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"rules": bson.M{
-					"$elemMatch": bson.M{
-						"$and": bson.A{
-							bson.M{"$or": bson.A{
-								bson.M{"apigroups": ""},
-								bson.M{"apigroups": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"resources": "pods/log"},
-								bson.M{"resources": "pods/*"},
-								bson.M{"resources": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"verbs": "get"},
-								bson.M{"verbs": "*"},
-							}},
-							bson.M{"resourcenames": nil}, // TODO: handle resource scope
-						},
-					},
-				},
-				"runtime.runID":        e.runtime.RunID.String(),
-				"runtime.cluster.name": e.runtime.Cluster.Name,
-			},
-		},
-		{
-			"$project": bson.M{
-				"_id": 1,
-			},
+	query := storedb.PermissionSetQuery{
+		AnyRule: []storedb.RuleMatch{
+			podLogRule.WithScope(storedb.UnscopedRules), // resource scoped rules are handled by EscapeVarLogSymlinkScoped
 		},
 	}
 
-	cur, err := permissionSets.Aggregate(ctx, pipeline)
-	if err != nil {
-		return err
-	}
-	defer cur.Close(ctx)
+	err := sdb.PermissionSets(ctx, e.runtime, query, func(ctx context.Context, ps *store.PermissionSet) error {
+		return callback(ctx, &permissionSetIDEscapeGroup{PermissionSetID: ps.Id})
+	})
 
-	return adapter.MongoCursorHandler[permissionSetIDEscapeGroup](ctx, cur, callback, complete)
+	return errors.Join(complete(ctx), err)
 }
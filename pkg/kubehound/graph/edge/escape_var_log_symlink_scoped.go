@@ -0,0 +1,201 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&EscapeVarLogSymlinkScoped{}, RegisterGraphDependency)
+}
+
+// EscapeVarLogSymlinkScoped handles the CE_VAR_LOG_SYMLINK edges generated by rules restricted to specific pods via resourceNames.
+type EscapeVarLogSymlinkScoped struct {
+	BaseContainerEscape
+}
+
+// The store query returns a list of permissionSet with the names of the pods they grant log access to.
+type permissionSetScopedEscapeGroup struct {
+	PermissionSetID primitive.ObjectID `bson:"_id" json:"permission_set"`
+	IsNamespaced    bool               `bson:"is_namespaced" json:"is_namespaced"`
+	Namespace       string             `bson:"namespace" json:"namespace"`
+	Names           []string           `bson:"names" json:"names"`
+}
+
+// varLogSymlinkScopedInsert is the traversal input for a single permission set.
+type varLogSymlinkScopedInsert struct {
+	PermissionSet any
+	Namespace     string
+	Pods          []any
+}
+
+// podLogRule matches the policy rules granting read access to pod logs.
+var podLogRule = storedb.RuleMatch{
+	APIGroups: []string{""},
+	Resources: []string{"pods/log"},
+	Verbs:     []string{"get"},
+}
+
+func (e *EscapeVarLogSymlinkScoped) Label() string {
+	return "CE_VAR_LOG_SYMLINK"
+}
+
+// List of needed edges to run the traversal query
+func (e *EscapeVarLogSymlinkScoped) Dependencies() []string {
+	return []string{"PERMISSION_DISCOVER", "IDENTITY_ASSUME", "VOLUME_DISCOVER", "VOLUME_ACCESS"}
+}
+
+func (e *EscapeVarLogSymlinkScoped) Name() string {
+	return "ContainerEscapeVarLogSymlinkScoped"
+}
+
+func (e *EscapeVarLogSymlinkScoped) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueUnsecuredCredentials
+}
+
+func (e *EscapeVarLogSymlinkScoped) AttckTacticID() AttckTacticID {
+	return AttckTacticCredentialAccess
+}
+
+func (e *EscapeVarLogSymlinkScoped) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*permissionSetScopedEscapeGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	permissionSetVertexID, err := oic.GraphID(ctx, typed.PermissionSetID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("%s edge IN id convert: %w", e.Label(), err)
+	}
+
+	insert := &varLogSymlinkScopedInsert{
+		PermissionSet: permissionSetVertexID,
+		Pods:          make([]any, 0, len(typed.Names)),
+	}
+
+	// Namespaced roles only grant access to the logs of pods within the same namespace
+	if typed.IsNamespaced {
+		insert.Namespace = typed.Namespace
+	}
+
+	for _, n := range typed.Names {
+		insert.Pods = append(insert.Pods, n)
+	}
+
+	return insert, nil
+}
+
+func (e *EscapeVarLogSymlinkScoped) Traversal() types.EdgeTraversal {
+	return func(source *gremlin.GraphTraversalSource, inserts []any) *gremlin.GraphTraversal {
+		// Each permission set grants access to a different set of pods, so build a dedicated sub-traversal per
+		// permission set and execute them all in a single batch.
+		paths := make([]any, 0, len(inserts))
+		for _, i := range inserts {
+			typed, ok := i.(*varLogSymlinkScopedInsert)
+			if !ok {
+				continue
+			}
+
+			path := __.V(typed.PermissionSet).Has("class", "PermissionSet").
+				// get identity vertices
+				InE("PERMISSION_DISCOVER").OutV().
+				// get container vertices running in the pods the permission set can read logs from
+				InE("IDENTITY_ASSUME").OutV().
+				Has("class", "Container").
+				Has("pod", P.Within(typed.Pods...))
+
+			if typed.Namespace != "" {
+				path = path.Has("namespace", typed.Namespace)
+			}
+
+			path = path.As("c").
+				// Get all the volumes
+				OutE("VOLUME_DISCOVER").InV().
+				Has("type", shared.VolumeTypeHost).
+				// filter only the volumes that are "affected" by this attacks ("/", "/var", "/var/log").
+				Has("sourcePath", P.Within("/", "/var", "/var/log")).
+				// get the node related to that volume mount
+				InE("VOLUME_ACCESS").OutV().
+				Has("class", "Node").As("n").
+				AddE("CE_VAR_LOG_SYMLINK").From("c").To("n").
+				Property("attckTechniqueID", string(e.AttckTechniqueID())).
+				Property("attckTacticID", string(e.AttckTacticID())).
+				Property("resourceScoped", true)
+
+			paths = append(paths, path)
+		}
+
+		g := source.GetGraphTraversal().
+			Inject(1).
+			Union(paths...).
+			Barrier().Limit(0)
+
+		return g
+	}
+}
+
+func (e *EscapeVarLogSymlinkScoped) Writes() types.EdgeWrites {
+	return func(inserts []any) ([]types.EdgeWrite, error) {
+		props := map[string]any{
+			"attckTechniqueID": string(e.AttckTechniqueID()),
+			"attckTacticID":    string(e.AttckTacticID()),
+			"resourceScoped":   true,
+		}
+
+		// Each permission set grants access to a different set of pods
+		writes := make([]types.EdgeWrite, 0, len(inserts))
+		for _, i := range inserts {
+			typed, ok := i.(*varLogSymlinkScopedInsert)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s edge insert type: %T", e.Label(), i)
+			}
+
+			id, ok := typed.PermissionSet.(int64)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s edge insert: %v", e.Label(), typed)
+			}
+
+			// Namespaced roles only grant access to the logs of pods within the same namespace
+			var containers map[string]any
+			if typed.Namespace != "" {
+				containers = map[string]any{"namespace": typed.Namespace}
+			}
+
+			writes = append(writes, varLogSymlinkWrite(e.Label(), []int64{id}, containers,
+				map[string][]any{"pod": typed.Pods}, props))
+		}
+
+		return writes, nil
+	}
+}
+
+// Stream finds all roles that have pods/log get or equivalent wildcard permissions restricted to a set of pod names via
+// resourceNames, alongside the pod names.
+func (e *EscapeVarLogSymlinkScoped) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback,
+) error {
+	query := storedb.PermissionSetQuery{
+		AllRules: []storedb.RuleMatch{podLogRule.WithScope(storedb.ResourceScopedRules)},
+	}
+
+	err := sdb.PermissionSets(ctx, e.runtime, query, func(ctx context.Context, ps *store.PermissionSet) error {
+		return callback(ctx, &permissionSetScopedEscapeGroup{
+			PermissionSetID: ps.Id,
+			IsNamespaced:    ps.IsNamespaced,
+			Namespace:       ps.Namespace,
+			Names:           podLogRule.ResourceNames(ps.Rules),
+		})
+	})
+
+	return errors.Join(complete(ctx), err)
+}
@@ -2,32 +2,19 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/hostmount"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
-	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// UnsafeReadMountlist represents dangerous mounts that can be abused to read secrets granting execution on the host.
-// Paths are normalized by K8s to remove the trailing slash.
-var UnsafeReadMountlist = bson.A{
-	"/",
-	"/home",
-	primitive.Regex{Pattern: "^/home/[a-zA-Z0-9]*/\\.ssh$"},
-	"/root",
-	"/root/.ssh",
-	"/proc",
-	"/etc",
-}
-
 func init() {
 	Register(&ExploitHostRead{}, RegisterDefault)
 }
@@ -39,6 +26,7 @@ type ExploitHostRead struct {
 type exploitHostReadGroup struct {
 	Volume primitive.ObjectID `bson:"_id" json:"volume"`
 	Node   primitive.ObjectID `bson:"node_id" json:"node"`
+	Source string             `bson:"source" json:"source"`
 }
 
 func (e *ExploitHostRead) Label() string {
@@ -63,35 +51,36 @@ func (e *ExploitHostRead) Processor(ctx context.Context, oic *converter.ObjectID
 		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
 	}
 
+	// Record why the mount is deemed dangerous
+	reason, _ := hostmount.Instance().UnsafeRead(e.runtime.Cluster.Name).Match(typed.Source)
+
 	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Volume, typed.Node, map[string]any{
 		"attckTechniqueID": string(e.AttckTechniqueID()),
 		"attckTacticID":    string(e.AttckTacticID()),
+		"mountReason":      reason,
 	})
 }
 
-func (e *ExploitHostRead) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+func (e *ExploitHostRead) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	volumes := adapter.MongoDB(ctx, store).Collection(collections.VolumeName)
-
-	filter := bson.M{
-		"type":     shared.VolumeTypeHost,
-		"readonly": true,
-		"source": bson.M{
-			"$in": UnsafeReadMountlist,
-		},
-		"runtime.runID":        e.runtime.RunID.String(),
-		"runtime.cluster.name": e.runtime.Cluster.Name,
+	// Dangerous read-only mounts are configurable via the builder.edge.host_mounts.unsafe_read setting. Persistent volume
+	// claims bound to a hostPath or local persistent volume are host mounts of the persistent volume path.
+	readOnly := true
+	query := storedb.VolumeQuery{
+		HostBacked: true,
+		ReadOnly:   &readOnly,
 	}
 
-	// We just need a 1:1 mapping of the node and container to create this edge
-	projection := bson.M{"_id": 1, "node_id": 1}
+	unsafe := hostmount.Instance().UnsafeRead(e.runtime.Cluster.Name)
+	err := sdb.Volumes(ctx, e.runtime, query, func(ctx context.Context, v *store.Volume) error {
+		if _, ok := unsafe.Match(v.SourcePath); !ok {
+			return nil
+		}
 
-	cur, err := volumes.Find(ctx, filter, options.Find().SetProjection(projection))
-	if err != nil {
-		return err
-	}
-	defer cur.Close(ctx)
+		// We just need a 1:1 mapping of the node and container to create this edge, plus the source to record the reason
+		return callback(ctx, &exploitHostReadGroup{Volume: v.Id, Node: v.NodeId, Source: v.SourcePath})
+	})
 
-	return adapter.MongoCursorHandler[exploitHostReadGroup](ctx, cur, callback, complete)
+	return errors.Join(complete(ctx), err)
 }
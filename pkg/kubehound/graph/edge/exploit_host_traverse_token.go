@@ -2,31 +2,35 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // TokenMountList represent ounts that grant access to the pod service account tokens that reside
 // in /var/lib/kubelet/pods/<uid>/volumes/kubernetes.io~projected/<name>/. Paths are normalized by K8s
-// to remove the trailing slash.
-var TokenMountList = bson.A{
+// to remove the trailing slash. Any mount below the kubelet pods directory is also included (see TokenMountPrefix).
+var TokenMountList = []string{
 	"/",
 	"/var",
 	"/var/lib",
 	"/var/lib/kubelet",
 	"/var/lib/kubelet/pods",
-	primitive.Regex{Pattern: "^/var/lib/kubelet/pods/.*"},
 }
 
+// TokenMountPrefix is the prefix of the mounts below the kubelet pods directory.
+const TokenMountPrefix = "/var/lib/kubelet/pods/"
+
 func init() {
 	Register(&ExploitHostTraverse{}, RegisterDefault)
 }
@@ -68,77 +72,42 @@ func (e *ExploitHostTraverse) Processor(ctx context.Context, oic *converter.Obje
 	})
 }
 
-func (e *ExploitHostTraverse) Stream(ctx context.Context, store storedb.Provider, c cache.CacheReader,
+func (e *ExploitHostTraverse) Stream(ctx context.Context, sdb storedb.Provider, c cache.CacheReader,
 	process types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	volumes := adapter.MongoDB(ctx, store).Collection(collections.VolumeName)
-
 	// Link child volumes ONLY where these have interesting properties. Currently this only supports parent
 	// directories of the pod token directory to enable TOKEN_STEAL attacks.
-	filter := bson.M{
-		"type": shared.VolumeTypeHost,
-		"source": bson.M{
-			"$in": TokenMountList,
-		},
-		"runtime.runID":        e.runtime.RunID.String(),
-		"runtime.cluster.name": e.runtime.Cluster.Name,
-	}
-
-	// Find the volume and associated pod namespace and service account.
-	pipeline := []bson.M{
+	parents := make(map[primitive.ObjectID][]primitive.ObjectID)
+	err := sdb.Volumes(ctx, e.runtime, storedb.VolumeQuery{HostBacked: true}, func(_ context.Context, v *store.Volume) error {
 		// Look for the volumes that encapsulate the kubernetes node token directory
-		{
-
-			"$match": filter,
-		},
-		{
-			"$lookup": bson.M{
-				"as":   "childVolumes",
-				"from": "volumes",
-				"let": bson.M{
-					"nid": "$node_id",
-				},
-				"pipeline": []bson.M{
-					{
-						"$match": bson.M{
-							"$and": bson.A{
-								bson.M{"$expr": bson.M{
-									"$eq": bson.A{
-										"$node_id", "$$nid",
-									},
-								}},
-								bson.M{"type": shared.VolumeTypeProjected},
-								bson.M{"projected_id": bson.M{"$ne": nil}},
-							},
-							"runtime.runID":        e.runtime.RunID.String(),
-							"runtime.cluster.name": e.runtime.Cluster.Name,
-						},
-					},
-					{
-						"$project": bson.M{
-							"_id": 1,
-						},
-					},
-				},
-			},
-		},
-		{
-			"$unwind": "$childVolumes",
-		},
-		{
-			"$project": bson.M{
-				"_id":   1,
-				"child": "$childVolumes._id",
-			},
-		},
+		if slices.Contains(TokenMountList, v.SourcePath) || strings.HasPrefix(v.SourcePath, TokenMountPrefix) {
+			parents[v.NodeId] = append(parents[v.NodeId], v.Id)
+		}
+
+		return nil
+	})
+	if err != nil || len(parents) == 0 {
+		return errors.Join(complete(ctx), err)
 	}
 
-	cur, err := volumes.Aggregate(ctx, pipeline)
-	if err != nil {
-		return err
+	// Link the projected token volumes of the same node
+	query := storedb.VolumeQuery{
+		Types: []string{shared.VolumeTypeProjected},
 	}
-	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[exploitTraverseTokenGroup](ctx, cur, process, complete)
+	err = sdb.Volumes(ctx, e.runtime, query, func(ctx context.Context, v *store.Volume) error {
+		if v.ProjectedId.IsZero() {
+			return nil
+		}
+
+		for _, parent := range parents[v.NodeId] {
+			if err := process(ctx, &exploitTraverseTokenGroup{Parent: parent, Child: v.Id}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 
+	return errors.Join(complete(ctx), err)
 }
@@ -2,44 +2,19 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/hostmount"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
-	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// SafeWriteMountList represent common safe mounts that are deemed not exploitable.
-// Paths are normalized by K8s to remove the trailing slash.
-var SafeWriteMountList = bson.A{
-	"/var/run/datadog-agent",
-	"/etc/datadog-agent",
-	"/etc/datadog-agent/auth",
-	"/opt/datadog-agent/run",
-	"/etc/datadog",
-	"/run/xtables\\.lock",
-	"/opt/datadog/heapdumps",
-	"/var/datadog/dumps",
-	primitive.Regex{Pattern: "^/mnt/disks"},
-	primitive.Regex{Pattern: "^/sys/kernel/debug"},
-	primitive.Regex{Pattern: "^/tmp?.*"},
-	primitive.Regex{Pattern: "^/var/run/.*"},
-	primitive.Regex{Pattern: "^/mnt/.*"},
-	primitive.Regex{Pattern: "^/var/lib/datadog-agent/.*"},
-	primitive.Regex{Pattern: "^/var/tmp/datadog-agent/.*"},
-	primitive.Regex{Pattern: "^/run/udev"},
-	primitive.Regex{Pattern: "^/lib/udev"},
-	primitive.Regex{Pattern: "^/etc/udev"},
-	primitive.Regex{Pattern: "^/data/[a-zA-Z0-9\\-]*/shared"},
-}
-
 func init() {
 	Register(&ExploitHostWrite{}, RegisterDefault)
 }
@@ -81,33 +56,29 @@ func (e *ExploitHostWrite) Processor(ctx context.Context, oic *converter.ObjectI
 	})
 }
 
-func (e *ExploitHostWrite) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+func (e *ExploitHostWrite) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	volumes := adapter.MongoDB(ctx, store).Collection(collections.VolumeName)
-
 	// Escape is possible if certain sensitive host directories are mounted into the container with write permissions.
 	// This enables a container to add cron jobs, write SSH keys, write binaries etc to gain execution in the host. With
 	// write access the number of possible attacks is very large so we adopt an assume vulnerable approach with an allowlist
-	// of known "safe" mounts.
-	filter := bson.M{
-		"type":     shared.VolumeTypeHost,
-		"readonly": false,
-		"source": bson.M{
-			"$nin": SafeWriteMountList,
-		},
-		"runtime.runID":        e.runtime.RunID.String(),
-		"runtime.cluster.name": e.runtime.Cluster.Name,
+	// of known "safe" mounts, configurable via the builder.edge.host_mounts.safe_write setting. Persistent volume claims
+	// bound to a hostPath or local persistent volume are host mounts of the persistent volume path.
+	readOnly := false
+	query := storedb.VolumeQuery{
+		HostBacked: true,
+		ReadOnly:   &readOnly,
 	}
 
-	// We just need a 1:1 mapping of the node and container to create this edge
-	projection := bson.M{"_id": 1, "node_id": 1}
+	safe := hostmount.Instance().SafeWrite(e.runtime.Cluster.Name)
+	err := sdb.Volumes(ctx, e.runtime, query, func(ctx context.Context, v *store.Volume) error {
+		if _, ok := safe.Match(v.SourcePath); ok {
+			return nil
+		}
 
-	cur, err := volumes.Find(ctx, filter, options.Find().SetProjection(projection))
-	if err != nil {
-		return err
-	}
-	defer cur.Close(ctx)
+		// We just need a 1:1 mapping of the node and container to create this edge
+		return callback(ctx, &exploitHostWriteGroup{Volume: v.Id, Node: v.NodeId})
+	})
 
-	return adapter.MongoCursorHandler[exploitHostWriteGroup](ctx, cur, callback, complete)
+	return errors.Join(complete(ctx), err)
 }
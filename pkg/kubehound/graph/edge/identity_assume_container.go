@@ -7,11 +7,8 @@ import (
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
-	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -56,70 +53,12 @@ func (e *IdentityAssumeContainer) Processor(ctx context.Context, oic *converter.
 	})
 }
 
-func (e *IdentityAssumeContainer) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+func (e *IdentityAssumeContainer) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	containers := adapter.MongoDB(ctx, store).Collection(collections.ContainerName)
-	pipeline := bson.A{
-		bson.M{
-			"$match": bson.M{
-				"runtime.runID":        e.runtime.RunID.String(),
-				"runtime.cluster.name": e.runtime.Cluster.Name,
-			},
-		},
-		bson.M{
-			"$lookup": bson.M{
-				"as":   "idc",
-				"from": collections.IdentityName,
-				"let": bson.M{
-					"idName":      "$inherited.service_account",
-					"idNamespace": "$inherited.namespace",
-				},
-				"pipeline": []bson.M{
-					{
-						"$match": bson.M{
-							"$and": bson.A{
-								bson.M{"$expr": bson.M{
-									"$eq": bson.A{
-										"$name", "$$idName",
-									},
-								}},
-								bson.M{"$expr": bson.M{
-									"$eq": bson.A{
-										"$namespace", "$$idNamespace",
-									},
-								}},
-								bson.M{"type": shared.IdentityTypeSA},
-							},
-							"runtime.runID":        e.runtime.RunID.String(),
-							"runtime.cluster.name": e.runtime.Cluster.Name,
-						},
-					},
-					{
-						"$project": bson.M{
-							"_id": 1,
-						},
-					},
-				},
-			},
-		},
-		bson.M{
-			"$unwind": "$idc",
-		},
-		bson.M{
-			"$project": bson.M{
-				"container_id": "$_id",
-				"identity_id":  "$idc._id",
-				"_id":          0,
-			},
-		},
-	}
-
-	cur, err := containers.Aggregate(ctx, pipeline)
-	if err != nil {
-		return err
-	}
-	defer cur.Close(ctx)
-
-	return adapter.MongoCursorHandler[containerIdentityGroup](ctx, cur, callback, complete)
+	// The service account token is not available to the container when automount is disabled, unless explicitly
+	// mounted via a projected token volume
+	return streamLinks(ctx, sdb, e.runtime, storedb.ContainerIdentityLink, func(l *storedb.Link) any {
+		return &containerIdentityGroup{Container: l.From, Identity: l.To}
+	}, callback, complete)
 }
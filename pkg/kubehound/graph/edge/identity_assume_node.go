@@ -2,17 +2,16 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func init() {
@@ -56,27 +55,19 @@ func (e *IdentityAssumeNode) Processor(ctx context.Context, oic *converter.Objec
 	})
 }
 
-func (e *IdentityAssumeNode) Stream(ctx context.Context, store storedb.Provider, c cache.CacheReader,
+func (e *IdentityAssumeNode) Stream(ctx context.Context, sdb storedb.Provider, c cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	nodes := adapter.MongoDB(ctx, store).Collection(collections.NodeName)
-
 	// Nodes will either have a dedicated user based on node name or use the default system:nodes group
 	// See reference for details: https://kubernetes.io/docs/reference/access-authn-authz/node/
-	projection := bson.M{"_id": 1, "user_id": 1}
-
-	// If the default node group has no permissions, we do not set a user id
-	filter := bson.M{
-		"user_id":              bson.M{"$ne": primitive.NilObjectID},
-		"runtime.runID":        e.runtime.RunID.String(),
-		"runtime.cluster.name": e.runtime.Cluster.Name,
-	}
+	err := sdb.Nodes(ctx, e.runtime, func(ctx context.Context, n *store.Node) error {
+		// If the default node group has no permissions, we do not set a user id
+		if n.UserId == primitive.NilObjectID {
+			return nil
+		}
 
-	cur, err := nodes.Find(ctx, filter, options.Find().SetProjection(projection))
-	if err != nil {
-		return err
-	}
-	defer cur.Close(ctx)
+		return callback(ctx, &nodeIdentityGroup{Node: n.Id, Identity: n.UserId})
+	})
 
-	return adapter.MongoCursorHandler[nodeIdentityGroup](ctx, cur, callback, complete)
+	return errors.Join(complete(ctx), err)
 }
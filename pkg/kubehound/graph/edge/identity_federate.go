@@ -0,0 +1,70 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	IdentityFederateLabel = "IDENTITY_FEDERATE"
+)
+
+func init() {
+	Register(&IdentityFederate{}, RegisterDefault)
+}
+
+// IdentityFederate links a service account identity to the cloud IAM principals granted to it via workload identity
+// (AWS IRSA, GKE workload identity, Azure workload identity).
+type IdentityFederate struct {
+	BaseEdge
+}
+
+type identityCloudGroup struct {
+	Identity      primitive.ObjectID `bson:"identity_id" json:"identity"`
+	CloudIdentity primitive.ObjectID `bson:"cloud_identity_id" json:"cloud_identity"`
+}
+
+func (e *IdentityFederate) Label() string {
+	return IdentityFederateLabel
+}
+
+func (e *IdentityFederate) Name() string {
+	return "IdentityFederate"
+}
+
+func (e *IdentityFederate) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueValidAccounts
+}
+
+func (e *IdentityFederate) AttckTacticID() AttckTacticID {
+	return AttckTacticPrivilegeEscalation
+}
+
+func (e *IdentityFederate) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*identityCloudGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Identity, typed.CloudIdentity, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+	})
+}
+
+// Stream finds all the service accounts annotated with a cloud IAM principal and matches them to the service account
+// identity and the cloud identity created for the principal.
+func (e *IdentityFederate) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	return streamLinks(ctx, sdb, e.runtime, storedb.FederatedIdentityLink, func(l *storedb.Link) any {
+		return &identityCloudGroup{Identity: l.From, CloudIdentity: l.To}
+	}, callback, complete)
+}
@@ -0,0 +1,66 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&IdentityFederateNode{}, RegisterDefault)
+}
+
+// IdentityFederateNode links a node to the cloud IAM principal whose credentials are served to the node by the
+// instance metadata service (AWS instance profile, GCE node service account, AKS kubelet identity).
+type IdentityFederateNode struct {
+	BaseEdge
+}
+
+type nodeCloudGroup struct {
+	Node          primitive.ObjectID `bson:"node_id" json:"node"`
+	CloudIdentity primitive.ObjectID `bson:"cloud_identity_id" json:"cloud_identity"`
+}
+
+func (e *IdentityFederateNode) Label() string {
+	return IdentityFederateLabel
+}
+
+func (e *IdentityFederateNode) Name() string {
+	return "IdentityFederateNode"
+}
+
+func (e *IdentityFederateNode) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueUnsecuredCredentials
+}
+
+func (e *IdentityFederateNode) AttckTacticID() AttckTacticID {
+	return AttckTacticCredentialAccess
+}
+
+func (e *IdentityFederateNode) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*nodeCloudGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Node, typed.CloudIdentity, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+	})
+}
+
+// Stream finds all the nodes running on a cloud provider and matches them to the cloud identity created for the
+// principal of their node pool (or instance).
+func (e *IdentityFederateNode) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	return streamLinks(ctx, sdb, e.runtime, storedb.FederatedNodeLink, func(l *storedb.Link) any {
+		return &nodeCloudGroup{Node: l.From, CloudIdentity: l.To}
+	}, callback, complete)
+}
@@ -0,0 +1,125 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&IdentityImpersonate{}, RegisterDefault)
+}
+
+// IdentityImpersonate handles the IDENTITY_IMPERSONATE edges generated by rules granting the impersonate verb on users,
+// groups or service accounts.
+type IdentityImpersonate struct {
+	BaseEdge
+}
+
+type identityImpersonateGroup struct {
+	Role           primitive.ObjectID `bson:"_id" json:"role"`
+	Identity       primitive.ObjectID `bson:"identity" json:"identity"`
+	ResourceScoped bool               `bson:"resource_scoped" json:"resource_scoped"`
+}
+
+var (
+	// impersonateRule matches the policy rules granting impersonation, restricted to the impersonated resources.
+	impersonateRule = storedb.RuleMatch{
+		APIGroups: []string{""},
+		Verbs:     []string{"impersonate"},
+	}
+
+	// Impersonable resources and the matching identities
+	impersonateResources = map[string]storedb.ResourceKind{
+		"users":           storedb.UserResource,
+		"groups":          storedb.GroupResource,
+		"serviceaccounts": storedb.ServiceAccountResource,
+	}
+)
+
+const (
+	// Identity targeted by unscoped cluster wide impersonation under large cluster optimizations.
+	impersonateOptimizedTarget = "system:masters"
+)
+
+func (e *IdentityImpersonate) Label() string {
+	return "IDENTITY_IMPERSONATE"
+}
+
+func (e *IdentityImpersonate) Name() string {
+	return "IdentityImpersonate"
+}
+
+func (e *IdentityImpersonate) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueValidAccounts
+}
+
+func (e *IdentityImpersonate) AttckTacticID() AttckTacticID {
+	return AttckTacticPrivilegeEscalation
+}
+
+func (e *IdentityImpersonate) BatchSize() int {
+	if e.cfg.LargeClusterOptimizations {
+		return e.cfg.BatchSize
+	}
+
+	return e.cfg.BatchSizeClusterImpact
+}
+
+func (e *IdentityImpersonate) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*identityImpersonateGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Identity, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   typed.ResourceScoped,
+	})
+}
+
+// Stream finds all roles granting the impersonate verb on users, groups or service accounts and the identities they
+// can impersonate, honoring the namespace of the role and any resourceNames restriction.
+func (e *IdentityImpersonate) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	// Users and groups are cluster wide resources and can only be impersonated via cluster wide permission sets, while
+	// service accounts can be impersonated in the namespace of a namespaced permission set, or in any namespace. Rules
+	// restricted via resourceNames only grant the impersonation of the named identities.
+	for resource, kind := range impersonateResources {
+		rule := impersonateRule
+		rule.Resources = []string{resource}
+		query := storedb.ResourceGrantQuery{
+			Resource: kind,
+			Rule:     &rule,
+		}
+
+		err := sdb.ResourceGrants(ctx, e.runtime, query, func(ctx context.Context, g *storedb.ResourceGrant) error {
+			// For larger clusters unscoped cluster wide grants only target the system:masters group to reduce
+			// redundant attack paths. Unscoped grants within a namespace are always considered.
+			if e.cfg.LargeClusterOptimizations && !g.Namespaced && !g.ResourceScoped && !g.Named &&
+				g.Name != impersonateOptimizedTarget {
+				return nil
+			}
+
+			return callback(ctx, &identityImpersonateGroup{
+				Role:           g.PermissionSet,
+				Identity:       g.Resource,
+				ResourceScoped: g.ResourceScoped,
+			})
+		})
+		if err != nil {
+			return errors.Join(complete(ctx), err)
+		}
+	}
+
+	return complete(ctx)
+}
@@ -461,6 +461,53 @@ func (_c *Builder_Traversal_Call) RunAndReturn(run func() types.EdgeTraversal) *
 	return _c
 }
 
+// Writes provides a mock function with no fields
+func (_m *Builder) Writes() types.EdgeWrites {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Writes")
+	}
+
+	var r0 types.EdgeWrites
+	if rf, ok := ret.Get(0).(func() types.EdgeWrites); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.EdgeWrites)
+		}
+	}
+
+	return r0
+}
+
+// Builder_Writes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Writes'
+type Builder_Writes_Call struct {
+	*mock.Call
+}
+
+// Writes is a helper method to define mock.On call
+func (_e *Builder_Expecter) Writes() *Builder_Writes_Call {
+	return &Builder_Writes_Call{Call: _e.mock.On("Writes")}
+}
+
+func (_c *Builder_Writes_Call) Run(run func()) *Builder_Writes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Builder_Writes_Call) Return(_a0 types.EdgeWrites) *Builder_Writes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Builder_Writes_Call) RunAndReturn(run func() types.EdgeWrites) *Builder_Writes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewBuilder creates a new instance of Builder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewBuilder(t interface {
@@ -0,0 +1,82 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&NamespaceDiscover{}, RegisterDefault)
+}
+
+type NamespaceDiscover struct {
+	BaseEdge
+}
+
+type namespaceDiscoverGroup struct {
+	Pod       primitive.ObjectID `bson:"_id" json:"pod"`
+	Namespace primitive.ObjectID `bson:"namespace_id" json:"namespace"`
+}
+
+func (e *NamespaceDiscover) Label() string {
+	return "NAMESPACE_DISCOVER"
+}
+
+func (e *NamespaceDiscover) Name() string {
+	return "NamespaceDiscover"
+}
+
+func (e *NamespaceDiscover) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueContainerAndResourceDiscovery
+}
+
+func (e *NamespaceDiscover) AttckTacticID() AttckTacticID {
+	return AttckTacticDiscovery
+}
+
+func (e *NamespaceDiscover) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*namespaceDiscoverGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Pod, typed.Namespace, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+	})
+}
+
+// Stream finds all pods and the namespace they run in.
+func (e *NamespaceDiscover) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	namespaces := make(map[string]primitive.ObjectID)
+	err := sdb.Namespaces(ctx, e.runtime, func(_ context.Context, ns *store.Namespace) error {
+		namespaces[ns.K8.Name] = ns.Id
+
+		return nil
+	})
+	if err != nil || len(namespaces) == 0 {
+		return errors.Join(complete(ctx), err)
+	}
+
+	err = sdb.Pods(ctx, e.runtime, storedb.PodQuery{}, func(ctx context.Context, p *store.Pod) error {
+		namespace, ok := namespaces[p.K8.Namespace]
+		if !p.IsNamespaced || !ok {
+			return nil
+		}
+
+		return callback(ctx, &namespaceDiscoverGroup{Pod: p.Id, Namespace: namespace})
+	})
+
+	return errors.Join(complete(ctx), err)
+}
@@ -0,0 +1,81 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&NamespacePatch{}, RegisterDefault)
+}
+
+type NamespacePatch struct {
+	BaseEdge
+}
+
+type namespacePatchGroup struct {
+	Role           primitive.ObjectID `bson:"_id" json:"role"`
+	Namespace      primitive.ObjectID `bson:"namespace_id" json:"namespace"`
+	ResourceScoped bool               `bson:"resource_scoped" json:"resource_scoped"`
+}
+
+// namespacePatchRule matches the policy rules granting modification of namespaces.
+var namespacePatchRule = storedb.RuleMatch{
+	APIGroups: []string{""},
+	Resources: []string{"namespaces"},
+	Verbs:     []string{"patch", "update"},
+}
+
+func (e *NamespacePatch) Label() string {
+	return "NAMESPACE_PATCH"
+}
+
+func (e *NamespacePatch) Name() string {
+	return "NamespacePatch"
+}
+
+func (e *NamespacePatch) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueImpairDefenses
+}
+
+func (e *NamespacePatch) AttckTacticID() AttckTacticID {
+	return AttckTacticDefenseEvasion
+}
+
+func (e *NamespacePatch) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*namespacePatchGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Namespace, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   typed.ResourceScoped,
+	})
+}
+
+// Stream finds all roles that have namespaces/patch or equivalent wildcard permissions and the namespaces they can
+// relabel. The API server evaluates requests on a namespace object as scoped to the namespace itself, hence namespaced
+// roles can patch their own namespace while cluster roles can patch any namespace. Rules restricted via resourceNames
+// only match the named namespaces.
+func (e *NamespacePatch) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	rule := namespacePatchRule
+	query := storedb.ResourceGrantQuery{
+		Resource: storedb.NamespaceResource,
+		Rule:     &rule,
+	}
+
+	return streamResourceGrants(ctx, sdb, e.runtime, query, func(g *storedb.ResourceGrant) any {
+		return &namespacePatchGroup{Role: g.PermissionSet, Namespace: g.Resource, ResourceScoped: g.ResourceScoped}
+	}, callback, complete)
+}
@@ -0,0 +1,163 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	NodeProxyExecLabel = "NODE_PROXY_EXEC"
+)
+
+func init() {
+	Register(&NodeProxyExec{}, RegisterGraphMutation)
+}
+
+// NodeProxyExec handles the NODE_PROXY_EXEC edges generated by cluster roles granting access to the kubelet API of
+// every node via the nodes/proxy subresource.
+type NodeProxyExec struct {
+	BaseEdge
+}
+
+type nodeProxyExecGroup struct {
+	Role primitive.ObjectID `bson:"_id" json:"role"`
+}
+
+// nodeProxyRule matches the policy rules granting access to the kubelet API via the node proxy. The kubelet exec
+// endpoints are reachable via a websocket upgrade of a GET request, so the get verb is sufficient to execute commands in
+// any container of the node.
+var nodeProxyRule = storedb.RuleMatch{
+	APIGroups: []string{""},
+	Resources: []string{"nodes/proxy"},
+	Verbs:     []string{"get", "create"},
+}
+
+func (e *NodeProxyExec) Label() string {
+	return NodeProxyExecLabel
+}
+
+func (e *NodeProxyExec) Name() string {
+	return "NodeProxyExec"
+}
+
+func (e *NodeProxyExec) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueContainerAdministrationCommand
+}
+
+func (e *NodeProxyExec) AttckTacticID() AttckTacticID {
+	return AttckTacticExecution
+}
+
+func (e *NodeProxyExec) BatchSize() int {
+	if e.cfg.LargeClusterOptimizations {
+		// Under optimization this becomes a very cheap operation
+		return e.cfg.BatchSize
+	}
+
+	return e.cfg.BatchSizeClusterImpact
+}
+
+func (e *NodeProxyExec) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*nodeProxyExecGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	rid, err := oic.GraphID(ctx, typed.Role.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("%s edge role id convert: %w", e.Label(), err)
+	}
+
+	if e.cfg.LargeClusterOptimizations {
+		return map[any]any{
+			gremlin.T.Label: vertex.PermissionSetLabel,
+			gremlin.T.Id:    rid,
+		}, nil
+	}
+
+	return rid, nil
+}
+
+func (e *NodeProxyExec) Traversal() types.EdgeTraversal {
+	return func(source *gremlin.GraphTraversalSource, inserts []any) *gremlin.GraphTraversal {
+		g := source.GetGraphTraversal()
+		if e.cfg.LargeClusterOptimizations {
+			// In large clusters this can explode the number of edges and we can safely assume this is a critical issue
+			g.
+				Inject(inserts).
+				Unfold().
+				As("rpe").
+				MergeV(__.Select("rpe")).
+				Option(gremlin.Merge.OnCreate, __.Fail("missing role vertex on NODE_PROXY_EXEC insert")).
+				Option(gremlin.Merge.OnMatch, map[any]any{
+					"critical": true,
+				}).
+				AddE(e.Label()).
+				Property("attckTechniqueID", string(e.AttckTechniqueID())).
+				Property("attckTacticID", string(e.AttckTacticID())).
+				Property("resourceScoped", false).
+				Barrier().Limit(0)
+		} else {
+			// In smaller clusters we can still show the (large set of) attack paths generated by this attack
+			g.V().
+				Has("runID", e.runtime.RunID.String()).
+				Has("cluster", e.runtime.Cluster.Name).
+				Has("class", "Node").
+				As("n").
+				V(inserts...).
+				Has("critical", false).
+				AddE(e.Label()).
+				To("n").
+				Property("attckTechniqueID", string(e.AttckTechniqueID())).
+				Property("attckTacticID", string(e.AttckTacticID())).
+				Property("resourceScoped", false).
+				Barrier().Limit(0)
+		}
+
+		return g
+	}
+}
+
+func (e *NodeProxyExec) Writes() types.EdgeWrites {
+	return func(inserts []any) ([]types.EdgeWrite, error) {
+		props := map[string]any{
+			"attckTechniqueID": string(e.AttckTechniqueID()),
+			"attckTacticID":    string(e.AttckTacticID()),
+			"resourceScoped":   false,
+		}
+
+		if e.cfg.LargeClusterOptimizations {
+			return criticalEdgeWrites(e.Label(), props, inserts)
+		}
+
+		return targetEdgeWrites(e.Label(), e.runVertices("Node", nil), props, inserts)
+	}
+}
+
+// Stream finds all roles that are NOT namespaced and have nodes/proxy or equivalent wildcard permissions.
+func (e *NodeProxyExec) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	query := storedb.PermissionSetQuery{
+		Scope: storedb.ClusterPermissionSets,
+		AnyRule: []storedb.RuleMatch{
+			nodeProxyRule.WithScope(storedb.UnscopedRules), // resource scoped rules are handled by NodeProxyExecScoped
+		},
+	}
+
+	err := sdb.PermissionSets(ctx, e.runtime, query, func(ctx context.Context, ps *store.PermissionSet) error {
+		return callback(ctx, &nodeProxyExecGroup{Role: ps.Id})
+	})
+
+	return errors.Join(complete(ctx), err)
+}
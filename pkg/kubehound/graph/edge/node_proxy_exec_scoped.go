@@ -0,0 +1,75 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&NodeProxyExecScoped{}, RegisterDefault)
+}
+
+// NodeProxyExecScoped handles the NODE_PROXY_EXEC edges generated by rules restricted to specific nodes via resourceNames.
+type NodeProxyExecScoped struct {
+	BaseEdge
+}
+
+type nodeProxyExecScopedGroup struct {
+	Role primitive.ObjectID `bson:"_id" json:"role"`
+	Node primitive.ObjectID `bson:"node" json:"node"`
+}
+
+func (e *NodeProxyExecScoped) Label() string {
+	return NodeProxyExecLabel
+}
+
+func (e *NodeProxyExecScoped) Name() string {
+	return "NodeProxyExecScoped"
+}
+
+func (e *NodeProxyExecScoped) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueContainerAdministrationCommand
+}
+
+func (e *NodeProxyExecScoped) AttckTacticID() AttckTacticID {
+	return AttckTacticExecution
+}
+
+func (e *NodeProxyExecScoped) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*nodeProxyExecScopedGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Node, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   true,
+	})
+}
+
+// Stream finds all roles that are NOT namespaced and have nodes/proxy or equivalent wildcard permissions restricted to
+// a set of node names via resourceNames, and the matching nodes.
+func (e *NodeProxyExecScoped) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	rule := nodeProxyRule.WithScope(storedb.ResourceScopedRules)
+	query := storedb.ResourceGrantQuery{
+		Resource: storedb.NodeResource,
+		Permissions: storedb.PermissionSetQuery{
+			Scope: storedb.ClusterPermissionSets,
+		},
+		Rule: &rule,
+	}
+
+	return streamResourceGrants(ctx, sdb, e.runtime, query, func(g *storedb.ResourceGrant) any {
+		return &nodeProxyExecScopedGroup{Role: g.PermissionSet, Node: g.Resource}
+	}, callback, complete)
+}
@@ -9,8 +9,6 @@ import (
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -55,97 +53,10 @@ func (e *PermissionDiscover) Processor(ctx context.Context, oic *converter.Objec
 	})
 }
 
-func (e *PermissionDiscover) Stream(ctx context.Context, store storedb.Provider, c cache.CacheReader,
+func (e *PermissionDiscover) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	permissionSets := adapter.MongoDB(ctx, store).Collection(collections.PermissionSetName)
-
-	pipeline := bson.A{
-		bson.M{
-			"$match": bson.M{
-				"runtime.runID":        e.runtime.RunID.String(),
-				"runtime.cluster.name": e.runtime.Cluster.Name,
-			},
-		},
-		// First we retrieve all rolebindings associated to the permissionset
-		bson.M{
-			"$lookup": bson.M{
-				"from":         "rolebindings",
-				"localField":   "role_binding_id",
-				"foreignField": "_id",
-				"as":           "result",
-			},
-		},
-		// We filter only when there are subjects associated to the rolebindings
-		bson.M{
-			"$match": bson.M{
-				"result": bson.M{
-					"$elemMatch": bson.M{
-						"subjects": bson.M{
-							"$exists": true,
-							"$ne":     bson.A{},
-						},
-					},
-				},
-			},
-		},
-		// We flatten all the subjects
-		bson.M{"$unwind": bson.M{"path": "$result"}},
-		bson.M{"$unwind": bson.M{"path": "$result.subjects"}},
-		// We check if the rolebinding is relevant
-		bson.M{
-			"$match": bson.M{
-				"$expr": bson.M{
-					"$or": bson.A{
-						bson.M{
-							"$and": bson.A{
-								// the identity and rolebinding namespace need to match
-								bson.M{
-									"$eq": bson.A{
-										"$namespace",
-										"$result.subjects.subject.namespace",
-									},
-								},
-								// the rolebinding is not a clusterrolebinding
-								bson.M{
-									"$eq": bson.A{
-										"$is_namespaced",
-										true,
-									},
-								},
-							},
-						},
-						// identities with no namespace so the scope is cluster wide
-						bson.M{
-							"$eq": bson.A{
-								"$result.subjects.subject.namespace",
-								"",
-							},
-						},
-						// clusterrolerbinding so no namespace checks needed
-						bson.M{
-							"$eq": bson.A{
-								"$is_namespaced",
-								false,
-							},
-						},
-					},
-				},
-			},
-		},
-		bson.M{
-			"$project": bson.M{
-				"_id":         1,
-				"identity_id": "$result.subjects.identity_id",
-			},
-		},
-	}
-
-	cur, err := permissionSets.Aggregate(ctx, pipeline)
-	if err != nil {
-		return err
-	}
-	defer cur.Close(ctx)
-
-	return adapter.MongoCursorHandler[permissionDiscoverGroup](ctx, cur, callback, complete)
+	return streamLinks(ctx, sdb, e.runtime, storedb.BoundIdentityLink, func(l *storedb.Link) any {
+		return &permissionDiscoverGroup{PermissionSet: l.From, Identity: l.To}
+	}, callback, complete)
 }
@@ -2,17 +2,16 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func init() {
@@ -56,24 +55,13 @@ func (e *PodAttach) Processor(ctx context.Context, oic *converter.ObjectIDConver
 	})
 }
 
-func (e *PodAttach) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+func (e *PodAttach) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	pods := adapter.MongoDB(ctx, store).Collection(collections.PodName)
-
 	// We just need a 1:1 mapping of the node and pod to create this edge
-	projection := bson.M{"_id": 1, "node_id": 1}
-
-	filter := bson.M{
-		"runtime.runID":        e.runtime.RunID.String(),
-		"runtime.cluster.name": e.runtime.Cluster.Name,
-	}
-
-	cur, err := pods.Find(ctx, filter, options.Find().SetProjection(projection))
-	if err != nil {
-		return err
-	}
-	defer cur.Close(ctx)
+	err := sdb.Pods(ctx, e.runtime, storedb.PodQuery{}, func(ctx context.Context, p *store.Pod) error {
+		return callback(ctx, &podAttachGroup{Node: p.NodeId, Pod: p.Id})
+	})
 
-	return adapter.MongoCursorHandler[podAttachGroup](ctx, cur, callback, complete)
+	return errors.Join(complete(ctx), err)
 }
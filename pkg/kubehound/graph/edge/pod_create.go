@@ -2,17 +2,16 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
 	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -28,6 +27,14 @@ type podCreateGroup struct {
 	Role primitive.ObjectID `bson:"_id" json:"role"`
 }
 
+// podCreateRule matches the policy rules granting the creation of pods. Workload controllers (deployments, jobs, etc)
+// are handled by WorkloadCreate.
+var podCreateRule = storedb.RuleMatch{
+	APIGroups: []string{""},
+	Resources: []string{"pods", "replicasets", "replicationcontrollers"},
+	Verbs:     []string{"create"},
+}
+
 func (e *PodCreate) Label() string {
 	return "POD_CREATE"
 }
@@ -112,56 +119,46 @@ func (e *PodCreate) Traversal() types.EdgeTraversal {
 	}
 }
 
-// Stream finds all roles that have pod/create or equivalent wildcard permissions.
-func (e *PodCreate) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
-	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+func (e *PodCreate) Writes() types.EdgeWrites {
+	return func(inserts []any) ([]types.EdgeWrite, error) {
+		props := map[string]any{
+			"attckTechniqueID": string(e.AttckTechniqueID()),
+			"attckTacticID":    string(e.AttckTacticID()),
+		}
 
-	permissionSets := adapter.MongoDB(ctx, store).Collection(collections.PermissionSetName)
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"runtime.runID":        e.runtime.RunID.String(),
-				"runtime.cluster.name": e.runtime.Cluster.Name,
-				"rules": bson.M{
-					"$elemMatch": bson.M{
-						"$and": bson.A{
-							bson.M{"$or": bson.A{
-								bson.M{"apigroups": ""},
-								bson.M{"apigroups": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"resources": "pods"},
-								bson.M{"resources": "cronjobs"},
-								bson.M{"resources": "daemonsets"},
-								bson.M{"resources": "deployments"},
-								bson.M{"resources": "jobs"},
-								bson.M{"resources": "replicasets"},
-								bson.M{"resources": "replicationcontrollers"},
-								bson.M{"resources": "statefulsets"},
-								bson.M{"resources": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"verbs": "create"},
-								bson.M{"verbs": "*"},
-							}},
-							bson.M{"resourcenames": nil}, // TODO: handle resource scope
-						},
-					},
-				},
-			},
-		},
-		{
-			"$project": bson.M{
-				"_id": 1,
-			},
-		},
+		if e.cfg.LargeClusterOptimizations {
+			return criticalEdgeWrites(e.Label(), props, inserts)
+		}
+
+		return targetEdgeWrites(e.Label(), e.runVertices("Node", nil), props, inserts)
 	}
+}
+
+// Stream finds all roles that have pod/create or equivalent wildcard permissions, excluding namespaced roles whose
+// namespace enforces a Pod Security Standard preventing the creation of privileged pods.
+func (e *PodCreate) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	cur, err := permissionSets.Aggregate(ctx, pipeline)
+	levels, err := namespacePodSecurityLevels(ctx, sdb, e.runtime)
 	if err != nil {
-		return err
+		return errors.Join(complete(ctx), err)
 	}
-	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[podCreateGroup](ctx, cur, callback, complete)
+	query := storedb.PermissionSetQuery{
+		AnyRule: []storedb.RuleMatch{
+			podCreateRule.WithScope(storedb.UnscopedRules), // create requests cannot be restricted by resource name
+		},
+	}
+
+	err = sdb.PermissionSets(ctx, e.runtime, query, func(ctx context.Context, ps *store.PermissionSet) error {
+		// Namespaced roles cannot be used to create privileged pods in namespaces enforcing the baseline or restricted
+		// Pod Security Standards. Cluster roles can create pods in any namespace and are always considered.
+		if ps.IsNamespaced && !levels.privileged(ps.Namespace) {
+			return nil
+		}
+
+		return callback(ctx, &podCreateGroup{Role: ps.Id})
+	})
+
+	return errors.Join(complete(ctx), err)
 }
@@ -0,0 +1,155 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&PodDebug{}, RegisterGraphMutation)
+}
+
+type PodDebug struct {
+	BaseEdge
+}
+
+type podDebugGroup struct {
+	Role primitive.ObjectID `bson:"_id" json:"role"`
+}
+
+// podDebugRule matches the policy rules granting the addition of ephemeral containers to pods.
+var podDebugRule = storedb.RuleMatch{
+	APIGroups: []string{""},
+	Resources: []string{"pods/ephemeralcontainers"},
+	Verbs:     []string{"patch", "update"},
+}
+
+func (e *PodDebug) Label() string {
+	return "POD_DEBUG"
+}
+
+func (e *PodDebug) Name() string {
+	return "PodDebug"
+}
+
+func (e *PodDebug) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueContainerAdministrationCommand
+}
+
+func (e *PodDebug) AttckTacticID() AttckTacticID {
+	return AttckTacticExecution
+}
+
+func (e *PodDebug) BatchSize() int {
+	if e.cfg.LargeClusterOptimizations {
+		// Under optimization this becomes a very cheap operation
+		return e.cfg.BatchSize
+	}
+
+	return e.cfg.BatchSizeClusterImpact
+}
+
+func (e *PodDebug) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*podDebugGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	rid, err := oic.GraphID(ctx, typed.Role.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("%s edge role id convert: %w", e.Label(), err)
+	}
+
+	if e.cfg.LargeClusterOptimizations {
+		return map[any]any{
+			gremlin.T.Label: vertex.PermissionSetLabel,
+			gremlin.T.Id:    rid,
+		}, nil
+	}
+
+	return rid, nil
+}
+
+func (e *PodDebug) Traversal() types.EdgeTraversal {
+	return func(source *gremlin.GraphTraversalSource, inserts []any) *gremlin.GraphTraversal {
+		g := source.GetGraphTraversal()
+		if e.cfg.LargeClusterOptimizations {
+			// In large clusters this can explode the number of edges and we can safely assume this is a critical issue
+			g.
+				Inject(inserts).
+				Unfold().
+				As("rpe").
+				MergeV(__.Select("rpe")).
+				Option(gremlin.Merge.OnCreate, __.Fail("missing role vertex on POD_DEBUG insert")).
+				Option(gremlin.Merge.OnMatch, map[any]any{
+					"critical": true,
+				}).
+				AddE(e.Label()).
+				Property("attckTechniqueID", string(e.AttckTechniqueID())).
+				Property("attckTacticID", string(e.AttckTacticID())).
+				Property("resourceScoped", false).
+				Barrier().Limit(0)
+		} else {
+			// In smaller clusters we can still show the (large set of) attack paths generated by this attack
+			g.V().
+				Has("runID", e.runtime.RunID.String()).
+				Has("cluster", e.runtime.Cluster.Name).
+				Has("class", "Pod").
+				As("p").
+				V(inserts...).
+				Has("critical", false).
+				AddE(e.Label()).
+				To("p").
+				Property("attckTechniqueID", string(e.AttckTechniqueID())).
+				Property("attckTacticID", string(e.AttckTacticID())).
+				Property("resourceScoped", false).
+				Barrier().Limit(0)
+		}
+
+		return g
+	}
+}
+
+func (e *PodDebug) Writes() types.EdgeWrites {
+	return func(inserts []any) ([]types.EdgeWrite, error) {
+		props := map[string]any{
+			"attckTechniqueID": string(e.AttckTechniqueID()),
+			"attckTacticID":    string(e.AttckTacticID()),
+			"resourceScoped":   false,
+		}
+
+		if e.cfg.LargeClusterOptimizations {
+			return criticalEdgeWrites(e.Label(), props, inserts)
+		}
+
+		return targetEdgeWrites(e.Label(), e.runVertices("Pod", nil), props, inserts)
+	}
+}
+
+// Stream finds all roles that are NOT namespaced and have pods/ephemeralcontainers write or equivalent wildcard permissions.
+func (e *PodDebug) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	query := storedb.PermissionSetQuery{
+		Scope: storedb.ClusterPermissionSets,
+		AnyRule: []storedb.RuleMatch{
+			podDebugRule.WithScope(storedb.UnscopedRules), // resource scoped rules are handled by PodDebugScoped
+		},
+	}
+
+	err := sdb.PermissionSets(ctx, e.runtime, query, func(ctx context.Context, ps *store.PermissionSet) error {
+		return callback(ctx, &podDebugGroup{Role: ps.Id})
+	})
+
+	return errors.Join(complete(ctx), err)
+}
@@ -0,0 +1,74 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&PodDebugNamespace{}, RegisterDefault)
+}
+
+type PodDebugNamespace struct {
+	BaseEdge
+}
+
+type podDebugNSGroup struct {
+	Role primitive.ObjectID `bson:"_id" json:"role"`
+	Pod  primitive.ObjectID `bson:"pod" json:"pod"`
+}
+
+func (e *PodDebugNamespace) Label() string {
+	return "POD_DEBUG"
+}
+
+func (e *PodDebugNamespace) Name() string {
+	return "PodDebugNamespace"
+}
+
+func (e *PodDebugNamespace) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueContainerAdministrationCommand
+}
+
+func (e *PodDebugNamespace) AttckTacticID() AttckTacticID {
+	return AttckTacticExecution
+}
+
+func (e *PodDebugNamespace) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*podDebugNSGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Pod, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   false,
+	})
+}
+
+// Stream finds all roles that are namespaced and have pods/ephemeralcontainers write or equivalent wildcard permissions and matching pods.
+// Matching pods are defined as all pods that share the role namespace or non-namespaced pods.
+func (e *PodDebugNamespace) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	rule := podDebugRule.WithScope(storedb.UnscopedRules) // resource scoped rules are handled by PodDebugScoped
+	query := storedb.ResourceGrantQuery{
+		Resource: storedb.PodResource,
+		Permissions: storedb.PermissionSetQuery{
+			Scope: storedb.NamespacedPermissionSets,
+		},
+		Rule: &rule,
+	}
+
+	return streamResourceGrants(ctx, sdb, e.runtime, query, func(g *storedb.ResourceGrant) any {
+		return &podDebugNSGroup{Role: g.PermissionSet, Pod: g.Resource}
+	}, callback, complete)
+}
@@ -0,0 +1,73 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&PodDebugScoped{}, RegisterDefault)
+}
+
+// PodDebugScoped handles the POD_DEBUG edges generated by rules restricted to specific pods via resourceNames.
+type PodDebugScoped struct {
+	BaseEdge
+}
+
+type podDebugScopedGroup struct {
+	Role primitive.ObjectID `bson:"_id" json:"role"`
+	Pod  primitive.ObjectID `bson:"resource" json:"pod"`
+}
+
+func (e *PodDebugScoped) Label() string {
+	return "POD_DEBUG"
+}
+
+func (e *PodDebugScoped) Name() string {
+	return "PodDebugScoped"
+}
+
+func (e *PodDebugScoped) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueContainerAdministrationCommand
+}
+
+func (e *PodDebugScoped) AttckTacticID() AttckTacticID {
+	return AttckTacticExecution
+}
+
+func (e *PodDebugScoped) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*podDebugScopedGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Pod, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   true,
+	})
+}
+
+// Stream finds all roles that have pods/ephemeralcontainers write or equivalent wildcard permissions restricted to a set
+// of pod names via resourceNames, and the matching pods. Matching pods are defined as pods with a name in the rule's
+// resourceNames that share the role namespace (namespaced roles) or exist in any namespace (cluster roles).
+func (e *PodDebugScoped) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	rule := podDebugRule.WithScope(storedb.ResourceScopedRules)
+	query := storedb.ResourceGrantQuery{
+		Resource: storedb.PodResource,
+		Rule:     &rule,
+	}
+
+	return streamResourceGrants(ctx, sdb, e.runtime, query, func(g *storedb.ResourceGrant) any {
+		return &podDebugScopedGroup{Role: g.PermissionSet, Pod: g.Resource}
+	}, callback, complete)
+}
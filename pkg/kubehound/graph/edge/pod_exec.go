@@ -2,17 +2,16 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
 	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -91,6 +90,7 @@ func (e *PodExec) Traversal() types.EdgeTraversal {
 				AddE(e.Label()).
 				Property("attckTechniqueID", string(e.AttckTechniqueID())).
 				Property("attckTacticID", string(e.AttckTacticID())).
+				Property("resourceScoped", false).
 				Barrier().Limit(0)
 		} else {
 			// In smaller clusters we can still show the (large set of) attack paths generated by this attack
@@ -105,6 +105,7 @@ func (e *PodExec) Traversal() types.EdgeTraversal {
 				To("p").
 				Property("attckTechniqueID", string(e.AttckTechniqueID())).
 				Property("attckTacticID", string(e.AttckTacticID())).
+				Property("resourceScoped", false).
 				Barrier().Limit(0)
 		}
 
@@ -112,51 +113,36 @@ func (e *PodExec) Traversal() types.EdgeTraversal {
 	}
 }
 
+func (e *PodExec) Writes() types.EdgeWrites {
+	return func(inserts []any) ([]types.EdgeWrite, error) {
+		props := map[string]any{
+			"attckTechniqueID": string(e.AttckTechniqueID()),
+			"attckTacticID":    string(e.AttckTacticID()),
+			"resourceScoped":   false,
+		}
+
+		if e.cfg.LargeClusterOptimizations {
+			return criticalEdgeWrites(e.Label(), props, inserts)
+		}
+
+		return targetEdgeWrites(e.Label(), e.runVertices("Pod", nil), props, inserts)
+	}
+}
+
 // Stream finds all roles that are NOT namespaced and have pod/exec or equivalent wildcard permissions.
-func (e *PodExec) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+func (e *PodExec) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	permissionSets := adapter.MongoDB(ctx, store).Collection(collections.PermissionSetName)
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"is_namespaced":        false,
-				"runtime.runID":        e.runtime.RunID.String(),
-				"runtime.cluster.name": e.runtime.Cluster.Name,
-				"rules": bson.M{
-					"$elemMatch": bson.M{
-						"$and": bson.A{
-							bson.M{"$or": bson.A{
-								bson.M{"apigroups": ""},
-								bson.M{"apigroups": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"resources": "pods/exec"},
-								bson.M{"resources": "pods/*"},
-								bson.M{"resources": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"verbs": "create"},
-								bson.M{"verbs": "*"},
-							}},
-							bson.M{"resourcenames": nil}, // TODO: handle resource scope
-						},
-					},
-				},
-			},
-		},
-		{
-			"$project": bson.M{
-				"_id": 1,
-			},
+	query := storedb.PermissionSetQuery{
+		Scope: storedb.ClusterPermissionSets,
+		AnyRule: []storedb.RuleMatch{
+			podExecRule.WithScope(storedb.UnscopedRules), // resource scoped rules are handled by PodExecScoped
 		},
 	}
 
-	cur, err := permissionSets.Aggregate(ctx, pipeline)
-	if err != nil {
-		return err
-	}
-	defer cur.Close(ctx)
+	err := sdb.PermissionSets(ctx, e.runtime, query, func(ctx context.Context, ps *store.PermissionSet) error {
+		return callback(ctx, &podExecGroup{Role: ps.Id})
+	})
 
-	return adapter.MongoCursorHandler[podExecGroup](ctx, cur, callback, complete)
+	return errors.Join(complete(ctx), err)
 }
@@ -9,8 +9,6 @@ import (
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -52,89 +50,25 @@ func (e *PodExecNamespace) Processor(ctx context.Context, oic *converter.ObjectI
 	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Pod, map[string]any{
 		"attckTechniqueID": string(e.AttckTechniqueID()),
 		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   false,
 	})
 }
 
 // Stream finds all roles that are namespaced and have pod/exec or equivalent wildcard permissions and matching pods.
 // Matching pods are defined as all pods that share the role namespace or non-namespaced pods.
-func (e *PodExecNamespace) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+func (e *PodExecNamespace) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	permissionSets := adapter.MongoDB(ctx, store).Collection(collections.PermissionSetName)
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"is_namespaced":        true,
-				"runtime.runID":        e.runtime.RunID.String(),
-				"runtime.cluster.name": e.runtime.Cluster.Name,
-				"rules": bson.M{
-					"$elemMatch": bson.M{
-						"$and": bson.A{
-							bson.M{"$or": bson.A{
-								bson.M{"apigroups": ""},
-								bson.M{"apigroups": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"resources": "pods/exec"},
-								bson.M{"resources": "pods/*"},
-								bson.M{"resources": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"verbs": "create"},
-								bson.M{"verbs": "*"},
-							}},
-							bson.M{"resourcenames": nil}, // TODO: handle resource scope
-						},
-					},
-				},
-			},
+	rule := podExecRule.WithScope(storedb.UnscopedRules) // resource scoped rules are handled by PodExecScoped
+	query := storedb.ResourceGrantQuery{
+		Resource: storedb.PodResource,
+		Permissions: storedb.PermissionSetQuery{
+			Scope: storedb.NamespacedPermissionSets,
 		},
-		{
-			"$lookup": bson.M{
-				"as":   "podsInNamespace",
-				"from": "pods",
-				"let": bson.M{
-					"roleNamespace": "$namespace",
-				},
-				"pipeline": []bson.M{
-					{
-						"$match": bson.M{
-							"$or": bson.A{
-								bson.M{"$expr": bson.M{
-									"$eq": bson.A{
-										"$k8.objectmeta.namespace", "$$roleNamespace",
-									},
-								}},
-								bson.M{"is_namespaced": false},
-							},
-							"runtime.runID":        e.runtime.RunID.String(),
-							"runtime.cluster.name": e.runtime.Cluster.Name,
-						},
-					},
-					{
-						"$project": bson.M{
-							"_id": 1,
-						},
-					},
-				},
-			},
-		},
-		{
-			"$unwind": "$podsInNamespace",
-		},
-		{
-			"$project": bson.M{
-				"_id": 1,
-				"pod": "$podsInNamespace._id",
-			},
-		},
-	}
-
-	cur, err := permissionSets.Aggregate(ctx, pipeline)
-	if err != nil {
-		return err
+		Rule: &rule,
 	}
-	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[podExecNSGroup](ctx, cur, callback, complete)
+	return streamResourceGrants(ctx, sdb, e.runtime, query, func(g *storedb.ResourceGrant) any {
+		return &podExecNSGroup{Role: g.PermissionSet, Pod: g.Resource}
+	}, callback, complete)
 }
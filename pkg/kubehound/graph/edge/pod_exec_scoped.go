@@ -0,0 +1,80 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&PodExecScoped{}, RegisterDefault)
+}
+
+// PodExecScoped handles the POD_EXEC edges generated by rules restricted to specific pods via resourceNames.
+type PodExecScoped struct {
+	BaseEdge
+}
+
+type podExecScopedGroup struct {
+	Role primitive.ObjectID `bson:"_id" json:"role"`
+	Pod  primitive.ObjectID `bson:"resource" json:"pod"`
+}
+
+// podExecRule matches the policy rules granting command execution in pods.
+var podExecRule = storedb.RuleMatch{
+	APIGroups: []string{""},
+	Resources: []string{"pods/exec"},
+	Verbs:     []string{"create"},
+}
+
+func (e *PodExecScoped) Label() string {
+	return "POD_EXEC"
+}
+
+func (e *PodExecScoped) Name() string {
+	return "PodExecScoped"
+}
+
+func (e *PodExecScoped) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueContainerAdministrationCommand
+}
+
+func (e *PodExecScoped) AttckTacticID() AttckTacticID {
+	return AttckTacticExecution
+}
+
+func (e *PodExecScoped) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*podExecScopedGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Pod, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   true,
+	})
+}
+
+// Stream finds all roles that have pod/exec or equivalent wildcard permissions restricted to a set of pod names via
+// resourceNames, and the matching pods. Matching pods are defined as pods with a name in the rule's resourceNames that
+// share the role namespace (namespaced roles) or exist in any namespace (cluster roles).
+func (e *PodExecScoped) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	rule := podExecRule.WithScope(storedb.ResourceScopedRules)
+	query := storedb.ResourceGrantQuery{
+		Resource: storedb.PodResource,
+		Rule:     &rule,
+	}
+
+	return streamResourceGrants(ctx, sdb, e.runtime, query, func(g *storedb.ResourceGrant) any {
+		return &podExecScopedGroup{Role: g.PermissionSet, Pod: g.Resource}
+	}, callback, complete)
+}
@@ -2,6 +2,7 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
@@ -9,8 +10,6 @@ import (
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -23,8 +22,9 @@ type PodPatchNamespace struct {
 }
 
 type podPatchNSGroup struct {
-	Role primitive.ObjectID `bson:"_id" json:"role"`
-	Pod  primitive.ObjectID `bson:"pod" json:"pod"`
+	Role       primitive.ObjectID `bson:"_id" json:"role"`
+	Pod        primitive.ObjectID `bson:"pod" json:"pod"`
+	PSAEnforce string             `bson:"psa_enforce" json:"psa_enforce"`
 }
 
 func (e *PodPatchNamespace) Label() string {
@@ -52,96 +52,32 @@ func (e *PodPatchNamespace) Processor(ctx context.Context, oic *converter.Object
 	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Pod, map[string]any{
 		"attckTechniqueID": string(e.AttckTechniqueID()),
 		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   false,
+		"psaEnforce":       typed.PSAEnforce,
 	})
 }
 
 // Stream finds all roles that are namespaced and have pod/exec or equivalent wildcard permissions and matching pods.
 // Matching pods are defined as all pods that share the role namespace or non-namespaced pods.
-func (e *PodPatchNamespace) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+func (e *PodPatchNamespace) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	permissionSets := adapter.MongoDB(ctx, store).Collection(collections.PermissionSetName)
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"is_namespaced":        true,
-				"runtime.runID":        e.runtime.RunID.String(),
-				"runtime.cluster.name": e.runtime.Cluster.Name,
-				"rules": bson.M{
-					"$elemMatch": bson.M{
-						"$and": bson.A{
-							bson.M{"$or": bson.A{
-								bson.M{"apigroups": ""},
-								bson.M{"apigroups": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"resources": "pods"},
-								bson.M{"resources": "cronjobs"},
-								bson.M{"resources": "daemonsets"},
-								bson.M{"resources": "deployments"},
-								bson.M{"resources": "jobs"},
-								bson.M{"resources": "replicasets"},
-								bson.M{"resources": "replicationcontrollers"},
-								bson.M{"resources": "statefulsets"},
-								bson.M{"resources": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"verbs": "patch"},
-								bson.M{"verbs": "update"},
-								bson.M{"verbs": "*"},
-							}},
-							bson.M{"resourcenames": nil}, // TODO: handle resource scope
-						},
-					},
-				},
-			},
-		},
-		{
-			"$lookup": bson.M{
-				"as":   "podsInNamespace",
-				"from": "pods",
-				"let": bson.M{
-					"roleNamespace": "$namespace",
-				},
-				"pipeline": []bson.M{
-					{
-						"$match": bson.M{
-							"$or": bson.A{
-								bson.M{"$expr": bson.M{
-									"$eq": bson.A{
-										"$k8.objectmeta.namespace", "$$roleNamespace",
-									},
-								}},
-								bson.M{"is_namespaced": false},
-							},
-							"runtime.runID":        e.runtime.RunID.String(),
-							"runtime.cluster.name": e.runtime.Cluster.Name,
-						},
-					},
-					{
-						"$project": bson.M{
-							"_id": 1,
-						},
-					},
-				},
-			},
-		},
-		{
-			"$unwind": "$podsInNamespace",
-		},
-		{
-			"$project": bson.M{
-				"_id": 1,
-				"pod": "$podsInNamespace._id",
-			},
-		},
+	levels, err := namespacePodSecurityLevels(ctx, sdb, e.runtime)
+	if err != nil {
+		return errors.Join(complete(ctx), err)
 	}
 
-	cur, err := permissionSets.Aggregate(ctx, pipeline)
-	if err != nil {
-		return err
+	rule := podPatchRule.WithScope(storedb.UnscopedRules) // resource scoped rules are handled by PodPatchScoped
+	query := storedb.ResourceGrantQuery{
+		Resource: storedb.PodResource,
+		Permissions: storedb.PermissionSetQuery{
+			Scope: storedb.NamespacedPermissionSets,
+		},
+		Rule: &rule,
 	}
-	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[podPatchNSGroup](ctx, cur, callback, complete)
+	return streamResourceGrants(ctx, sdb, e.runtime, query, func(g *storedb.ResourceGrant) any {
+		// Resolve the Pod Security Admission level enforced in the namespace of the pod
+		return &podPatchNSGroup{Role: g.PermissionSet, Pod: g.Resource, PSAEnforce: levels.level(g.Namespace)}
+	}, callback, complete)
 }
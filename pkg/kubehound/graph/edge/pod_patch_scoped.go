@@ -0,0 +1,90 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&PodPatchScoped{}, RegisterDefault)
+}
+
+// PodPatchScoped handles the POD_PATCH edges generated by rules restricted to specific pods via resourceNames.
+type PodPatchScoped struct {
+	BaseEdge
+}
+
+type podPatchScopedGroup struct {
+	Role       primitive.ObjectID `bson:"_id" json:"role"`
+	Pod        primitive.ObjectID `bson:"resource" json:"pod"`
+	PSAEnforce string             `bson:"psa_enforce" json:"psa_enforce"`
+}
+
+// podPatchScopedRule matches the policy rules granting modification of pods.
+var podPatchScopedRule = storedb.RuleMatch{
+	APIGroups: []string{""},
+	Resources: []string{"pods"},
+	Verbs:     []string{"patch", "update"},
+}
+
+func (e *PodPatchScoped) Label() string {
+	return "POD_PATCH"
+}
+
+func (e *PodPatchScoped) Name() string {
+	return "PodPatchScoped"
+}
+
+func (e *PodPatchScoped) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueContainerAdministrationCommand
+}
+
+func (e *PodPatchScoped) AttckTacticID() AttckTacticID {
+	return AttckTacticExecution
+}
+
+func (e *PodPatchScoped) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*podPatchScopedGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Pod, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   true,
+		"psaEnforce":       typed.PSAEnforce,
+	})
+}
+
+// Stream finds all roles that have pod/patch or equivalent wildcard permissions restricted to a set of pod names via
+// resourceNames, and the matching pods. Name restricted permissions on workload resources (deployments, jobs, etc)
+// cannot be resolved to a pod and are ignored. Matching pods are defined as pods with a name in the rule's resourceNames that
+// share the role namespace (namespaced roles) or exist in any namespace (cluster roles).
+func (e *PodPatchScoped) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	levels, err := namespacePodSecurityLevels(ctx, sdb, e.runtime)
+	if err != nil {
+		return errors.Join(complete(ctx), err)
+	}
+
+	rule := podPatchScopedRule.WithScope(storedb.ResourceScopedRules)
+	query := storedb.ResourceGrantQuery{
+		Resource: storedb.PodResource,
+		Rule:     &rule,
+	}
+
+	return streamResourceGrants(ctx, sdb, e.runtime, query, func(g *storedb.ResourceGrant) any {
+		// Resolve the Pod Security Admission level enforced in the namespace of the pod
+		return &podPatchScopedGroup{Role: g.PermissionSet, Pod: g.Resource, PSAEnforce: levels.level(g.Namespace)}
+	}, callback, complete)
+}
@@ -0,0 +1,43 @@
+package edge
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+)
+
+// podSecurityLevels holds the Pod Security Admission enforce level of the namespaces of an ingestion run.
+type podSecurityLevels map[string]string
+
+// namespacePodSecurityLevels returns the Pod Security Admission enforce level of the namespaces of the ingestion run.
+func namespacePodSecurityLevels(ctx context.Context, sdb storedb.Provider,
+	runtime *config.DynamicConfig) (podSecurityLevels, error) {
+
+	levels := make(podSecurityLevels)
+	err := sdb.Namespaces(ctx, runtime, func(_ context.Context, ns *store.Namespace) error {
+		levels[ns.K8.Name] = ns.PSAEnforce
+
+		return nil
+	})
+
+	return levels, err
+}
+
+// level returns the enforce level of the provided namespace. Namespaces that have not been collected (e.g cluster wide
+// objects or dumps generated by older versions) default to the privileged level.
+func (l podSecurityLevels) level(namespace string) string {
+	level, ok := l[namespace]
+	if !ok {
+		return shared.PodSecurityLevelPrivileged
+	}
+
+	return level
+}
+
+// privileged returns whether privileged pods can be created in the provided namespace.
+func (l podSecurityLevels) privileged(namespace string) bool {
+	return l.level(namespace) == shared.PodSecurityLevelPrivileged
+}
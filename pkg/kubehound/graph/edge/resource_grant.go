@@ -0,0 +1,39 @@
+package edge
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+)
+
+// streamResourceGrants streams the entry built from each resource grant matching the query to the callback. Grants for
+// which no entry is built (i.e nil) are skipped.
+func streamResourceGrants(ctx context.Context, sdb storedb.Provider, runtime *config.DynamicConfig,
+	q storedb.ResourceGrantQuery, entry func(g *storedb.ResourceGrant) any,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	err := sdb.ResourceGrants(ctx, runtime, q, func(ctx context.Context, g *storedb.ResourceGrant) error {
+		e := entry(g)
+		if e == nil {
+			return nil
+		}
+
+		return callback(ctx, e)
+	})
+
+	return errors.Join(complete(ctx), err)
+}
+
+// streamLinks streams the entry built from each link of the provided kind to the callback.
+func streamLinks(ctx context.Context, sdb storedb.Provider, runtime *config.DynamicConfig, kind storedb.LinkKind,
+	entry func(l *storedb.Link) any, callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	err := sdb.Links(ctx, runtime, kind, func(ctx context.Context, l *storedb.Link) error {
+		return callback(ctx, entry(l))
+	})
+
+	return errors.Join(complete(ctx), err)
+}
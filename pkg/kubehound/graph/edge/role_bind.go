@@ -1,6 +1,9 @@
 package edge
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
 const (
 	RoleBindLabel = "ROLE_BIND"
@@ -9,3 +12,33 @@ const (
 type roleBindGroup struct {
 	PermissionSet primitive.ObjectID `bson:"_id" json:"permission_set"`
 }
+
+// roleBindCreateRule returns the rule match of roles allowed to create the provided binding resources. The create verb
+// cannot be restricted via resourceNames, as the name of a new object is not known at authorization time.
+func roleBindCreateRule(bindings []string) storedb.RuleMatch {
+	return storedb.RuleMatch{
+		APIGroups: []string{"rbac.authorization.k8s.io"},
+		Resources: bindings,
+		Verbs:     []string{"create"},
+		Scope:     storedb.UnscopedRules,
+	}
+}
+
+// roleBindRule returns the rule match of roles allowed to bind the provided role resources.
+func roleBindRule(roles []string) storedb.RuleMatch {
+	return storedb.RuleMatch{
+		APIGroups: []string{"rbac.authorization.k8s.io"},
+		Resources: roles,
+		Verbs:     []string{"bind"},
+	}
+}
+
+// roleBindRules returns the rule matches of roles allowed to create the provided binding resources and to bind the
+// provided role resources, without any resourceNames restriction. Bind rules restricted to specific roles via
+// resourceNames are handled by RoleBindScoped.
+func roleBindRules(bindings []string, roles []string) []storedb.RuleMatch {
+	return []storedb.RuleMatch{
+		roleBindCreateRule(bindings),
+		roleBindRule(roles).WithScope(storedb.UnscopedRules),
+	}
+}
@@ -2,16 +2,15 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
 	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
-	"go.mongodb.org/mongo-driver/bson"
 )
 
 const (
@@ -99,99 +98,34 @@ func (e *RoleBindCrbCrCr) Traversal() types.EdgeTraversal {
 	}
 }
 
-func (e *RoleBindCrbCrCr) Stream(ctx context.Context, store storedb.Provider, c cache.CacheReader,
+func (e *RoleBindCrbCrCr) Writes() types.EdgeWrites {
+	return func(inserts []any) ([]types.EdgeWrite, error) {
+		props := map[string]any{
+			"attckTechniqueID": string(e.AttckTechniqueID()),
+			"attckTacticID":    string(e.AttckTacticID()),
+		}
+
+		if e.cfg.LargeClusterOptimizations {
+			return targetEdgeWrites(e.Label(), e.runVertices("PermissionSet", map[string]any{"isNamespaced": false, "critical": true}), props, inserts)
+		}
+
+		return targetEdgeWrites(e.Label(), e.runVertices("PermissionSet", map[string]any{"isNamespaced": false}), props, inserts)
+	}
+}
+
+func (e *RoleBindCrbCrCr) Stream(ctx context.Context, sdb storedb.Provider, c cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	permissionSets := adapter.MongoDB(ctx, store).Collection(collections.PermissionSetName)
 	// Handle clusterrolebindings against clusterroles
-	pipeline := []bson.M{
-		// $match stage
-		{
-			"$match": bson.M{
-				// looking for CRB/CR role only
-				"is_namespaced":        false,
-				"runtime.runID":        e.runtime.RunID.String(),
-				"runtime.cluster.name": e.runtime.Cluster.Name,
-				"$and": []bson.M{
-					// Looking for RBAC objects
-					{
-						"rules": bson.M{
-							"$elemMatch": bson.M{
-								"$or": []bson.M{
-									{"apigroups": "*"},
-									{"apigroups": "rbac.authorization.k8s.io"},
-								},
-							},
-						},
-					},
-					// looking for creation of rolebindings / clusterrolebindings
-					{
-						"rules": bson.M{
-							"$elemMatch": bson.M{
-								"$and": []bson.M{
-									{
-										"$or": []bson.M{
-											{"verbs": "create"},
-											{"verbs": "*"},
-										},
-									},
-									{
-										"$or": []bson.M{
-											{"resources": "clusterrolebindings"},
-											{"resources": "rolebindings"},
-											{"resources": "*"},
-										},
-									},
-									{
-										"$or": []bson.M{
-											{"resourcenames": nil},
-										},
-									},
-								},
-							},
-						},
-					},
-					// Looking for binding clusterroles
-					{
-						"rules": bson.M{
-							"$elemMatch": bson.M{
-								"$and": []bson.M{
-									{
-										"$or": []bson.M{
-											{"verbs": "bind"},
-											{"verbs": "*"},
-										},
-									},
-									{
-										"$or": []bson.M{
-											{"resources": "clusterroles"},
-											{"resources": "*"},
-										},
-									},
-									{
-										"$or": []bson.M{
-											{"resourcenames": nil},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-		// $project stage
-		{
-			"$project": bson.M{
-				"_id": 1,
-			},
-		},
+	query := storedb.PermissionSetQuery{
+		// looking for CRB/CR role only
+		Scope:    storedb.ClusterPermissionSets,
+		AllRules: roleBindRules([]string{"clusterrolebindings", "rolebindings"}, []string{"clusterroles"}),
 	}
-	cur, err := permissionSets.Aggregate(ctx, pipeline)
-	if err != nil {
-		return err
-	}
-	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[roleBindGroup](ctx, cur, callback, complete)
+	err := sdb.PermissionSets(ctx, e.runtime, query, func(ctx context.Context, ps *store.PermissionSet) error {
+		return callback(ctx, &roleBindGroup{PermissionSet: ps.Id})
+	})
+
+	return errors.Join(complete(ctx), err)
 }
@@ -2,16 +2,15 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
 	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
-	"go.mongodb.org/mongo-driver/bson"
 )
 
 const (
@@ -99,118 +98,33 @@ func (e *RoleBindCrbCrR) Traversal() types.EdgeTraversal {
 	}
 }
 
-func (e *RoleBindCrbCrR) Stream(ctx context.Context, store storedb.Provider, c cache.CacheReader,
-	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+func (e *RoleBindCrbCrR) Writes() types.EdgeWrites {
+	return func(inserts []any) ([]types.EdgeWrite, error) {
+		props := map[string]any{
+			"attckTechniqueID": string(e.AttckTechniqueID()),
+			"attckTacticID":    string(e.AttckTacticID()),
+		}
+
+		if e.cfg.LargeClusterOptimizations {
+			return targetEdgeWrites(e.Label(), e.runVertices("PermissionSet", map[string]any{"isNamespaced": true, "critical": true}), props, inserts)
+		}
 
-	permissionSets := adapter.MongoDB(ctx, store).Collection(collections.PermissionSetName)
-	// Handle clusterrolebindings against clusterroles
-	pipeline := []bson.M{
-		// $match stage
-		{
-			"$match": bson.M{
-				"is_namespaced":        false,
-				"runtime.runID":        e.runtime.RunID.String(),
-				"runtime.cluster.name": e.runtime.Cluster.Name,
-				"$and": []bson.M{
-					// Looking for RBAC objects
-					{
-						"rules": bson.M{
-							"$elemMatch": bson.M{
-								"$or": []bson.M{
-									{"apigroups": "*"},
-									{"apigroups": "rbac.authorization.k8s.io"},
-								},
-							},
-						},
-					},
-					// Looking for creation of rolebindings
-					{
-						"rules": bson.M{
-							"$elemMatch": bson.M{
-								"$and": []bson.M{
-									{
-										"$or": []bson.M{
-											{"verbs": "create"},
-											{"verbs": "*"},
-										},
-									},
-									{
-										"$or": []bson.M{
-											{"resources": "rolebindings"},
-											{"resources": "*"},
-										},
-									},
-									{
-										"$or": []bson.M{
-											{"resourcenames": nil},
-										},
-									},
-								},
-							},
-						},
-					},
-					// Looking for binding roles
-					{
-						"rules": bson.M{
-							"$elemMatch": bson.M{
-								"$and": []bson.M{
-									{
-										"$or": []bson.M{
-											{"verbs": "bind"},
-											{"verbs": "*"},
-										},
-									},
-									{
-										"$or": []bson.M{
-											{"resources": "roles"},
-											{"resources": "*"},
-										},
-									},
-									{
-										"$or": []bson.M{
-											{"resourcenames": nil},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-		// Looking for all permissionSets link to the same namespace
-		{
-			"$lookup": bson.M{
-				"from":         "rolebindings",
-				"localField":   "role_binding_id",
-				"foreignField": "_id",
-				"as":           "rb",
-			},
-		},
-		{
-			"$unwind": bson.M{
-				"path": "$rb",
-			},
-		},
-		{
-			"$match": bson.M{
-				"rb.is_namespaced":     false,
-				"runtime.runID":        e.runtime.RunID.String(),
-				"runtime.cluster.name": e.runtime.Cluster.Name,
-			},
-		},
-		// $project stage
-		{
-			"$project": bson.M{
-				"_id": 1,
-			},
-		},
+		return targetEdgeWrites(e.Label(), e.runVertices("PermissionSet", map[string]any{"isNamespaced": true}), props, inserts)
 	}
-	cur, err := permissionSets.Aggregate(ctx, pipeline)
-	if err != nil {
-		return err
+}
+
+func (e *RoleBindCrbCrR) Stream(ctx context.Context, sdb storedb.Provider, c cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	// Handle clusterrolebindings against roles. Cluster permission sets are always created from a clusterrolebinding.
+	query := storedb.PermissionSetQuery{
+		Scope:    storedb.ClusterPermissionSets,
+		AllRules: roleBindRules([]string{"rolebindings"}, []string{"roles"}),
 	}
-	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[roleBindGroup](ctx, cur, callback, complete)
+	err := sdb.PermissionSets(ctx, e.runtime, query, func(ctx context.Context, ps *store.PermissionSet) error {
+		return callback(ctx, &roleBindGroup{PermissionSet: ps.Id})
+	})
+
+	return errors.Join(complete(ctx), err)
 }
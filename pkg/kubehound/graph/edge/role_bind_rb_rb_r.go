@@ -2,15 +2,15 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -59,144 +59,44 @@ func (e *RoleBindRbRbR) Processor(ctx context.Context, oic *converter.ObjectIDCo
 	})
 }
 
-func (e *RoleBindRbRbR) Stream(ctx context.Context, store storedb.Provider, c cache.CacheReader,
+func (e *RoleBindRbRbR) Stream(ctx context.Context, sdb storedb.Provider, c cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	permissionSets := adapter.MongoDB(ctx, store).Collection(collections.PermissionSetName)
-	pipeline := bson.A{
-		bson.M{
-			"$match": bson.M{
-				// looking for RB CR/R role only
-				"is_namespaced":        true,
-				"runtime.runID":        e.runtime.RunID.String(),
-				"runtime.cluster.name": e.runtime.Cluster.Name,
-				"$and": []bson.M{
-					// Looking for RBAC objects
-					{
-						"rules": bson.M{
-							"$elemMatch": bson.M{
-								"$or": []bson.M{
-									{"apigroups": "*"},
-									{"apigroups": "rbac.authorization.k8s.io"},
-								},
-							},
-						},
-					},
-					// Looking for creation of rolebindings
-					{
-						"rules": bson.M{
-							"$elemMatch": bson.M{
-								"$and": []bson.M{
-									{
-										"$or": []bson.M{
-											{"verbs": "create"},
-											{"verbs": "*"},
-										},
-									},
-									{
-										"$or": []bson.M{
-											{"resources": "rolebindings"},
-											{"resources": "*"},
-										},
-									},
-									{
-										"$or": []bson.M{
-											{"resourcenames": nil},
-										},
-									},
-								},
-							},
-						},
-					},
-					// Looking for binding roles
-					{
-						"rules": bson.M{
-							"$elemMatch": bson.M{
-								"$and": []bson.M{
-									{
-										"$or": []bson.M{
-											{"verbs": "bind"},
-											{"verbs": "*"},
-										},
-									},
-									{
-										"$or": []bson.M{
-											{"resources": "roles"},
-											{"resources": "*"},
-										},
-									},
-									{
-										"$or": []bson.M{
-											{"resourcenames": nil},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-		// Looking for all permissionSets link to the same namespace
-		bson.M{
-			"$lookup": bson.M{
-				"as":   "linkpermset",
-				"from": "permissionsets",
-				"let": bson.M{
-					"roleNamespace": "$namespace",
-				},
-				"pipeline": []bson.M{
-					{
-						"$match": bson.M{
-							"$or": bson.A{
-								bson.M{"$expr": bson.M{
-									"$eq": bson.A{
-										"$k8.objectmeta.namespace", "$$roleNamespace",
-									},
-								}},
-								bson.M{"is_namespaced": true},
-							},
-							"runtime.runID":        e.runtime.RunID.String(),
-							"runtime.cluster.name": e.runtime.Cluster.Name,
-						},
-					},
-					{
-						"$project": bson.M{
-							"_id": 1,
-						},
-					},
-				},
-			},
-		},
-		bson.M{
-			"$unwind": bson.M{
-				"path": "$linkpermset",
-			},
-		},
-		// Removing the reference of the current PermissionSet from the pointed PermissionSet
-		bson.M{
-			"$match": bson.M{
-				"$expr": bson.M{
-					"$ne": bson.A{
-						"$linkpermset._id",
-						"$_id",
-					},
-				},
-			},
-		},
-		// Projecting current PermissionSet with the associated one
-		bson.M{
-			"$project": bson.M{
-				"_id":     1,
-				"permset": "$linkpermset._id",
-			},
-		},
+	// looking for RB CR/R role only
+	var from []primitive.ObjectID
+	query := storedb.PermissionSetQuery{
+		Scope:    storedb.NamespacedPermissionSets,
+		AllRules: roleBindRules([]string{"rolebindings"}, []string{"roles"}),
 	}
-	cur, err := permissionSets.Aggregate(ctx, pipeline)
-	if err != nil {
-		return err
+
+	err := sdb.PermissionSets(ctx, e.runtime, query, func(_ context.Context, ps *store.PermissionSet) error {
+		from = append(from, ps.Id)
+
+		return nil
+	})
+	if err != nil || len(from) == 0 {
+		return errors.Join(complete(ctx), err)
+	}
+
+	// Looking for all the namespaced permission sets
+	query = storedb.PermissionSetQuery{
+		Scope: storedb.NamespacedPermissionSets,
 	}
-	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[roleBindNameSpaceGroup](ctx, cur, callback, complete)
+	err = sdb.PermissionSets(ctx, e.runtime, query, func(ctx context.Context, ps *store.PermissionSet) error {
+		for _, id := range from {
+			// Removing the reference of the current PermissionSet from the pointed PermissionSet
+			if id == ps.Id {
+				continue
+			}
+
+			if err := callback(ctx, &roleBindNameSpaceGroup{FromPerm: id, ToPerm: ps.Id}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return errors.Join(complete(ctx), err)
 }
@@ -0,0 +1,131 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	RoleBindScopedName = "RoleBindScoped"
+)
+
+func init() {
+	Register(&RoleBindScoped{}, RegisterDefault)
+}
+
+// RoleBindScoped handles the ROLE_BIND edges generated by rules allowing to bind specific (cluster) roles via
+// resourceNames. Only the permission sets of the named roles can be granted via the new binding.
+type RoleBindScoped struct {
+	BaseEdge
+}
+
+type roleBindScopedGroup struct {
+	FromPerm primitive.ObjectID `bson:"_id" json:"from_permission_set"`
+	ToPerm   primitive.ObjectID `bson:"resource" json:"to_permission_set"`
+}
+
+// roleBindScopedCase describes a binding scenario covered by the unscoped ROLE_BIND builders, restricted to the roles
+// named in the bind rule.
+type roleBindScopedCase struct {
+	scope      storedb.PermissionSetScope // Scope of the permission sets allowed to create the binding
+	bindings   []string                   // Binding resources that can be created
+	roles      []string                   // Role resources that can be bound
+	namespaced bool                       // Whether the bound permission sets are namespaced
+}
+
+// roleBindScopedCases mirrors the RoleBindCrbCrCr, RoleBindCrbCrR and RoleBindRbRbR builders respectively.
+var roleBindScopedCases = []roleBindScopedCase{
+	{
+		scope:      storedb.ClusterPermissionSets,
+		bindings:   []string{"clusterrolebindings", "rolebindings"},
+		roles:      []string{"clusterroles"},
+		namespaced: false,
+	},
+	{
+		scope:      storedb.ClusterPermissionSets,
+		bindings:   []string{"rolebindings"},
+		roles:      []string{"roles"},
+		namespaced: true,
+	},
+	{
+		scope:      storedb.NamespacedPermissionSets,
+		bindings:   []string{"rolebindings"},
+		roles:      []string{"roles"},
+		namespaced: true,
+	},
+}
+
+func (e *RoleBindScoped) Label() string {
+	return RoleBindLabel
+}
+
+func (e *RoleBindScoped) Name() string {
+	return RoleBindScopedName
+}
+
+func (e *RoleBindScoped) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueValidAccounts
+}
+
+func (e *RoleBindScoped) AttckTacticID() AttckTacticID {
+	return AttckTacticPrivilegeEscalation
+}
+
+func (e *RoleBindScoped) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*roleBindScopedGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.FromPerm, typed.ToPerm, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   true,
+	})
+}
+
+// Stream finds all roles allowed to create (cluster) role bindings and to bind (cluster) roles restricted to a set of
+// role names via resourceNames, and the permission sets of the named roles. Named roles of a namespaced permission set
+// must share its namespace.
+func (e *RoleBindScoped) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	for _, c := range roleBindScopedCases {
+		resource := storedb.ClusterPermissionSetResource
+		if c.namespaced {
+			resource = storedb.NamespacedPermissionSetResource
+		}
+
+		rule := roleBindRule(c.roles).WithScope(storedb.ResourceScopedRules)
+		query := storedb.ResourceGrantQuery{
+			Resource: resource,
+			Permissions: storedb.PermissionSetQuery{
+				Scope:    c.scope,
+				AllRules: []storedb.RuleMatch{roleBindCreateRule(c.bindings)},
+			},
+			Rule: &rule,
+		}
+
+		err := sdb.ResourceGrants(ctx, e.runtime, query, func(ctx context.Context, g *storedb.ResourceGrant) error {
+			// Removing the reference of the current PermissionSet from the pointed PermissionSet
+			if g.Resource == g.PermissionSet {
+				return nil
+			}
+
+			return callback(ctx, &roleBindScopedGroup{FromPerm: g.PermissionSet, ToPerm: g.Resource})
+		})
+		if err != nil {
+			return errors.Join(complete(ctx), err)
+		}
+	}
+
+	return complete(ctx)
+}
@@ -0,0 +1,156 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	RoleEscalateLabel = "ROLE_ESCALATE"
+)
+
+func init() {
+	Register(&RoleEscalate{}, RegisterDefault)
+}
+
+// RoleEscalate handles the ROLE_ESCALATE edges generated by cluster roles allowing to escalate any cluster role.
+type RoleEscalate struct {
+	BaseEdge
+}
+
+type roleEscalateGroup struct {
+	PermissionSet primitive.ObjectID `bson:"_id" json:"permission_set"`
+}
+
+var roleEscalateAPIGroups = []string{"rbac.authorization.k8s.io"}
+
+func (e *RoleEscalate) Label() string {
+	return RoleEscalateLabel
+}
+
+func (e *RoleEscalate) Name() string {
+	return "RoleEscalateCluster"
+}
+
+func (e *RoleEscalate) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueAccountManipulation
+}
+
+func (e *RoleEscalate) AttckTacticID() AttckTacticID {
+	return AttckTacticPrivilegeEscalation
+}
+
+func (e *RoleEscalate) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*roleEscalateGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	psid, err := oic.GraphID(ctx, typed.PermissionSet.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("%s edge PermissionSet id convert: %w", e.Label(), err)
+	}
+
+	return psid, nil
+}
+
+func (e *RoleEscalate) Traversal() types.EdgeTraversal {
+	return func(source *gremlin.GraphTraversalSource, inserts []any) *gremlin.GraphTraversal {
+		g := source.GetGraphTraversal()
+
+		if e.cfg.LargeClusterOptimizations {
+			// For larger clusters simply target critical roles to reduce number of attack paths
+			g.V().
+				Has("runID", e.runtime.RunID.String()).
+				Has("cluster", e.runtime.Cluster.Name).
+				Has("class", "PermissionSet").
+				Has("isNamespaced", false).
+				Has("critical", true).
+				As("r").
+				V(inserts...).
+				Has("critical", false).
+				AddE(e.Label()).
+				To("r").
+				Property("attckTechniqueID", string(e.AttckTechniqueID())).
+				Property("attckTacticID", string(e.AttckTacticID())).
+				Barrier().Limit(0)
+		} else {
+			// In smaller clusters we can still show the (large set of) attack paths generated by this attack
+			g.V().
+				Has("runID", e.runtime.RunID.String()).
+				Has("cluster", e.runtime.Cluster.Name).
+				Has("class", "PermissionSet").
+				Has("isNamespaced", false).
+				As("r").
+				V(inserts...).
+				Has("critical", false).
+				AddE(e.Label()).
+				To("r").
+				Property("attckTechniqueID", string(e.AttckTechniqueID())).
+				Property("attckTacticID", string(e.AttckTacticID())).
+				Barrier().Limit(0)
+		}
+
+		return g
+	}
+}
+
+func (e *RoleEscalate) Writes() types.EdgeWrites {
+	return func(inserts []any) ([]types.EdgeWrite, error) {
+		props := map[string]any{
+			"attckTechniqueID": string(e.AttckTechniqueID()),
+			"attckTacticID":    string(e.AttckTacticID()),
+		}
+
+		if e.cfg.LargeClusterOptimizations {
+			return targetEdgeWrites(e.Label(), e.runVertices("PermissionSet", map[string]any{"isNamespaced": false, "critical": true}), props, inserts)
+		}
+
+		return targetEdgeWrites(e.Label(), e.runVertices("PermissionSet", map[string]any{"isNamespaced": false}), props, inserts)
+	}
+}
+
+// roleEscalateRules returns the rule matches of roles allowed to modify (update or patch) and escalate the provided
+// role resources, without any resourceNames restriction.
+func roleEscalateRules(resources []string) []storedb.RuleMatch {
+	return []storedb.RuleMatch{
+		{
+			APIGroups: roleEscalateAPIGroups,
+			Resources: resources,
+			Verbs:     []string{"escalate"},
+			Scope:     storedb.UnscopedRules,
+		},
+		{
+			APIGroups: roleEscalateAPIGroups,
+			Resources: resources,
+			Verbs:     []string{"update", "patch"},
+			Scope:     storedb.UnscopedRules,
+		},
+	}
+}
+
+// Stream finds all cluster wide permission sets allowed to escalate and modify cluster roles. The escalate verb bypasses
+// the RBAC privilege escalation prevention, allowing the rules of any cluster role to be rewritten with arbitrary permissions.
+func (e *RoleEscalate) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	query := storedb.PermissionSetQuery{
+		Scope:    storedb.ClusterPermissionSets,
+		AllRules: roleEscalateRules([]string{"clusterroles"}),
+	}
+
+	err := sdb.PermissionSets(ctx, e.runtime, query, func(ctx context.Context, ps *store.PermissionSet) error {
+		return callback(ctx, &roleEscalateGroup{PermissionSet: ps.Id})
+	})
+
+	return errors.Join(complete(ctx), err)
+}
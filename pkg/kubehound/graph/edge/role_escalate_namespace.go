@@ -0,0 +1,81 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&RoleEscalateNamespace{}, RegisterDefault)
+}
+
+// RoleEscalateNamespace handles the ROLE_ESCALATE edges generated by namespaced permission sets allowing to escalate
+// any role within their namespace.
+type RoleEscalateNamespace struct {
+	BaseEdge
+}
+
+type roleEscalateNamespaceGroup struct {
+	FromPerm primitive.ObjectID `bson:"_id" json:"from_permission_set"`
+	ToPerm   primitive.ObjectID `bson:"permset" json:"to_permission_set"`
+}
+
+func (e *RoleEscalateNamespace) Label() string {
+	return RoleEscalateLabel
+}
+
+func (e *RoleEscalateNamespace) Name() string {
+	return "RoleEscalateNamespace"
+}
+
+func (e *RoleEscalateNamespace) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueAccountManipulation
+}
+
+func (e *RoleEscalateNamespace) AttckTacticID() AttckTacticID {
+	return AttckTacticPrivilegeEscalation
+}
+
+func (e *RoleEscalateNamespace) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*roleEscalateNamespaceGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.FromPerm, typed.ToPerm, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+	})
+}
+
+// Stream finds all namespaced permission sets allowed to escalate and modify roles, and all the other permission sets
+// of the same namespace backed by a role. As roles are namespaced, the permission set can rewrite the rules of any role
+// in its namespace.
+func (e *RoleEscalateNamespace) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	// Looking for all permission sets of the same namespace. Only roles can be escalated, permission sets binding a
+	// cluster role are out of reach.
+	query := storedb.ResourceGrantQuery{
+		Resource: storedb.RolePermissionSetResource,
+		Permissions: storedb.PermissionSetQuery{
+			Scope:    storedb.NamespacedPermissionSets,
+			AllRules: roleEscalateRules([]string{"roles"}),
+		},
+	}
+
+	return streamResourceGrants(ctx, sdb, e.runtime, query, func(g *storedb.ResourceGrant) any {
+		if g.Resource == g.PermissionSet {
+			return nil
+		}
+
+		return &roleEscalateNamespaceGroup{FromPerm: g.PermissionSet, ToPerm: g.Resource}
+	}, callback, complete)
+}
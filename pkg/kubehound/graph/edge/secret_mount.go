@@ -0,0 +1,65 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&SecretMount{}, RegisterDefault)
+}
+
+type secretMountGroup struct {
+	Volume primitive.ObjectID `bson:"_id" json:"volume"`
+	Secret primitive.ObjectID `bson:"secret" json:"secret"`
+}
+
+// SecretMount links a secret volume to the secret it exposes to the mounting containers.
+type SecretMount struct {
+	BaseEdge
+}
+
+func (e *SecretMount) Label() string {
+	return "SECRET_MOUNT"
+}
+
+func (e *SecretMount) Name() string {
+	return "SecretMount"
+}
+
+func (e *SecretMount) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueUnsecuredCredentials
+}
+
+func (e *SecretMount) AttckTacticID() AttckTacticID {
+	return AttckTacticCredentialAccess
+}
+
+func (e *SecretMount) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*secretMountGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Volume, typed.Secret, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+	})
+}
+
+// Stream finds all secret volumes and the secret they reference. Secret volumes can only reference a secret in the
+// namespace of the mounting pod.
+func (e *SecretMount) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	return streamLinks(ctx, sdb, e.runtime, storedb.MountedSecretLink, func(l *storedb.Link) any {
+		return &secretMountGroup{Volume: l.From, Secret: l.To}
+	}, callback, complete)
+}
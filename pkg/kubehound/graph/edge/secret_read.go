@@ -0,0 +1,148 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	Register(&SecretRead{}, RegisterGraphMutation)
+}
+
+type SecretRead struct {
+	BaseEdge
+}
+
+type secretReadGroup struct {
+	Role primitive.ObjectID `bson:"_id" json:"role"`
+}
+
+// secretReadRule matches the policy rules granting read access to secrets.
+var secretReadRule = storedb.RuleMatch{
+	APIGroups: []string{""},
+	Resources: []string{"secrets"},
+	Verbs:     []string{"get", "list"},
+}
+
+func (e *SecretRead) Label() string {
+	return "SECRET_READ"
+}
+
+func (e *SecretRead) Name() string {
+	return "SecretReadCluster"
+}
+
+func (e *SecretRead) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueUnsecuredCredentials
+}
+
+func (e *SecretRead) AttckTacticID() AttckTacticID {
+	return AttckTacticCredentialAccess
+}
+
+func (e *SecretRead) BatchSize() int {
+	if e.cfg.LargeClusterOptimizations {
+		// Under optimization this becomes a very cheap operation
+		return e.cfg.BatchSize
+	}
+
+	return e.cfg.BatchSizeClusterImpact
+}
+
+func (e *SecretRead) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*secretReadGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	rid, err := oic.GraphID(ctx, typed.Role.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("%s edge role id convert: %w", e.Label(), err)
+	}
+
+	return rid, nil
+}
+
+func (e *SecretRead) Traversal() types.EdgeTraversal {
+	return func(source *gremlin.GraphTraversalSource, inserts []any) *gremlin.GraphTraversal {
+		g := source.GetGraphTraversal()
+		if e.cfg.LargeClusterOptimizations {
+			// For larger clusters simply target the service account token secrets to reduce redundant attack paths
+			g.V().
+				Has("runID", e.runtime.RunID.String()).
+				Has("cluster", e.runtime.Cluster.Name).
+				Has("class", "Secret").
+				Has("type", string(corev1.SecretTypeServiceAccountToken)).
+				As("s").
+				V(inserts...).
+				Has("critical", false).
+				AddE(e.Label()).
+				To("s").
+				Property("attckTechniqueID", string(e.AttckTechniqueID())).
+				Property("attckTacticID", string(e.AttckTacticID())).
+				Property("resourceScoped", false).
+				Barrier().Limit(0)
+		} else {
+			// In smaller clusters we can still show the (large set of) attack paths generated by this attack
+			g.V().
+				Has("runID", e.runtime.RunID.String()).
+				Has("cluster", e.runtime.Cluster.Name).
+				Has("class", "Secret").
+				As("s").
+				V(inserts...).
+				Has("critical", false).
+				AddE(e.Label()).
+				To("s").
+				Property("attckTechniqueID", string(e.AttckTechniqueID())).
+				Property("attckTacticID", string(e.AttckTacticID())).
+				Property("resourceScoped", false).
+				Barrier().Limit(0)
+		}
+
+		return g
+	}
+}
+
+func (e *SecretRead) Writes() types.EdgeWrites {
+	return func(inserts []any) ([]types.EdgeWrite, error) {
+		props := map[string]any{
+			"attckTechniqueID": string(e.AttckTechniqueID()),
+			"attckTacticID":    string(e.AttckTacticID()),
+			"resourceScoped":   false,
+		}
+
+		if e.cfg.LargeClusterOptimizations {
+			return targetEdgeWrites(e.Label(), e.runVertices("Secret", map[string]any{"type": string(corev1.SecretTypeServiceAccountToken)}), props, inserts)
+		}
+
+		return targetEdgeWrites(e.Label(), e.runVertices("Secret", nil), props, inserts)
+	}
+}
+
+// Stream finds all roles that are NOT namespaced and have secrets/get, secrets/list or equivalent wildcard permissions.
+func (e *SecretRead) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	query := storedb.PermissionSetQuery{
+		Scope: storedb.ClusterPermissionSets,
+		AnyRule: []storedb.RuleMatch{
+			secretReadRule.WithScope(storedb.UnscopedRules), // resource scoped rules are handled by SecretReadScoped
+		},
+	}
+
+	err := sdb.PermissionSets(ctx, e.runtime, query, func(ctx context.Context, ps *store.PermissionSet) error {
+		return callback(ctx, &secretReadGroup{Role: ps.Id})
+	})
+
+	return errors.Join(complete(ctx), err)
+}
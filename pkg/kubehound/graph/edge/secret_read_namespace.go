@@ -0,0 +1,74 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&SecretReadNamespace{}, RegisterDefault)
+}
+
+type SecretReadNamespace struct {
+	BaseEdge
+}
+
+type secretReadNSGroup struct {
+	Role   primitive.ObjectID `bson:"_id" json:"role"`
+	Secret primitive.ObjectID `bson:"secret" json:"secret"`
+}
+
+func (e *SecretReadNamespace) Label() string {
+	return "SECRET_READ"
+}
+
+func (e *SecretReadNamespace) Name() string {
+	return "SecretReadNamespace"
+}
+
+func (e *SecretReadNamespace) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueUnsecuredCredentials
+}
+
+func (e *SecretReadNamespace) AttckTacticID() AttckTacticID {
+	return AttckTacticCredentialAccess
+}
+
+func (e *SecretReadNamespace) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*secretReadNSGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Secret, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   false,
+	})
+}
+
+// Stream finds all roles that are namespaced and have secrets/get, secrets/list or equivalent wildcard permissions and
+// the matching secrets. Matching secrets are defined as secrets that share the role namespace.
+func (e *SecretReadNamespace) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	rule := secretReadRule.WithScope(storedb.UnscopedRules) // resource scoped rules are handled by SecretReadScoped
+	query := storedb.ResourceGrantQuery{
+		Resource: storedb.SecretResource,
+		Permissions: storedb.PermissionSetQuery{
+			Scope: storedb.NamespacedPermissionSets,
+		},
+		Rule: &rule,
+	}
+
+	return streamResourceGrants(ctx, sdb, e.runtime, query, func(g *storedb.ResourceGrant) any {
+		return &secretReadNSGroup{Role: g.PermissionSet, Secret: g.Resource}
+	}, callback, complete)
+}
@@ -0,0 +1,73 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&SecretReadScoped{}, RegisterDefault)
+}
+
+// SecretReadScoped handles the SECRET_READ edges generated by rules restricted to specific secrets via resourceNames.
+type SecretReadScoped struct {
+	BaseEdge
+}
+
+type secretReadScopedGroup struct {
+	Role   primitive.ObjectID `bson:"_id" json:"role"`
+	Secret primitive.ObjectID `bson:"secret" json:"secret"`
+}
+
+func (e *SecretReadScoped) Label() string {
+	return "SECRET_READ"
+}
+
+func (e *SecretReadScoped) Name() string {
+	return "SecretReadScoped"
+}
+
+func (e *SecretReadScoped) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueUnsecuredCredentials
+}
+
+func (e *SecretReadScoped) AttckTacticID() AttckTacticID {
+	return AttckTacticCredentialAccess
+}
+
+func (e *SecretReadScoped) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*secretReadScopedGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Secret, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   true,
+	})
+}
+
+// Stream finds all roles that have secrets/get, secrets/list or equivalent wildcard permissions restricted to a set of
+// secret names via resourceNames, and the matching secrets. Matching secrets are defined as secrets with a name in the
+// rule's resourceNames that share the role namespace (namespaced roles) or exist in any namespace (cluster roles).
+func (e *SecretReadScoped) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	rule := secretReadRule.WithScope(storedb.ResourceScopedRules)
+	query := storedb.ResourceGrantQuery{
+		Resource: storedb.SecretResource,
+		Rule:     &rule,
+	}
+
+	return streamResourceGrants(ctx, sdb, e.runtime, query, func(g *storedb.ResourceGrant) any {
+		return &secretReadScopedGroup{Role: g.PermissionSet, Secret: g.Resource}
+	}, callback, complete)
+}
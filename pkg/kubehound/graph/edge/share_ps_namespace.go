@@ -2,15 +2,15 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -22,9 +22,6 @@ type SharePSNamespace struct {
 	BaseEdge
 }
 
-type sharedPsNamespaceGroup struct {
-	Containers []primitive.ObjectID `bson:"container_ids" json:"container_ids"`
-}
 type sharedPsNamespaceGroupPair struct {
 	ContainerA primitive.ObjectID `bson:"container_a_id" json:"container_a"`
 	ContainerB primitive.ObjectID `bson:"container_b_id" json:"container_b"`
@@ -59,55 +56,33 @@ func (e *SharePSNamespace) Processor(ctx context.Context, oic *converter.ObjectI
 	})
 }
 
-func (e *SharePSNamespace) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+func (e *SharePSNamespace) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	coll := adapter.MongoDB(ctx, store).Collection(collections.PodName)
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"k8.spec.shareprocessnamespace": true,
-				"runtime.runID":                 e.runtime.RunID.String(),
-				"runtime.cluster.name":          e.runtime.Cluster.Name,
-			},
-		},
-		{
-			"$lookup": bson.M{
-				"as":           "containers_with_shared_ns",
-				"from":         "containers",
-				"localField":   "_id",
-				"foreignField": "pod_id",
-			},
-		},
-		{
-			"$project": bson.M{
-				"_id":                       1,
-				"containers_with_shared_ns": bson.M{"_id": 1},
-			},
-		},
-		{
-			"$project": bson.M{
-				"_id":           0,
-				"container_ids": "$containers_with_shared_ns._id",
-			},
-		},
-	}
-	cur, err := coll.Aggregate(ctx, pipeline)
+	pods := make(map[primitive.ObjectID][]primitive.ObjectID)
+	err := sdb.Pods(ctx, e.runtime, storedb.PodQuery{ShareProcessNamespace: true}, func(_ context.Context, p *store.Pod) error {
+		pods[p.Id] = nil
 
-	if err != nil {
-		return err
+		return nil
+	})
+	if err != nil || len(pods) == 0 {
+		return errors.Join(complete(ctx), err)
 	}
-	defer cur.Close(ctx)
 
-	for cur.Next(ctx) {
-		var entry sharedPsNamespaceGroup
-		err := cur.Decode(&entry)
-		if err != nil {
-			return err
+	err = sdb.Containers(ctx, e.runtime, storedb.ContainerQuery{}, func(_ context.Context, c *store.Container) error {
+		if containers, ok := pods[c.PodId]; ok {
+			pods[c.PodId] = append(containers, c.Id)
 		}
 
-		for _, containerSrc := range entry.Containers {
-			for _, containerDst := range entry.Containers {
+		return nil
+	})
+	if err != nil {
+		return errors.Join(complete(ctx), err)
+	}
+
+	for _, containers := range pods {
+		for _, containerSrc := range containers {
+			for _, containerDst := range containers {
 				// No need to create a link with itself
 				if containerSrc == containerDst {
 					continue
@@ -117,7 +92,7 @@ func (e *SharePSNamespace) Stream(ctx context.Context, store storedb.Provider, _
 					ContainerB: containerDst,
 				})
 				if err != nil {
-					return err
+					return errors.Join(complete(ctx), err)
 				}
 			}
 		}
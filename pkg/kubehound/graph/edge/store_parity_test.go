@@ -0,0 +1,652 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	admv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	netv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	parityTestCluster = "parity-test-cluster"
+)
+
+// parityDataset is a store dataset exercising all the edge builders. Models reference each other by name.
+type parityDataset struct {
+	runtime store.RuntimeInfo
+	ids     map[string]primitive.ObjectID
+	models  map[collections.Collection][]any
+}
+
+func newParityDataset(runtime store.RuntimeInfo) *parityDataset {
+	d := &parityDataset{
+		runtime: runtime,
+		ids:     make(map[string]primitive.ObjectID),
+		models:  make(map[collections.Collection][]any),
+	}
+
+	d.identities()
+	d.nodes()
+	d.pods()
+	d.containers()
+	d.volumes()
+	d.services()
+	d.rbac()
+
+	return d
+}
+
+// id returns the object id of the named model.
+func (d *parityDataset) id(name string) primitive.ObjectID {
+	if name == "" {
+		return primitive.NilObjectID
+	}
+
+	id, ok := d.ids[name]
+	if !ok {
+		id = primitive.NewObjectID()
+		d.ids[name] = id
+	}
+
+	return id
+}
+
+func (d *parityDataset) add(c collections.Collection, models ...any) {
+	d.models[c] = append(d.models[c], models...)
+}
+
+func (d *parityDataset) identities() {
+	identity := func(name, namespace, typ string, secrets ...string) any {
+		return &store.Identity{
+			Id:           d.id("identity:" + namespace + "/" + name),
+			Name:         name,
+			Namespace:    namespace,
+			IsNamespaced: namespace != "",
+			Type:         typ,
+			TokenSecrets: secrets,
+			Runtime:      d.runtime,
+		}
+	}
+
+	d.add(collections.Identity{},
+		identity("system:masters", "", shared.IdentityTypeGroup),
+		identity("system:nodes", "", shared.IdentityTypeGroup),
+		identity("system:node:n1", "", shared.IdentityTypeUser),
+		identity("alice", "", shared.IdentityTypeUser),
+		identity("devs", "", shared.IdentityTypeGroup),
+		identity("app", "default", shared.IdentityTypeSA, "legacy-secret"),
+		identity("app", "other", shared.IdentityTypeSA),
+		identity("cloud", "default", shared.IdentityTypeSA),
+	)
+
+	d.add(collections.CloudIdentity{},
+		&store.CloudIdentity{Id: d.id("cloud:roleA"), Provider: "aws", Name: "roleA", Runtime: d.runtime},
+		&store.CloudIdentity{Id: d.id("cloud:nodeRole"), Provider: "aws", Name: "nodeRole", Runtime: d.runtime},
+	)
+
+	d.add(collections.ServiceAccount{},
+		&store.ServiceAccount{
+			Id:           d.id("sa:default/cloud"),
+			IdentityId:   d.id("identity:default/cloud"),
+			Namespace:    "default",
+			Name:         "cloud",
+			IsNamespaced: true,
+			CloudIdentities: []store.CloudPrincipal{
+				{Provider: "aws", Name: "roleA"},
+				{Provider: "aws", Name: "missing"},
+			},
+			Runtime: d.runtime,
+		},
+		&store.ServiceAccount{
+			Id:           d.id("sa:default/app"),
+			IdentityId:   d.id("identity:default/app"),
+			Namespace:    "default",
+			Name:         "app",
+			IsNamespaced: true,
+			Runtime:      d.runtime,
+		},
+	)
+}
+
+func (d *parityDataset) nodes() {
+	node := func(name, kernel, image, user string, clouds ...store.CloudPrincipal) any {
+		return &store.Node{
+			Id:              d.id("node:" + name),
+			UserId:          d.id(user),
+			CloudIdentities: clouds,
+			Runtime:         d.runtime,
+			K8: corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Status: corev1.NodeStatus{
+					NodeInfo: corev1.NodeSystemInfo{
+						KernelVersion:   kernel,
+						OSImage:         image,
+						OperatingSystem: "linux",
+					},
+				},
+			},
+		}
+	}
+
+	d.add(collections.Node{},
+		node("n1", "5.15.0-1051-azure", "Ubuntu 22.04.3 LTS", "identity:/system:node:n1",
+			store.CloudPrincipal{Provider: "aws", Name: "nodeRole"}),
+		node("n2", "4.19.0", "Debian GNU/Linux 10 (buster)", ""),
+		node("n3", "unknown", "Container-Optimized OS from Google", "identity:/system:nodes"),
+	)
+
+	d.add(collections.Namespace{},
+		&store.Namespace{
+			Id:         d.id("namespace:default"),
+			K8:         corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default", Labels: map[string]string{"inject": "yes"}}},
+			PSAEnforce: shared.PodSecurityLevelPrivileged,
+			Runtime:    d.runtime,
+		},
+		&store.Namespace{
+			Id:         d.id("namespace:other"),
+			K8:         corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+			PSAEnforce: "restricted",
+			Runtime:    d.runtime,
+		},
+	)
+}
+
+func (d *parityDataset) pods() {
+	workload := func(namespace, name, group, resource, uid, owner string) any {
+		w := &store.Workload{
+			Id:           d.id("workload:" + namespace + "/" + name),
+			IsNamespaced: true,
+			Namespace:    namespace,
+			Name:         name,
+			APIGroup:     group,
+			Resource:     resource,
+			Runtime:      d.runtime,
+			K8:           metav1.ObjectMeta{UID: k8stypes.UID(uid)},
+		}
+		if owner != "" {
+			w.K8.OwnerReferences = []metav1.OwnerReference{{UID: k8stypes.UID(owner)}}
+		}
+
+		return w
+	}
+
+	d.add(collections.Workload{},
+		workload("default", "web", "apps", "deployments", "uid-web", ""),
+		workload("default", "cron", "batch", "cronjobs", "uid-cron", ""),
+		workload("default", "cron-1", "batch", "jobs", "uid-cron-1", "uid-cron"),
+		workload("other", "cron-2", "batch", "jobs", "uid-cron-2", "uid-cron"),
+	)
+
+	pod := func(namespace, name, node, workload string, share bool, labels map[string]string) any {
+		return &store.Pod{
+			Id:           d.id("pod:" + namespace + "/" + name),
+			NodeId:       d.id(node),
+			WorkloadId:   d.id(workload),
+			IsNamespaced: namespace != "",
+			Runtime:      d.runtime,
+			K8: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+				Spec:       corev1.PodSpec{ShareProcessNamespace: &share},
+			},
+		}
+	}
+
+	d.add(collections.Pod{},
+		pod("default", "a", "node:n1", "workload:default/web", true, map[string]string{"app": "a"}),
+		pod("default", "b", "node:n1", "", false, map[string]string{"app": "b"}),
+		pod("other", "a", "node:n2", "workload:default/cron-1", false, map[string]string{"app": "c"}),
+		pod("", "static", "node:n3", "", false, nil),
+	)
+}
+
+func (d *parityDataset) containers() {
+	root := int64(0)
+	privileged := true
+	container := func(name, pod, node, sa string, automount bool, sc *corev1.SecurityContext, ports ...int32) any {
+		namespace, podName, _ := strings.Cut(strings.TrimPrefix(pod, "pod:"), "/")
+		c := &store.Container{
+			Id:     d.id("container:" + name),
+			PodId:  d.id(pod),
+			NodeId: d.id(node),
+			Inherited: store.ContainerInherited{
+				Namespace:      namespace,
+				PodName:        podName,
+				ServiceAccount: sa,
+				Automount:      automount,
+			},
+			K8:      corev1.Container{Name: name, SecurityContext: sc},
+			Runtime: d.runtime,
+		}
+		for _, port := range ports {
+			c.K8.Ports = append(c.K8.Ports, corev1.ContainerPort{ContainerPort: port, Protocol: corev1.ProtocolTCP})
+		}
+
+		return c
+	}
+	capabilities := func(capabilities ...corev1.Capability) *corev1.SecurityContext {
+		return &corev1.SecurityContext{Capabilities: &corev1.Capabilities{Add: capabilities}}
+	}
+
+	rootAdmin := capabilities("SYS_ADMIN", "SYS_RAWIO")
+	rootAdmin.RunAsUser = &root
+
+	d.add(collections.Container{},
+		container("a1", "pod:default/a", "node:n1", "app", true, rootAdmin, 8080),
+		container("a2", "pod:default/a", "node:n1", "app", true, capabilities("BPF", "PERFMON")),
+		container("b", "pod:default/b", "node:n1", "cloud", false, capabilities("BPF", "PERFMON"), 9090),
+		container("c", "pod:other/a", "node:n2", "app", true, &corev1.SecurityContext{Privileged: &privileged, RunAsUser: &root}),
+		container("s", "pod:/static", "node:n3", "app", false, capabilities("BPF", "PERFMON", "SYS_RAWIO", "SYS_ADMIN")),
+		container("p", "pod:default/b", "node:n1", "cloud", false, capabilities("SYS_PTRACE", "SYS_ADMIN", "DAC_READ_SEARCH")),
+	)
+
+	// Containers c and p share the host PID namespace
+	for _, m := range d.models[collections.Container{}] {
+		if c, ok := m.(*store.Container); ok {
+			c.Inherited.HostPID = c.Id == d.id("container:c") || c.Id == d.id("container:p")
+		}
+	}
+}
+
+func (d *parityDataset) volumes() {
+	volume := func(name, container, typ, source string, readOnly bool) *store.Volume {
+		c := d.models[collections.Container{}]
+		var owner *store.Container
+		for _, m := range c {
+			if candidate, ok := m.(*store.Container); ok && candidate.Id == d.id("container:"+container) {
+				owner = candidate
+			}
+		}
+
+		return &store.Volume{
+			Id:          d.id("volume:" + name),
+			PodId:       owner.PodId,
+			NodeId:      owner.NodeId,
+			ContainerId: owner.Id,
+			Name:        name,
+			Type:        typ,
+			SourcePath:  source,
+			ReadOnly:    readOnly,
+			Runtime:     d.runtime,
+		}
+	}
+	projected := func(name, container, source, identity string) any {
+		v := volume(name, container, shared.VolumeTypeProjected, source, true)
+		v.ProjectedId = d.id(identity)
+
+		return v
+	}
+	target := func(v *store.Volume, namespace, name, backing string) any {
+		v.TargetNamespace, v.TargetName, v.BackingType = namespace, name, backing
+
+		return v
+	}
+
+	d.add(collections.Volume{},
+		volume("root", "a1", shared.VolumeTypeHost, "/", false),
+		volume("proc", "a2", shared.VolumeTypeHost, "/proc", true),
+		volume("varlog", "b", shared.VolumeTypeHost, "/var/log", true),
+		volume("containerd", "b", shared.VolumeTypeHost, "/run/containerd/containerd.sock", false),
+		volume("docker", "b", shared.VolumeTypeHost, "/var/run/docker.sock", false),
+		volume("kubelet-pods", "c", shared.VolumeTypeHost, "/var/lib/kubelet/pods/uid", false),
+		volume("etc", "s", shared.VolumeTypeHost, "/etc", true),
+		projected("token-a", "a1", "/var/lib/kubelet/pods/a/token", "identity:default/app"),
+		projected("token-b", "b", "/var/lib/kubelet/pods/b/token", "identity:default/cloud"),
+		projected("token-c", "c", "/var/lib/kubelet/pods/c/token", "identity:other/app"),
+		projected("token-none", "c", "/var/lib/kubelet/pods/c/none", ""),
+		target(volume("secret", "a1", shared.VolumeTypeSecret, "", true), "default", "db", ""),
+		target(volume("secret-missing", "a2", shared.VolumeTypeSecret, "", true), "default", "missing", ""),
+		target(volume("local-write", "a1", shared.VolumeTypePersistentVolumeClaim, "/mnt/data", false), "default", "data", shared.VolumeTypeLocal),
+		target(volume("local-read", "b", shared.VolumeTypePersistentVolumeClaim, "/mnt/data", true), "default", "data", shared.VolumeTypeLocal),
+		volume("nfs-write", "a2", shared.VolumeTypeNFS, "nfs:/export", false),
+		target(volume("nfs-read", "c", shared.VolumeTypePersistentVolumeClaim, "nfs:/export", true), "other", "nfs", shared.VolumeTypeNFS),
+		target(volume("claim-write", "b", shared.VolumeTypePersistentVolumeClaim, "", false), "default", "shared", ""),
+		target(volume("claim-read", "a1", shared.VolumeTypePersistentVolumeClaim, "", true), "default", "shared", ""),
+	)
+}
+
+func (d *parityDataset) services() {
+	secret := func(namespace, name string) any {
+		return &store.Secret{
+			Id:           d.id("secret:" + namespace + "/" + name),
+			Namespace:    namespace,
+			Name:         name,
+			IsNamespaced: true,
+			Runtime:      d.runtime,
+		}
+	}
+
+	d.add(collections.Secret{},
+		secret("default", "db"),
+		secret("default", "app-token-abc"),
+		secret("other", "db"),
+	)
+
+	tcp := corev1.ProtocolTCP
+	endpoint := func(name, pod, container, service string, port int32) any {
+		namespace, podName, _ := strings.Cut(strings.TrimPrefix(pod, "pod:"), "/")
+
+		return &store.Endpoint{
+			Id:           d.id("endpoint:" + name),
+			ContainerId:  d.id(container),
+			PodName:      podName,
+			PodNamespace: namespace,
+			Namespace:    namespace,
+			HasSlice:     service != "",
+			ServiceName:  service,
+			Port:         discoveryv1.EndpointPort{Port: &port, Protocol: &tcp},
+			Runtime:      d.runtime,
+		}
+	}
+
+	d.add(collections.Endpoint{},
+		endpoint("web", "pod:default/a", "", "websvc", 8080),
+		endpoint("web-unmatched", "pod:default/a", "", "websvc", 1234),
+		endpoint("private-b", "pod:default/b", "container:b", "", 9090),
+		endpoint("private-a", "pod:default/a", "container:a1", "", 8080),
+	)
+
+	// Deny all ingress traffic to the b pods of the default namespace and only allow the c pods of the other namespace
+	// to reach the a pods
+	d.add(collections.NetworkPolicy{},
+		&store.NetworkPolicy{
+			Id:           d.id("networkpolicy:default/deny-b"),
+			Namespace:    "default",
+			Name:         "deny-b",
+			IsNamespaced: true,
+			Runtime:      d.runtime,
+			K8: netv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "deny-b", Namespace: "default"},
+				Spec: netv1.NetworkPolicySpec{
+					PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "b"}},
+					PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeIngress},
+				},
+			},
+		},
+		&store.NetworkPolicy{
+			Id:           d.id("networkpolicy:default/allow-a"),
+			Namespace:    "default",
+			Name:         "allow-a",
+			IsNamespaced: true,
+			Runtime:      d.runtime,
+			K8: netv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "allow-a", Namespace: "default"},
+				Spec: netv1.NetworkPolicySpec{
+					PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}},
+					Ingress: []netv1.NetworkPolicyIngressRule{{
+						From: []netv1.NetworkPolicyPeer{{
+							NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: "other"}},
+							PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "c"}},
+						}},
+					}},
+				},
+			},
+		},
+	)
+
+	d.add(collections.Webhook{},
+		&store.Webhook{
+			Id:               d.id("webhook:inject"),
+			Name:             "inject",
+			Configuration:    "injector",
+			Type:             shared.WebhookTypeMutating,
+			ServiceNamespace: "default",
+			ServiceName:      "websvc",
+			Rules: []admv1.RuleWithOperations{{
+				Operations: []admv1.OperationType{admv1.Create},
+				Rule:       admv1.Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}},
+			}},
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"inject": "yes"}},
+			Runtime:           d.runtime,
+		},
+		&store.Webhook{
+			Id:               d.id("webhook:validate"),
+			Name:             "validate",
+			Configuration:    "validator",
+			Type:             "validating",
+			ServiceNamespace: "default",
+			ServiceName:      "other",
+			Runtime:          d.runtime,
+		},
+	)
+}
+
+func (d *parityDataset) rbac() {
+	d.add(collections.Role{},
+		&store.Role{Id: d.id("role:default/ns-role"), Name: "ns-role", IsNamespaced: true, Namespace: "default", Runtime: d.runtime},
+		&store.Role{Id: d.id("role:/cluster-role"), Name: "cluster-role", Runtime: d.runtime},
+	)
+
+	subject := func(kind, namespace, name string) store.BindSubject {
+		identity := "identity:" + namespace + "/" + name
+
+		return store.BindSubject{
+			IdentityId: d.id(identity),
+			Subject:    rbacv1.Subject{Kind: kind, Name: name, Namespace: namespace},
+		}
+	}
+	binding := func(namespace, name, role string, subjects ...store.BindSubject) any {
+		return &store.RoleBinding{
+			Id:           d.id("binding:" + namespace + "/" + name),
+			Name:         name,
+			IsNamespaced: namespace != "",
+			Namespace:    namespace,
+			RoleId:       d.id(role),
+			Subjects:     subjects,
+			Runtime:      d.runtime,
+		}
+	}
+
+	d.add(collections.RoleBinding{},
+		binding("default", "ns", "role:default/ns-role",
+			subject("ServiceAccount", "default", "app"),
+			subject("ServiceAccount", "other", "app"),
+			subject("User", "", "alice"),
+		),
+		binding("", "cluster", "role:/cluster-role",
+			subject("ServiceAccount", "other", "app"),
+			subject("Group", "", "devs"),
+		),
+		binding("default", "bind-cr", "role:/cluster-role",
+			subject("User", "", "alice"),
+		),
+	)
+
+	rule := func(groups []string, resources []string, verbs []string, names ...string) rbacv1.PolicyRule {
+		return rbacv1.PolicyRule{APIGroups: groups, Resources: resources, Verbs: verbs, ResourceNames: names}
+	}
+	permissionSet := func(name, role, binding string, rules ...rbacv1.PolicyRule) any {
+		_, roleName, _ := strings.Cut(strings.TrimPrefix(role, "role:"), "/")
+		namespace, _, _ := strings.Cut(strings.TrimPrefix(binding, "binding:"), "/")
+
+		return &store.PermissionSet{
+			Id:            d.id("permissionset:" + name),
+			RoleId:        d.id(role),
+			RoleName:      roleName,
+			RoleBindingId: d.id(binding),
+			Name:          name,
+			IsNamespaced:  namespace != "",
+			Namespace:     namespace,
+			Rules:         rules,
+			Runtime:       d.runtime,
+		}
+	}
+
+	core := []string{""}
+	rbac := []string{"rbac.authorization.k8s.io"}
+	certificates := []string{"certificates.k8s.io"}
+	all := []string{"*"}
+
+	d.add(collections.PermissionSet{},
+		permissionSet("ns-admin", "role:default/ns-role", "binding:default/ns",
+			rule(core, []string{"pods", "pods/exec", "pods/log", "pods/ephemeralcontainers", "secrets", "serviceaccounts/token"}, all),
+			rule(rbac, []string{"roles", "rolebindings"}, all),
+			rule([]string{"apps"}, []string{"deployments"}, []string{"patch"}),
+			rule([]string{"batch"}, []string{"jobs"}, []string{"update"}, "cron-1"),
+		),
+		permissionSet("ns-scoped", "role:default/ns-role", "binding:default/ns",
+			rule(core, []string{"pods/exec", "pods", "pods/ephemeralcontainers"}, []string{"create", "get", "patch"}, "a", "b"),
+			rule(core, []string{"pods/log"}, []string{"get"}, "a"),
+			rule(core, []string{"secrets"}, []string{"get", "list"}, "db", "app-token-abc", "legacy-secret"),
+			rule(core, []string{"namespaces"}, []string{"patch"}, "default", "other"),
+			rule(core, []string{"serviceaccounts"}, []string{"impersonate"}, "app"),
+			rule(rbac, []string{"rolebindings"}, []string{"create"}),
+			rule(rbac, []string{"roles"}, []string{"bind"}, "ns-role"),
+		),
+		permissionSet("cluster-admin", "role:/cluster-role", "binding:/cluster",
+			rule(all, all, all),
+		),
+		permissionSet("cluster-scoped", "role:/cluster-role", "binding:/cluster",
+			rule(core, []string{"nodes/proxy"}, []string{"get", "create"}, "n1"),
+			rule(core, []string{"users", "groups"}, []string{"impersonate"}, "alice", "devs"),
+			rule(core, []string{"serviceaccounts"}, []string{"impersonate"}),
+			rule(core, []string{"namespaces"}, []string{"update"}),
+			rule(certificates, []string{"certificatesigningrequests"}, []string{"create"}),
+			rule(certificates, []string{"certificatesigningrequests/approval"}, []string{"update"}),
+			rule(certificates, []string{"signers"}, []string{"approve"}, "kubernetes.io/kube-apiserver-client-kubelet"),
+			rule([]string{"admissionregistration.k8s.io"}, []string{"mutatingwebhookconfigurations"}, []string{"patch"}, "injector"),
+			rule(rbac, []string{"clusterrolebindings", "rolebindings"}, []string{"create"}),
+			rule(rbac, []string{"clusterroles"}, []string{"bind"}, "cluster-role"),
+			rule(rbac, []string{"roles"}, []string{"bind"}),
+			rule([]string{"apps"}, []string{"deployments"}, []string{"update"}, "web"),
+		),
+		permissionSet("cluster-role-in-ns", "role:/cluster-role", "binding:default/bind-cr",
+			rule(core, []string{"pods"}, []string{"create"}),
+			rule(rbac, []string{"roles"}, []string{"escalate", "bind", "patch", "update"}),
+		),
+		permissionSet("other-ns", "role:default/ns-role", "binding:other/ns",
+			rule(core, []string{"pods"}, []string{"create", "patch"}),
+			rule(core, []string{"serviceaccounts"}, []string{"list", "get"}),
+		),
+	)
+}
+
+// writeParityDataset writes the dataset to the store provider.
+func writeParityDataset(t *testing.T, sdb storedb.Provider, d *parityDataset) {
+	t.Helper()
+
+	for c, models := range d.models {
+		w, err := sdb.BulkWriter(t.Context(), c)
+		require.NoError(t, err)
+
+		for _, m := range models {
+			require.NoError(t, w.Queue(t.Context(), m))
+		}
+		require.NoError(t, w.Flush(t.Context()))
+		require.NoError(t, w.Close(t.Context()))
+	}
+}
+
+// streamEdges returns the entries streamed by the edge builder, sorted.
+func streamEdges(t *testing.T, e Builder, sdb storedb.Provider) []string {
+	t.Helper()
+
+	var entries []string
+	completed := 0
+	err := e.Stream(t.Context(), sdb, nil, func(_ context.Context, entry types.DataContainer) error {
+		entries = append(entries, fmt.Sprintf("%+v", reflect.Indirect(reflect.ValueOf(entry)).Interface()))
+
+		return nil
+	}, func(context.Context) error {
+		completed++
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, completed, "the complete callback must be invoked once")
+	slices.Sort(entries)
+
+	return entries
+}
+
+// TestEdges_StoreParity runs the edge builders against the embedded store and MongoDB, loaded with the same dataset,
+// and verifies both store providers produce the same edges.
+func TestEdges_StoreParity(t *testing.T) {
+	t.Parallel()
+
+	// FIXME: we should probably setup a mongodb test server in CI for the system tests
+	if config.IsCI() {
+		t.Skip("Skip mongo tests in CI")
+	}
+
+	cfg := &config.KubehoundConfig{
+		Storage: config.StorageConfig{
+			Wipe: true,
+		},
+		MongoDB: config.MongoDBConfig{
+			URL:               storedb.MongoLocalDatabaseURL,
+			ConnectionTimeout: 1 * time.Second,
+		},
+		Dynamic: config.DynamicConfig{
+			RunID: config.NewRunID(),
+			Cluster: config.DynamicClusterInfo{
+				Name:         parityTestCluster,
+				VersionMajor: "1",
+				VersionMinor: "29",
+			},
+		},
+	}
+
+	mongo, err := storedb.NewMongoProvider(t.Context(), cfg)
+	if err != nil {
+		t.Skipf("Skip store parity tests, mongo is unavailable: %v", err)
+	}
+	t.Cleanup(func() {
+		// The mongo database is not wiped, only the documents of the test run are removed
+		_ = mongo.Clean(context.Background(), cfg.Dynamic.RunID.String(), parityTestCluster)
+		_ = mongo.Close(context.Background())
+	})
+
+	embedded, err := storedb.NewEmbeddedProvider(t.Context(), cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = embedded.Close(context.Background()) })
+	require.NoError(t, embedded.Prepare(t.Context()))
+
+	dataset := newParityDataset(store.Runtime(&cfg.Dynamic))
+	writeParityDataset(t, mongo, dataset)
+	writeParityDataset(t, embedded, dataset)
+
+	builders := make(map[string]Builder)
+	for name, e := range Registered().Mutating() {
+		builders[name] = e
+	}
+	for name, e := range Registered().Simple() {
+		builders[name] = e
+	}
+	for name, e := range Registered().Dependent() {
+		builders[name] = e
+	}
+
+	total := 0
+	for _, optimized := range []bool{false, true} {
+		for name, e := range builders {
+			require.NoError(t, e.Initialize(&config.EdgeBuilderConfig{LargeClusterOptimizations: optimized}, &cfg.Dynamic))
+
+			want := streamEdges(t, e, mongo)
+			got := streamEdges(t, e, embedded)
+			assert.Equal(t, want, got, "%s (large cluster optimizations: %t)", name, optimized)
+			total += len(got)
+		}
+	}
+
+	assert.NotZero(t, total, "the dataset should produce edges")
+}
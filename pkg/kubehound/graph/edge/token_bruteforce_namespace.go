@@ -9,8 +9,6 @@ import (
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -52,99 +50,30 @@ func (e *TokenBruteforceNamespace) Processor(ctx context.Context, oic *converter
 	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Identity, map[string]any{
 		"attckTechniqueID": string(e.AttckTechniqueID()),
 		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   false,
 	})
 }
 
 // Stream finds all roles that are namespaced and have secrets/get or equivalent wildcard permissions and matching identities.
 // Matching identities are defined as namespaced identities that share the role namespace or non-namespaced identities.
-func (e *TokenBruteforceNamespace) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+func (e *TokenBruteforceNamespace) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	var verbMatcher bson.M
+	rule := tokenBruteforceRule.WithScope(storedb.UnscopedRules) // resource scoped rules are handled by TokenBruteforceScoped
 	if e.cfg.LargeClusterOptimizations {
 		// For large clusters do not create a redundant edge already covered by the TOKEN_LIST attack as this technique is much more complex
-		verbMatcher = bson.M{"verbs": "get"}
-	} else {
-		verbMatcher = bson.M{"$or": bson.A{
-			bson.M{"verbs": "get"},
-			bson.M{"verbs": "*"},
-		}}
+		rule.ExplicitVerbs = true
 	}
 
-	permissionSets := adapter.MongoDB(ctx, store).Collection(collections.PermissionSetName)
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"is_namespaced":        true,
-				"runtime.runID":        e.runtime.RunID.String(),
-				"runtime.cluster.name": e.runtime.Cluster.Name,
-				"rules": bson.M{
-					"$elemMatch": bson.M{
-						"$and": bson.A{
-							bson.M{"$or": bson.A{
-								bson.M{"apigroups": ""},
-								bson.M{"apigroups": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"resources": "secrets"},
-								bson.M{"resources": "*"},
-							}},
-							verbMatcher,
-							bson.M{"resourcenames": nil}, // TODO: handle resource scope
-						},
-					},
-				},
-			},
+	query := storedb.ResourceGrantQuery{
+		Resource: storedb.ServiceAccountResource,
+		Permissions: storedb.PermissionSetQuery{
+			Scope: storedb.NamespacedPermissionSets,
 		},
-		{
-			"$lookup": bson.M{
-				"as":   "idsInNamespace",
-				"from": "identities",
-				"let": bson.M{
-					"roleNamespace": "$namespace",
-				},
-				"pipeline": []bson.M{
-					{
-						"$match": bson.M{
-							"$and": bson.A{
-								bson.M{"$or": bson.A{
-									bson.M{"$expr": bson.M{
-										"$eq": bson.A{
-											"$namespace", "$$roleNamespace",
-										},
-									}},
-									bson.M{"is_namespaced": false},
-								}},
-								bson.M{"type": "ServiceAccount"},
-							},
-							"runtime.runID":        e.runtime.RunID.String(),
-							"runtime.cluster.name": e.runtime.Cluster.Name,
-						},
-					},
-					{
-						"$project": bson.M{
-							"_id": 1,
-						},
-					},
-				},
-			},
-		},
-		{
-			"$unwind": "$idsInNamespace",
-		},
-		{
-			"$project": bson.M{
-				"_id":      1,
-				"identity": "$idsInNamespace._id",
-			},
-		},
-	}
-
-	cur, err := permissionSets.Aggregate(ctx, pipeline)
-	if err != nil {
-		return err
+		Rule: &rule,
 	}
-	defer cur.Close(ctx)
 
-	return adapter.MongoCursorHandler[tokenBruteforceNSGroup](ctx, cur, callback, complete)
+	return streamResourceGrants(ctx, sdb, e.runtime, query, func(g *storedb.ResourceGrant) any {
+		return &tokenBruteforceNSGroup{Role: g.PermissionSet, Identity: g.Resource}
+	}, callback, complete)
 }
@@ -0,0 +1,81 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&TokenBruteforceScoped{}, RegisterDefault)
+}
+
+// TokenBruteforceScoped handles the TOKEN_BRUTEFORCE edges generated by rules restricted to specific secrets via resourceNames.
+type TokenBruteforceScoped struct {
+	BaseEdge
+}
+
+type tokenBruteforceScopedGroup struct {
+	Role     primitive.ObjectID `bson:"_id" json:"role"`
+	Identity primitive.ObjectID `bson:"resource" json:"identity"`
+}
+
+// tokenBruteforceRule matches the policy rules granting the retrieval of secrets by name.
+var tokenBruteforceRule = storedb.RuleMatch{
+	APIGroups: []string{""},
+	Resources: []string{"secrets"},
+	Verbs:     []string{"get"},
+}
+
+func (e *TokenBruteforceScoped) Label() string {
+	return "TOKEN_BRUTEFORCE"
+}
+
+func (e *TokenBruteforceScoped) Name() string {
+	return "TokenBruteforceScoped"
+}
+
+func (e *TokenBruteforceScoped) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueStealApplicationAccessTokens
+}
+
+func (e *TokenBruteforceScoped) AttckTacticID() AttckTacticID {
+	return AttckTacticCredentialAccess
+}
+
+func (e *TokenBruteforceScoped) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*tokenBruteforceScopedGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Identity, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   true,
+	})
+}
+
+// Stream finds all roles that have secrets/get or equivalent wildcard permissions restricted to a set of secret names via
+// resourceNames, and the matching identities. Matching identities are defined as service accounts whose legacy token
+// secret (named <serviceaccount>-token-<suffix>) is in the rule's resourceNames and that share the role namespace
+// (namespaced roles) or exist in any namespace (cluster roles).
+func (e *TokenBruteforceScoped) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	rule := tokenBruteforceRule.WithScope(storedb.ResourceScopedRules)
+	query := storedb.ResourceGrantQuery{
+		Resource: storedb.ServiceAccountTokenResource,
+		Rule:     &rule,
+	}
+
+	return streamResourceGrants(ctx, sdb, e.runtime, query, func(g *storedb.ResourceGrant) any {
+		return &tokenBruteforceScopedGroup{Role: g.PermissionSet, Identity: g.Resource}
+	}, callback, complete)
+}
@@ -2,16 +2,15 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
 	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -83,6 +82,7 @@ func (e *TokenList) Traversal() types.EdgeTraversal {
 				To("i").
 				Property("attckTechniqueID", string(e.AttckTechniqueID())).
 				Property("attckTacticID", string(e.AttckTacticID())).
+				Property("resourceScoped", false).
 				Barrier().Limit(0)
 		} else {
 			// In smaller clusters we can still show the (large set of) attack paths generated by this attack
@@ -97,6 +97,7 @@ func (e *TokenList) Traversal() types.EdgeTraversal {
 				To("i").
 				Property("attckTechniqueID", string(e.AttckTechniqueID())).
 				Property("attckTacticID", string(e.AttckTacticID())).
+				Property("resourceScoped", false).
 				Barrier().Limit(0)
 		}
 
@@ -104,50 +105,36 @@ func (e *TokenList) Traversal() types.EdgeTraversal {
 	}
 }
 
+func (e *TokenList) Writes() types.EdgeWrites {
+	return func(inserts []any) ([]types.EdgeWrite, error) {
+		props := map[string]any{
+			"attckTechniqueID": string(e.AttckTechniqueID()),
+			"attckTacticID":    string(e.AttckTacticID()),
+			"resourceScoped":   false,
+		}
+
+		if e.cfg.LargeClusterOptimizations {
+			return targetEdgeWrites(e.Label(), e.runVertices("Identity", map[string]any{"name": "system:masters"}), props, inserts)
+		}
+
+		return targetEdgeWrites(e.Label(), e.runVertices("Identity", nil), props, inserts)
+	}
+}
+
 // Stream finds all roles that are NOT namespaced and have secrets/list or equivalent wildcard permissions.
-func (e *TokenList) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+func (e *TokenList) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	permissionSets := adapter.MongoDB(ctx, store).Collection(collections.PermissionSetName)
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"is_namespaced":        false,
-				"runtime.runID":        e.runtime.RunID.String(),
-				"runtime.cluster.name": e.runtime.Cluster.Name,
-				"rules": bson.M{
-					"$elemMatch": bson.M{
-						"$and": bson.A{
-							bson.M{"$or": bson.A{
-								bson.M{"apigroups": ""},
-								bson.M{"apigroups": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"resources": "secrets"},
-								bson.M{"resources": "*"},
-							}},
-							bson.M{"$or": bson.A{
-								bson.M{"verbs": "list"},
-								bson.M{"verbs": "*"},
-							}},
-							bson.M{"resourcenames": nil}, // TODO: handle resource scope
-						},
-					},
-				},
-			},
-		},
-		{
-			"$project": bson.M{
-				"_id": 1,
-			},
+	query := storedb.PermissionSetQuery{
+		Scope: storedb.ClusterPermissionSets,
+		AnyRule: []storedb.RuleMatch{
+			tokenListRule.WithScope(storedb.UnscopedRules), // resource scoped rules are handled by TokenListScoped
 		},
 	}
 
-	cur, err := permissionSets.Aggregate(ctx, pipeline)
-	if err != nil {
-		return err
-	}
-	defer cur.Close(ctx)
+	err := sdb.PermissionSets(ctx, e.runtime, query, func(ctx context.Context, ps *store.PermissionSet) error {
+		return callback(ctx, &tokenListGroup{Role: ps.Id})
+	})
 
-	return adapter.MongoCursorHandler[tokenListGroup](ctx, cur, callback, complete)
+	return errors.Join(complete(ctx), err)
 }
@@ -0,0 +1,81 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&TokenListScoped{}, RegisterDefault)
+}
+
+// TokenListScoped handles the TOKEN_LIST edges generated by rules restricted to specific secrets via resourceNames.
+type TokenListScoped struct {
+	BaseEdge
+}
+
+type tokenListScopedGroup struct {
+	Role     primitive.ObjectID `bson:"_id" json:"role"`
+	Identity primitive.ObjectID `bson:"resource" json:"identity"`
+}
+
+// tokenListRule matches the policy rules granting the listing of secrets.
+var tokenListRule = storedb.RuleMatch{
+	APIGroups: []string{""},
+	Resources: []string{"secrets"},
+	Verbs:     []string{"list"},
+}
+
+func (e *TokenListScoped) Label() string {
+	return "TOKEN_LIST"
+}
+
+func (e *TokenListScoped) Name() string {
+	return "TokenListScoped"
+}
+
+func (e *TokenListScoped) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueStealApplicationAccessTokens
+}
+
+func (e *TokenListScoped) AttckTacticID() AttckTacticID {
+	return AttckTacticCredentialAccess
+}
+
+func (e *TokenListScoped) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*tokenListScopedGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Identity, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   true,
+	})
+}
+
+// Stream finds all roles that have secrets/list or equivalent wildcard permissions restricted to a set of secret names via
+// resourceNames, and the matching identities. Matching identities are defined as service accounts whose legacy token
+// secret (named <serviceaccount>-token-<suffix>) is in the rule's resourceNames and that share the role namespace
+// (namespaced roles) or exist in any namespace (cluster roles).
+func (e *TokenListScoped) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	rule := tokenListRule.WithScope(storedb.ResourceScopedRules)
+	query := storedb.ResourceGrantQuery{
+		Resource: storedb.ServiceAccountTokenResource,
+		Rule:     &rule,
+	}
+
+	return streamResourceGrants(ctx, sdb, e.runtime, query, func(g *storedb.ResourceGrant) any {
+		return &tokenListScopedGroup{Role: g.PermissionSet, Identity: g.Resource}
+	}, callback, complete)
+}
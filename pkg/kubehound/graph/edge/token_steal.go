@@ -2,18 +2,17 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func init() {
@@ -60,23 +59,18 @@ func (e *TokenSteal) Processor(ctx context.Context, oic *converter.ObjectIDConve
 func (e *TokenSteal) Stream(ctx context.Context, sdb storedb.Provider, c cache.CacheReader,
 	process types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	volumes := adapter.MongoDB(ctx, sdb).Collection(collections.VolumeName)
-
-	filter := bson.M{
-		"type":                 shared.VolumeTypeProjected,
-		"projected_id":         bson.M{"$ne": nil},
-		"runtime.runID":        e.runtime.RunID.String(),
-		"runtime.cluster.name": e.runtime.Cluster.Name,
+	query := storedb.VolumeQuery{
+		Types: []string{shared.VolumeTypeProjected},
 	}
 
 	// We just need a 1:1 mapping of the volume and projected service account to create this edge
-	projection := bson.M{"_id": 1, "projected_id": 1}
+	err := sdb.Volumes(ctx, e.runtime, query, func(ctx context.Context, v *store.Volume) error {
+		if v.ProjectedId.IsZero() {
+			return nil
+		}
 
-	cur, err := volumes.Find(ctx, filter, options.Find().SetProjection(projection))
-	if err != nil {
-		return err
-	}
-	defer cur.Close(ctx)
+		return process(ctx, &tokenStealGroup{Volume: v.Id, Identity: v.ProjectedId})
+	})
 
-	return adapter.MongoCursorHandler[tokenStealGroup](ctx, cur, process, complete)
+	return errors.Join(complete(ctx), err)
 }
@@ -2,17 +2,16 @@ package edge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
-	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func init() {
@@ -56,23 +55,13 @@ func (e *VolumeDiscover) Processor(ctx context.Context, oic *converter.ObjectIDC
 	})
 }
 
-func (e *VolumeDiscover) Stream(ctx context.Context, store storedb.Provider, _ cache.CacheReader,
+func (e *VolumeDiscover) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
 	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
 
-	volumes := adapter.MongoDB(ctx, store).Collection(collections.VolumeName)
-
 	// We just need a 1:1 mapping of the container and volume to create this edge
-	projection := bson.M{"_id": 1, "container_id": 1}
-
-	filter := bson.M{
-		"runtime.runID":        e.runtime.RunID.String(),
-		"runtime.cluster.name": e.runtime.Cluster.Name,
-	}
-
-	cur, err := volumes.Find(ctx, filter, options.Find().SetProjection(projection))
-	if err != nil {
-		return err
-	}
+	err := sdb.Volumes(ctx, e.runtime, storedb.VolumeQuery{}, func(ctx context.Context, v *store.Volume) error {
+		return callback(ctx, &volumeMountGroup{Volume: v.Id, Container: v.ContainerId})
+	})
 
-	return adapter.MongoCursorHandler[volumeMountGroup](ctx, cur, callback, complete)
+	return errors.Join(complete(ctx), err)
 }
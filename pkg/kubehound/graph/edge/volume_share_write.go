@@ -0,0 +1,157 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&VolumeShareWrite{}, RegisterDefault)
+}
+
+type VolumeShareWrite struct {
+	BaseEdge
+}
+
+type volumeShareWriteGroup struct {
+	Writers    []primitive.ObjectID `bson:"writers" json:"writers"`
+	Containers []primitive.ObjectID `bson:"containers" json:"containers"`
+}
+
+type volumeShareWritePair struct {
+	Writer    primitive.ObjectID `bson:"writer" json:"writer"`
+	Container primitive.ObjectID `bson:"container" json:"container"`
+}
+
+func (e *VolumeShareWrite) Label() string {
+	return "VOLUME_SHARE_WRITE"
+}
+
+func (e *VolumeShareWrite) Name() string {
+	return "VolumeShareWrite"
+}
+
+func (e *VolumeShareWrite) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueTaintedSharedContent
+}
+
+func (e *VolumeShareWrite) AttckTacticID() AttckTacticID {
+	return AttckTacticLateralMovement
+}
+
+func (e *VolumeShareWrite) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*volumeShareWritePair)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Writer, typed.Container, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+	})
+}
+
+// volumeBacking identifies the storage backing a volume.
+type volumeBacking struct {
+	node      primitive.ObjectID
+	source    string
+	namespace string
+	claim     string
+}
+
+// volumeBackingSource returns the storage backing a volume. Host backed volumes (hostPath volumes and claims bound to a
+// hostPath or local persistent volume) are only shared on the same node, NFS exports are shared cluster wide and other
+// claims are identified by the claim as a claim is bound to a single persistent volume.
+func volumeBackingSource(v *store.Volume) volumeBacking {
+	switch {
+	case v.HostBacked():
+		return volumeBacking{node: v.NodeId, source: v.SourcePath}
+	case v.Type == shared.VolumeTypeNFS || v.BackingType == shared.VolumeTypeNFS:
+		return volumeBacking{source: v.SourcePath}
+	default:
+		return volumeBacking{namespace: v.TargetNamespace, claim: v.TargetName}
+	}
+}
+
+// Stream finds all the containers mounting the same storage (host directory, NFS export or persistent volume claim)
+// as a container with write access to it. The writer container can tamper with the code and configuration read by
+// the other containers.
+func (e *VolumeShareWrite) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	query := storedb.VolumeQuery{
+		Types: []string{
+			shared.VolumeTypeHost,
+			shared.VolumeTypeNFS,
+			shared.VolumeTypePersistentVolumeClaim,
+		},
+	}
+
+	groups := make(map[volumeBacking]*volumeShareWriteGroup)
+	err := sdb.Volumes(ctx, e.runtime, query, func(_ context.Context, v *store.Volume) error {
+		backing := volumeBackingSource(v)
+		group, ok := groups[backing]
+		if !ok {
+			group = &volumeShareWriteGroup{}
+			groups[backing] = group
+		}
+
+		if !v.ReadOnly && !slices.Contains(group.Writers, v.ContainerId) {
+			group.Writers = append(group.Writers, v.ContainerId)
+		}
+
+		if !slices.Contains(group.Containers, v.ContainerId) {
+			group.Containers = append(group.Containers, v.ContainerId)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(complete(ctx), err)
+	}
+
+	// A single edge per container pair, even if multiple storages are shared
+	seen := make(map[volumeShareWritePair]struct{})
+	for _, group := range groups {
+		// Only keep the storages with at least one writer and shared between multiple containers
+		if len(group.Writers) == 0 || len(group.Containers) < 2 {
+			continue
+		}
+
+		for _, writer := range group.Writers {
+			for _, container := range group.Containers {
+				// No need to create a link with itself
+				if writer == container {
+					continue
+				}
+
+				pair := volumeShareWritePair{
+					Writer:    writer,
+					Container: container,
+				}
+				if _, ok := seen[pair]; ok {
+					continue
+				}
+				seen[pair] = struct{}{}
+
+				err = callback(ctx, &pair)
+				if err != nil {
+					return errors.Join(complete(ctx), err)
+				}
+			}
+		}
+	}
+
+	return complete(ctx)
+}
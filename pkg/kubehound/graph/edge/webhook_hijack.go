@@ -0,0 +1,66 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&WebhookHijack{}, RegisterDefault)
+}
+
+// WebhookHijack handles the WEBHOOK_HIJACK edges between the endpoints of a K8s service and the admission webhooks
+// served by that service.
+type WebhookHijack struct {
+	BaseEdge
+}
+
+type webhookHijackGroup struct {
+	Webhook  primitive.ObjectID `bson:"_id" json:"webhook"`
+	Endpoint primitive.ObjectID `bson:"endpoint" json:"endpoint"`
+}
+
+func (e *WebhookHijack) Label() string {
+	return "WEBHOOK_HIJACK"
+}
+
+func (e *WebhookHijack) Name() string {
+	return "WebhookHijack"
+}
+
+func (e *WebhookHijack) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueExploitationOfRemoteServices
+}
+
+func (e *WebhookHijack) AttckTacticID() AttckTacticID {
+	return AttckTacticLateralMovement
+}
+
+func (e *WebhookHijack) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*webhookHijackGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Endpoint, typed.Webhook, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+	})
+}
+
+// Stream finds all admission webhooks served by an in-cluster service and the endpoints of that service. The service
+// port of the webhook is not matched as endpoint slices only expose the target ports of the service.
+func (e *WebhookHijack) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	return streamLinks(ctx, sdb, e.runtime, storedb.WebhookEndpointLink, func(l *storedb.Link) any {
+		return &webhookHijackGroup{Webhook: l.From, Endpoint: l.To}
+	}, callback, complete)
+}
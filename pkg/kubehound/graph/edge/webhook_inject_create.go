@@ -0,0 +1,149 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&WebhookInjectCreate{}, RegisterGraphMutation)
+}
+
+// WebhookInjectCreate handles the WEBHOOK_INJECT edges generated by cluster roles allowed to create mutating webhook
+// configurations. A new configuration can intercept the creation of any pod of the cluster.
+type WebhookInjectCreate struct {
+	BaseEdge
+}
+
+type webhookInjectCreateGroup struct {
+	Role primitive.ObjectID `bson:"_id" json:"role"`
+}
+
+func (e *WebhookInjectCreate) Label() string {
+	return WebhookInjectLabel
+}
+
+func (e *WebhookInjectCreate) Name() string {
+	return "WebhookInjectCreate"
+}
+
+func (e *WebhookInjectCreate) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueDeployContainer
+}
+
+func (e *WebhookInjectCreate) AttckTacticID() AttckTacticID {
+	return AttckTacticPersistence
+}
+
+func (e *WebhookInjectCreate) BatchSize() int {
+	if e.cfg.LargeClusterOptimizations {
+		// Under optimization this becomes a very cheap operation
+		return e.cfg.BatchSize
+	}
+
+	return e.cfg.BatchSizeClusterImpact
+}
+
+func (e *WebhookInjectCreate) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*webhookInjectCreateGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	rid, err := oic.GraphID(ctx, typed.Role.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("%s edge role id convert: %w", e.Label(), err)
+	}
+
+	if e.cfg.LargeClusterOptimizations {
+		return map[any]any{
+			gremlin.T.Label: vertex.PermissionSetLabel,
+			gremlin.T.Id:    rid,
+		}, nil
+	}
+
+	return rid, nil
+}
+
+func (e *WebhookInjectCreate) Traversal() types.EdgeTraversal {
+	return func(source *gremlin.GraphTraversalSource, inserts []any) *gremlin.GraphTraversal {
+		g := source.GetGraphTraversal()
+		if e.cfg.LargeClusterOptimizations {
+			// In large clusters this can explode the number of edges and we can safely assume this is a critical issue
+			g.
+				Inject(inserts).
+				Unfold().
+				As("rpe").
+				MergeV(__.Select("rpe")).
+				Option(gremlin.Merge.OnCreate, __.Fail("missing role vertex on WEBHOOK_INJECT insert")).
+				Option(gremlin.Merge.OnMatch, map[any]any{
+					"critical": true,
+				}).
+				AddE(e.Label()).
+				Property("attckTechniqueID", string(e.AttckTechniqueID())).
+				Property("attckTacticID", string(e.AttckTacticID())).
+				Property("resourceScoped", false).
+				Barrier().Limit(0)
+		} else {
+			// In smaller clusters we can still show the (large set of) attack paths generated by this attack
+			g.V().
+				Has("runID", e.runtime.RunID.String()).
+				Has("cluster", e.runtime.Cluster.Name).
+				Has("class", "Pod").
+				As("p").
+				V(inserts...).
+				Has("critical", false).
+				AddE(e.Label()).
+				To("p").
+				Property("attckTechniqueID", string(e.AttckTechniqueID())).
+				Property("attckTacticID", string(e.AttckTacticID())).
+				Property("resourceScoped", false).
+				Barrier().Limit(0)
+		}
+
+		return g
+	}
+}
+
+func (e *WebhookInjectCreate) Writes() types.EdgeWrites {
+	return func(inserts []any) ([]types.EdgeWrite, error) {
+		props := map[string]any{
+			"attckTechniqueID": string(e.AttckTechniqueID()),
+			"attckTacticID":    string(e.AttckTacticID()),
+			"resourceScoped":   false,
+		}
+
+		if e.cfg.LargeClusterOptimizations {
+			return criticalEdgeWrites(e.Label(), props, inserts)
+		}
+
+		return targetEdgeWrites(e.Label(), e.runVertices("Pod", nil), props, inserts)
+	}
+}
+
+// Stream finds all roles that are NOT namespaced and have create (or equivalent wildcard) permissions on mutating
+// webhook configurations.
+func (e *WebhookInjectCreate) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	query := storedb.PermissionSetQuery{
+		Scope:   storedb.ClusterPermissionSets,
+		AnyRule: []storedb.RuleMatch{webhookConfigCreateRule},
+	}
+
+	err := sdb.PermissionSets(ctx, e.runtime, query, func(ctx context.Context, ps *store.PermissionSet) error {
+		return callback(ctx, &webhookInjectCreateGroup{Role: ps.Id})
+	})
+
+	return errors.Join(complete(ctx), err)
+}
@@ -0,0 +1,146 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	WebhookInjectLabel = "WEBHOOK_INJECT"
+)
+
+func init() {
+	Register(&WebhookInjectPermission{}, RegisterDefault)
+}
+
+// WebhookInjectPermission handles the WEBHOOK_INJECT edges generated by cluster roles allowed to modify existing
+// mutating webhook configurations. Roles allowed to create new configurations are handled by WebhookInjectCreate.
+type WebhookInjectPermission struct {
+	BaseEdge
+}
+
+type webhookInjectPermissionGroup struct {
+	Role           primitive.ObjectID `bson:"_id" json:"role"`
+	Pod            primitive.ObjectID `bson:"pod" json:"pod"`
+	ResourceScoped bool               `bson:"resource_scoped" json:"resource_scoped"`
+}
+
+// webhookConfigGrant holds the mutating webhook configurations a permission set is allowed to modify. Rules
+// restricted via resourceNames only grant access to the named configurations.
+type webhookConfigGrant struct {
+	Role           primitive.ObjectID
+	Unscoped       bool
+	Configurations []string
+}
+
+func (e *WebhookInjectPermission) Label() string {
+	return WebhookInjectLabel
+}
+
+func (e *WebhookInjectPermission) Name() string {
+	return "WebhookInjectPermission"
+}
+
+func (e *WebhookInjectPermission) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueDeployContainer
+}
+
+func (e *WebhookInjectPermission) AttckTacticID() AttckTacticID {
+	return AttckTacticPersistence
+}
+
+func (e *WebhookInjectPermission) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*webhookInjectPermissionGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Pod, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   typed.ResourceScoped,
+	})
+}
+
+// Stream finds all roles that are NOT namespaced and have patch/update (or equivalent wildcard) permissions on mutating
+// webhook configurations, and the pods intercepted on creation by the existing mutating webhooks of the configurations
+// they can modify. Rules restricted via resourceNames only grant access to the named configurations. Roles that can
+// also create mutating webhook configurations are skipped as they can intercept any pod (see WebhookInjectCreate).
+func (e *WebhookInjectPermission) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	grants, err := e.grants(ctx, sdb)
+	if err != nil {
+		return errors.Join(complete(ctx), err)
+	}
+
+	if len(grants) == 0 {
+		return complete(ctx)
+	}
+
+	webhooks, err := mutatingWebhooks(ctx, sdb, e.runtime)
+	if err != nil {
+		return errors.Join(complete(ctx), err)
+	}
+
+	return streamInterceptedPods(ctx, sdb, e.runtime, webhooks,
+		func(ctx context.Context, pod primitive.ObjectID, intercepting []*store.Webhook) error {
+			for _, grant := range grants {
+				if !grant.Unscoped && !slices.ContainsFunc(intercepting, func(w *store.Webhook) bool {
+					return slices.Contains(grant.Configurations, w.Configuration)
+				}) {
+					continue
+				}
+
+				err := callback(ctx, &webhookInjectPermissionGroup{
+					Role:           grant.Role,
+					Pod:            pod,
+					ResourceScoped: !grant.Unscoped,
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}, complete)
+}
+
+// grants returns the mutating webhook configurations each cluster role NOT allowed to create new configurations is
+// allowed to modify.
+func (e *WebhookInjectPermission) grants(ctx context.Context, sdb storedb.Provider) ([]webhookConfigGrant, error) {
+	query := storedb.PermissionSetQuery{
+		Scope: storedb.ClusterPermissionSets,
+		AnyRule: []storedb.RuleMatch{
+			webhookConfigRule.WithScope(storedb.UnscopedRules),
+			webhookConfigScopedRule,
+		},
+	}
+
+	var grants []webhookConfigGrant
+	err := sdb.PermissionSets(ctx, e.runtime, query, func(_ context.Context, ps *store.PermissionSet) error {
+		if webhookConfigCreateRule.Unscoped(ps.Rules) {
+			return nil
+		}
+
+		grants = append(grants, webhookConfigGrant{
+			Role:           ps.Id,
+			Unscoped:       webhookConfigRule.Unscoped(ps.Rules),
+			Configurations: webhookConfigScopedRule.ResourceNames(ps.Rules),
+		})
+
+		return nil
+	})
+
+	return grants, err
+}
@@ -0,0 +1,80 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&WebhookInjectWebhook{}, RegisterDefault)
+}
+
+// WebhookInjectWebhook handles the WEBHOOK_INJECT edges between a mutating webhook and the pods it intercepts.
+type WebhookInjectWebhook struct {
+	BaseEdge
+}
+
+type webhookInjectWebhookGroup struct {
+	Webhook primitive.ObjectID `bson:"_id" json:"webhook"`
+	Pod     primitive.ObjectID `bson:"pod" json:"pod"`
+}
+
+func (e *WebhookInjectWebhook) Label() string {
+	return WebhookInjectLabel
+}
+
+func (e *WebhookInjectWebhook) Name() string {
+	return "WebhookInjectWebhook"
+}
+
+func (e *WebhookInjectWebhook) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueDeployContainer
+}
+
+func (e *WebhookInjectWebhook) AttckTacticID() AttckTacticID {
+	return AttckTacticPersistence
+}
+
+func (e *WebhookInjectWebhook) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*webhookInjectWebhookGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Webhook, typed.Pod, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   false,
+	})
+}
+
+// Stream finds all mutating webhooks and the pods they intercept on creation, i.e pods matched by both the namespace
+// and object selectors of a webhook with a rule matching the creation of pods.
+func (e *WebhookInjectWebhook) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	webhooks, err := mutatingWebhooks(ctx, sdb, e.runtime)
+	if err != nil {
+		return errors.Join(complete(ctx), err)
+	}
+
+	return streamInterceptedPods(ctx, sdb, e.runtime, webhooks,
+		func(ctx context.Context, pod primitive.ObjectID, intercepting []*store.Webhook) error {
+			for _, w := range intercepting {
+				if err := callback(ctx, &webhookInjectWebhookGroup{Webhook: w.Id, Pod: pod}); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}, complete)
+}
@@ -0,0 +1,97 @@
+package edge
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// webhookConfigCreateRule matches the policy rules granting the creation of mutating webhook configurations. Create
+	// requests cannot be restricted via resourceNames as the object name is not known at authorization time.
+	webhookConfigCreateRule = storedb.RuleMatch{
+		APIGroups: []string{"admissionregistration.k8s.io"},
+		Resources: []string{"mutatingwebhookconfigurations"},
+		Verbs:     []string{"create"},
+		Scope:     storedb.UnscopedRules,
+	}
+
+	// webhookConfigRule matches the policy rules granting the modification of mutating webhook configurations.
+	webhookConfigRule = storedb.RuleMatch{
+		APIGroups: []string{"admissionregistration.k8s.io"},
+		Resources: []string{"mutatingwebhookconfigurations"},
+		Verbs:     []string{"patch", "update"},
+	}
+
+	// webhookConfigScopedRule matches the policy rules granting the modification of named mutating webhook configurations.
+	webhookConfigScopedRule = storedb.RuleMatch{
+		APIGroups: []string{"admissionregistration.k8s.io"},
+		Resources: []string{"mutatingwebhookconfigurations"},
+		Verbs:     []string{"patch", "update"},
+		Scope:     storedb.ResourceScopedRules,
+	}
+)
+
+// interceptedPodCallback is invoked for each pod intercepted by at least one mutating webhook on creation.
+type interceptedPodCallback func(ctx context.Context, pod primitive.ObjectID, webhooks []*store.Webhook) error
+
+// mutatingWebhooks returns the mutating webhooks collected for the current run.
+func mutatingWebhooks(ctx context.Context, sdb storedb.Provider, runtime *config.DynamicConfig) ([]*store.Webhook, error) {
+	query := storedb.WebhookQuery{
+		Type: shared.WebhookTypeMutating,
+	}
+
+	var mutating []*store.Webhook
+	err := sdb.Webhooks(ctx, runtime, query, func(_ context.Context, w *store.Webhook) error {
+		mutating = append(mutating, w)
+
+		return nil
+	})
+
+	return mutating, err
+}
+
+// streamInterceptedPods evaluates the provided mutating webhooks against all the pods of the current run and invokes
+// the callback for each pod intercepted by at least one of them, along with the intercepting webhooks.
+func streamInterceptedPods(ctx context.Context, sdb storedb.Provider, runtime *config.DynamicConfig,
+	webhooks []*store.Webhook, callback interceptedPodCallback, complete types.CompleteQueryCallback) error {
+
+	if len(webhooks) == 0 {
+		return complete(ctx)
+	}
+
+	namespaceLabels := make(map[string]map[string]string)
+	err := sdb.Namespaces(ctx, runtime, func(_ context.Context, ns *store.Namespace) error {
+		namespaceLabels[ns.K8.Name] = ns.K8.Labels
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(complete(ctx), err)
+	}
+
+	err = sdb.Pods(ctx, runtime, storedb.PodQuery{}, func(ctx context.Context, p *store.Pod) error {
+		var intercepting []*store.Webhook
+		for _, w := range webhooks {
+			if libkube.WebhookInterceptsPod(w.Rules, w.NamespaceSelector, w.ObjectSelector, p.K8.Namespace,
+				namespaceLabels[p.K8.Namespace], p.K8.Labels) {
+				intercepting = append(intercepting, w)
+			}
+		}
+
+		if len(intercepting) == 0 {
+			return nil
+		}
+
+		return callback(ctx, p.Id, intercepting)
+	})
+
+	return errors.Join(complete(ctx), err)
+}
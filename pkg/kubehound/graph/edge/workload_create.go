@@ -0,0 +1,156 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&WorkloadCreate{}, RegisterGraphMutation)
+}
+
+type WorkloadCreate struct {
+	BaseEdge
+}
+
+type workloadCreateGroup struct {
+	Role primitive.ObjectID `bson:"_id" json:"role"`
+}
+
+func (e *WorkloadCreate) Label() string {
+	return "WORKLOAD_CREATE"
+}
+
+func (e *WorkloadCreate) Name() string {
+	return "WorkloadCreate"
+}
+
+func (e *WorkloadCreate) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueDeployContainer
+}
+
+func (e *WorkloadCreate) AttckTacticID() AttckTacticID {
+	return AttckTacticExecution
+}
+
+func (e *WorkloadCreate) BatchSize() int {
+	if e.cfg.LargeClusterOptimizations {
+		// Under optimization this becomes a very cheap operation
+		return e.cfg.BatchSize
+	}
+
+	return e.cfg.BatchSizeClusterImpact
+}
+
+func (e *WorkloadCreate) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*workloadCreateGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	rid, err := oic.GraphID(ctx, typed.Role.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("%s edge role id convert: %w", e.Label(), err)
+	}
+
+	if e.cfg.LargeClusterOptimizations {
+		return map[any]any{
+			gremlin.T.Label: vertex.PermissionSetLabel,
+			gremlin.T.Id:    rid,
+		}, nil
+	}
+
+	return rid, nil
+}
+
+func (e *WorkloadCreate) Traversal() types.EdgeTraversal {
+	return func(source *gremlin.GraphTraversalSource, inserts []any) *gremlin.GraphTraversal {
+		g := source.GetGraphTraversal()
+		if e.cfg.LargeClusterOptimizations {
+			// In large clusters this can explode the number of edges and we can safely assume this is a critical issue
+			g.
+				Inject(inserts).
+				Unfold().
+				As("rwc").
+				MergeV(__.Select("rwc")).
+				Option(gremlin.Merge.OnCreate, __.Fail("missing role vertex on WORKLOAD_CREATE insert")).
+				Option(gremlin.Merge.OnMatch, map[any]any{
+					"critical": true,
+				}).
+				AddE(e.Label()).
+				Property("attckTechniqueID", string(e.AttckTechniqueID())).
+				Property("attckTacticID", string(e.AttckTacticID())).
+				Barrier().Limit(0)
+		} else {
+			// In smaller clusters we can still show the (large set of) attack paths generated by this attack
+			g.V().
+				Has("runID", e.runtime.RunID.String()).
+				Has("cluster", e.runtime.Cluster.Name).
+				Has("class", "Node").
+				As("n").
+				V(inserts...).
+				Has("critical", false).
+				AddE(e.Label()).
+				To("n").
+				Property("attckTechniqueID", string(e.AttckTechniqueID())).
+				Property("attckTacticID", string(e.AttckTacticID())).
+				Barrier().Limit(0)
+		}
+
+		return g
+	}
+}
+
+func (e *WorkloadCreate) Writes() types.EdgeWrites {
+	return func(inserts []any) ([]types.EdgeWrite, error) {
+		props := map[string]any{
+			"attckTechniqueID": string(e.AttckTechniqueID()),
+			"attckTacticID":    string(e.AttckTacticID()),
+		}
+
+		if e.cfg.LargeClusterOptimizations {
+			return criticalEdgeWrites(e.Label(), props, inserts)
+		}
+
+		return targetEdgeWrites(e.Label(), e.runVertices("Node", nil), props, inserts)
+	}
+}
+
+// Stream finds all roles that have create (or equivalent wildcard) permissions on workload controller resources,
+// excluding namespaced roles whose namespace enforces a Pod Security Standard preventing the creation of privileged
+// pods. Creating a workload results in pods being created on any of the cluster nodes, as with the POD_CREATE attack.
+func (e *WorkloadCreate) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	levels, err := namespacePodSecurityLevels(ctx, sdb, e.runtime)
+	if err != nil {
+		return errors.Join(complete(ctx), err)
+	}
+
+	// Create requests cannot be restricted by resource name
+	query := storedb.PermissionSetQuery{
+		AnyRule: workloadRules(workloadCreateVerbs, storedb.UnscopedRules),
+	}
+
+	err = sdb.PermissionSets(ctx, e.runtime, query, func(ctx context.Context, ps *store.PermissionSet) error {
+		// The pods created by the workload controllers are subject to the Pod Security Admission level enforced in
+		// the namespace of the workload, as for POD_CREATE.
+		if ps.IsNamespaced && !levels.privileged(ps.Namespace) {
+			return nil
+		}
+
+		return callback(ctx, &workloadCreateGroup{Role: ps.Id})
+	})
+
+	return errors.Join(complete(ctx), err)
+}
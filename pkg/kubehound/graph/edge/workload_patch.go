@@ -0,0 +1,89 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&WorkloadPatch{}, RegisterDefault)
+}
+
+// WorkloadPatch handles the WORKLOAD_PATCH edges generated by rules granting patch/update on workload controllers.
+type WorkloadPatch struct {
+	BaseEdge
+}
+
+type workloadPatchGroup struct {
+	Role       primitive.ObjectID `bson:"_id" json:"role"`
+	Workload   primitive.ObjectID `bson:"workload" json:"workload"`
+	PSAEnforce string             `bson:"psa_enforce" json:"psa_enforce"`
+}
+
+func (e *WorkloadPatch) Label() string {
+	return "WORKLOAD_PATCH"
+}
+
+func (e *WorkloadPatch) Name() string {
+	return "WorkloadPatch"
+}
+
+func (e *WorkloadPatch) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueContainerAdministrationCommand
+}
+
+func (e *WorkloadPatch) AttckTacticID() AttckTacticID {
+	return AttckTacticExecution
+}
+
+func (e *WorkloadPatch) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*workloadPatchGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Workload, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   false,
+		"psaEnforce":       typed.PSAEnforce,
+	})
+}
+
+// Stream finds all roles that have patch/update (or equivalent wildcard) permissions on workload controller resources
+// and the matching workloads. Matching workloads are defined as workloads of a kind granted by one of the role rules,
+// that share the role namespace (namespaced roles) or exist in any namespace (cluster roles). Resource scoped rules
+// are handled by WorkloadPatchScoped.
+func (e *WorkloadPatch) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	levels, err := namespacePodSecurityLevels(ctx, sdb, e.runtime)
+	if err != nil {
+		return errors.Join(complete(ctx), err)
+	}
+
+	// The API group and resource of the rules are matched against each workload
+	query := storedb.ResourceGrantQuery{
+		Resource: storedb.WorkloadResource,
+		Permissions: storedb.PermissionSetQuery{
+			AnyRule: workloadRules(workloadPatchVerbs, storedb.UnscopedRules),
+		},
+		Rule: &storedb.RuleMatch{
+			Verbs: workloadPatchVerbs,
+			Scope: storedb.UnscopedRules,
+		},
+	}
+
+	return streamResourceGrants(ctx, sdb, e.runtime, query, func(g *storedb.ResourceGrant) any {
+		// Resolve the Pod Security Admission level enforced in the namespace of the workload
+		return &workloadPatchGroup{Role: g.PermissionSet, Workload: g.Resource, PSAEnforce: levels.level(g.Namespace)}
+	}, callback, complete)
+}
@@ -0,0 +1,89 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&WorkloadPatchScoped{}, RegisterDefault)
+}
+
+// WorkloadPatchScoped handles the WORKLOAD_PATCH edges generated by rules restricted to specific workloads via resourceNames.
+type WorkloadPatchScoped struct {
+	BaseEdge
+}
+
+type workloadPatchScopedGroup struct {
+	Role       primitive.ObjectID `bson:"_id" json:"role"`
+	Workload   primitive.ObjectID `bson:"workload" json:"workload"`
+	PSAEnforce string             `bson:"psa_enforce" json:"psa_enforce"`
+}
+
+func (e *WorkloadPatchScoped) Label() string {
+	return "WORKLOAD_PATCH"
+}
+
+func (e *WorkloadPatchScoped) Name() string {
+	return "WorkloadPatchScoped"
+}
+
+func (e *WorkloadPatchScoped) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueContainerAdministrationCommand
+}
+
+func (e *WorkloadPatchScoped) AttckTacticID() AttckTacticID {
+	return AttckTacticExecution
+}
+
+func (e *WorkloadPatchScoped) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*workloadPatchScopedGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Role, typed.Workload, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+		"resourceScoped":   true,
+		"psaEnforce":       typed.PSAEnforce,
+	})
+}
+
+// Stream finds all roles that have patch/update (or equivalent wildcard) permissions on workload controller resources
+// restricted to a set of workload names via resourceNames, and the matching workloads. Matching workloads are defined as
+// workloads of a kind granted by one of the role rules with a name in the rule's resourceNames, that share the role
+// namespace (namespaced roles) or exist in any namespace (cluster roles).
+func (e *WorkloadPatchScoped) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	levels, err := namespacePodSecurityLevels(ctx, sdb, e.runtime)
+	if err != nil {
+		return errors.Join(complete(ctx), err)
+	}
+
+	// The API group and resource of the rules are matched against each workload
+	query := storedb.ResourceGrantQuery{
+		Resource: storedb.WorkloadResource,
+		Permissions: storedb.PermissionSetQuery{
+			AnyRule: workloadRules(workloadPatchVerbs, storedb.ResourceScopedRules),
+		},
+		Rule: &storedb.RuleMatch{
+			Verbs: workloadPatchVerbs,
+			Scope: storedb.ResourceScopedRules,
+		},
+	}
+
+	return streamResourceGrants(ctx, sdb, e.runtime, query, func(g *storedb.ResourceGrant) any {
+		// Resolve the Pod Security Admission level enforced in the namespace of the workload
+		return &workloadPatchScopedGroup{Role: g.PermissionSet, Workload: g.Resource, PSAEnforce: levels.level(g.Namespace)}
+	}, callback, complete)
+}
@@ -0,0 +1,30 @@
+package edge
+
+import (
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+)
+
+var (
+	workloadPatchVerbs  = []string{"patch", "update"}
+	workloadCreateVerbs = []string{"create"}
+)
+
+// workloadRules returns the rule matches of policy rules granting one of the provided verbs on a workload controller
+// resource (i.e deployments, daemonsets and statefulsets from the apps API group, or jobs and cronjobs from the batch API
+// group), restricted to the provided rule scope.
+func workloadRules(verbs []string, scope storedb.RuleScope) []storedb.RuleMatch {
+	return []storedb.RuleMatch{
+		{
+			APIGroups: []string{"apps"},
+			Resources: []string{"daemonsets", "deployments", "statefulsets"},
+			Verbs:     verbs,
+			Scope:     scope,
+		},
+		{
+			APIGroups: []string{"batch"},
+			Resources: []string{"cronjobs", "jobs"},
+			Verbs:     verbs,
+			Scope:     scope,
+		},
+	}
+}
@@ -0,0 +1,66 @@
+package edge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/converter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func init() {
+	Register(&WorkloadSpawn{}, RegisterDefault)
+}
+
+// WorkloadSpawn links a workload controller to the pods it manages, and cron jobs to the jobs they create.
+type WorkloadSpawn struct {
+	BaseEdge
+}
+
+type workloadSpawnGroup struct {
+	Workload primitive.ObjectID `bson:"_id" json:"workload"`
+	Child    primitive.ObjectID `bson:"child" json:"child"`
+}
+
+func (e *WorkloadSpawn) Label() string {
+	return "WORKLOAD_SPAWN"
+}
+
+func (e *WorkloadSpawn) Name() string {
+	return "WorkloadSpawn"
+}
+
+func (e *WorkloadSpawn) AttckTechniqueID() AttckTechniqueID {
+	return AttckTechniqueDeployContainer
+}
+
+func (e *WorkloadSpawn) AttckTacticID() AttckTacticID {
+	return AttckTacticExecution
+}
+
+func (e *WorkloadSpawn) Processor(ctx context.Context, oic *converter.ObjectIDConverter, entry any) (any, error) {
+	typed, ok := entry.(*workloadSpawnGroup)
+	if !ok {
+		return nil, fmt.Errorf("invalid type passed to processor: %T", entry)
+	}
+
+	return adapter.GremlinEdgeProcessor(ctx, oic, e.Label(), typed.Workload, typed.Child, map[string]any{
+		"attckTechniqueID": string(e.AttckTechniqueID()),
+		"attckTacticID":    string(e.AttckTacticID()),
+	})
+}
+
+// Stream finds all workloads and the objects they own. Pods are linked to their workload at ingestion time (via the pod
+// controller owner reference), while workloads owned by another workload (i.e jobs created by a cron job) are matched
+// via their owner references.
+func (e *WorkloadSpawn) Stream(ctx context.Context, sdb storedb.Provider, _ cache.CacheReader,
+	callback types.ProcessEntryCallback, complete types.CompleteQueryCallback) error {
+
+	return streamLinks(ctx, sdb, e.runtime, storedb.WorkloadChildLink, func(l *storedb.Link) any {
+		return &workloadSpawnGroup{Workload: l.From, Child: l.To}
+	}, callback, complete)
+}
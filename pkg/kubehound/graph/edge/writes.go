@@ -0,0 +1,121 @@
+package edge
+
+import (
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+)
+
+// runVertices returns the filter selecting all the vertices of the current run with the provided class and properties.
+func (e *BaseEdge) runVertices(class string, props map[string]any) *types.VertexFilter {
+	filter := &types.VertexFilter{
+		Properties: map[string]any{
+			"runID":   e.runtime.RunID.String(),
+			"cluster": e.runtime.Cluster.Name,
+			"class":   class,
+		},
+	}
+	for k, v := range props {
+		filter.Properties[k] = v
+	}
+
+	return filter
+}
+
+// targetEdgeWrites returns the backend-neutral equivalent of the traversals linking every non critical insert vertex
+// to all the vertices selected by the target filter.
+func targetEdgeWrites(label string, target *types.VertexFilter, props map[string]any, inserts []any) ([]types.EdgeWrite, error) {
+	ids := make([]int64, 0, len(inserts))
+	for _, i := range inserts {
+		id, ok := i.(int64)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s edge insert type: %T", label, i)
+		}
+		ids = append(ids, id)
+	}
+
+	return []types.EdgeWrite{
+		{
+			Label: label,
+			Out: types.VertexFilter{
+				IDs:        ids,
+				Properties: map[string]any{"critical": false},
+			},
+			In:         target,
+			Properties: props,
+		},
+	}, nil
+}
+
+// criticalEdgeWrites returns the backend-neutral equivalent of the large cluster traversals flagging the insert
+// vertices as critical with a self loop edge.
+func criticalEdgeWrites(label string, props map[string]any, inserts []any) ([]types.EdgeWrite, error) {
+	writes := make([]types.EdgeWrite, 0, len(inserts))
+	for _, i := range inserts {
+		typed, ok := i.(map[any]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s edge insert type: %T", label, i)
+		}
+
+		id, ok := typed[gremlin.T.Id].(int64)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s edge insert: %v", label, typed)
+		}
+
+		writes = append(writes, types.EdgeWrite{
+			Label: label,
+			Out: types.VertexFilter{
+				IDs: []int64{id},
+				Set: map[string]any{"critical": true},
+			},
+			Properties: props,
+		})
+	}
+
+	return writes, nil
+}
+
+// varLogSymlinkWrite returns the backend-neutral equivalent of the CE_VAR_LOG_SYMLINK traversals, following the path
+// from the permission sets to the containers of the identities they are granted to (restricted to the containers
+// matching the provided properties), and from these containers to the nodes whose /var/log directory they mount.
+func varLogSymlinkWrite(label string, permissionSets []int64, containers map[string]any, containersWithin map[string][]any,
+	props map[string]any) types.EdgeWrite {
+
+	container := types.VertexHop{
+		Label:      "IDENTITY_ASSUME",
+		Inbound:    true,
+		Properties: map[string]any{"class": "Container"},
+		Within:     containersWithin,
+	}
+	for k, v := range containers {
+		container.Properties[k] = v
+	}
+
+	return types.EdgeWrite{
+		Label: label,
+		Out: types.VertexFilter{
+			IDs:        permissionSets,
+			Properties: map[string]any{"class": "PermissionSet"},
+		},
+		OutPath: []types.VertexHop{
+			{Label: "PERMISSION_DISCOVER", Inbound: true},
+			container,
+		},
+		InPath: []types.VertexHop{
+			{
+				Label:      "VOLUME_DISCOVER",
+				Properties: map[string]any{"type": shared.VolumeTypeHost},
+				// filter only the volumes that are "affected" by this attacks ("/", "/var", "/var/log").
+				Within: map[string][]any{"sourcePath": {"/", "/var", "/var/log"}},
+			},
+			{
+				Label:      "VOLUME_ACCESS",
+				Inbound:    true,
+				Properties: map[string]any{"class": "Node"},
+			},
+		},
+		Properties: props,
+	}
+}
@@ -0,0 +1,42 @@
+package types
+
+// VertexWrite is a backend-neutral representation of a vertex insert, for graph databases not speaking Gremlin.
+type VertexWrite struct {
+	Label      string         // Vertex label
+	Properties map[string]any // Vertex properties
+}
+
+// VertexFilter is a backend-neutral selection of existing graph vertices.
+type VertexFilter struct {
+	IDs        []int64        // Graph vertex ids to select (any vertex if empty)
+	Properties map[string]any // Property values the selected vertices must match
+	Set        map[string]any // Properties to update on the selected vertices before writing the edge
+}
+
+// VertexHop is a backend-neutral step from a set of vertices to their adjacent vertices, following the edges with the
+// provided label.
+type VertexHop struct {
+	Label      string           // Label of the followed edges
+	Inbound    bool             // Whether to follow the edges backwards, from their in vertex to their out vertex
+	Properties map[string]any   // Property values the adjacent vertices must match
+	Within     map[string][]any // Property values the adjacent vertices must match one of
+}
+
+// EdgeWrite is a backend-neutral representation of an edge insert, for graph databases not speaking Gremlin. An edge
+// is created from each vertex selected by Out to each vertex selected by In. Edges following a path in the graph
+// select their vertices by walking hops, with one edge created per path.
+type EdgeWrite struct {
+	Label      string         // Edge label
+	Out        VertexFilter   // Vertices the edge goes out of (or the path starts from if OutPath is set)
+	OutPath    []VertexHop    // Hops walked from the vertices selected by Out to reach the vertices the edge goes out of
+	In         *VertexFilter  // Vertices the edge goes in to (a nil value creates a self loop on the out vertices)
+	InPath     []VertexHop    // Hops walked from each out vertex to reach the vertices the edge goes in to, replaces In
+	Properties map[string]any // Edge properties
+	Merge      bool           // Whether to skip the write if an edge with the same label already links the vertices
+}
+
+// VertexWrites returns the function to create backend-neutral vertex writes from an array of input objects.
+type VertexWrites func(inserts []any) ([]VertexWrite, error)
+
+// EdgeWrites returns the function to create backend-neutral edge writes from an array of input objects.
+type EdgeWrites func(inserts []any) ([]EdgeWrite, error)
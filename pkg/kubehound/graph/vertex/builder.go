@@ -29,4 +29,7 @@ type Builder interface {
 
 	// Traversal returns a graph traversal function that enables creating vertices from an input array of TraversalInput objects.
 	Traversal() types.VertexTraversal
+
+	// Writes returns a function creating backend-neutral vertex writes from an input array of TraversalInput objects.
+	Writes() types.VertexWrites
 }
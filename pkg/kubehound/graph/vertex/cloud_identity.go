@@ -0,0 +1,35 @@
+package vertex
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/graph"
+)
+
+const (
+	CloudIdentityLabel = "CloudIdentity"
+)
+
+var _ Builder = (*CloudIdentity)(nil)
+
+type CloudIdentity struct {
+	BaseVertex
+}
+
+func (v *CloudIdentity) Label() string {
+	return CloudIdentityLabel
+}
+
+func (v *CloudIdentity) Processor(ctx context.Context, entry any) (any, error) {
+	return adapter.GremlinVertexProcessor[*graph.CloudIdentity](ctx, entry)
+}
+
+func (v *CloudIdentity) Traversal() types.VertexTraversal {
+	return v.DefaultTraversal(v.Label())
+}
+
+func (v *CloudIdentity) Writes() types.VertexWrites {
+	return adapter.DefaultVertexWrites(v.Label())
+}
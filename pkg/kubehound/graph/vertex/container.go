@@ -29,3 +29,7 @@ func (v *Container) Processor(ctx context.Context, entry any) (any, error) {
 func (v *Container) Traversal() types.VertexTraversal {
 	return v.DefaultTraversal(v.Label())
 }
+
+func (v *Container) Writes() types.VertexWrites {
+	return adapter.DefaultVertexWrites(v.Label())
+}
@@ -29,3 +29,7 @@ func (v *Endpoint) Processor(ctx context.Context, entry any) (any, error) {
 func (v *Endpoint) Traversal() types.VertexTraversal {
 	return v.DefaultTraversal(v.Label())
 }
+
+func (v *Endpoint) Writes() types.VertexWrites {
+	return adapter.DefaultVertexWrites(v.Label())
+}
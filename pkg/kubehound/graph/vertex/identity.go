@@ -29,3 +29,7 @@ func (v *Identity) Processor(ctx context.Context, entry any) (any, error) {
 func (v *Identity) Traversal() types.VertexTraversal {
 	return v.DefaultTraversal(v.Label())
 }
+
+func (v *Identity) Writes() types.VertexWrites {
+	return adapter.DefaultVertexWrites(v.Label())
+}
@@ -23,11 +23,14 @@ func TestIdentity_Traversal(t *testing.T) {
 			// We set the values to all field with non default values
 			// so we are sure all are correctly propagated.
 			data: graph.Identity{
-				StoreID:      "test id",
-				Name:         "test name identity",
-				IsNamespaced: true,
-				Namespace:    "lol namespace",
-				Type:         "some type",
+				StoreID:          "test id",
+				Name:             "test name identity",
+				IsNamespaced:     true,
+				Namespace:        "lol namespace",
+				Type:             "some type",
+				Automount:        true,
+				ImagePullSecrets: []string{"test pull secret"},
+				TokenSecrets:     []string{"test token secret"},
 			},
 		},
 	}
@@ -49,6 +52,8 @@ func TestIdentity_Traversal(t *testing.T) {
 			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test name identity")
 			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "lol namespace")
 			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "some type")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test pull secret")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test token secret")
 		})
 	}
 }
@@ -0,0 +1,63 @@
+package vertex
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/graph"
+	gremlingo "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespace_Traversal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		want types.VertexTraversal
+		data graph.Namespace
+	}{
+		{
+			name: "Add Namespaces in JanusGraph",
+			// We set the values to all field with non default values
+			// so we are sure all are correctly propagated.
+			data: graph.Namespace{
+				StoreID:           "test id",
+				App:               "test app",
+				Team:              "test team",
+				Service:           "test service",
+				Name:              "test name namespace",
+				PSAEnforce:        "test enforce",
+				PSAEnforceVersion: "test version",
+				PSAAudit:          "test audit",
+				PSAWarn:           "test warn",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v := Namespace{}
+
+			g := gremlingo.GraphTraversalSource{}
+
+			vertexTraversal := v.Traversal()
+			inserts := []any{&tt.data}
+
+			traversal := vertexTraversal(&g, inserts)
+			// This is ugly but doesn't need to write to the DB
+			// This just makes sure the traversal is correctly returned with the correct values
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test id")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test app")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test team")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test service")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test name namespace")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test enforce")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test version")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test audit")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test warn")
+		})
+	}
+}
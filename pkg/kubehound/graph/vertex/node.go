@@ -29,3 +29,7 @@ func (v *Node) Processor(ctx context.Context, entry any) (any, error) {
 func (v *Node) Traversal() types.VertexTraversal {
 	return v.DefaultTraversal(v.Label())
 }
+
+func (v *Node) Writes() types.VertexWrites {
+	return adapter.DefaultVertexWrites(v.Label())
+}
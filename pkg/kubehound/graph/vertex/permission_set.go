@@ -33,3 +33,7 @@ func (v *PermissionSet) Processor(ctx context.Context, entry any) (any, error) {
 func (v *PermissionSet) Traversal() types.VertexTraversal {
 	return v.DefaultTraversal(v.Label())
 }
+
+func (v *PermissionSet) Writes() types.VertexWrites {
+	return adapter.DefaultVertexWrites(v.Label())
+}
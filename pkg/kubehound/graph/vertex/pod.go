@@ -29,3 +29,7 @@ func (v *Pod) Processor(ctx context.Context, entry any) (any, error) {
 func (v *Pod) Traversal() types.VertexTraversal {
 	return v.DefaultTraversal(v.Label())
 }
+
+func (v *Pod) Writes() types.VertexWrites {
+	return adapter.DefaultVertexWrites(v.Label())
+}
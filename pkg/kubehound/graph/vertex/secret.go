@@ -0,0 +1,35 @@
+package vertex
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/graph"
+)
+
+const (
+	SecretLabel = "Secret"
+)
+
+var _ Builder = (*Secret)(nil)
+
+type Secret struct {
+	BaseVertex
+}
+
+func (v *Secret) Label() string {
+	return SecretLabel
+}
+
+func (v *Secret) Processor(ctx context.Context, entry any) (any, error) {
+	return adapter.GremlinVertexProcessor[*graph.Secret](ctx, entry)
+}
+
+func (v *Secret) Traversal() types.VertexTraversal {
+	return v.DefaultTraversal(v.Label())
+}
+
+func (v *Secret) Writes() types.VertexWrites {
+	return adapter.DefaultVertexWrites(v.Label())
+}
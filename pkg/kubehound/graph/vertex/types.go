@@ -3,12 +3,17 @@ package vertex
 var (
 	// Labels is a list of all possible labels for a vertex in the graph.
 	Labels = []string{
+		CloudIdentityLabel,
 		ContainerLabel,
 		EndpointLabel,
 		IdentityLabel,
+		NamespaceLabel,
 		NodeLabel,
 		PermissionSetLabel,
 		PodLabel,
+		SecretLabel,
 		VolumeLabel,
+		WebhookLabel,
+		WorkloadLabel,
 	}
 )
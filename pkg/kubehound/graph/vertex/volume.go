@@ -29,3 +29,7 @@ func (v *Volume) Processor(ctx context.Context, entry any) (any, error) {
 func (v *Volume) Traversal() types.VertexTraversal {
 	return v.DefaultTraversal(v.Label())
 }
+
+func (v *Volume) Writes() types.VertexWrites {
+	return adapter.DefaultVertexWrites(v.Label())
+}
@@ -0,0 +1,35 @@
+package vertex
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/graph"
+)
+
+const (
+	WebhookLabel = "Webhook"
+)
+
+var _ Builder = (*Webhook)(nil)
+
+type Webhook struct {
+	BaseVertex
+}
+
+func (v *Webhook) Label() string {
+	return WebhookLabel
+}
+
+func (v *Webhook) Processor(ctx context.Context, entry any) (any, error) {
+	return adapter.GremlinVertexProcessor[*graph.Webhook](ctx, entry)
+}
+
+func (v *Webhook) Traversal() types.VertexTraversal {
+	return v.DefaultTraversal(v.Label())
+}
+
+func (v *Webhook) Writes() types.VertexWrites {
+	return adapter.DefaultVertexWrites(v.Label())
+}
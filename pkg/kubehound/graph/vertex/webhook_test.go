@@ -0,0 +1,59 @@
+package vertex
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/graph"
+	gremlingo "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook_Traversal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		want types.VertexTraversal
+		data graph.Webhook
+	}{
+		{
+			name: "Add Webhooks in JanusGraph",
+			// We set the values to all field with non default values
+			// so we are sure all are correctly propagated.
+			data: graph.Webhook{
+				StoreID:          "test id",
+				Name:             "test name webhook",
+				Configuration:    "test configuration",
+				Type:             "test type",
+				ServiceNamespace: "test service namespace",
+				ServiceName:      "test service name",
+				Resources:        []string{"test resource"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v := Webhook{}
+
+			g := gremlingo.GraphTraversalSource{}
+
+			vertexTraversal := v.Traversal()
+			inserts := []any{&tt.data}
+
+			traversal := vertexTraversal(&g, inserts)
+			// This is ugly but doesn't need to write to the DB
+			// This just makes sure the traversal is correctly returned with the correct values
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test id")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test name webhook")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test configuration")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test type")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test service namespace")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test service name")
+			assert.Contains(t, fmt.Sprintf("%s", traversal.Bytecode), "test resource")
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package vertex
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/adapter"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/graph"
+)
+
+const (
+	WorkloadLabel = "Workload"
+)
+
+var _ Builder = (*Workload)(nil)
+
+type Workload struct {
+	BaseVertex
+}
+
+func (v *Workload) Label() string {
+	return WorkloadLabel
+}
+
+func (v *Workload) Processor(ctx context.Context, entry any) (any, error) {
+	return adapter.GremlinVertexProcessor[*graph.Workload](ctx, entry)
+}
+
+func (v *Workload) Traversal() types.VertexTraversal {
+	return v.DefaultTraversal(v.Label())
+}
+
+func (v *Workload) Writes() types.VertexWrites {
+	return adapter.DefaultVertexWrites(v.Label())
+}
@@ -0,0 +1,229 @@
+package hostmount
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/telemetry/log"
+)
+
+var matcherInstance *Matcher
+var hmMu sync.RWMutex
+
+// Instance returns the host mount matcher singleton instance. If the matcher has not been initialized
+// from the application configuration, the default mount lists are used.
+func Instance() *Matcher {
+	hmMu.RLock()
+	matcher := matcherInstance
+	hmMu.RUnlock()
+	if matcher != nil {
+		return matcher
+	}
+
+	hmMu.Lock()
+	defer hmMu.Unlock()
+	if matcherInstance == nil {
+		l := log.Logger(context.Background())
+		var err error
+		matcherInstance, err = NewMatcher(&config.HostMountConfig{})
+		if err != nil {
+			l.Fatal("Host mount matcher initialization", log.ErrorField(err))
+		}
+	}
+
+	return matcherInstance
+}
+
+// Initialize (re)creates the host mount matcher singleton instance from the provided application configuration.
+func Initialize(ctx context.Context, cfg *config.KubehoundConfig) error {
+	l := log.Logger(ctx)
+
+	matcher, err := NewMatcher(&cfg.Builder.Edge.HostMounts)
+	if err != nil {
+		return err
+	}
+
+	l.Info("Host mount matcher initialized", log.Int("clusters", len(matcher.clusters)))
+
+	hmMu.Lock()
+	matcherInstance = matcher
+	hmMu.Unlock()
+
+	return nil
+}
+
+// rule is the compiled form of a host mount rule.
+type rule struct {
+	path   string
+	regex  *regexp.Regexp
+	reason string
+}
+
+func compileRule(def *config.HostMountRule) (*rule, error) {
+	switch {
+	case def.Path != "" && def.Regex != "":
+		return nil, fmt.Errorf("host mount rule %q: path and regex are mutually exclusive", def.Path)
+	case def.Path != "":
+		return &rule{path: def.Path, reason: def.Reason}, nil
+	case def.Regex != "":
+		re, err := regexp.Compile(def.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("host mount rule %q: %w", def.Regex, err)
+		}
+
+		return &rule{regex: re, reason: def.Reason}, nil
+	default:
+		return nil, fmt.Errorf("host mount rule must define a path or a regex")
+	}
+}
+
+func (r *rule) match(path string) bool {
+	if r.regex != nil {
+		return r.regex.MatchString(path)
+	}
+
+	return r.path == path
+}
+
+// List holds an ordered list of host mount rules, the first matching rule wins.
+type List struct {
+	rules []*rule
+}
+
+func newList(defs ...[]config.HostMountRule) (*List, error) {
+	l := &List{
+		rules: make([]*rule, 0),
+	}
+
+	for _, d := range defs {
+		for i := range d {
+			r, err := compileRule(&d[i])
+			if err != nil {
+				return nil, err
+			}
+			l.rules = append(l.rules, r)
+		}
+	}
+
+	return l, nil
+}
+
+// Match returns the reason of the first rule matching the provided host mount source path, if any.
+func (l *List) Match(path string) (string, bool) {
+	for _, r := range l.rules {
+		if r.match(path) {
+			return r.reason, true
+		}
+	}
+
+	return "", false
+}
+
+// mountLists holds the host mount lists applied to a cluster.
+type mountLists struct {
+	safeWrite      *List
+	unsafeRead     *List
+	runtimeSockets *List
+}
+
+func newMountLists(disableDefaults bool, safeWrite []config.HostMountRule, unsafeRead []config.HostMountRule,
+	runtimeSockets []config.HostMountRule) (*mountLists, error) {
+
+	var defaultSafeWrite, defaultUnsafeRead, defaultRuntimeSockets []config.HostMountRule
+	if !disableDefaults {
+		defaultSafeWrite = DefaultSafeWrite()
+		defaultUnsafeRead = DefaultUnsafeRead()
+		defaultRuntimeSockets = DefaultRuntimeSockets()
+	}
+
+	sw, err := newList(safeWrite, defaultSafeWrite)
+	if err != nil {
+		return nil, fmt.Errorf("safe write list: %w", err)
+	}
+
+	ur, err := newList(unsafeRead, defaultUnsafeRead)
+	if err != nil {
+		return nil, fmt.Errorf("unsafe read list: %w", err)
+	}
+
+	rs, err := newList(runtimeSockets, defaultRuntimeSockets)
+	if err != nil {
+		return nil, fmt.Errorf("runtime sockets list: %w", err)
+	}
+
+	return &mountLists{
+		safeWrite:      sw,
+		unsafeRead:     ur,
+		runtimeSockets: rs,
+	}, nil
+}
+
+// Matcher resolves the host mount lists of a cluster from the global configuration and the cluster overrides.
+type Matcher struct {
+	global   *mountLists
+	clusters map[string]*mountLists
+}
+
+// NewMatcher creates a new host mount matcher instance from the provided configuration. The singleton returned
+// by Instance() should be preferred outside of tests.
+func NewMatcher(cfg *config.HostMountConfig) (*Matcher, error) {
+	global, err := newMountLists(cfg.DisableDefaults, cfg.SafeWrite, cfg.UnsafeRead, cfg.RuntimeSockets)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := make(map[string]*mountLists, len(cfg.Clusters))
+	for _, c := range cfg.Clusters {
+		// Cluster rules are evaluated first, followed by the global rules and the defaults (unless disabled)
+		safeWrite := append(append([]config.HostMountRule{}, c.SafeWrite...), cfg.SafeWrite...)
+		unsafeRead := append(append([]config.HostMountRule{}, c.UnsafeRead...), cfg.UnsafeRead...)
+		runtimeSockets := append(append([]config.HostMountRule{}, c.RuntimeSockets...), cfg.RuntimeSockets...)
+
+		lists, err := newMountLists(cfg.DisableDefaults || c.DisableDefaults, safeWrite, unsafeRead, runtimeSockets)
+		if err != nil {
+			return nil, fmt.Errorf("cluster %s: %w", c.Name, err)
+		}
+		clusters[c.Name] = lists
+	}
+
+	return &Matcher{
+		global:   global,
+		clusters: clusters,
+	}, nil
+}
+
+func (m *Matcher) lists(cluster string) *mountLists {
+	if lists, ok := m.clusters[cluster]; ok {
+		return lists
+	}
+
+	return m.global
+}
+
+// SafeWrite returns the list of writable host mounts deemed not exploitable in the provided cluster.
+func (m *Matcher) SafeWrite(cluster string) *List {
+	return m.lists(cluster).safeWrite
+}
+
+// UnsafeRead returns the list of read-only host mounts granting execution on the host in the provided cluster.
+func (m *Matcher) UnsafeRead(cluster string) *List {
+	return m.lists(cluster).unsafeRead
+}
+
+// RuntimeSockets returns the list of host mounts exposing a container runtime socket in the provided cluster.
+func (m *Matcher) RuntimeSockets(cluster string) *List {
+	return m.lists(cluster).runtimeSockets
+}
+
+// Reason returns the reason of the first rule matching a host mount source path in the provided cluster. Writable
+// mounts are matched against the safe write list and read-only mounts against the unsafe read list.
+func (m *Matcher) Reason(cluster string, path string, readOnly bool) (string, bool) {
+	if readOnly {
+		return m.UnsafeRead(cluster).Match(path)
+	}
+
+	return m.SafeWrite(cluster).Match(path)
+}
@@ -0,0 +1,116 @@
+package hostmount
+
+import (
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcher_DefaultLists(t *testing.T) {
+	t.Parallel()
+
+	matcher, err := NewMatcher(&config.HostMountConfig{})
+	require.NoError(t, err)
+
+	reason, ok := matcher.SafeWrite("test-cluster").Match("/var/lib/datadog-agent/logs")
+	assert.True(t, ok)
+	assert.Equal(t, reasonDatadogAgent, reason)
+
+	_, ok = matcher.SafeWrite("test-cluster").Match("/etc")
+	assert.False(t, ok)
+
+	reason, ok = matcher.UnsafeRead("test-cluster").Match("/home/user/.ssh")
+	assert.True(t, ok)
+	assert.Equal(t, "user SSH keys", reason)
+
+	_, ok = matcher.UnsafeRead("test-cluster").Match("/var/log")
+	assert.False(t, ok)
+
+	reason, ok = matcher.RuntimeSockets("test-cluster").Match("/run/containerd/containerd.sock")
+	assert.True(t, ok)
+	assert.Equal(t, reasonContainerd, reason)
+
+	reason, ok = matcher.RuntimeSockets("test-cluster").Match("/var/run/docker.sock")
+	assert.True(t, ok)
+	assert.Equal(t, reasonDocker, reason)
+
+	_, ok = matcher.RuntimeSockets("test-cluster").Match("/var/run/datadog-agent")
+	assert.False(t, ok)
+}
+
+func TestMatcher_Config(t *testing.T) {
+	t.Parallel()
+
+	matcher, err := NewMatcher(&config.HostMountConfig{
+		DisableDefaults: true,
+		SafeWrite: []config.HostMountRule{
+			{Path: "/var/run/falco", Reason: "falco runtime directory"},
+			{Regex: "^/var/lib/kubelet/plugins/.*", Reason: "CSI driver sockets"},
+		},
+		UnsafeRead: []config.HostMountRule{
+			{Path: "/etc/kubernetes", Reason: "kubelet credentials"},
+		},
+		Clusters: []config.HostMountClusterConfig{
+			{
+				Name: "cilium-cluster",
+				SafeWrite: []config.HostMountRule{
+					{Regex: "^/var/run/cilium", Reason: "cilium runtime directory"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	reason, ok := matcher.Reason("test-cluster", "/var/run/falco", false)
+	assert.True(t, ok)
+	assert.Equal(t, "falco runtime directory", reason)
+
+	reason, ok = matcher.Reason("test-cluster", "/var/lib/kubelet/plugins/csi.sock", false)
+	assert.True(t, ok)
+	assert.Equal(t, "CSI driver sockets", reason)
+
+	reason, ok = matcher.Reason("test-cluster", "/etc/kubernetes", true)
+	assert.True(t, ok)
+	assert.Equal(t, "kubelet credentials", reason)
+
+	// Defaults are disabled
+	_, ok = matcher.Reason("test-cluster", "/etc/datadog-agent", false)
+	assert.False(t, ok)
+	_, ok = matcher.Reason("test-cluster", "/root", true)
+	assert.False(t, ok)
+
+	// Cluster overrides are only applied to the matching cluster, in addition to the global rules
+	_, ok = matcher.Reason("test-cluster", "/var/run/cilium", false)
+	assert.False(t, ok)
+
+	reason, ok = matcher.Reason("cilium-cluster", "/var/run/cilium", false)
+	assert.True(t, ok)
+	assert.Equal(t, "cilium runtime directory", reason)
+
+	reason, ok = matcher.Reason("cilium-cluster", "/var/run/falco", false)
+	assert.True(t, ok)
+	assert.Equal(t, "falco runtime directory", reason)
+}
+
+func TestMatcher_InvalidRule(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewMatcher(&config.HostMountConfig{
+		SafeWrite: []config.HostMountRule{{Regex: "^/var/run/(falco"}},
+	})
+	assert.ErrorContains(t, err, "safe write list")
+
+	_, err = NewMatcher(&config.HostMountConfig{
+		UnsafeRead: []config.HostMountRule{{Path: "/etc", Regex: "^/etc"}},
+	})
+	assert.ErrorContains(t, err, "mutually exclusive")
+
+	_, err = NewMatcher(&config.HostMountConfig{
+		Clusters: []config.HostMountClusterConfig{
+			{Name: "test-cluster", UnsafeRead: []config.HostMountRule{{Reason: "empty"}}},
+		},
+	})
+	assert.ErrorContains(t, err, "cluster test-cluster")
+}
@@ -0,0 +1,70 @@
+package hostmount
+
+import (
+	"github.com/DataDog/KubeHound/pkg/config"
+)
+
+const (
+	reasonDatadogAgent = "datadog agent runtime and configuration directory"
+	reasonRuntimeState = "runtime state directory"
+	reasonTemporary    = "temporary directory"
+	reasonMount        = "external disk mount point"
+	reasonDevice       = "device manager directory"
+	reasonDocker       = "docker daemon socket"
+	reasonContainerd   = "containerd socket"
+	reasonCrio         = "cri-o socket"
+)
+
+// DefaultSafeWrite returns the built-in list of common writable host mounts that are deemed not exploitable.
+// Paths are normalized by K8s to remove the trailing slash.
+func DefaultSafeWrite() []config.HostMountRule {
+	return []config.HostMountRule{
+		{Path: "/var/run/datadog-agent", Reason: reasonDatadogAgent},
+		{Path: "/etc/datadog-agent", Reason: reasonDatadogAgent},
+		{Path: "/etc/datadog-agent/auth", Reason: reasonDatadogAgent},
+		{Path: "/opt/datadog-agent/run", Reason: reasonDatadogAgent},
+		{Path: "/etc/datadog", Reason: reasonDatadogAgent},
+		{Path: "/run/xtables.lock", Reason: "iptables lock file"},
+		{Path: "/opt/datadog/heapdumps", Reason: reasonDatadogAgent},
+		{Path: "/var/datadog/dumps", Reason: reasonDatadogAgent},
+		{Regex: "^/mnt/disks", Reason: reasonMount},
+		{Regex: "^/sys/kernel/debug", Reason: "kernel debug filesystem"},
+		{Regex: "^/tmp?.*", Reason: reasonTemporary},
+		{Regex: "^/var/run/.*", Reason: reasonRuntimeState},
+		{Regex: "^/mnt/.*", Reason: reasonMount},
+		{Regex: "^/var/lib/datadog-agent/.*", Reason: reasonDatadogAgent},
+		{Regex: "^/var/tmp/datadog-agent/.*", Reason: reasonDatadogAgent},
+		{Regex: "^/run/udev", Reason: reasonDevice},
+		{Regex: "^/lib/udev", Reason: reasonDevice},
+		{Regex: "^/etc/udev", Reason: reasonDevice},
+		{Regex: "^/data/[a-zA-Z0-9\\-]*/shared", Reason: "shared data directory"},
+	}
+}
+
+// DefaultUnsafeRead returns the built-in list of read-only host mounts that can be abused to read secrets granting
+// execution on the host. Paths are normalized by K8s to remove the trailing slash.
+func DefaultUnsafeRead() []config.HostMountRule {
+	return []config.HostMountRule{
+		{Path: "/", Reason: "host root filesystem"},
+		{Path: "/home", Reason: "user home directories"},
+		{Regex: "^/home/[a-zA-Z0-9]*/\\.ssh$", Reason: "user SSH keys"},
+		{Path: "/root", Reason: "root home directory"},
+		{Path: "/root/.ssh", Reason: "root SSH keys"},
+		{Path: "/proc", Reason: "host process filesystem"},
+		{Path: "/etc", Reason: "host configuration directory"},
+	}
+}
+
+// DefaultRuntimeSockets returns the built-in list of host mounts exposing a container runtime socket, either directly
+// or via the parent directory of the socket. Paths are normalized by K8s to remove the trailing slash.
+func DefaultRuntimeSockets() []config.HostMountRule {
+	return []config.HostMountRule{
+		{Regex: "^(/var)?/run/docker\\.sock$", Reason: reasonDocker},
+		{Regex: "^(/var)?/run/dockershim\\.sock$", Reason: reasonDocker},
+		{Regex: "^(/var)?/run/cri-dockerd\\.sock$", Reason: reasonDocker},
+		{Regex: "^(/var)?/run/containerd(/containerd\\.sock)?$", Reason: reasonContainerd},
+		{Regex: "^/run/k3s/containerd(/containerd\\.sock)?$", Reason: reasonContainerd},
+		{Regex: "^(/var)?/run/crio(/crio\\.sock)?$", Reason: reasonCrio},
+		{Regex: "^(/var)?/run$", Reason: "runtime state directory holding the container runtime sockets"},
+	}
+}
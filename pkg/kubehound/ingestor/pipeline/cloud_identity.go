@@ -0,0 +1,54 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"github.com/DataDog/KubeHound/pkg/telemetry/log"
+)
+
+// processCloudIdentity handles the ingestion of a cloud IAM principal granted to a service account or node. The same
+// principal is commonly granted to multiple service accounts or nodes (e.g all the nodes of a node pool), so lookup in
+// cache before writing to the store. The cache writer of the calling pipeline must be created with the WithTest option.
+func processCloudIdentity(ctx context.Context, r *IngestResources, c collections.CloudIdentity, v *vertex.CloudIdentity,
+	principal store.CloudPrincipal) error {
+
+	// Normalize cloud principal to store cloud identity object format
+	cid, err := r.storeConvert.CloudIdentity(ctx, principal)
+	if err != nil {
+		return err
+	}
+
+	// Async write to cache. If entry is already present skip further processing.
+	ck := cachekey.CloudIdentity(cid.Provider, cid.Name)
+	err = r.writeCache(ctx, ck, cid.Id.Hex())
+	if err != nil {
+		var errOverwrite *cache.OverwriteError
+		if errors.As(err, &errOverwrite) {
+			log.Trace(ctx).Debugf("cloud identity cache entry %#v already exists, skipping inserts", ck)
+
+			return nil
+		}
+
+		return err
+	}
+
+	// Async write cloud identity to store
+	if err := r.writeStore(ctx, c, cid); err != nil {
+		return err
+	}
+
+	// Transform store model to vertex input
+	insert, err := r.graphConvert.CloudIdentity(cid) //nolint: contextcheck
+	if err != nil {
+		return err
+	}
+
+	// Aysnc write to graph
+	return r.writeVertex(ctx, v, insert)
+}
@@ -102,17 +102,21 @@ func TestClusterRoleBindingIngest_Pipeline(t *testing.T) {
 
 	// Graph setup
 	vtxInsert := map[string]any{
-		"critical":     false,
-		"isNamespaced": false,
-		"name":         "app-monitors-cluster",
-		"namespace":    "",
-		"storeID":      storeID.Hex(),
-		"type":         "ServiceAccount",
-		"team":         "test-team",
-		"app":          "test-app",
-		"service":      "test-service",
-		"cluster":      "test-cluster",
-		"runID":        testID.String(),
+		"automount":        true,
+		"imagePullSecrets": []interface{}{},
+		"tokenSecrets":     []interface{}{},
+		"critical":         false,
+		"criticalRule":     "",
+		"isNamespaced":     false,
+		"name":             "app-monitors-cluster",
+		"namespace":        "",
+		"storeID":          storeID.Hex(),
+		"type":             "ServiceAccount",
+		"team":             "test-team",
+		"app":              "test-app",
+		"service":          "test-service",
+		"cluster":          "test-cluster",
+		"runID":            testID.String(),
 	}
 	gdb := graphdb.NewProvider(t)
 	gw := graphdb.NewAsyncVertexWriter(t)
@@ -122,19 +126,21 @@ func TestClusterRoleBindingIngest_Pipeline(t *testing.T) {
 	gdb.EXPECT().VertexWriter(ctx, mock.AnythingOfType("*vertex.Identity"), c, mock.AnythingOfType("graphdb.WriterOption")).Return(gw, nil)
 
 	psVtxInsert := map[string]any{
-		"isNamespaced": false,
-		"critical":     false,
-		"name":         "test-reader::app-monitors-read",
-		"namespace":    "",
-		"role":         "test-reader",
-		"roleBinding":  "app-monitors-read",
-		"storeID":      psStoreID.Hex(),
-		"team":         "test-team",
-		"app":          "test-app",
-		"service":      "test-service",
-		"cluster":      "test-cluster",
-		"runID":        testID.String(),
-		"rules":        []interface{}{"API()::R(pods)::N()::V(get,list)", "API()::R(configmaps)::N()::V(get)", "API(apps)::R(statefulsets)::N()::V(get,list)"},
+		"isNamespaced":   false,
+		"critical":       false,
+		"criticalRule":   "",
+		"name":           "test-reader::app-monitors-read",
+		"namespace":      "",
+		"role":           "test-reader",
+		"roleBinding":    "app-monitors-read",
+		"storeID":        psStoreID.Hex(),
+		"team":           "test-team",
+		"app":            "test-app",
+		"service":        "test-service",
+		"cluster":        "test-cluster",
+		"runID":          testID.String(),
+		"rules":          []interface{}{"API()::R(pods)::N()::V(get,list)", "API()::R(configmaps)::N()::V(get)", "API(apps)::R(statefulsets)::N()::V(get,list)"},
+		"aggregatedFrom": []interface{}{},
 	}
 
 	psgw := graphdb.NewAsyncVertexWriter(t)
@@ -2,9 +2,11 @@ package pipeline
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/DataDog/KubeHound/pkg/globals/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
 	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
 )
@@ -16,6 +18,8 @@ const (
 type ClusterRoleIngest struct {
 	collection collections.Role
 	r          *IngestResources
+	aggregator *libkube.ClusterRoleAggregator // Resolves aggregated cluster roles once all roles are collected
+	aggregated []types.ClusterRoleType        // Cluster roles defining an aggregation rule, ingested on completion
 }
 
 var _ ObjectIngest = (*ClusterRoleIngest)(nil)
@@ -28,6 +32,8 @@ func (i *ClusterRoleIngest) Initialize(ctx context.Context, deps *Dependencies)
 	var err error
 
 	i.collection = collections.Role{}
+	i.aggregator = libkube.NewClusterRoleAggregator()
+	i.aggregated = make([]types.ClusterRoleType, 0)
 
 	i.r, err = CreateResources(ctx, deps,
 		WithCacheWriter(),
@@ -46,6 +52,19 @@ func (i *ClusterRoleIngest) IngestClusterRole(ctx context.Context, role types.Cl
 		return err
 	}
 
+	// Aggregated cluster roles can only be resolved once all cluster roles have been collected
+	i.aggregator.Add(role)
+	if role.AggregationRule != nil {
+		i.aggregated = append(i.aggregated, role)
+
+		return nil
+	}
+
+	return i.ingest(ctx, role, nil)
+}
+
+// ingest writes a cluster role to the store and cache, overriding its rules with the resolved aggregation if provided.
+func (i *ClusterRoleIngest) ingest(ctx context.Context, role types.ClusterRoleType, agg *libkube.AggregatedClusterRole) error {
 	// Normalize K8s cluster role to store object format. Cluster roles are treated as
 	// role within our model (with IsNamespaced flag set to false).
 	o, err := i.r.storeConvert.ClusterRole(ctx, role)
@@ -53,6 +72,11 @@ func (i *ClusterRoleIngest) IngestClusterRole(ctx context.Context, role types.Cl
 		return err
 	}
 
+	if agg != nil {
+		o.Rules = agg.Rules
+		o.AggregatedFrom = agg.AggregatedFrom
+	}
+
 	// Async write to store
 	if err := i.r.writeStore(ctx, i.collection, o); err != nil {
 		return err
@@ -63,8 +87,19 @@ func (i *ClusterRoleIngest) IngestClusterRole(ctx context.Context, role types.Cl
 }
 
 // completeCallback is invoked by the collector when all cluster roles have been streamed.
-// The function flushes all writers and waits for completion.
+// The function resolves and ingests the aggregated cluster roles, then flushes all writers and waits for completion.
 func (i *ClusterRoleIngest) Complete(ctx context.Context) error {
+	for _, role := range i.aggregated {
+		agg, err := i.aggregator.Resolve(role)
+		if err != nil {
+			return fmt.Errorf("resolving aggregated cluster role: %w", err)
+		}
+
+		if err := i.ingest(ctx, role, agg); err != nil {
+			return err
+		}
+	}
+
 	return i.r.flushWriters(ctx)
 }
 
@@ -91,3 +91,89 @@ func TestClusterRoleIngest_Pipeline(t *testing.T) {
 	err = cri.Close(ctx)
 	assert.NoError(t, err)
 }
+
+func TestClusterRoleIngest_Aggregation(t *testing.T) {
+	t.Parallel()
+
+	cri := &ClusterRoleIngest{}
+
+	ctx := t.Context()
+	fakeAggregated, err := loadTestObject[types.ClusterRoleType]("testdata/clusterrole-aggregated.json")
+	assert.NoError(t, err)
+	fakeRole, err := loadTestObject[types.ClusterRoleType]("testdata/clusterrole.json")
+	assert.NoError(t, err)
+
+	client := mockcollect.NewCollectorClient(t)
+	client.EXPECT().StreamClusterRoles(ctx, cri).
+		RunAndReturn(func(ctx context.Context, i collector.ClusterRoleIngestor) error {
+			// Fake the stream of the aggregated cluster role before its source from the collector client
+			err := i.IngestClusterRole(ctx, fakeAggregated)
+			if err != nil {
+				return err
+			}
+
+			err = i.IngestClusterRole(ctx, fakeRole)
+			if err != nil {
+				return err
+			}
+
+			return i.Complete(ctx)
+		})
+
+	// Cache setup
+	c := cache.NewCacheProvider(t)
+	cw := cache.NewAsyncWriter(t)
+	cw.EXPECT().Queue(ctx, cachekey.Role("test-reader", ""), mock.AnythingOfType("store.Role")).Return(nil).Once()
+	cw.EXPECT().Queue(ctx, cachekey.Role("test-aggregated", ""), mock.AnythingOfType("store.Role")).Return(nil).Once()
+	cw.EXPECT().Flush(ctx).Return(nil)
+	cw.EXPECT().Close(ctx).Return(nil)
+	c.EXPECT().BulkWriter(ctx).Return(cw, nil)
+
+	// Store setup
+	sdb := storedb.NewProvider(t)
+	sw := storedb.NewAsyncWriter(t)
+	roles := collections.Role{}
+	written := make(map[string]*store.Role)
+	sw.EXPECT().Queue(ctx, mock.AnythingOfType("*store.Role")).
+		RunAndReturn(func(ctx context.Context, i any) error {
+			r := i.(*store.Role)
+			written[r.Name] = r
+
+			return nil
+		}).Twice()
+	sw.EXPECT().Flush(ctx).Return(nil)
+	sw.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, roles, mock.Anything).Return(sw, nil)
+
+	deps := &Dependencies{
+		Collector: client,
+		Cache:     c,
+		StoreDB:   sdb,
+		Config: &config.KubehoundConfig{
+			Dynamic: config.DynamicConfig{
+				RunID: config.NewRunID(),
+				Cluster: config.DynamicClusterInfo{
+					Name: "test-cluster",
+				},
+			},
+		},
+	}
+
+	// Initialize
+	err = cri.Initialize(ctx, deps)
+	assert.NoError(t, err)
+
+	// Run
+	err = cri.Run(ctx)
+	assert.NoError(t, err)
+
+	// The literal rules of the aggregated role are replaced by the rules of its sources
+	assert.Len(t, written, 2)
+	assert.Equal(t, fakeRole.Rules, written["test-aggregated"].Rules)
+	assert.Equal(t, []string{"test-reader"}, written["test-aggregated"].AggregatedFrom)
+	assert.Empty(t, written["test-reader"].AggregatedFrom)
+
+	// Close
+	err = cri.Close(ctx)
+	assert.NoError(t, err)
+}
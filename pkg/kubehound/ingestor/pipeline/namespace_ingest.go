@@ -0,0 +1,84 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+)
+
+const (
+	NamespaceIngestName = "k8s-namespace-ingest"
+)
+
+type NamespaceIngest struct {
+	vertex     *vertex.Namespace
+	collection collections.Namespace
+	r          *IngestResources
+}
+
+var _ ObjectIngest = (*NamespaceIngest)(nil)
+
+func (i *NamespaceIngest) Name() string {
+	return NamespaceIngestName
+}
+
+func (i *NamespaceIngest) Initialize(ctx context.Context, deps *Dependencies) error {
+	var err error
+
+	i.vertex = &vertex.Namespace{}
+	i.collection = collections.Namespace{}
+
+	i.r, err = CreateResources(ctx, deps,
+		WithStoreWriter(i.collection),
+		WithGraphWriter(i.vertex))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IngestNamespace is invoked by the collector for each namespace collected.
+// The function ingests an input namespace into the store/graph databases asynchronously.
+func (i *NamespaceIngest) IngestNamespace(ctx context.Context, namespace types.NamespaceType) error {
+	if ok, err := preflight.CheckNamespace(namespace); !ok {
+		return err
+	}
+
+	// Normalize K8s namespace to store object format
+	o, err := i.r.storeConvert.Namespace(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	// Async write to store
+	if err := i.r.writeStore(ctx, i.collection, o); err != nil {
+		return err
+	}
+
+	// Transform store model to vertex input
+	insert, err := i.r.graphConvert.Namespace(o)
+	if err != nil {
+		return err
+	}
+
+	// Aysnc write to graph
+	return i.r.writeVertex(ctx, i.vertex, insert)
+}
+
+// Complete is invoked by the collector when all namespaces have been streamed.
+// The function flushes all writers and waits for completion.
+func (i *NamespaceIngest) Complete(ctx context.Context) error {
+	return i.r.flushWriters(ctx)
+}
+
+func (i *NamespaceIngest) Run(ctx context.Context) error {
+	return i.r.collect.StreamNamespaces(ctx, i)
+}
+
+func (i *NamespaceIngest) Close(ctx context.Context) error {
+	return i.r.cleanupAll(ctx)
+}
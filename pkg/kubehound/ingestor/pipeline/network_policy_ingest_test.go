@@ -0,0 +1,86 @@
+//nolint:forcetypeassert
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	mockcollect "github.com/DataDog/KubeHound/pkg/collector/mockcollector"
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	cache "github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/mocks"
+	storedb "github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb/mocks"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNetworkPolicyIngest_Pipeline(t *testing.T) {
+	t.Parallel()
+
+	ni := &NetworkPolicyIngest{}
+	ctx := t.Context()
+	fakePolicy, err := loadTestObject[types.NetworkPolicyType]("testdata/networkpolicy.json")
+	assert.NoError(t, err)
+
+	client := mockcollect.NewCollectorClient(t)
+	client.EXPECT().StreamNetworkPolicies(ctx, ni).
+		RunAndReturn(func(ctx context.Context, i collector.NetworkPolicyIngestor) error {
+			// Fake the stream of a single network policy from the collector client
+			err := i.IngestNetworkPolicy(ctx, fakePolicy)
+			if err != nil {
+				return err
+			}
+
+			return i.Complete(ctx)
+		})
+
+	// Cache setup
+	c := cache.NewCacheProvider(t)
+
+	// Store setup
+	sdb := storedb.NewProvider(t)
+	sw := storedb.NewAsyncWriter(t)
+	policies := collections.NetworkPolicy{}
+	storeID := store.ObjectID()
+	sw.EXPECT().Queue(ctx, mock.AnythingOfType("*store.NetworkPolicy")).
+		RunAndReturn(func(ctx context.Context, i any) error {
+			i.(*store.NetworkPolicy).Id = storeID
+
+			return nil
+		}).Once()
+	sw.EXPECT().Flush(ctx).Return(nil)
+	sw.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, policies, mock.Anything).Return(sw, nil)
+
+	deps := &Dependencies{
+		Collector: client,
+		Cache:     c,
+		StoreDB:   sdb,
+		Config: &config.KubehoundConfig{
+			Builder: config.BuilderConfig{
+				Edge: config.EdgeBuilderConfig{},
+			},
+			Dynamic: config.DynamicConfig{
+				RunID: testID,
+				Cluster: config.DynamicClusterInfo{
+					Name: "test-cluster",
+				},
+			},
+		},
+	}
+
+	// Initialize
+	err = ni.Initialize(ctx, deps)
+	assert.NoError(t, err)
+
+	// Run
+	err = ni.Run(ctx)
+	assert.NoError(t, err)
+
+	// Close
+	err = ni.Close(ctx)
+	assert.NoError(t, err)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/DataDog/KubeHound/pkg/globals/types"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
 	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
 	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
 )
@@ -15,9 +16,11 @@ const (
 )
 
 type NodeIngest struct {
-	vertex     *vertex.Node
-	collection collections.Node
-	r          *IngestResources
+	vertex              *vertex.Node
+	vertexCloudIdentity *vertex.CloudIdentity
+	collection          collections.Node
+	cloudidentity       collections.CloudIdentity
+	r                   *IngestResources
 }
 
 var _ ObjectIngest = (*NodeIngest)(nil)
@@ -30,12 +33,16 @@ func (i *NodeIngest) Initialize(ctx context.Context, deps *Dependencies) error {
 	var err error
 
 	i.vertex = &vertex.Node{}
+	i.vertexCloudIdentity = &vertex.CloudIdentity{}
 	i.collection = collections.Node{}
+	i.cloudidentity = collections.CloudIdentity{}
 
 	i.r, err = CreateResources(ctx, deps,
-		WithCacheWriter(),
+		WithCacheWriter(cache.WithTest()),
 		WithStoreWriter(i.collection),
+		WithStoreWriter(i.cloudidentity),
 		WithGraphWriter(i.vertex),
+		WithGraphWriter(i.vertexCloudIdentity),
 		WithConverterCache())
 	if err != nil {
 		return err
@@ -66,6 +73,14 @@ func (i *NodeIngest) IngestNode(ctx context.Context, node types.NodeType) error
 	if err := i.r.writeCache(ctx, cachekey.Node(o.K8.Name), o.Id.Hex()); err != nil {
 		return err
 	}
+
+	// Nodes of the same node pool share the same cloud IAM principal which is ingested once
+	for _, principal := range o.CloudIdentities {
+		if err := processCloudIdentity(ctx, i.r, i.cloudidentity, i.vertexCloudIdentity, principal); err != nil {
+			return err
+		}
+	}
+
 	// Transform store model to vertex input
 	insert, err := i.r.graphConvert.Node(o) //nolint: contextcheck
 	if err != nil {
@@ -46,7 +46,7 @@ func TestNodeIngest_Pipeline(t *testing.T) {
 	cw.EXPECT().Queue(ctx, mock.AnythingOfType("*cachekey.nodeCacheKey"), mock.AnythingOfType("string")).Return(nil).Once()
 	cw.EXPECT().Flush(ctx).Return(nil)
 	cw.EXPECT().Close(ctx).Return(nil)
-	c.EXPECT().BulkWriter(ctx).Return(cw, nil)
+	c.EXPECT().BulkWriter(ctx, mock.AnythingOfType("cache.WriterOption")).Return(cw, nil)
 	c.EXPECT().Get(ctx, cachekey.Identity("system:node:node-1", "")).Return(&cache.CacheResult{
 		Value: nil,
 		Err:   cache.ErrNoEntry,
@@ -71,10 +71,17 @@ func TestNodeIngest_Pipeline(t *testing.T) {
 	sw.EXPECT().Close(ctx).Return(nil)
 	sdb.EXPECT().BulkWriter(ctx, nodes, mock.Anything).Return(sw, nil)
 
+	// The test node does not run on a cloud provider
+	csw := storedb.NewAsyncWriter(t)
+	csw.EXPECT().Flush(ctx).Return(nil)
+	csw.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, collections.CloudIdentity{}, mock.Anything).Return(csw, nil)
+
 	// Graph setup
 	vtxInsert := map[string]any{
 		"compromised":  float64(0), // weird conversion to float by processor
 		"critical":     false,
+		"criticalRule": "",
 		"isNamespaced": false,
 		"name":         "node-1",
 		"namespace":    "",
@@ -92,6 +99,11 @@ func TestNodeIngest_Pipeline(t *testing.T) {
 	gw.EXPECT().Close(ctx).Return(nil)
 	gdb.EXPECT().VertexWriter(ctx, mock.AnythingOfType("*vertex.Node"), c, mock.AnythingOfType("graphdb.WriterOption")).Return(gw, nil)
 
+	cgw := graphdb.NewAsyncVertexWriter(t)
+	cgw.EXPECT().Flush(ctx).Return(nil)
+	cgw.EXPECT().Close(ctx).Return(nil)
+	gdb.EXPECT().VertexWriter(ctx, mock.AnythingOfType("*vertex.CloudIdentity"), c, mock.AnythingOfType("graphdb.WriterOption")).Return(cgw, nil)
+
 	deps := &Dependencies{
 		Collector: client,
 		Cache:     c,
@@ -0,0 +1,108 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+)
+
+const (
+	PersistentVolumeIngestName = "k8s-persistent-volume-ingest"
+)
+
+type PersistentVolumeIngest struct {
+	volumes collections.PersistentVolume
+	claims  collections.PersistentVolumeClaim
+	r       *IngestResources
+}
+
+var _ ObjectIngest = (*PersistentVolumeIngest)(nil)
+
+func (i *PersistentVolumeIngest) Name() string {
+	return PersistentVolumeIngestName
+}
+
+func (i *PersistentVolumeIngest) Initialize(ctx context.Context, deps *Dependencies) error {
+	var err error
+
+	i.volumes = collections.PersistentVolume{}
+	i.claims = collections.PersistentVolumeClaim{}
+	i.r, err = CreateResources(ctx, deps,
+		WithCacheWriter(),
+		WithStoreWriter(i.volumes),
+		WithStoreWriter(i.claims))
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IngestPersistentVolume is invoked by the collector for each persistent volume collected.
+// The function ingests an input persistent volume into the cache/store databases asynchronously. Persistent volumes
+// have no graph representation and are only used to resolve the backing storage of the pod volumes.
+func (i *PersistentVolumeIngest) IngestPersistentVolume(ctx context.Context, volume types.PersistentVolumeType) error {
+	if ok, err := preflight.CheckPersistentVolume(volume); !ok {
+		return err
+	}
+
+	// Normalize K8s persistent volume to store object format
+	o, err := i.r.storeConvert.PersistentVolume(ctx, volume)
+	if err != nil {
+		return err
+	}
+
+	// Async write to store
+	if err := i.r.writeStore(ctx, i.volumes, o); err != nil {
+		return err
+	}
+
+	// Async write to cache
+	return i.r.writeCache(ctx, cachekey.PersistentVolume(o.Name), *o)
+}
+
+// IngestPersistentVolumeClaim is invoked by the collector for each persistent volume claim collected.
+// The function ingests an input persistent volume claim into the cache/store databases asynchronously. The cache
+// entry allows the pod volumes referencing the claim to be resolved to the bound persistent volume.
+func (i *PersistentVolumeIngest) IngestPersistentVolumeClaim(ctx context.Context, claim types.PersistentVolumeClaimType) error {
+	if ok, err := preflight.CheckPersistentVolumeClaim(claim); !ok {
+		return err
+	}
+
+	// Normalize K8s persistent volume claim to store object format
+	o, err := i.r.storeConvert.PersistentVolumeClaim(ctx, claim)
+	if err != nil {
+		return err
+	}
+
+	// Async write to store
+	if err := i.r.writeStore(ctx, i.claims, o); err != nil {
+		return err
+	}
+
+	// Unbound claims cannot be resolved to a persistent volume
+	if o.VolumeName == "" {
+		return nil
+	}
+
+	// Async write to cache
+	return i.r.writeCache(ctx, cachekey.PersistentVolumeClaim(o.Name, o.Namespace), o.VolumeName)
+}
+
+// Complete is invoked by the collector when all persistent volumes and claims have been streamed.
+// The function flushes all writers and waits for completion.
+func (i *PersistentVolumeIngest) Complete(ctx context.Context) error {
+	return i.r.flushWriters(ctx)
+}
+
+func (i *PersistentVolumeIngest) Run(ctx context.Context) error {
+	return i.r.collect.StreamPersistentVolumes(ctx, i)
+}
+
+func (i *PersistentVolumeIngest) Close(ctx context.Context) error {
+	return i.r.cleanupAll(ctx)
+}
@@ -0,0 +1,115 @@
+//nolint:forcetypeassert
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	mockcollect "github.com/DataDog/KubeHound/pkg/collector/mockcollector"
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	cache "github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/mocks"
+	storedb "github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb/mocks"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPersistentVolumeIngest_Pipeline(t *testing.T) {
+	t.Parallel()
+
+	pi := &PersistentVolumeIngest{}
+	ctx := t.Context()
+	fakeVolume, err := loadTestObject[types.PersistentVolumeType]("testdata/persistentvolume.json")
+	assert.NoError(t, err)
+	fakeClaim, err := loadTestObject[types.PersistentVolumeClaimType]("testdata/persistentvolumeclaim.json")
+	assert.NoError(t, err)
+
+	client := mockcollect.NewCollectorClient(t)
+	client.EXPECT().StreamPersistentVolumes(ctx, pi).
+		RunAndReturn(func(ctx context.Context, i collector.PersistentVolumeIngestor) error {
+			// Fake the stream of a single persistent volume and its claim from the collector client
+			err := i.IngestPersistentVolume(ctx, fakeVolume)
+			if err != nil {
+				return err
+			}
+
+			err = i.IngestPersistentVolumeClaim(ctx, fakeClaim)
+			if err != nil {
+				return err
+			}
+
+			return i.Complete(ctx)
+		})
+
+	// Cache setup
+	c := cache.NewCacheProvider(t)
+	cw := cache.NewAsyncWriter(t)
+
+	cw.EXPECT().Queue(ctx, cachekey.PersistentVolume("test-app-data"), mock.MatchedBy(func(pv store.PersistentVolume) bool {
+		return pv.Type == shared.VolumeTypeHost && pv.SourcePath == "/var/lib/test-app"
+	})).Return(nil).Once()
+	cw.EXPECT().Queue(ctx, cachekey.PersistentVolumeClaim("test-app-data", "test-app"), "test-app-data").Return(nil).Once()
+	cw.EXPECT().Flush(ctx).Return(nil)
+	cw.EXPECT().Close(ctx).Return(nil)
+	c.EXPECT().BulkWriter(ctx).Return(cw, nil)
+
+	// Store setup
+	sdb := storedb.NewProvider(t)
+	swv := storedb.NewAsyncWriter(t)
+	volumes := collections.PersistentVolume{}
+	swv.EXPECT().Queue(ctx, mock.AnythingOfType("*store.PersistentVolume")).
+		RunAndReturn(func(ctx context.Context, i any) error {
+			i.(*store.PersistentVolume).Id = store.ObjectID()
+
+			return nil
+		}).Once()
+	swv.EXPECT().Flush(ctx).Return(nil)
+	swv.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, volumes, mock.Anything).Return(swv, nil)
+
+	swc := storedb.NewAsyncWriter(t)
+	claims := collections.PersistentVolumeClaim{}
+	swc.EXPECT().Queue(ctx, mock.AnythingOfType("*store.PersistentVolumeClaim")).
+		RunAndReturn(func(ctx context.Context, i any) error {
+			i.(*store.PersistentVolumeClaim).Id = store.ObjectID()
+
+			return nil
+		}).Once()
+	swc.EXPECT().Flush(ctx).Return(nil)
+	swc.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, claims, mock.Anything).Return(swc, nil)
+
+	deps := &Dependencies{
+		Collector: client,
+		Cache:     c,
+		StoreDB:   sdb,
+		Config: &config.KubehoundConfig{
+			Builder: config.BuilderConfig{
+				Edge: config.EdgeBuilderConfig{},
+			},
+			Dynamic: config.DynamicConfig{
+				RunID: testID,
+				Cluster: config.DynamicClusterInfo{
+					Name: "test-cluster",
+				},
+			},
+		},
+	}
+
+	// Initialize
+	err = pi.Initialize(ctx, deps)
+	assert.NoError(t, err)
+
+	// Run
+	err = pi.Run(ctx)
+	assert.NoError(t, err)
+
+	// Close
+	err = pi.Close(ctx)
+	assert.NoError(t, err)
+}
@@ -142,6 +142,42 @@ func (i *PodIngest) processContainer(ctx context.Context, parent *store.Pod, con
 		return err
 	}
 
+	return i.ingestContainer(ctx, parent, sc)
+}
+
+// processInitContainer will handle the ingestion pipeline for an init container belonging to a processed K8s pod input.
+func (i *PodIngest) processInitContainer(ctx context.Context, parent *store.Pod, container types.ContainerType) error {
+	if ok, err := preflight.CheckContainer(container); !ok {
+		return err
+	}
+
+	// Normalize init container to store object format
+	sc, err := i.r.storeConvert.InitContainer(ctx, container, parent)
+	if err != nil {
+		return err
+	}
+
+	return i.ingestContainer(ctx, parent, sc)
+}
+
+// processEphemeralContainer will handle the ingestion pipeline for an ephemeral container (e.g injected via kubectl debug)
+// belonging to a processed K8s pod input.
+func (i *PodIngest) processEphemeralContainer(ctx context.Context, parent *store.Pod, container types.EphemeralContainerType) error {
+	if ok, err := preflight.CheckEphemeralContainer(container); !ok {
+		return err
+	}
+
+	// Normalize ephemeral container to store object format
+	sc, err := i.r.storeConvert.EphemeralContainer(ctx, container, parent)
+	if err != nil {
+		return err
+	}
+
+	return i.ingestContainer(ctx, parent, sc)
+}
+
+// ingestContainer writes a normalized container to the cache/store/graph and processes its volume mounts and endpoints.
+func (i *PodIngest) ingestContainer(ctx context.Context, parent *store.Pod, sc *store.Container) error {
 	// Async write to store
 	if err := i.r.writeStore(ctx, i.c[containerIndex], sc); err != nil {
 		return err
@@ -165,7 +201,7 @@ func (i *PodIngest) processContainer(ctx context.Context, parent *store.Pod, con
 	}
 
 	// Handle volume mounts
-	for _, volumeMount := range container.VolumeMounts {
+	for _, volumeMount := range sc.K8.VolumeMounts {
 		vm := volumeMount
 		err := i.processVolumeMount(ctx, &vm, parent, sc)
 		if err != nil {
@@ -174,7 +210,7 @@ func (i *PodIngest) processContainer(ctx context.Context, parent *store.Pod, con
 	}
 
 	// Handle endpoints (derived from container ports)
-	for _, port := range container.Ports {
+	for _, port := range sc.K8.Ports {
 		p := port
 		err := i.processEndpoints(ctx, &p, parent, sc)
 		if err != nil {
@@ -256,9 +292,25 @@ func (i *PodIngest) IngestPod(ctx context.Context, pod types.PodType) error {
 		}
 	}
 
-	// Currently do not process init containers. Any interesting identity they are running under will be the same as the container since
-	// service accounts are defined at a pod level (although this may change in future K8s releases). Any interesting properties of the
-	// container will be ephemeral, making any exploitation very complex. Thus we do not include init containers within our graph.
+	// Handle init containers. These commonly run with elevated privileges (e.g sysctl tweaks, CNI installers, volume permission fixes)
+	// and are re-run on every pod restart, so they are included in the graph alongside the regular containers.
+	for _, container := range pod.Spec.InitContainers {
+		c := container
+		err := i.processInitContainer(ctx, sp, &c)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Handle ephemeral containers. These are injected in running pods (e.g via kubectl debug) with an arbitrary image
+	// and security context and remain in the pod spec after they terminate.
+	for _, container := range pod.Spec.EphemeralContainers {
+		c := container
+		err := i.processEphemeralContainer(ctx, sp, &c)
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -52,6 +52,10 @@ func TestPodIngest_Pipeline(t *testing.T) {
 		Value: store.ObjectID().Hex(),
 		Err:   nil,
 	})
+	c.EXPECT().Get(ctx, mock.AnythingOfType("*cachekey.automountCacheKey")).Return(&cache.CacheResult{
+		Value: true,
+		Err:   nil,
+	})
 	c.EXPECT().Get(ctx, mock.AnythingOfType("*cachekey.identityCacheKey")).Return(&cache.CacheResult{
 		Value: store.ObjectID().Hex(),
 		Err:   nil,
@@ -125,6 +129,7 @@ func TestPodIngest_Pipeline(t *testing.T) {
 	pv := map[string]any{
 		"compromised":           float64(0),
 		"critical":              false,
+		"criticalRule":          "",
 		"isNamespaced":          true,
 		"name":                  "app-monitors-client-78cb6d7899-j2rjp",
 		"namespace":             "test-app",
@@ -159,6 +164,8 @@ func TestPodIngest_Pipeline(t *testing.T) {
 		"image":        "dockerhub.com/elasticsearch:latest",
 		"name":         "elasticsearch",
 		"node":         "test-node.ec2.internal",
+		"init":         false,
+		"ephemeral":    false,
 		"pod":          "app-monitors-client-78cb6d7899-j2rjp",
 		"ports":        []any{"9200"},
 		"privesc":      false,
@@ -191,6 +198,9 @@ func TestPodIngest_Pipeline(t *testing.T) {
 		"service":      "test-service",
 		"type":         "Projected",
 		"readonly":     true,
+		"mountReason":  "",
+		"claimName":    "",
+		"backingType":  "",
 		"cluster":      "test-cluster",
 		"runID":        testID.String(),
 	}
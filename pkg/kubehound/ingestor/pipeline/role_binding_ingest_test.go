@@ -104,17 +104,21 @@ func TestRoleBindingIngest_Pipeline(t *testing.T) {
 
 	// Graph setup
 	vtxInsert := map[string]any{
-		"isNamespaced": true,
-		"critical":     false,
-		"name":         "app-monitors",
-		"namespace":    "test-app",
-		"storeID":      storeID.Hex(),
-		"type":         "ServiceAccount",
-		"team":         "test-team",
-		"app":          "test-app",
-		"service":      "test-service",
-		"cluster":      "test-cluster",
-		"runID":        testID.String(),
+		"isNamespaced":     true,
+		"automount":        true,
+		"imagePullSecrets": []interface{}{},
+		"tokenSecrets":     []interface{}{},
+		"critical":         false,
+		"criticalRule":     "",
+		"name":             "app-monitors",
+		"namespace":        "test-app",
+		"storeID":          storeID.Hex(),
+		"type":             "ServiceAccount",
+		"team":             "test-team",
+		"app":              "test-app",
+		"service":          "test-service",
+		"cluster":          "test-cluster",
+		"runID":            testID.String(),
 	}
 	gdb := graphdb.NewProvider(t)
 	gw := graphdb.NewAsyncVertexWriter(t)
@@ -124,19 +128,21 @@ func TestRoleBindingIngest_Pipeline(t *testing.T) {
 	gdb.EXPECT().VertexWriter(ctx, mock.AnythingOfType("*vertex.Identity"), c, mock.AnythingOfType("graphdb.WriterOption")).Return(gw, nil)
 
 	psVtxInsert := map[string]any{
-		"isNamespaced": true,
-		"critical":     false,
-		"name":         "test-reader::app-monitors-read",
-		"role":         "test-reader",
-		"roleBinding":  "app-monitors-read",
-		"namespace":    "test-app",
-		"storeID":      psStoreID.Hex(),
-		"team":         "test-team",
-		"app":          "test-app",
-		"service":      "test-service",
-		"cluster":      "test-cluster",
-		"runID":        testID.String(),
-		"rules":        []interface{}{"API()::R(pods)::N()::V(get,list)", "API()::R(configmaps)::N()::V(get)", "API(apps)::R(statefulsets)::N()::V(get,list)"},
+		"isNamespaced":   true,
+		"critical":       false,
+		"criticalRule":   "",
+		"name":           "test-reader::app-monitors-read",
+		"role":           "test-reader",
+		"roleBinding":    "app-monitors-read",
+		"namespace":      "test-app",
+		"storeID":        psStoreID.Hex(),
+		"team":           "test-team",
+		"app":            "test-app",
+		"service":        "test-service",
+		"cluster":        "test-cluster",
+		"runID":          testID.String(),
+		"rules":          []interface{}{"API()::R(pods)::N()::V(get,list)", "API()::R(configmaps)::N()::V(get)", "API(apps)::R(statefulsets)::N()::V(get,list)"},
+		"aggregatedFrom": []interface{}{},
 	}
 
 	psgw := graphdb.NewAsyncVertexWriter(t)
@@ -0,0 +1,84 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+)
+
+const (
+	SecretIngestName = "k8s-secret-ingest"
+)
+
+type SecretIngest struct {
+	vertex     *vertex.Secret
+	collection collections.Secret
+	r          *IngestResources
+}
+
+var _ ObjectIngest = (*SecretIngest)(nil)
+
+func (i *SecretIngest) Name() string {
+	return SecretIngestName
+}
+
+func (i *SecretIngest) Initialize(ctx context.Context, deps *Dependencies) error {
+	var err error
+
+	i.vertex = &vertex.Secret{}
+	i.collection = collections.Secret{}
+
+	i.r, err = CreateResources(ctx, deps,
+		WithStoreWriter(i.collection),
+		WithGraphWriter(i.vertex))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IngestSecret is invoked by the collector for each secret collected.
+// The function ingests an input secret (metadata only) into the store/graph databases asynchronously.
+func (i *SecretIngest) IngestSecret(ctx context.Context, secret types.SecretType) error {
+	if ok, err := preflight.CheckSecret(secret); !ok {
+		return err
+	}
+
+	// Normalize secret to store object format
+	o, err := i.r.storeConvert.Secret(ctx, secret)
+	if err != nil {
+		return err
+	}
+
+	// Async write to store
+	if err := i.r.writeStore(ctx, i.collection, o); err != nil {
+		return err
+	}
+
+	// Transform store model to vertex input
+	insert, err := i.r.graphConvert.Secret(o)
+	if err != nil {
+		return err
+	}
+
+	// Aysnc write to graph
+	return i.r.writeVertex(ctx, i.vertex, insert)
+}
+
+// Complete is invoked by the collector when all secrets have been streamed.
+// The function flushes all writers and waits for completion.
+func (i *SecretIngest) Complete(ctx context.Context) error {
+	return i.r.flushWriters(ctx)
+}
+
+func (i *SecretIngest) Run(ctx context.Context) error {
+	return i.r.collect.StreamSecrets(ctx, i)
+}
+
+func (i *SecretIngest) Close(ctx context.Context) error {
+	return i.r.cleanupAll(ctx)
+}
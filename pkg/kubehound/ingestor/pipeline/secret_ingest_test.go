@@ -0,0 +1,112 @@
+//nolint:forcetypeassert
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	mockcollect "github.com/DataDog/KubeHound/pkg/collector/mockcollector"
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	mockcache "github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/mocks"
+	graphdb "github.com/DataDog/KubeHound/pkg/kubehound/storage/graphdb/mocks"
+	storedb "github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb/mocks"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSecretIngest_Pipeline(t *testing.T) {
+	t.Parallel()
+
+	si := &SecretIngest{}
+
+	ctx := t.Context()
+	fakeSecret, err := loadTestObject[types.SecretType]("testdata/secret.json")
+	assert.NoError(t, err)
+
+	client := mockcollect.NewCollectorClient(t)
+	client.EXPECT().StreamSecrets(ctx, si).
+		RunAndReturn(func(ctx context.Context, i collector.SecretIngestor) error {
+			// Fake the stream of a single secret from the collector client
+			err := i.IngestSecret(ctx, fakeSecret)
+			if err != nil {
+				return err
+			}
+
+			return i.Complete(ctx)
+		})
+
+	// Cache setup
+	c := mockcache.NewCacheProvider(t)
+
+	// Store setup
+	sdb := storedb.NewProvider(t)
+	sw := storedb.NewAsyncWriter(t)
+	secrets := collections.Secret{}
+	storeID := store.ObjectID()
+	sw.EXPECT().Queue(ctx, mock.AnythingOfType("*store.Secret")).
+		RunAndReturn(func(ctx context.Context, i any) error {
+			i.(*store.Secret).Id = storeID
+
+			return nil
+		}).Once()
+
+	sw.EXPECT().Flush(ctx).Return(nil)
+	sw.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, secrets, mock.Anything).Return(sw, nil)
+
+	// Graph setup
+	vtx := map[string]interface{}{
+		"app":            "test-app",
+		"cluster":        "test-cluster",
+		"isNamespaced":   true,
+		"name":           "app-monitors-token-7pkhv",
+		"namespace":      "test-app",
+		"runID":          testID.String(),
+		"service":        "test-service",
+		"serviceAccount": "app-monitors",
+		"storeID":        storeID.Hex(),
+		"team":           "test-team",
+		"type":           "kubernetes.io/service-account-token",
+	}
+
+	gdb := graphdb.NewProvider(t)
+	gw := graphdb.NewAsyncVertexWriter(t)
+	gw.EXPECT().Queue(ctx, vtx).Return(nil).Once()
+	gw.EXPECT().Flush(ctx).Return(nil)
+	gw.EXPECT().Close(ctx).Return(nil)
+	gdb.EXPECT().VertexWriter(ctx, mock.AnythingOfType("*vertex.Secret"), c, mock.AnythingOfType("graphdb.WriterOption")).Return(gw, nil)
+
+	deps := &Dependencies{
+		Collector: client,
+		Cache:     c,
+		GraphDB:   gdb,
+		StoreDB:   sdb,
+		Config: &config.KubehoundConfig{
+			Builder: config.BuilderConfig{
+				Edge: config.EdgeBuilderConfig{},
+			},
+			Dynamic: config.DynamicConfig{
+				RunID: testID,
+				Cluster: config.DynamicClusterInfo{
+					Name: "test-cluster",
+				},
+			},
+		},
+	}
+
+	// Initialize
+	err = si.Initialize(ctx, deps)
+	assert.NoError(t, err)
+
+	// Run
+	err = si.Run(ctx)
+	assert.NoError(t, err)
+
+	// Close
+	err = si.Close(ctx)
+	assert.NoError(t, err)
+}
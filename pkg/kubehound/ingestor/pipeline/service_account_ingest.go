@@ -0,0 +1,137 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"github.com/DataDog/KubeHound/pkg/telemetry/log"
+)
+
+const (
+	ServiceAccountIngestName = "k8s-service-account-ingest"
+)
+
+type ServiceAccountIngest struct {
+	vertexIdentity      *vertex.Identity
+	vertexCloudIdentity *vertex.CloudIdentity
+	serviceaccount      collections.ServiceAccount
+	identity            collections.Identity
+	cloudidentity       collections.CloudIdentity
+	r                   *IngestResources
+}
+
+var _ ObjectIngest = (*ServiceAccountIngest)(nil)
+
+func (i *ServiceAccountIngest) Name() string {
+	return ServiceAccountIngestName
+}
+
+func (i *ServiceAccountIngest) Initialize(ctx context.Context, deps *Dependencies) error {
+	var err error
+
+	i.vertexIdentity = &vertex.Identity{}
+	i.vertexCloudIdentity = &vertex.CloudIdentity{}
+	i.serviceaccount = collections.ServiceAccount{}
+	i.identity = collections.Identity{}
+	i.cloudidentity = collections.CloudIdentity{}
+
+	i.r, err = CreateResources(ctx, deps,
+		WithCacheWriter(cache.WithTest()),
+		WithStoreWriter(i.serviceaccount),
+		WithStoreWriter(i.identity),
+		WithStoreWriter(i.cloudidentity),
+		WithGraphWriter(i.vertexIdentity),
+		WithGraphWriter(i.vertexCloudIdentity))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IngestServiceAccount is invoked by the collector for each service account collected.
+// The function ingests an input service account into the store and creates the corresponding identity in the
+// cache/store/graph. Service accounts are ingested ahead of the role bindings so that the identities created
+// from the role binding subjects reuse the identity (and its service account properties) created here.
+func (i *ServiceAccountIngest) IngestServiceAccount(ctx context.Context, sa types.ServiceAccountType) error {
+	if ok, err := preflight.CheckServiceAccount(sa); !ok {
+		return err
+	}
+
+	// Normalize K8s service account to store object format
+	o, err := i.r.storeConvert.ServiceAccount(ctx, sa)
+	if err != nil {
+		return err
+	}
+
+	// Async write service account to store
+	if err := i.r.writeStore(ctx, i.serviceaccount, o); err != nil {
+		return err
+	}
+
+	// Async write the automount setting to cache for use when processing the pod volumes
+	if err := i.r.writeCache(ctx, cachekey.Automount(o.Name, o.Namespace), o.Automount); err != nil {
+		return err
+	}
+
+	// Cloud IAM principals granted via workload identity are shared across service accounts and ingested once
+	for _, principal := range o.CloudIdentities {
+		if err := processCloudIdentity(ctx, i.r, i.cloudidentity, i.vertexCloudIdentity, principal); err != nil {
+			return err
+		}
+	}
+
+	// Normalize store service account to store identity object format
+	sid, err := i.r.storeConvert.IdentityServiceAccount(ctx, o)
+	if err != nil {
+		return err
+	}
+
+	// Async write to cache. If entry is already present skip further processing.
+	ck := cachekey.Identity(sid.Name, sid.Namespace)
+	err = i.r.writeCache(ctx, ck, sid.Id.Hex())
+	if err != nil {
+		var errOverwrite *cache.OverwriteError
+		if errors.As(err, &errOverwrite) {
+			log.Trace(ctx).Debugf("identity cache entry %#v already exists, skipping inserts", ck)
+
+			return nil
+		}
+
+		return err
+	}
+
+	// Async write identity to store
+	if err := i.r.writeStore(ctx, i.identity, sid); err != nil {
+		return err
+	}
+
+	// Transform store model to vertex input
+	insert, err := i.r.graphConvert.Identity(sid) //nolint: contextcheck
+	if err != nil {
+		return err
+	}
+
+	// Aysnc write to graph
+	return i.r.writeVertex(ctx, i.vertexIdentity, insert)
+}
+
+// Complete is invoked by the collector when all service accounts have been streamed.
+// The function flushes all writers and waits for completion.
+func (i *ServiceAccountIngest) Complete(ctx context.Context) error {
+	return i.r.flushWriters(ctx)
+}
+
+func (i *ServiceAccountIngest) Run(ctx context.Context) error {
+	return i.r.collect.StreamServiceAccounts(ctx, i)
+}
+
+func (i *ServiceAccountIngest) Close(ctx context.Context) error {
+	return i.r.cleanupAll(ctx)
+}
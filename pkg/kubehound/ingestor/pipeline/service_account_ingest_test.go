@@ -0,0 +1,162 @@
+//nolint:forcetypeassert
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	mockcollect "github.com/DataDog/KubeHound/pkg/collector/mockcollector"
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	mockcache "github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/mocks"
+	graphdb "github.com/DataDog/KubeHound/pkg/kubehound/storage/graphdb/mocks"
+	storedb "github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb/mocks"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestServiceAccountIngest_Pipeline(t *testing.T) {
+	t.Parallel()
+
+	si := &ServiceAccountIngest{}
+
+	ctx := t.Context()
+	fakeSa, err := loadTestObject[types.ServiceAccountType]("testdata/serviceaccount.json")
+	assert.NoError(t, err)
+
+	client := mockcollect.NewCollectorClient(t)
+	client.EXPECT().StreamServiceAccounts(ctx, si).
+		RunAndReturn(func(ctx context.Context, i collector.ServiceAccountIngestor) error {
+			// Fake the stream of a single service account from the collector client
+			err := i.IngestServiceAccount(ctx, fakeSa)
+			if err != nil {
+				return err
+			}
+
+			return i.Complete(ctx)
+		})
+
+	// Cache setup
+	c := mockcache.NewCacheProvider(t)
+	cw := mockcache.NewAsyncWriter(t)
+	cw.EXPECT().Queue(ctx, cachekey.Automount("app-monitors", "test-app"), false).Return(nil).Once()
+	cw.EXPECT().Queue(ctx, cachekey.Identity("app-monitors", "test-app"), mock.AnythingOfType("string")).Return(nil).Once()
+	cw.EXPECT().Queue(ctx, cachekey.CloudIdentity("AWS", "arn:aws:iam::123456789012:role/app-monitors"), mock.AnythingOfType("string")).Return(nil).Once()
+	cw.EXPECT().Flush(ctx).Return(nil)
+	cw.EXPECT().Close(ctx).Return(nil)
+	c.EXPECT().BulkWriter(ctx, mock.AnythingOfType("cache.WriterOption")).Return(cw, nil)
+
+	// Store setup - service accounts
+	sdb := storedb.NewProvider(t)
+	ssw := storedb.NewAsyncWriter(t)
+	ssw.EXPECT().Queue(ctx, mock.AnythingOfType("*store.ServiceAccount")).Return(nil).Once()
+	ssw.EXPECT().Flush(ctx).Return(nil)
+	ssw.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, collections.ServiceAccount{}, mock.Anything).Return(ssw, nil)
+
+	// Store setup - identities
+	isw := storedb.NewAsyncWriter(t)
+	storeID := store.ObjectID()
+	isw.EXPECT().Queue(ctx, mock.AnythingOfType("*store.Identity")).
+		RunAndReturn(func(ctx context.Context, i any) error {
+			i.(*store.Identity).Id = storeID
+
+			return nil
+		}).Once()
+	isw.EXPECT().Flush(ctx).Return(nil)
+	isw.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, collections.Identity{}, mock.Anything).Return(isw, nil)
+
+	// Store setup - cloud identities
+	csw := storedb.NewAsyncWriter(t)
+	cloudStoreID := store.ObjectID()
+	csw.EXPECT().Queue(ctx, mock.AnythingOfType("*store.CloudIdentity")).
+		RunAndReturn(func(ctx context.Context, i any) error {
+			i.(*store.CloudIdentity).Id = cloudStoreID
+
+			return nil
+		}).Once()
+	csw.EXPECT().Flush(ctx).Return(nil)
+	csw.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, collections.CloudIdentity{}, mock.Anything).Return(csw, nil)
+
+	// Graph setup
+	vtx := map[string]interface{}{
+		"app":              "test-app",
+		"automount":        false,
+		"cluster":          "test-cluster",
+		"critical":         false,
+		"criticalRule":     "",
+		"imagePullSecrets": []interface{}{"registry-credentials"},
+		"isNamespaced":     true,
+		"name":             "app-monitors",
+		"namespace":        "test-app",
+		"runID":            testID.String(),
+		"service":          "test-service",
+		"storeID":          storeID.Hex(),
+		"team":             "test-team",
+		"tokenSecrets":     []interface{}{"app-monitors-token-7pkhv"},
+		"type":             "ServiceAccount",
+	}
+
+	gdb := graphdb.NewProvider(t)
+	gw := graphdb.NewAsyncVertexWriter(t)
+	gw.EXPECT().Queue(ctx, vtx).Return(nil).Once()
+	gw.EXPECT().Flush(ctx).Return(nil)
+	gw.EXPECT().Close(ctx).Return(nil)
+	gdb.EXPECT().VertexWriter(ctx, mock.AnythingOfType("*vertex.Identity"), c, mock.AnythingOfType("graphdb.WriterOption")).Return(gw, nil)
+
+	cloudVtx := map[string]interface{}{
+		"account":      "123456789012",
+		"app":          "",
+		"cluster":      "test-cluster",
+		"isNamespaced": false,
+		"name":         "arn:aws:iam::123456789012:role/app-monitors",
+		"provider":     "AWS",
+		"runID":        testID.String(),
+		"service":      "",
+		"storeID":      cloudStoreID.Hex(),
+		"team":         "",
+		"type":         "Role",
+	}
+
+	cgw := graphdb.NewAsyncVertexWriter(t)
+	cgw.EXPECT().Queue(ctx, cloudVtx).Return(nil).Once()
+	cgw.EXPECT().Flush(ctx).Return(nil)
+	cgw.EXPECT().Close(ctx).Return(nil)
+	gdb.EXPECT().VertexWriter(ctx, mock.AnythingOfType("*vertex.CloudIdentity"), c, mock.AnythingOfType("graphdb.WriterOption")).Return(cgw, nil)
+
+	deps := &Dependencies{
+		Collector: client,
+		Cache:     c,
+		GraphDB:   gdb,
+		StoreDB:   sdb,
+		Config: &config.KubehoundConfig{
+			Builder: config.BuilderConfig{
+				Edge: config.EdgeBuilderConfig{},
+			},
+			Dynamic: config.DynamicConfig{
+				RunID: testID,
+				Cluster: config.DynamicClusterInfo{
+					Name: "test-cluster",
+				},
+			},
+		},
+	}
+
+	// Initialize
+	err = si.Initialize(ctx, deps)
+	assert.NoError(t, err)
+
+	// Run
+	err = si.Run(ctx)
+	assert.NoError(t, err)
+
+	// Close
+	err = si.Close(ctx)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,116 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+)
+
+const (
+	WebhookIngestName = "k8s-webhook-ingest"
+)
+
+type WebhookIngest struct {
+	vertex     *vertex.Webhook
+	collection collections.Webhook
+	r          *IngestResources
+}
+
+var _ ObjectIngest = (*WebhookIngest)(nil)
+
+func (i *WebhookIngest) Name() string {
+	return WebhookIngestName
+}
+
+func (i *WebhookIngest) Initialize(ctx context.Context, deps *Dependencies) error {
+	var err error
+
+	i.vertex = &vertex.Webhook{}
+	i.collection = collections.Webhook{}
+
+	i.r, err = CreateResources(ctx, deps,
+		WithStoreWriter(i.collection),
+		WithGraphWriter(i.vertex))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ingestWebhook ingests a normalized admission webhook into the store/graph databases asynchronously.
+func (i *WebhookIngest) ingestWebhook(ctx context.Context, o *store.Webhook) error {
+	// Async write to store
+	if err := i.r.writeStore(ctx, i.collection, o); err != nil {
+		return err
+	}
+
+	// Transform store model to vertex input
+	insert, err := i.r.graphConvert.Webhook(o)
+	if err != nil {
+		return err
+	}
+
+	// Aysnc write to graph
+	return i.r.writeVertex(ctx, i.vertex, insert)
+}
+
+// IngestMutatingWebhookConfiguration is invoked by the collector for each mutating webhook configuration collected.
+// Each webhook of the configuration is ingested as a separate entity.
+func (i *WebhookIngest) IngestMutatingWebhookConfiguration(ctx context.Context, config types.MutatingWebhookConfigurationType) error {
+	if ok, err := preflight.CheckMutatingWebhookConfiguration(config); !ok {
+		return err
+	}
+
+	for idx := range config.Webhooks {
+		o, err := i.r.storeConvert.MutatingWebhook(ctx, &config.Webhooks[idx], config)
+		if err != nil {
+			return err
+		}
+
+		if err := i.ingestWebhook(ctx, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IngestValidatingWebhookConfiguration is invoked by the collector for each validating webhook configuration collected.
+// Each webhook of the configuration is ingested as a separate entity.
+func (i *WebhookIngest) IngestValidatingWebhookConfiguration(ctx context.Context, config types.ValidatingWebhookConfigurationType) error {
+	if ok, err := preflight.CheckValidatingWebhookConfiguration(config); !ok {
+		return err
+	}
+
+	for idx := range config.Webhooks {
+		o, err := i.r.storeConvert.ValidatingWebhook(ctx, &config.Webhooks[idx], config)
+		if err != nil {
+			return err
+		}
+
+		if err := i.ingestWebhook(ctx, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Complete is invoked by the collector when all webhook configurations have been streamed.
+// The function flushes all writers and waits for completion.
+func (i *WebhookIngest) Complete(ctx context.Context) error {
+	return i.r.flushWriters(ctx)
+}
+
+func (i *WebhookIngest) Run(ctx context.Context) error {
+	return i.r.collect.StreamWebhooks(ctx, i)
+}
+
+func (i *WebhookIngest) Close(ctx context.Context) error {
+	return i.r.cleanupAll(ctx)
+}
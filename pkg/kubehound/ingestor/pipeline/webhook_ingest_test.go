@@ -0,0 +1,117 @@
+//nolint:forcetypeassert
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	mockcollect "github.com/DataDog/KubeHound/pkg/collector/mockcollector"
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	mockcache "github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/mocks"
+	graphdb "github.com/DataDog/KubeHound/pkg/kubehound/storage/graphdb/mocks"
+	storedb "github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb/mocks"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookIngest_Pipeline(t *testing.T) {
+	t.Parallel()
+
+	wi := &WebhookIngest{}
+
+	ctx := t.Context()
+	fakeConfig, err := loadTestObject[types.MutatingWebhookConfigurationType]("testdata/mutatingwebhookconfiguration.json")
+	assert.NoError(t, err)
+
+	client := mockcollect.NewCollectorClient(t)
+	client.EXPECT().StreamWebhooks(ctx, wi).
+		RunAndReturn(func(ctx context.Context, i collector.WebhookIngestor) error {
+			// Fake the stream of a single mutating webhook configuration from the collector client
+			err := i.IngestMutatingWebhookConfiguration(ctx, fakeConfig)
+			if err != nil {
+				return err
+			}
+
+			return i.Complete(ctx)
+		})
+
+	// Cache setup
+	c := mockcache.NewCacheProvider(t)
+
+	// Store setup
+	sdb := storedb.NewProvider(t)
+	sw := storedb.NewAsyncWriter(t)
+	webhooks := collections.Webhook{}
+	storeID := store.ObjectID()
+	sw.EXPECT().Queue(ctx, mock.AnythingOfType("*store.Webhook")).
+		RunAndReturn(func(ctx context.Context, i any) error {
+			i.(*store.Webhook).Id = storeID
+
+			return nil
+		}).Once()
+
+	sw.EXPECT().Flush(ctx).Return(nil)
+	sw.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, webhooks, mock.Anything).Return(sw, nil)
+
+	// Graph setup
+	vtx := map[string]interface{}{
+		"app":              "test-app",
+		"cluster":          "test-cluster",
+		"configuration":    "app-injector",
+		"failurePolicy":    "Ignore",
+		"isNamespaced":     false,
+		"name":             "inject.app.example.com",
+		"operations":       []interface{}{"CREATE", "UPDATE"},
+		"resources":        []interface{}{"pods", "pods/ephemeralcontainers", "deployments"},
+		"runID":            testID.String(),
+		"service":          "test-service",
+		"serviceName":      "app-injector",
+		"serviceNamespace": "test-app",
+		"storeID":          storeID.Hex(),
+		"team":             "test-team",
+		"type":             "Mutating",
+		"url":              "",
+	}
+
+	gdb := graphdb.NewProvider(t)
+	gw := graphdb.NewAsyncVertexWriter(t)
+	gw.EXPECT().Queue(ctx, vtx).Return(nil).Once()
+	gw.EXPECT().Flush(ctx).Return(nil)
+	gw.EXPECT().Close(ctx).Return(nil)
+	gdb.EXPECT().VertexWriter(ctx, mock.AnythingOfType("*vertex.Webhook"), c, mock.AnythingOfType("graphdb.WriterOption")).Return(gw, nil)
+
+	deps := &Dependencies{
+		Collector: client,
+		Cache:     c,
+		GraphDB:   gdb,
+		StoreDB:   sdb,
+		Config: &config.KubehoundConfig{
+			Builder: config.BuilderConfig{
+				Edge: config.EdgeBuilderConfig{},
+			},
+			Dynamic: config.DynamicConfig{
+				RunID: testID,
+				Cluster: config.DynamicClusterInfo{
+					Name: "test-cluster",
+				},
+			},
+		},
+	}
+
+	// Initialize
+	err = wi.Initialize(ctx, deps)
+	assert.NoError(t, err)
+
+	// Run
+	err = wi.Run(ctx)
+	assert.NoError(t, err)
+
+	// Close
+	err = wi.Close(ctx)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,152 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/ingestor/preflight"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+)
+
+const (
+	WorkloadIngestName = "k8s-workload-ingest"
+)
+
+type WorkloadIngest struct {
+	vertex     *vertex.Workload
+	collection collections.Workload
+	r          *IngestResources
+}
+
+var _ ObjectIngest = (*WorkloadIngest)(nil)
+
+func (i *WorkloadIngest) Name() string {
+	return WorkloadIngestName
+}
+
+func (i *WorkloadIngest) Initialize(ctx context.Context, deps *Dependencies) error {
+	var err error
+
+	i.vertex = &vertex.Workload{}
+	i.collection = collections.Workload{}
+
+	i.r, err = CreateResources(ctx, deps,
+		WithCacheWriter(),
+		WithStoreWriter(i.collection),
+		WithGraphWriter(i.vertex))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ingestWorkload ingests a normalized workload controller into the cache/store/graph databases asynchronously.
+// The cache entry allows the pods managed by the workload to be linked back to it.
+func (i *WorkloadIngest) ingestWorkload(ctx context.Context, o *store.Workload) error {
+	// Async write to store
+	if err := i.r.writeStore(ctx, i.collection, o); err != nil {
+		return err
+	}
+
+	// Async write to cache
+	if err := i.r.writeCache(ctx, cachekey.Workload(o.Kind, o.Name, o.Namespace), o.Id.Hex()); err != nil {
+		return err
+	}
+
+	// Transform store model to vertex input
+	insert, err := i.r.graphConvert.Workload(o)
+	if err != nil {
+		return err
+	}
+
+	// Aysnc write to graph
+	return i.r.writeVertex(ctx, i.vertex, insert)
+}
+
+// IngestDeployment is invoked by the collector for each deployment collected.
+func (i *WorkloadIngest) IngestDeployment(ctx context.Context, deployment types.DeploymentType) error {
+	if ok, err := preflight.CheckDeployment(deployment); !ok {
+		return err
+	}
+
+	o, err := i.r.storeConvert.Deployment(ctx, deployment)
+	if err != nil {
+		return err
+	}
+
+	return i.ingestWorkload(ctx, o)
+}
+
+// IngestDaemonSet is invoked by the collector for each daemon set collected.
+func (i *WorkloadIngest) IngestDaemonSet(ctx context.Context, daemonSet types.DaemonSetType) error {
+	if ok, err := preflight.CheckDaemonSet(daemonSet); !ok {
+		return err
+	}
+
+	o, err := i.r.storeConvert.DaemonSet(ctx, daemonSet)
+	if err != nil {
+		return err
+	}
+
+	return i.ingestWorkload(ctx, o)
+}
+
+// IngestStatefulSet is invoked by the collector for each stateful set collected.
+func (i *WorkloadIngest) IngestStatefulSet(ctx context.Context, statefulSet types.StatefulSetType) error {
+	if ok, err := preflight.CheckStatefulSet(statefulSet); !ok {
+		return err
+	}
+
+	o, err := i.r.storeConvert.StatefulSet(ctx, statefulSet)
+	if err != nil {
+		return err
+	}
+
+	return i.ingestWorkload(ctx, o)
+}
+
+// IngestJob is invoked by the collector for each job collected.
+func (i *WorkloadIngest) IngestJob(ctx context.Context, job types.JobType) error {
+	if ok, err := preflight.CheckJob(job); !ok {
+		return err
+	}
+
+	o, err := i.r.storeConvert.Job(ctx, job)
+	if err != nil {
+		return err
+	}
+
+	return i.ingestWorkload(ctx, o)
+}
+
+// IngestCronJob is invoked by the collector for each cron job collected.
+func (i *WorkloadIngest) IngestCronJob(ctx context.Context, cronJob types.CronJobType) error {
+	if ok, err := preflight.CheckCronJob(cronJob); !ok {
+		return err
+	}
+
+	o, err := i.r.storeConvert.CronJob(ctx, cronJob)
+	if err != nil {
+		return err
+	}
+
+	return i.ingestWorkload(ctx, o)
+}
+
+// Complete is invoked by the collector when all workloads have been streamed.
+// The function flushes all writers and waits for completion.
+func (i *WorkloadIngest) Complete(ctx context.Context) error {
+	return i.r.flushWriters(ctx)
+}
+
+func (i *WorkloadIngest) Run(ctx context.Context) error {
+	return i.r.collect.StreamWorkloads(ctx, i)
+}
+
+func (i *WorkloadIngest) Close(ctx context.Context) error {
+	return i.r.cleanupAll(ctx)
+}
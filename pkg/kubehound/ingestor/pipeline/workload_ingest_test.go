@@ -0,0 +1,118 @@
+//nolint:forcetypeassert
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/collector"
+	mockcollect "github.com/DataDog/KubeHound/pkg/collector/mockcollector"
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/globals/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	mockcache "github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/mocks"
+	graphdb "github.com/DataDog/KubeHound/pkg/kubehound/storage/graphdb/mocks"
+	storedb "github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb/mocks"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorkloadIngest_Pipeline(t *testing.T) {
+	t.Parallel()
+
+	wi := &WorkloadIngest{}
+
+	ctx := t.Context()
+	fakeDeployment, err := loadTestObject[types.DeploymentType]("testdata/deployment.json")
+	assert.NoError(t, err)
+
+	client := mockcollect.NewCollectorClient(t)
+	client.EXPECT().StreamWorkloads(ctx, wi).
+		RunAndReturn(func(ctx context.Context, i collector.WorkloadIngestor) error {
+			// Fake the stream of a single deployment from the collector client
+			err := i.IngestDeployment(ctx, fakeDeployment)
+			if err != nil {
+				return err
+			}
+
+			return i.Complete(ctx)
+		})
+
+	// Cache setup
+	c := mockcache.NewCacheProvider(t)
+	cw := mockcache.NewAsyncWriter(t)
+	cw.EXPECT().Queue(ctx, cachekey.Workload("Deployment", "app-monitors", "test-app"), mock.AnythingOfType("string")).Return(nil).Once()
+	cw.EXPECT().Flush(ctx).Return(nil)
+	cw.EXPECT().Close(ctx).Return(nil)
+	c.EXPECT().BulkWriter(ctx).Return(cw, nil)
+
+	// Store setup
+	sdb := storedb.NewProvider(t)
+	sw := storedb.NewAsyncWriter(t)
+	workloads := collections.Workload{}
+	storeID := store.ObjectID()
+	sw.EXPECT().Queue(ctx, mock.AnythingOfType("*store.Workload")).
+		RunAndReturn(func(ctx context.Context, i any) error {
+			i.(*store.Workload).Id = storeID
+
+			return nil
+		}).Once()
+
+	sw.EXPECT().Flush(ctx).Return(nil)
+	sw.EXPECT().Close(ctx).Return(nil)
+	sdb.EXPECT().BulkWriter(ctx, workloads, mock.Anything).Return(sw, nil)
+
+	// Graph setup
+	vtx := map[string]interface{}{
+		"app":            "test-app",
+		"cluster":        "test-cluster",
+		"isNamespaced":   true,
+		"kind":           "Deployment",
+		"name":           "app-monitors",
+		"namespace":      "test-app",
+		"runID":          testID.String(),
+		"service":        "test-service",
+		"serviceAccount": "app-monitors",
+		"storeID":        storeID.Hex(),
+		"team":           "test-team",
+	}
+
+	gdb := graphdb.NewProvider(t)
+	gw := graphdb.NewAsyncVertexWriter(t)
+	gw.EXPECT().Queue(ctx, vtx).Return(nil).Once()
+	gw.EXPECT().Flush(ctx).Return(nil)
+	gw.EXPECT().Close(ctx).Return(nil)
+	gdb.EXPECT().VertexWriter(ctx, mock.AnythingOfType("*vertex.Workload"), c, mock.AnythingOfType("graphdb.WriterOption")).Return(gw, nil)
+
+	deps := &Dependencies{
+		Collector: client,
+		Cache:     c,
+		GraphDB:   gdb,
+		StoreDB:   sdb,
+		Config: &config.KubehoundConfig{
+			Builder: config.BuilderConfig{
+				Edge: config.EdgeBuilderConfig{},
+			},
+			Dynamic: config.DynamicConfig{
+				RunID: testID,
+				Cluster: config.DynamicClusterInfo{
+					Name: "test-cluster",
+				},
+			},
+		},
+	}
+
+	// Initialize
+	err = wi.Initialize(ctx, deps)
+	assert.NoError(t, err)
+
+	// Run
+	err = wi.Run(ctx)
+	assert.NoError(t, err)
+
+	// Close
+	err = wi.Close(ctx)
+	assert.NoError(t, err)
+}
@@ -36,6 +36,8 @@ func ingestSequence() []pipeline.Sequence {
 					Ingests: []pipeline.ObjectIngest{
 						&pipeline.RoleIngest{},
 						&pipeline.ClusterRoleIngest{},
+						// Service accounts must be ingested before the role binding subjects (see ServiceAccountIngest)
+						&pipeline.ServiceAccountIngest{},
 					},
 				},
 				{
@@ -50,6 +52,14 @@ func ingestSequence() []pipeline.Sequence {
 					Ingests: []pipeline.ObjectIngest{
 						&pipeline.NodeIngest{},
 						&pipeline.EndpointIngest{},
+						&pipeline.SecretIngest{},
+						// Workloads must be ingested before the pods they manage (see StoreConverter.Pod)
+						&pipeline.WorkloadIngest{},
+						&pipeline.NetworkPolicyIngest{},
+						// Persistent volumes must be ingested before the pods claiming them (see StoreConverter.Volume)
+						&pipeline.PersistentVolumeIngest{},
+						&pipeline.WebhookIngest{},
+						&pipeline.NamespaceIngest{},
 					},
 				},
 				{
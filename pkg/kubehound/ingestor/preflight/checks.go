@@ -61,6 +61,15 @@ func CheckContainer(container types.ContainerType) (bool, error) {
 	return true, nil
 }
 
+// CheckEphemeralContainer checks an input K8s ephemeral container object and reports whether it should be ingested.
+func CheckEphemeralContainer(container types.EphemeralContainerType) (bool, error) {
+	if container == nil {
+		return false, errors.New("nil ephemeral container input in preflight check")
+	}
+
+	return true, nil
+}
+
 // CheckRole checks an input K8s role object and reports whether it should be ingested.
 func CheckRole(role types.RoleType) (bool, error) {
 	if role == nil {
@@ -112,3 +121,120 @@ func CheckEndpoint(ctx context.Context, ep types.EndpointType) (bool, error) {
 
 	return true, nil
 }
+
+// CheckSecret checks an input K8s secret object and reports whether it should be ingested.
+func CheckSecret(secret types.SecretType) (bool, error) {
+	if secret == nil {
+		return false, errors.New("nil secret input in preflight check")
+	}
+
+	return true, nil
+}
+
+// CheckServiceAccount checks an input K8s service account object and reports whether it should be ingested.
+func CheckServiceAccount(sa types.ServiceAccountType) (bool, error) {
+	if sa == nil {
+		return false, errors.New("nil service account input in preflight check")
+	}
+
+	return true, nil
+}
+
+// CheckDeployment checks an input K8s deployment object and reports whether it should be ingested.
+func CheckDeployment(deployment types.DeploymentType) (bool, error) {
+	if deployment == nil {
+		return false, errors.New("nil deployment input in preflight check")
+	}
+
+	return true, nil
+}
+
+// CheckDaemonSet checks an input K8s daemon set object and reports whether it should be ingested.
+func CheckDaemonSet(ds types.DaemonSetType) (bool, error) {
+	if ds == nil {
+		return false, errors.New("nil daemon set input in preflight check")
+	}
+
+	return true, nil
+}
+
+// CheckStatefulSet checks an input K8s stateful set object and reports whether it should be ingested.
+func CheckStatefulSet(sts types.StatefulSetType) (bool, error) {
+	if sts == nil {
+		return false, errors.New("nil stateful set input in preflight check")
+	}
+
+	return true, nil
+}
+
+// CheckJob checks an input K8s job object and reports whether it should be ingested.
+func CheckJob(job types.JobType) (bool, error) {
+	if job == nil {
+		return false, errors.New("nil job input in preflight check")
+	}
+
+	return true, nil
+}
+
+// CheckCronJob checks an input K8s cron job object and reports whether it should be ingested.
+func CheckCronJob(cronJob types.CronJobType) (bool, error) {
+	if cronJob == nil {
+		return false, errors.New("nil cron job input in preflight check")
+	}
+
+	return true, nil
+}
+
+// CheckNetworkPolicy checks an input K8s network policy object and reports whether it should be ingested.
+func CheckNetworkPolicy(policy types.NetworkPolicyType) (bool, error) {
+	if policy == nil {
+		return false, errors.New("nil network policy input in preflight check")
+	}
+
+	return true, nil
+}
+
+// CheckPersistentVolume checks an input K8s persistent volume object and reports whether it should be ingested.
+func CheckPersistentVolume(volume types.PersistentVolumeType) (bool, error) {
+	if volume == nil {
+		return false, errors.New("nil persistent volume input in preflight check")
+	}
+
+	return true, nil
+}
+
+// CheckPersistentVolumeClaim checks an input K8s persistent volume claim object and reports whether it should be ingested.
+func CheckPersistentVolumeClaim(claim types.PersistentVolumeClaimType) (bool, error) {
+	if claim == nil {
+		return false, errors.New("nil persistent volume claim input in preflight check")
+	}
+
+	return true, nil
+}
+
+// CheckMutatingWebhookConfiguration checks an input K8s mutating webhook configuration object and reports whether it should be ingested.
+func CheckMutatingWebhookConfiguration(config types.MutatingWebhookConfigurationType) (bool, error) {
+	if config == nil {
+		return false, errors.New("nil mutating webhook configuration input in preflight check")
+	}
+
+	return true, nil
+}
+
+// CheckValidatingWebhookConfiguration checks an input K8s validating webhook configuration object and reports whether it should be ingested.
+func CheckValidatingWebhookConfiguration(config types.ValidatingWebhookConfigurationType) (bool, error) {
+	if config == nil {
+		return false, errors.New("nil validating webhook configuration input in preflight check")
+	}
+
+	return true, nil
+}
+
+// CheckNamespace checks an input K8s namespace object and reports whether it should be ingested.
+func CheckNamespace(namespace types.NamespaceType) (bool, error) {
+	if namespace == nil {
+		return false, errors.New("nil namespace input in preflight check")
+	}
+
+	return true, nil
+}
@@ -0,0 +1,136 @@
+package libkube
+
+import (
+	"fmt"
+	"sort"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// AggregatedClusterRole holds the resolved rules of a cluster role defining an aggregation rule.
+type AggregatedClusterRole struct {
+	Rules          []rbacv1.PolicyRule // Union of the rules of all the contributing cluster roles
+	AggregatedFrom []string            // Sorted names of all the (direct or transitive) contributing cluster roles
+}
+
+// ClusterRoleAggregator resolves the rules of aggregated cluster roles from the label selectors of their aggregation
+// rule over all the collected cluster roles, mirroring the behaviour of the clusterrole-aggregation-controller.
+// The aggregator is not safe for concurrent use.
+// See reference for details: https://kubernetes.io/docs/reference/access-authn-authz/rbac/#aggregated-clusterroles.
+type ClusterRoleAggregator struct {
+	roles    []*rbacv1.ClusterRole
+	resolved map[string]*AggregatedClusterRole
+}
+
+// NewClusterRoleAggregator creates a new empty cluster role aggregator.
+func NewClusterRoleAggregator() *ClusterRoleAggregator {
+	return &ClusterRoleAggregator{
+		roles:    make([]*rbacv1.ClusterRole, 0),
+		resolved: make(map[string]*AggregatedClusterRole),
+	}
+}
+
+// Add registers a collected cluster role as a potential aggregation source.
+func (a *ClusterRoleAggregator) Add(role *rbacv1.ClusterRole) {
+	a.roles = append(a.roles, role)
+}
+
+// Resolve returns the aggregated rules of the provided cluster role. The literal rules of the role are discarded
+// in favour of the union of the rules of the cluster roles matched by its aggregation rule, as done by the
+// aggregation controller. Aggregation is resolved transitively and cycles are broken using the literal rules.
+func (a *ClusterRoleAggregator) Resolve(role *rbacv1.ClusterRole) (*AggregatedClusterRole, error) {
+	return a.resolve(role, make(map[string]bool))
+}
+
+func (a *ClusterRoleAggregator) resolve(role *rbacv1.ClusterRole, visiting map[string]bool) (*AggregatedClusterRole, error) {
+	if role.AggregationRule == nil {
+		return &AggregatedClusterRole{Rules: role.Rules}, nil
+	}
+
+	if res, ok := a.resolved[role.Name]; ok {
+		return res, nil
+	}
+
+	if visiting[role.Name] {
+		// Aggregation cycle, fallback to the literal rules of the role
+		return &AggregatedClusterRole{Rules: role.Rules}, nil
+	}
+	visiting[role.Name] = true
+	defer delete(visiting, role.Name)
+
+	rules := make([]rbacv1.PolicyRule, 0)
+	contributors := make(map[string]bool)
+	for i := range role.AggregationRule.ClusterRoleSelectors {
+		selector, err := metav1.LabelSelectorAsSelector(&role.AggregationRule.ClusterRoleSelectors[i])
+		if err != nil {
+			return nil, fmt.Errorf("cluster role %s aggregation selector: %w", role.Name, err)
+		}
+
+		for _, source := range a.matching(selector) {
+			if source.Name == role.Name {
+				continue
+			}
+
+			res, err := a.resolve(source, visiting)
+			if err != nil {
+				return nil, err
+			}
+
+			contributors[source.Name] = true
+			for _, c := range res.AggregatedFrom {
+				if c != role.Name {
+					contributors[c] = true
+				}
+			}
+
+			for _, r := range res.Rules {
+				if !ruleExists(rules, r) {
+					rules = append(rules, r)
+				}
+			}
+		}
+	}
+
+	res := &AggregatedClusterRole{
+		Rules:          rules,
+		AggregatedFrom: make([]string, 0, len(contributors)),
+	}
+	for c := range contributors {
+		res.AggregatedFrom = append(res.AggregatedFrom, c)
+	}
+	sort.Strings(res.AggregatedFrom)
+
+	a.resolved[role.Name] = res
+
+	return res, nil
+}
+
+// matching returns the registered cluster roles matching the selector, sorted by name.
+func (a *ClusterRoleAggregator) matching(selector labels.Selector) []*rbacv1.ClusterRole {
+	matches := make([]*rbacv1.ClusterRole, 0)
+	for _, r := range a.roles {
+		if selector.Matches(labels.Set(r.Labels)) {
+			matches = append(matches, r)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Name < matches[j].Name
+	})
+
+	return matches
+}
+
+// ruleExists returns whether the rule is already present in the list.
+func ruleExists(rules []rbacv1.PolicyRule, rule rbacv1.PolicyRule) bool {
+	for _, r := range rules {
+		if equality.Semantic.DeepEqual(r, rule) {
+			return true
+		}
+	}
+
+	return false
+}
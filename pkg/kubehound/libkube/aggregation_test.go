@@ -0,0 +1,98 @@
+package libkube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClusterRoleAggregator_Resolve(t *testing.T) {
+	t.Parallel()
+
+	clusterRole := func(name string, labels map[string]string, aggregate []string, rules ...rbacv1.PolicyRule) *rbacv1.ClusterRole {
+		role := &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Rules:      rules,
+		}
+
+		if aggregate != nil {
+			role.AggregationRule = &rbacv1.AggregationRule{}
+			for _, l := range aggregate {
+				role.AggregationRule.ClusterRoleSelectors = append(role.AggregationRule.ClusterRoleSelectors,
+					metav1.LabelSelector{MatchLabels: map[string]string{l: "true"}})
+			}
+		}
+
+		return role
+	}
+
+	readPods := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list"}}
+	editPods := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"create", "patch"}}
+	escalate := rbacv1.PolicyRule{APIGroups: []string{"rbac.authorization.k8s.io"}, Resources: []string{"clusterroles"}, Verbs: []string{"escalate"}}
+	stale := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"*"}}
+
+	admin := clusterRole("admin", nil, []string{"aggregate-to-admin"}, stale)
+	edit := clusterRole("edit", map[string]string{"aggregate-to-admin": "true"}, []string{"aggregate-to-edit"})
+	view := clusterRole("view", map[string]string{"aggregate-to-edit": "true"}, []string{"aggregate-to-view"}, readPods)
+	viewSource := clusterRole("system:aggregate-to-view", map[string]string{"aggregate-to-view": "true"}, nil, readPods)
+	editSource := clusterRole("system:aggregate-to-edit", map[string]string{"aggregate-to-edit": "true"}, nil, editPods, readPods)
+	crd := clusterRole("crd-installed-role", map[string]string{"aggregate-to-admin": "true"}, nil, escalate)
+	cycleA := clusterRole("cycle-a", map[string]string{"cycle-b": "true"}, []string{"cycle-a"}, readPods)
+	cycleB := clusterRole("cycle-b", map[string]string{"cycle-a": "true"}, []string{"cycle-b"}, editPods)
+	unrelated := clusterRole("unrelated", nil, nil, escalate)
+
+	a := NewClusterRoleAggregator()
+	for _, r := range []*rbacv1.ClusterRole{admin, edit, view, viewSource, editSource, crd, cycleA, cycleB, unrelated} {
+		a.Add(r)
+	}
+
+	tests := []struct {
+		name           string
+		role           *rbacv1.ClusterRole
+		rules          []rbacv1.PolicyRule
+		aggregatedFrom []string
+	}{
+		{
+			name:  "non aggregated role",
+			role:  unrelated,
+			rules: []rbacv1.PolicyRule{escalate},
+		},
+		{
+			name:           "single level aggregation",
+			role:           view,
+			rules:          []rbacv1.PolicyRule{readPods},
+			aggregatedFrom: []string{"system:aggregate-to-view"},
+		},
+		{
+			name:           "transitive aggregation",
+			role:           edit,
+			rules:          []rbacv1.PolicyRule{editPods, readPods},
+			aggregatedFrom: []string{"system:aggregate-to-edit", "system:aggregate-to-view", "view"},
+		},
+		{
+			name:           "literal rules are replaced",
+			role:           admin,
+			rules:          []rbacv1.PolicyRule{escalate, editPods, readPods},
+			aggregatedFrom: []string{"crd-installed-role", "edit", "system:aggregate-to-edit", "system:aggregate-to-view", "view"},
+		},
+		{
+			name:           "aggregation cycle",
+			role:           cycleA,
+			rules:          []rbacv1.PolicyRule{readPods},
+			aggregatedFrom: []string{"cycle-b"},
+		},
+	}
+
+	for _, tt := range tests {
+		// Resolution results are cached by the aggregator, run sequentially
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := a.Resolve(tt.role)
+			require.NoError(t, err)
+			assert.Equal(t, tt.rules, res.Rules)
+			assert.Equal(t, tt.aggregatedFrom, res.AggregatedFrom)
+		})
+	}
+}
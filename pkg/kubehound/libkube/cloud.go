@@ -0,0 +1,169 @@
+package libkube
+
+import (
+	"strings"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// AWS IAM roles for service accounts (IRSA). See reference for details:
+	// https://docs.aws.amazon.com/eks/latest/userguide/associate-service-account-role.html
+	AnnotationAWSRoleARN = "eks.amazonaws.com/role-arn"
+
+	// GKE workload identity. See reference for details:
+	// https://cloud.google.com/kubernetes-engine/docs/how-to/workload-identity
+	AnnotationGCPServiceAccount = "iam.gke.io/gcp-service-account"
+
+	// Azure workload identity. See reference for details:
+	// https://azure.github.io/azure-workload-identity/docs/topics/service-account-labels-and-annotations.html
+	AnnotationAzureClientID = "azure.workload.identity/client-id"
+	AnnotationAzureTenantID = "azure.workload.identity/tenant-id"
+)
+
+// Well known labels set by the managed K8s offerings on the nodes of a node pool, in order of precedence.
+var nodePoolLabels = []struct {
+	label    string
+	provider string
+}{
+	{"eks.amazonaws.com/nodegroup", shared.CloudProviderAWS},
+	{"alpha.eksctl.io/nodegroup-name", shared.CloudProviderAWS},
+	{"cloud.google.com/gke-nodepool", shared.CloudProviderGCP},
+	{"kubernetes.azure.com/agentpool", shared.CloudProviderAzure},
+}
+
+// Prefixes of the node provider ID set by the cloud controller manager of each provider.
+var providerIDPrefixes = map[string]string{
+	"aws://":   shared.CloudProviderAWS,
+	"gce://":   shared.CloudProviderGCP,
+	"azure://": shared.CloudProviderAzure,
+}
+
+// ServiceAccountCloudPrincipals returns the cloud IAM principals granted to a service account via the workload
+// identity annotations of the supported cloud providers.
+func ServiceAccountCloudPrincipals(annotations map[string]string) []store.CloudPrincipal {
+	principals := make([]store.CloudPrincipal, 0)
+
+	if arn := strings.TrimSpace(annotations[AnnotationAWSRoleARN]); arn != "" {
+		principals = append(principals, store.CloudPrincipal{
+			Provider: shared.CloudProviderAWS,
+			Type:     shared.CloudIdentityTypeRole,
+			Name:     arn,
+			Account:  awsAccountFromARN(arn),
+		})
+	}
+
+	if email := strings.TrimSpace(annotations[AnnotationGCPServiceAccount]); email != "" {
+		principals = append(principals, store.CloudPrincipal{
+			Provider: shared.CloudProviderGCP,
+			Type:     shared.CloudIdentityTypeServiceAccount,
+			Name:     email,
+			Account:  gcpProjectFromEmail(email),
+		})
+	}
+
+	if clientID := strings.TrimSpace(annotations[AnnotationAzureClientID]); clientID != "" {
+		principals = append(principals, store.CloudPrincipal{
+			Provider: shared.CloudProviderAzure,
+			Type:     shared.CloudIdentityTypeManagedIdentity,
+			Name:     clientID,
+			Account:  strings.TrimSpace(annotations[AnnotationAzureTenantID]),
+		})
+	}
+
+	return principals
+}
+
+// NodeCloudPrincipal returns the cloud IAM principal whose credentials are exposed to the node (and any process
+// with access to the instance metadata service) by the cloud provider. The principal attached to a node is not
+// visible from the K8s API, nodes of the same node pool share the same principal which is named after the pool.
+// Nodes outside of a known node pool are identified by their provider ID.
+func NodeCloudPrincipal(node *corev1.Node) (store.CloudPrincipal, bool) {
+	provider, account := nodeProvider(node.Spec.ProviderID)
+
+	for _, np := range nodePoolLabels {
+		pool, ok := node.Labels[np.label]
+		if !ok || pool == "" {
+			continue
+		}
+
+		if provider != "" && provider != np.provider {
+			continue
+		}
+
+		return store.CloudPrincipal{
+			Provider: np.provider,
+			Type:     shared.CloudIdentityTypeInstance,
+			Name:     pool,
+			Account:  account,
+		}, true
+	}
+
+	if provider == "" {
+		return store.CloudPrincipal{}, false
+	}
+
+	return store.CloudPrincipal{
+		Provider: provider,
+		Type:     shared.CloudIdentityTypeInstance,
+		Name:     node.Spec.ProviderID,
+		Account:  account,
+	}, true
+}
+
+// nodeProvider returns the cloud provider and account (GCP project, Azure subscription) encoded in a node provider ID:
+//
+//	aws:///us-east-1a/i-0123456789abcdef0
+//	gce://my-project/us-central1-a/gke-cluster-default-pool-1234
+//	azure:///subscriptions/<subscription>/resourceGroups/<group>/providers/Microsoft.Compute/virtualMachineScaleSets/...
+func nodeProvider(providerID string) (string, string) {
+	for prefix, provider := range providerIDPrefixes {
+		path, ok := strings.CutPrefix(providerID, prefix)
+		if !ok {
+			continue
+		}
+
+		segments := strings.Split(path, "/")
+		switch provider {
+		case shared.CloudProviderGCP:
+			return provider, segments[0]
+		case shared.CloudProviderAzure:
+			for i := 0; i < len(segments)-1; i++ {
+				if strings.EqualFold(segments[i], "subscriptions") {
+					return provider, segments[i+1]
+				}
+			}
+		}
+
+		return provider, ""
+	}
+
+	return "", ""
+}
+
+// awsAccountFromARN returns the account ID of an IAM role ARN (arn:aws:iam::123456789012:role/name).
+func awsAccountFromARN(arn string) string {
+	parts := strings.Split(arn, ":")
+	if len(parts) < 6 || parts[0] != "arn" {
+		return ""
+	}
+
+	return parts[4]
+}
+
+// gcpProjectFromEmail returns the project of a GCP service account (name@project.iam.gserviceaccount.com).
+func gcpProjectFromEmail(email string) string {
+	_, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return ""
+	}
+
+	project, ok := strings.CutSuffix(domain, ".iam.gserviceaccount.com")
+	if !ok {
+		return ""
+	}
+
+	return project
+}
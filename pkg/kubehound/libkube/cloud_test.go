@@ -0,0 +1,179 @@
+package libkube
+
+import (
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestServiceAccountCloudPrincipals(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []store.CloudPrincipal
+	}{
+		{
+			name:        "no annotations",
+			annotations: nil,
+			want:        []store.CloudPrincipal{},
+		},
+		{
+			name: "AWS IRSA",
+			annotations: map[string]string{
+				AnnotationAWSRoleARN: "arn:aws:iam::123456789012:role/app-role",
+			},
+			want: []store.CloudPrincipal{
+				{
+					Provider: shared.CloudProviderAWS,
+					Type:     shared.CloudIdentityTypeRole,
+					Name:     "arn:aws:iam::123456789012:role/app-role",
+					Account:  "123456789012",
+				},
+			},
+		},
+		{
+			name: "GKE workload identity",
+			annotations: map[string]string{
+				AnnotationGCPServiceAccount: "app@my-project.iam.gserviceaccount.com",
+			},
+			want: []store.CloudPrincipal{
+				{
+					Provider: shared.CloudProviderGCP,
+					Type:     shared.CloudIdentityTypeServiceAccount,
+					Name:     "app@my-project.iam.gserviceaccount.com",
+					Account:  "my-project",
+				},
+			},
+		},
+		{
+			name: "Azure workload identity",
+			annotations: map[string]string{
+				AnnotationAzureClientID: "00000000-0000-0000-0000-000000000001",
+				AnnotationAzureTenantID: "00000000-0000-0000-0000-000000000002",
+			},
+			want: []store.CloudPrincipal{
+				{
+					Provider: shared.CloudProviderAzure,
+					Type:     shared.CloudIdentityTypeManagedIdentity,
+					Name:     "00000000-0000-0000-0000-000000000001",
+					Account:  "00000000-0000-0000-0000-000000000002",
+				},
+			},
+		},
+		{
+			name: "malformed ARN",
+			annotations: map[string]string{
+				AnnotationAWSRoleARN: "app-role",
+			},
+			want: []store.CloudPrincipal{
+				{
+					Provider: shared.CloudProviderAWS,
+					Type:     shared.CloudIdentityTypeRole,
+					Name:     "app-role",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, ServiceAccountCloudPrincipals(tt.annotations))
+		})
+	}
+}
+
+func TestNodeCloudPrincipal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		labels     map[string]string
+		providerID string
+		want       store.CloudPrincipal
+		wantOK     bool
+	}{
+		{
+			name:       "kind node",
+			providerID: "kind://docker/kubehound/kubehound-worker",
+			wantOK:     false,
+		},
+		{
+			name:       "EKS managed node group",
+			labels:     map[string]string{"eks.amazonaws.com/nodegroup": "workers"},
+			providerID: "aws:///us-east-1a/i-0123456789abcdef0",
+			want: store.CloudPrincipal{
+				Provider: shared.CloudProviderAWS,
+				Type:     shared.CloudIdentityTypeInstance,
+				Name:     "workers",
+			},
+			wantOK: true,
+		},
+		{
+			name:       "EC2 instance outside a node group",
+			providerID: "aws:///us-east-1a/i-0123456789abcdef0",
+			want: store.CloudPrincipal{
+				Provider: shared.CloudProviderAWS,
+				Type:     shared.CloudIdentityTypeInstance,
+				Name:     "aws:///us-east-1a/i-0123456789abcdef0",
+			},
+			wantOK: true,
+		},
+		{
+			name:       "GKE node pool",
+			labels:     map[string]string{"cloud.google.com/gke-nodepool": "default-pool"},
+			providerID: "gce://my-project/us-central1-a/gke-cluster-default-pool-1234",
+			want: store.CloudPrincipal{
+				Provider: shared.CloudProviderGCP,
+				Type:     shared.CloudIdentityTypeInstance,
+				Name:     "default-pool",
+				Account:  "my-project",
+			},
+			wantOK: true,
+		},
+		{
+			name:       "AKS agent pool",
+			labels:     map[string]string{"kubernetes.azure.com/agentpool": "nodepool1"},
+			providerID: "azure:///subscriptions/sub-id/resourceGroups/rg/providers/Microsoft.Compute/virtualMachineScaleSets/vmss/virtualMachines/0",
+			want: store.CloudPrincipal{
+				Provider: shared.CloudProviderAzure,
+				Type:     shared.CloudIdentityTypeInstance,
+				Name:     "nodepool1",
+				Account:  "sub-id",
+			},
+			wantOK: true,
+		},
+		{
+			name:       "node pool label of another provider",
+			labels:     map[string]string{"cloud.google.com/gke-nodepool": "default-pool"},
+			providerID: "aws:///us-east-1a/i-0123456789abcdef0",
+			want: store.CloudPrincipal{
+				Provider: shared.CloudProviderAWS,
+				Type:     shared.CloudIdentityTypeInstance,
+				Name:     "aws:///us-east-1a/i-0123456789abcdef0",
+			},
+			wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Labels: tt.labels},
+				Spec:       corev1.NodeSpec{ProviderID: tt.providerID},
+			}
+
+			got, ok := NodeCloudPrincipal(node)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package libkube
+
+import (
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+)
+
+const (
+	// Pod Security Admission namespace labels. See reference for details:
+	// https://kubernetes.io/docs/concepts/security/pod-security-admission/#pod-security-admission-labels-for-namespaces
+	LabelPodSecurityEnforce        = "pod-security.kubernetes.io/enforce"
+	LabelPodSecurityEnforceVersion = "pod-security.kubernetes.io/enforce-version"
+	LabelPodSecurityAudit          = "pod-security.kubernetes.io/audit"
+	LabelPodSecurityWarn           = "pod-security.kubernetes.io/warn"
+)
+
+// PodSecurityLevel returns the Pod Security Standard level set by the provided Pod Security Admission label of a
+// namespace. Namespaces without the label default to the privileged level, which matches the default configuration
+// of the admission controller. As done by the admission controller, an invalid level is evaluated as restricted.
+//
+// NOTE: cluster wide defaults and exemptions set in the admission controller configuration are not collected.
+func PodSecurityLevel(namespaceLabels map[string]string, label string) string {
+	level, ok := namespaceLabels[label]
+	if !ok {
+		return shared.PodSecurityLevelPrivileged
+	}
+
+	switch level {
+	case shared.PodSecurityLevelPrivileged, shared.PodSecurityLevelBaseline, shared.PodSecurityLevelRestricted:
+		return level
+	default:
+		return shared.PodSecurityLevelRestricted
+	}
+}
@@ -0,0 +1,60 @@
+package libkube
+
+import (
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPodSecurityLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   string
+	}{
+		{
+			name:   "no labels",
+			labels: nil,
+			want:   shared.PodSecurityLevelPrivileged,
+		},
+		{
+			name: "other mode only",
+			labels: map[string]string{
+				LabelPodSecurityWarn: shared.PodSecurityLevelRestricted,
+			},
+			want: shared.PodSecurityLevelPrivileged,
+		},
+		{
+			name: "baseline",
+			labels: map[string]string{
+				LabelPodSecurityEnforce: shared.PodSecurityLevelBaseline,
+			},
+			want: shared.PodSecurityLevelBaseline,
+		},
+		{
+			name: "restricted",
+			labels: map[string]string{
+				LabelPodSecurityEnforce:        shared.PodSecurityLevelRestricted,
+				LabelPodSecurityEnforceVersion: "v1.29",
+			},
+			want: shared.PodSecurityLevelRestricted,
+		},
+		{
+			name: "invalid level",
+			labels: map[string]string{
+				LabelPodSecurityEnforce: "Privileged",
+			},
+			want: shared.PodSecurityLevelRestricted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, PodSecurityLevel(tt.labels, LabelPodSecurityEnforce))
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package libkube
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// NetworkPolicySource holds the labels of a collected pod (and of its namespace) that can be the source of ingress
+// traffic within the cluster.
+type NetworkPolicySource struct {
+	Namespace       string
+	NamespaceLabels map[string]string
+	PodLabels       map[string]string
+}
+
+// NetworkPolicyIngressBlocked reports whether ingress traffic to a pod port is denied by the provided network policies,
+// which must all belong to the pod namespace. A pod is only isolated for ingress once selected by at least one policy
+// of type Ingress, in which case traffic is allowed if any ingress rule of the selecting policies matches the port and
+// allows traffic from at least one of the provided in-cluster sources.
+// See reference for details: https://kubernetes.io/docs/concepts/services-networking/network-policies/.
+func NetworkPolicyIngressBlocked(policies []netv1.NetworkPolicy, podLabels map[string]string,
+	port int, portName string, protocol corev1.Protocol, sources []NetworkPolicySource) bool {
+
+	isolated := false
+	for i := range policies {
+		policy := &policies[i]
+		if !networkPolicyIngress(policy) || !networkPolicySelects(policy, podLabels) {
+			continue
+		}
+
+		isolated = true
+		for _, rule := range policy.Spec.Ingress {
+			if networkPolicyPortMatch(rule.Ports, port, portName, protocol) &&
+				networkPolicyPeersMatch(rule.From, policy.Namespace, sources) {
+				return false
+			}
+		}
+	}
+
+	return isolated
+}
+
+// networkPolicyIngress returns whether the network policy applies to ingress traffic. Policies without an explicit
+// policy type always apply to ingress traffic.
+func networkPolicyIngress(policy *netv1.NetworkPolicy) bool {
+	if len(policy.Spec.PolicyTypes) == 0 {
+		return true
+	}
+
+	for _, t := range policy.Spec.PolicyTypes {
+		if t == netv1.PolicyTypeIngress {
+			return true
+		}
+	}
+
+	return false
+}
+
+// networkPolicySelects returns whether the network policy pod selector matches the provided pod labels.
+func networkPolicySelects(policy *netv1.NetworkPolicy, podLabels map[string]string) bool {
+	selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+	if err != nil {
+		// Invalid selectors are rejected by the K8s API and cannot select any pod
+		return false
+	}
+
+	return selector.Matches(labels.Set(podLabels))
+}
+
+// networkPolicyPeersMatch returns whether any of the provided sources is allowed by a network policy rule peer list. An
+// empty list allows all sources, while IP block peers are assumed to only cover sources outside of the cluster as pod
+// IPs are not collected.
+func networkPolicyPeersMatch(peers []netv1.NetworkPolicyPeer, namespace string, sources []NetworkPolicySource) bool {
+	if len(peers) == 0 {
+		return true
+	}
+
+	for _, peer := range peers {
+		if peer.PodSelector == nil && peer.NamespaceSelector == nil {
+			continue
+		}
+
+		podSelector, err := networkPolicyPeerSelector(peer.PodSelector)
+		if err != nil {
+			continue
+		}
+
+		namespaceSelector, err := networkPolicyPeerSelector(peer.NamespaceSelector)
+		if err != nil {
+			continue
+		}
+
+		for _, source := range sources {
+			// Without a namespace selector, only the pods of the policy namespace are selected
+			if peer.NamespaceSelector == nil && source.Namespace != namespace {
+				continue
+			}
+
+			nsLabels := labels.Set{}
+			for k, v := range source.NamespaceLabels {
+				nsLabels[k] = v
+			}
+			nsLabels[corev1.LabelMetadataName] = source.Namespace
+
+			if namespaceSelector.Matches(nsLabels) && podSelector.Matches(labels.Set(source.PodLabels)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// networkPolicyPeerSelector converts a network policy peer selector. A missing selector selects everything.
+func networkPolicyPeerSelector(selector *metav1.LabelSelector) (labels.Selector, error) {
+	if selector == nil {
+		return labels.Everything(), nil
+	}
+
+	// Invalid selectors are rejected by the K8s API and cannot select any pod
+	return metav1.LabelSelectorAsSelector(selector)
+}
+
+// networkPolicyPortMatch returns whether the provided port is matched by a network policy rule port list. An empty list
+// matches all ports and a named port only matches container ports of the same name.
+func networkPolicyPortMatch(ports []netv1.NetworkPolicyPort, port int, portName string, protocol corev1.Protocol) bool {
+	if len(ports) == 0 {
+		return true
+	}
+
+	for _, p := range ports {
+		ruleProtocol := corev1.ProtocolTCP
+		if p.Protocol != nil {
+			ruleProtocol = *p.Protocol
+		}
+
+		if ruleProtocol != protocol {
+			continue
+		}
+
+		if p.Port == nil {
+			// All ports of the protocol are allowed
+			return true
+		}
+
+		switch p.Port.Type {
+		case intstr.Int:
+			start := int(p.Port.IntVal)
+			end := start
+			if p.EndPort != nil {
+				end = int(*p.EndPort)
+			}
+
+			if port >= start && port <= end {
+				return true
+			}
+		case intstr.String:
+			if len(portName) != 0 && p.Port.StrVal == portName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
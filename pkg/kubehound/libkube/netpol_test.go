@@ -0,0 +1,180 @@
+package libkube
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestNetworkPolicyIngressBlocked(t *testing.T) {
+	t.Parallel()
+
+	udp := corev1.ProtocolUDP
+	http := intstr.FromInt32(8080)
+	httpName := intstr.FromString("http")
+	rangeEnd := int32(9000)
+
+	denyAll := netv1.NetworkPolicy{
+		Spec: netv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeIngress},
+		},
+	}
+
+	allowApp := func(ports ...netv1.NetworkPolicyPort) netv1.NetworkPolicy {
+		return netv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+			Spec: netv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "web"},
+				},
+				Ingress: []netv1.NetworkPolicyIngressRule{
+					{
+						From: []netv1.NetworkPolicyPeer{
+							{PodSelector: &metav1.LabelSelector{}},
+						},
+						Ports: ports,
+					},
+				},
+			},
+		}
+	}
+
+	allowFrom := func(peers ...netv1.NetworkPolicyPeer) netv1.NetworkPolicy {
+		return netv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+			Spec: netv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{},
+				Ingress:     []netv1.NetworkPolicyIngressRule{{From: peers}},
+			},
+		}
+	}
+
+	egressOnly := netv1.NetworkPolicy{
+		Spec: netv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeEgress},
+		},
+	}
+
+	web := map[string]string{"app": "web"}
+	db := map[string]string{"app": "db"}
+
+	cluster := []NetworkPolicySource{
+		{Namespace: "default", PodLabels: web},
+		{Namespace: "monitoring", NamespaceLabels: map[string]string{"team": "sre"}, PodLabels: map[string]string{"app": "prometheus"}},
+	}
+	prometheus := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "prometheus"}}
+
+	type args struct {
+		policies []netv1.NetworkPolicy
+		labels   map[string]string
+		port     int
+		portName string
+		protocol corev1.Protocol
+		sources  []NetworkPolicySource
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "no policy",
+			args: args{policies: nil, labels: web, port: 8080, protocol: corev1.ProtocolTCP, sources: cluster},
+			want: false,
+		},
+		{
+			name: "egress policy only",
+			args: args{policies: []netv1.NetworkPolicy{egressOnly}, labels: web, port: 8080, protocol: corev1.ProtocolTCP, sources: cluster},
+			want: false,
+		},
+		{
+			name: "default deny",
+			args: args{policies: []netv1.NetworkPolicy{denyAll}, labels: web, port: 8080, protocol: corev1.ProtocolTCP, sources: cluster},
+			want: true,
+		},
+		{
+			name: "default deny with allowed port",
+			args: args{policies: []netv1.NetworkPolicy{denyAll, allowApp(netv1.NetworkPolicyPort{Port: &http})}, labels: web, port: 8080, protocol: corev1.ProtocolTCP, sources: cluster},
+			want: false,
+		},
+		{
+			name: "default deny with other port allowed",
+			args: args{policies: []netv1.NetworkPolicy{denyAll, allowApp(netv1.NetworkPolicyPort{Port: &http})}, labels: web, port: 9090, protocol: corev1.ProtocolTCP, sources: cluster},
+			want: true,
+		},
+		{
+			name: "default deny with allow policy selecting other pods",
+			args: args{policies: []netv1.NetworkPolicy{denyAll, allowApp()}, labels: db, port: 8080, protocol: corev1.ProtocolTCP, sources: cluster},
+			want: true,
+		},
+		{
+			name: "allow policy without ports",
+			args: args{policies: []netv1.NetworkPolicy{allowApp()}, labels: web, port: 443, protocol: corev1.ProtocolTCP, sources: cluster},
+			want: false,
+		},
+		{
+			name: "allow policy with protocol mismatch",
+			args: args{policies: []netv1.NetworkPolicy{allowApp(netv1.NetworkPolicyPort{Protocol: &udp, Port: &http})}, labels: web, port: 8080, protocol: corev1.ProtocolTCP, sources: cluster},
+			want: true,
+		},
+		{
+			name: "allow policy with named port",
+			args: args{policies: []netv1.NetworkPolicy{allowApp(netv1.NetworkPolicyPort{Port: &httpName})}, labels: web, port: 8080, portName: "http", protocol: corev1.ProtocolTCP, sources: cluster},
+			want: false,
+		},
+		{
+			name: "allow policy with port range",
+			args: args{policies: []netv1.NetworkPolicy{allowApp(netv1.NetworkPolicyPort{Port: &http, EndPort: &rangeEnd})}, labels: web, port: 8500, protocol: corev1.ProtocolTCP, sources: cluster},
+			want: false,
+		},
+		{
+			name: "allow policy without peers",
+			args: args{policies: []netv1.NetworkPolicy{{Spec: netv1.NetworkPolicySpec{Ingress: []netv1.NetworkPolicyIngressRule{{}}}}}, labels: web, port: 8080, protocol: corev1.ProtocolTCP},
+			want: false,
+		},
+		{
+			name: "allow policy with ip block peers only",
+			args: args{policies: []netv1.NetworkPolicy{allowFrom(netv1.NetworkPolicyPeer{IPBlock: &netv1.IPBlock{CIDR: "10.0.0.0/8"}})}, labels: web, port: 8080, protocol: corev1.ProtocolTCP, sources: cluster},
+			want: true,
+		},
+		{
+			name: "allow policy with pod selector matching no pod",
+			args: args{policies: []netv1.NetworkPolicy{allowFrom(netv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{MatchLabels: db}})}, labels: web, port: 8080, protocol: corev1.ProtocolTCP, sources: cluster},
+			want: true,
+		},
+		{
+			name: "allow policy with pod selector matching pods of another namespace",
+			args: args{policies: []netv1.NetworkPolicy{allowFrom(netv1.NetworkPolicyPeer{PodSelector: prometheus})}, labels: web, port: 8080, protocol: corev1.ProtocolTCP, sources: cluster},
+			want: true,
+		},
+		{
+			name: "allow policy with namespace and pod selectors",
+			args: args{policies: []netv1.NetworkPolicy{allowFrom(netv1.NetworkPolicyPeer{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "sre"}},
+				PodSelector:       prometheus,
+			})}, labels: web, port: 8080, protocol: corev1.ProtocolTCP, sources: cluster},
+			want: false,
+		},
+		{
+			name: "allow policy with namespace name selector",
+			args: args{policies: []netv1.NetworkPolicy{allowFrom(netv1.NetworkPolicyPeer{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: "monitoring"}},
+			})}, labels: web, port: 8080, protocol: corev1.ProtocolTCP, sources: cluster},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := NetworkPolicyIngressBlocked(tt.args.policies, tt.args.labels, tt.args.port, tt.args.portName, tt.args.protocol, tt.args.sources)
+			if got != tt.want {
+				t.Errorf("NetworkPolicyIngressBlocked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
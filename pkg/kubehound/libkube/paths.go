@@ -2,6 +2,12 @@ package libkube
 
 import (
 	"fmt"
+	"strings"
+)
+
+const (
+	// automountTokenVolumePrefix is the name prefix of the projected token volume added by the service account admission controller.
+	automountTokenVolumePrefix = "kube-api-access-"
 )
 
 // ServiceAccountTokenPath returns the full path of a pod's service account token on the host node.
@@ -9,3 +15,27 @@ func ServiceAccountTokenPath(podUid string, volumeName string) string {
 	return fmt.Sprintf("/var/lib/kubelet/pods/%s/volumes/kubernetes.io~projected/%s/token",
 		podUid, volumeName)
 }
+
+// ConfigMapVolumePath returns the full path of a pod's configmap volume on the host node.
+func ConfigMapVolumePath(podUid string, volumeName string) string {
+	return fmt.Sprintf("/var/lib/kubelet/pods/%s/volumes/kubernetes.io~configmap/%s",
+		podUid, volumeName)
+}
+
+// EmptyDirVolumePath returns the full path of a pod's (disk backed) emptyDir volume on the host node.
+func EmptyDirVolumePath(podUid string, volumeName string) string {
+	return fmt.Sprintf("/var/lib/kubelet/pods/%s/volumes/kubernetes.io~empty-dir/%s",
+		podUid, volumeName)
+}
+
+// CSIVolumePath returns the full path of a pod's inline CSI volume on the host node.
+func CSIVolumePath(podUid string, volumeName string) string {
+	return fmt.Sprintf("/var/lib/kubelet/pods/%s/volumes/kubernetes.io~csi/%s/mount",
+		podUid, volumeName)
+}
+
+// IsAutomountTokenVolume returns whether the provided pod volume name corresponds to the projected service account
+// token volume injected by the service account admission controller (i.e only present when automount is enabled).
+func IsAutomountTokenVolume(volumeName string) bool {
+	return strings.HasPrefix(volumeName, automountTokenVolumePrefix)
+}
@@ -0,0 +1,93 @@
+package libkube
+
+import (
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// WebhookInterceptsPod reports whether an admission webhook is invoked by the API server when a pod with the provided
+// namespace and labels is created. The webhook must have a rule matching the creation of pods and both its namespace
+// and object selectors must match. See reference for details:
+// https://kubernetes.io/docs/reference/access-authn-authz/extensible-admission-controllers/#matching-requests-rules.
+//
+// The namespace selector is evaluated against the provided namespace labels. If the namespace has not been collected
+// (e.g dumps generated by older versions), only the well known kubernetes.io/metadata.name label set by the API server
+// on every namespace is considered.
+func WebhookInterceptsPod(rules []admissionregistrationv1.RuleWithOperations, namespaceSelector *metav1.LabelSelector,
+	objectSelector *metav1.LabelSelector, namespace string, namespaceLabels map[string]string, podLabels map[string]string) bool {
+
+	if !webhookRulesMatchPodCreate(rules) {
+		return false
+	}
+
+	nsLabels := labels.Set{}
+	for k, v := range namespaceLabels {
+		nsLabels[k] = v
+	}
+	nsLabels[corev1.LabelMetadataName] = namespace
+
+	return webhookSelectorMatches(namespaceSelector, nsLabels) &&
+		webhookSelectorMatches(objectSelector, labels.Set(podLabels))
+}
+
+// webhookRulesMatchPodCreate returns whether any of the webhook rules matches the creation of a namespaced pod.
+func webhookRulesMatchPodCreate(rules []admissionregistrationv1.RuleWithOperations) bool {
+	for _, rule := range rules {
+		if rule.Scope != nil && *rule.Scope == admissionregistrationv1.ClusterScope {
+			continue
+		}
+
+		if webhookRuleContains(rule.APIGroups, "") &&
+			webhookRuleContains(rule.Resources, "pods", "*/*") &&
+			webhookRuleContains(operationNames(rule.Operations), string(admissionregistrationv1.Create)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// webhookRuleContains returns whether a rule field contains one of the provided values or the wildcard.
+func webhookRuleContains(field []string, values ...string) bool {
+	for _, f := range field {
+		if f == "*" {
+			return true
+		}
+
+		for _, v := range values {
+			if f == v {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// operationNames converts a list of webhook rule operations to their string representation.
+func operationNames(operations []admissionregistrationv1.OperationType) []string {
+	names := make([]string, 0, len(operations))
+	for _, op := range operations {
+		names = append(names, string(op))
+	}
+
+	return names
+}
+
+// webhookSelectorMatches returns whether a webhook selector matches the provided labels. A nil selector matches
+// everything, as the API server defaults it to the empty selector.
+func webhookSelectorMatches(selector *metav1.LabelSelector, set labels.Set) bool {
+	if selector == nil {
+		return true
+	}
+
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		// Invalid selectors are rejected by the K8s API and cannot match anything
+		return false
+	}
+
+	return s.Matches(set)
+}
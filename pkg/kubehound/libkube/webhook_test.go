@@ -0,0 +1,164 @@
+package libkube
+
+import (
+	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWebhookInterceptsPod(t *testing.T) {
+	t.Parallel()
+
+	clusterScope := admissionregistrationv1.ClusterScope
+
+	podCreate := []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+			},
+		},
+	}
+
+	wildcard := []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.OperationAll},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups: []string{"*"},
+				Resources: []string{"*"},
+			},
+		},
+	}
+
+	podUpdate := []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Update},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+			},
+		},
+	}
+
+	podSubresource := []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups: []string{""},
+				Resources: []string{"pods/exec"},
+			},
+		},
+	}
+
+	clusterScoped := []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups: []string{"*"},
+				Resources: []string{"*"},
+				Scope:     &clusterScope,
+			},
+		},
+	}
+
+	web := map[string]string{"app": "web"}
+	webSelector := &metav1.LabelSelector{MatchLabels: web}
+	excludeSystem := &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      "kubernetes.io/metadata.name",
+				Operator: metav1.LabelSelectorOpNotIn,
+				Values:   []string{"kube-system"},
+			},
+		},
+	}
+
+	injection := map[string]string{"injection": "enabled"}
+	injectionSelector := &metav1.LabelSelector{MatchLabels: injection}
+
+	type args struct {
+		rules             []admissionregistrationv1.RuleWithOperations
+		namespaceSelector *metav1.LabelSelector
+		objectSelector    *metav1.LabelSelector
+		namespace         string
+		namespaceLabels   map[string]string
+		labels            map[string]string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "pod creation with no selectors",
+			args: args{rules: podCreate, namespace: "default", labels: web},
+			want: true,
+		},
+		{
+			name: "wildcard rule",
+			args: args{rules: wildcard, namespace: "default", labels: web},
+			want: true,
+		},
+		{
+			name: "pod updates only",
+			args: args{rules: podUpdate, namespace: "default", labels: web},
+			want: false,
+		},
+		{
+			name: "pod subresource only",
+			args: args{rules: podSubresource, namespace: "default", labels: web},
+			want: false,
+		},
+		{
+			name: "cluster scoped resources only",
+			args: args{rules: clusterScoped, namespace: "default", labels: web},
+			want: false,
+		},
+		{
+			name: "object selector match",
+			args: args{rules: podCreate, objectSelector: webSelector, namespace: "default", labels: web},
+			want: true,
+		},
+		{
+			name: "object selector mismatch",
+			args: args{rules: podCreate, objectSelector: webSelector, namespace: "default", labels: map[string]string{"app": "db"}},
+			want: false,
+		},
+		{
+			name: "namespace selector match",
+			args: args{rules: podCreate, namespaceSelector: excludeSystem, namespace: "default", labels: web},
+			want: true,
+		},
+		{
+			name: "namespace selector mismatch",
+			args: args{rules: podCreate, namespaceSelector: excludeSystem, namespace: "kube-system", labels: web},
+			want: false,
+		},
+		{
+			name: "namespace label selector match",
+			args: args{rules: podCreate, namespaceSelector: injectionSelector, namespace: "default", namespaceLabels: injection, labels: web},
+			want: true,
+		},
+		{
+			name: "namespace label selector mismatch",
+			args: args{rules: podCreate, namespaceSelector: injectionSelector, namespace: "default", namespaceLabels: map[string]string{"team": "web"}, labels: web},
+			want: false,
+		},
+		{
+			name: "namespace not collected",
+			args: args{rules: podCreate, namespaceSelector: injectionSelector, namespace: "default", labels: web},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := WebhookInterceptsPod(tt.args.rules, tt.args.namespaceSelector, tt.args.objectSelector,
+				tt.args.namespace, tt.args.namespaceLabels, tt.args.labels); got != tt.want {
+				t.Errorf("WebhookInterceptsPod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"os"
 	"testing"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	v1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/DataDog/KubeHound/pkg/config"
 	"github.com/DataDog/KubeHound/pkg/globals/types"
@@ -543,11 +545,18 @@ func TestConverter_PodPipeline(t *testing.T) {
 		Err:   nil,
 	})
 
+	ak := cachekey.Automount("app-monitors", "test-app")
+	c.EXPECT().Get(mock.Anything, ak).Return(&cache.CacheResult{
+		Value: nil,
+		Err:   cache.ErrNoEntry,
+	})
+
 	// Collector input -> store pod
 	storePod, err := NewStoreWithCache(testConfig, c).Pod(t.Context(), input)
 	assert.NoError(t, err, "store pod convert error")
 
 	assert.Equal(t, storePod.NodeId.Hex(), id)
+	assert.True(t, storePod.Automount)
 	assert.Equal(t, storePod.K8.Name, input.Name)
 	assert.True(t, storePod.IsNamespaced)
 	assert.Equal(t, storePod.K8.Namespace, input.Namespace)
@@ -583,6 +592,12 @@ func TestConverter_PodChildPipeline(t *testing.T) {
 		Err:   nil,
 	})
 
+	ak := cachekey.Automount("app-monitors", "test-app")
+	c.EXPECT().Get(mock.Anything, ak).Return(&cache.CacheResult{
+		Value: nil,
+		Err:   cache.ErrNoEntry,
+	})
+
 	ik := cachekey.Identity("app-monitors", "test-app")
 	iid := store.ObjectID().Hex()
 	c.EXPECT().Get(mock.Anything, ik).Return(&cache.CacheResult{
@@ -664,6 +679,7 @@ func TestConverter_PodChildPipeline(t *testing.T) {
 	assert.Equal(t, "/var/run/datadog-agent", graphVolume.SourcePath)
 	assert.Equal(t, "/var/run/datadog-agent", graphVolume.MountPath)
 	assert.False(t, graphVolume.Readonly)
+	assert.Equal(t, "datadog agent runtime and configuration directory", graphVolume.MountReason)
 
 	graphVolume, err = NewGraph(testConfig).Volume(storeVolume1, storePod)
 	assert.NoError(t, err, "graph volume convert error")
@@ -677,6 +693,190 @@ func TestConverter_PodChildPipeline(t *testing.T) {
 	assert.Equal(t, "/var/lib/kubelet/pods/5a9fc508-8410-444a-bf63-9f11e5979bee/volumes/kubernetes.io~projected/kube-api-access-4x9fz/token", graphVolume.SourcePath)
 	assert.Equal(t, "/var/run/secrets/kubernetes.io/serviceaccount", graphVolume.MountPath)
 	assert.True(t, graphVolume.Readonly)
+	assert.Empty(t, graphVolume.MountReason)
+}
+
+func TestConverter_InitContainer(t *testing.T) {
+	t.Parallel()
+
+	input, err := loadTestObject[types.PodType]("testdata/pod.json")
+	assert.NoError(t, err, "pod load error")
+
+	c := mocks.NewCacheReader(t)
+	c.EXPECT().Get(mock.Anything, cachekey.Node("test-node.ec2.internal")).Return(&cache.CacheResult{
+		Value: store.ObjectID().Hex(),
+		Err:   nil,
+	})
+	c.EXPECT().Get(mock.Anything, cachekey.Automount("app-monitors", "test-app")).Return(&cache.CacheResult{
+		Value: nil,
+		Err:   cache.ErrNoEntry,
+	})
+
+	storePod, err := NewStoreWithCache(testConfig, c).Pod(t.Context(), input)
+	assert.NoError(t, err, "store pod convert error")
+
+	// Privileged init container tweaking the node sysctls
+	privileged := true
+	inContainer := v1.Container{
+		Name:            "sysctl",
+		Image:           "busybox:1.28",
+		Command:         []string{"sysctl", "-w", "vm.max_map_count=262144"},
+		SecurityContext: &v1.SecurityContext{Privileged: &privileged},
+	}
+
+	storeContainer, err := NewStoreWithCache(testConfig, c).InitContainer(t.Context(), &inContainer, storePod)
+	assert.NoError(t, err, "store init container convert error")
+
+	assert.True(t, storeContainer.Init)
+	assert.False(t, storeContainer.Ephemeral)
+	assert.Equal(t, storeContainer.PodId, storePod.Id)
+
+	graphContainer, err := NewGraph(testConfig).Container(storeContainer, storePod)
+	assert.NoError(t, err, "graph container convert error")
+
+	assert.True(t, graphContainer.Init)
+	assert.False(t, graphContainer.Ephemeral)
+	assert.True(t, graphContainer.Privileged)
+	assert.Equal(t, storeContainer.Inherited.PodName, graphContainer.Pod)
+}
+
+func TestConverter_EphemeralContainer(t *testing.T) {
+	t.Parallel()
+
+	input, err := loadTestObject[types.PodType]("testdata/pod.json")
+	assert.NoError(t, err, "pod load error")
+
+	c := mocks.NewCacheReader(t)
+	nid := store.ObjectID().Hex()
+	c.EXPECT().Get(mock.Anything, cachekey.Node("test-node.ec2.internal")).Return(&cache.CacheResult{
+		Value: nid,
+		Err:   nil,
+	})
+	c.EXPECT().Get(mock.Anything, cachekey.Automount("app-monitors", "test-app")).Return(&cache.CacheResult{
+		Value: nil,
+		Err:   cache.ErrNoEntry,
+	})
+
+	storePod, err := NewStoreWithCache(testConfig, c).Pod(t.Context(), input)
+	assert.NoError(t, err, "store pod convert error")
+
+	// Ephemeral container injected via kubectl debug, targeting the process namespace of an existing container
+	privileged := true
+	inContainer := v1.EphemeralContainer{
+		EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name:            "debugger-x7k2p",
+			Image:           "busybox:1.28",
+			SecurityContext: &v1.SecurityContext{Privileged: &privileged},
+		},
+		TargetContainerName: "elasticsearch",
+	}
+
+	storeContainer, err := NewStoreWithCache(testConfig, c).EphemeralContainer(t.Context(), &inContainer, storePod)
+	assert.NoError(t, err, "store ephemeral container convert error")
+
+	assert.True(t, storeContainer.Ephemeral)
+	assert.Equal(t, storeContainer.NodeId.Hex(), nid)
+	assert.Equal(t, storeContainer.PodId, storePod.Id)
+	assert.Equal(t, "debugger-x7k2p", storeContainer.K8.Name)
+	assert.Equal(t, storeContainer.Inherited.ServiceAccount, storePod.K8.Spec.ServiceAccountName)
+
+	graphContainer, err := NewGraph(testConfig).Container(storeContainer, storePod)
+	assert.NoError(t, err, "graph container convert error")
+
+	assert.True(t, graphContainer.Ephemeral)
+	assert.False(t, graphContainer.Init)
+	assert.True(t, graphContainer.Privileged)
+	assert.Equal(t, "busybox:1.28", graphContainer.Image)
+	assert.Equal(t, storeContainer.Inherited.PodName, graphContainer.Pod)
+}
+
+func TestConverter_PodAutomountDisabled(t *testing.T) {
+	t.Parallel()
+
+	input, err := loadTestObject[types.PodType]("testdata/pod.json")
+	assert.NoError(t, err, "pod load error")
+
+	c := mocks.NewCacheReader(t)
+	c.EXPECT().Get(mock.Anything, cachekey.Node("test-node.ec2.internal")).Return(&cache.CacheResult{
+		Value: store.ObjectID().Hex(),
+		Err:   nil,
+	})
+
+	// Automount disabled at the service account level
+	c.EXPECT().Get(mock.Anything, cachekey.Automount("app-monitors", "test-app")).Return(&cache.CacheResult{
+		Value: false,
+		Err:   nil,
+	}).Once()
+
+	storePod, err := NewStoreWithCache(testConfig, c).Pod(t.Context(), input)
+	assert.NoError(t, err, "store pod convert error")
+	assert.False(t, storePod.Automount)
+
+	inContainer := input.Spec.Containers[0]
+	storeContainer, err := NewStoreWithCache(testConfig, c).Container(t.Context(), &inContainer, storePod)
+	assert.NoError(t, err, "store container convert error")
+	assert.False(t, storeContainer.Inherited.Automount)
+
+	inVolume := storeContainer.K8.VolumeMounts[1]
+	_, err = NewStoreWithCache(testConfig, c).Volume(t.Context(), &inVolume, storePod, storeContainer)
+	assert.ErrorIs(t, err, ErrProjectedNoAutomount)
+
+	// Automount enabled at the pod level takes precedence over the service account setting
+	automount := true
+	input.Spec.AutomountServiceAccountToken = &automount
+	storePod, err = NewStoreWithCache(testConfig, c).Pod(t.Context(), input)
+	assert.NoError(t, err, "store pod convert error")
+	assert.True(t, storePod.Automount)
+}
+
+func TestConverter_PodWorkload(t *testing.T) {
+	t.Parallel()
+
+	input, err := loadTestObject[types.PodType]("testdata/pod.json")
+	assert.NoError(t, err, "pod load error")
+
+	// Pod managed by a deployment via an intermediate replica set
+	isController := true
+	input.Labels["pod-template-hash"] = "5d8f7c9b6"
+	input.OwnerReferences = []metav1.OwnerReference{
+		{
+			APIVersion: "apps/v1",
+			Kind:       "ReplicaSet",
+			Name:       "app-monitors-5d8f7c9b6",
+			Controller: &isController,
+		},
+	}
+
+	wid := store.ObjectID()
+	c := mocks.NewCacheReader(t)
+	c.EXPECT().Get(mock.Anything, cachekey.Node("test-node.ec2.internal")).Return(&cache.CacheResult{
+		Value: store.ObjectID().Hex(),
+		Err:   nil,
+	})
+	c.EXPECT().Get(mock.Anything, cachekey.Automount("app-monitors", "test-app")).Return(&cache.CacheResult{
+		Value: nil,
+		Err:   cache.ErrNoEntry,
+	})
+	c.EXPECT().Get(mock.Anything, cachekey.Workload("Deployment", "app-monitors", "test-app")).Return(&cache.CacheResult{
+		Value: wid.Hex(),
+		Err:   nil,
+	}).Once()
+
+	storePod, err := NewStoreWithCache(testConfig, c).Pod(t.Context(), input)
+	assert.NoError(t, err, "store pod convert error")
+	assert.Equal(t, wid, storePod.WorkloadId)
+
+	// Pods managed by a workload that was not collected are not linked to any workload
+	input.OwnerReferences[0].Kind = "Job"
+	input.OwnerReferences[0].Name = "report-28876140"
+	c.EXPECT().Get(mock.Anything, cachekey.Workload("Job", "report-28876140", "test-app")).Return(&cache.CacheResult{
+		Value: nil,
+		Err:   cache.ErrNoEntry,
+	}).Once()
+
+	storePod, err = NewStoreWithCache(testConfig, c).Pod(t.Context(), input)
+	assert.NoError(t, err, "store pod convert error")
+	assert.True(t, storePod.WorkloadId.IsZero())
 }
 
 func TestConverter_PodCacheFailure(t *testing.T) {
@@ -748,6 +948,10 @@ func TestConverter_EndpointPrivatePipeline(t *testing.T) {
 		Value: store.ObjectID().Hex(),
 		Err:   nil,
 	})
+	c.EXPECT().Get(mock.Anything, mock.AnythingOfType("*cachekey.automountCacheKey")).Return(&cache.CacheResult{
+		Value: nil,
+		Err:   cache.ErrNoEntry,
+	})
 	converter := NewStoreWithCache(testConfig, c)
 
 	// Collector input -> store model
@@ -790,3 +994,423 @@ func TestConverter_EndpointPrivatePipeline(t *testing.T) {
 	assert.Equal(t, "TCP", graphEp.Protocol)
 	assert.Equal(t, shared.EndpointExposureNodeIP, graphEp.Exposure)
 }
+
+func TestConverter_SecretPipeline(t *testing.T) {
+	t.Parallel()
+
+	input, err := loadTestObject[types.SecretType]("testdata/secret.json")
+	assert.NoError(t, err, "secret load error")
+
+	// Collector input -> store model
+	storeSecret, err := NewStore(testConfig).Secret(t.Context(), input)
+	assert.NoError(t, err, "store secret convert error")
+
+	assert.Equal(t, storeSecret.Name, input.Name)
+	assert.True(t, storeSecret.IsNamespaced)
+	assert.Equal(t, storeSecret.Namespace, input.Namespace)
+	assert.Equal(t, storeSecret.Type, v1.SecretTypeServiceAccountToken)
+	assert.Equal(t, storeSecret.ServiceAccount, "app-monitors")
+	assert.Equal(t, storeSecret.Runtime.Cluster.Name, testConfig.Dynamic.Cluster.Name)
+	assert.Equal(t, storeSecret.Runtime.RunID, testConfig.Dynamic.RunID.String())
+
+	// Store model -> graph model
+	graphSecret, err := NewGraph(testConfig).Secret(storeSecret)
+	assert.NoError(t, err, "graph secret convert error")
+
+	assert.Equal(t, storeSecret.Id.Hex(), graphSecret.StoreID)
+	assert.Equal(t, graphSecret.App, "test-app")
+	assert.Equal(t, graphSecret.Service, "test-service")
+	assert.Equal(t, graphSecret.Team, "test-team")
+	assert.Equal(t, storeSecret.Name, graphSecret.Name)
+	assert.Equal(t, storeSecret.Namespace, graphSecret.Namespace)
+	assert.Equal(t, "kubernetes.io/service-account-token", graphSecret.Type)
+	assert.Equal(t, "app-monitors", graphSecret.ServiceAccount)
+}
+
+func TestConverter_ServiceAccountPipeline(t *testing.T) {
+	t.Parallel()
+
+	input, err := loadTestObject[types.ServiceAccountType]("testdata/serviceaccount.json")
+	assert.NoError(t, err, "service account load error")
+
+	// Collector input -> store model
+	storeSa, err := NewStore(testConfig).ServiceAccount(t.Context(), input)
+	assert.NoError(t, err, "store service account convert error")
+
+	assert.Equal(t, storeSa.Name, input.Name)
+	assert.True(t, storeSa.IsNamespaced)
+	assert.Equal(t, storeSa.Namespace, input.Namespace)
+	assert.False(t, storeSa.Automount)
+	assert.Equal(t, []string{"registry-credentials"}, storeSa.ImagePullSecrets)
+	assert.Equal(t, []string{"app-monitors-token-7pkhv"}, storeSa.TokenSecrets)
+	assert.Equal(t, storeSa.Runtime.Cluster.Name, testConfig.Dynamic.Cluster.Name)
+	assert.Equal(t, storeSa.Runtime.RunID, testConfig.Dynamic.RunID.String())
+
+	// Store service account -> store identity
+	storeIdentity, err := NewStore(testConfig).IdentityServiceAccount(t.Context(), storeSa)
+	assert.NoError(t, err, "store identity convert error")
+
+	assert.Equal(t, storeSa.IdentityId, storeIdentity.Id)
+	assert.Equal(t, storeSa.Name, storeIdentity.Name)
+	assert.Equal(t, storeSa.Namespace, storeIdentity.Namespace)
+	assert.Equal(t, shared.IdentityTypeSA, storeIdentity.Type)
+
+	// Store model -> graph model
+	graphIdentity, err := NewGraph(testConfig).Identity(storeIdentity)
+	assert.NoError(t, err, "graph identity convert error")
+
+	assert.Equal(t, storeIdentity.Id.Hex(), graphIdentity.StoreID)
+	assert.Equal(t, graphIdentity.App, "test-app")
+	assert.Equal(t, graphIdentity.Service, "test-service")
+	assert.Equal(t, graphIdentity.Team, "test-team")
+	assert.True(t, graphIdentity.IsNamespaced)
+	assert.False(t, graphIdentity.Automount)
+	assert.Equal(t, []string{"registry-credentials"}, graphIdentity.ImagePullSecrets)
+	assert.Equal(t, []string{"app-monitors-token-7pkhv"}, graphIdentity.TokenSecrets)
+}
+
+func TestConverter_CloudIdentityPipeline(t *testing.T) {
+	t.Parallel()
+
+	input, err := loadTestObject[types.ServiceAccountType]("testdata/serviceaccount.json")
+	assert.NoError(t, err, "service account load error")
+
+	// Collector input -> store model
+	storeSa, err := NewStore(testConfig).ServiceAccount(t.Context(), input)
+	assert.NoError(t, err, "store service account convert error")
+
+	expected := store.CloudPrincipal{
+		Provider: shared.CloudProviderAWS,
+		Type:     shared.CloudIdentityTypeRole,
+		Name:     "arn:aws:iam::123456789012:role/app-monitors",
+		Account:  "123456789012",
+	}
+	assert.Equal(t, []store.CloudPrincipal{expected}, storeSa.CloudIdentities)
+
+	// Store cloud principal -> store cloud identity
+	storeCloudIdentity, err := NewStore(testConfig).CloudIdentity(t.Context(), storeSa.CloudIdentities[0])
+	assert.NoError(t, err, "store cloud identity convert error")
+
+	assert.Equal(t, expected.Provider, storeCloudIdentity.Provider)
+	assert.Equal(t, expected.Type, storeCloudIdentity.Type)
+	assert.Equal(t, expected.Name, storeCloudIdentity.Name)
+	assert.Equal(t, expected.Account, storeCloudIdentity.Account)
+	assert.Equal(t, storeCloudIdentity.Runtime.RunID, testConfig.Dynamic.RunID.String())
+
+	// Store model -> graph model
+	graphCloudIdentity, err := NewGraph(testConfig).CloudIdentity(storeCloudIdentity)
+	assert.NoError(t, err, "graph cloud identity convert error")
+
+	assert.Equal(t, storeCloudIdentity.Id.Hex(), graphCloudIdentity.StoreID)
+	assert.False(t, graphCloudIdentity.IsNamespaced)
+	assert.Equal(t, expected.Name, graphCloudIdentity.Name)
+	assert.Equal(t, expected.Provider, graphCloudIdentity.Provider)
+	assert.Equal(t, expected.Type, graphCloudIdentity.Type)
+	assert.Equal(t, expected.Account, graphCloudIdentity.Account)
+	assert.Equal(t, testConfig.Dynamic.Cluster.Name, graphCloudIdentity.Cluster)
+}
+
+func TestConverter_WorkloadPipeline(t *testing.T) {
+	t.Parallel()
+
+	input, err := loadTestObject[types.DeploymentType]("testdata/deployment.json")
+	assert.NoError(t, err, "deployment load error")
+
+	// Collector input -> store model
+	storeWorkload, err := NewStore(testConfig).Deployment(t.Context(), input)
+	assert.NoError(t, err, "store deployment convert error")
+
+	assert.Equal(t, storeWorkload.Name, input.Name)
+	assert.True(t, storeWorkload.IsNamespaced)
+	assert.Equal(t, storeWorkload.Namespace, input.Namespace)
+	assert.Equal(t, shared.WorkloadTypeDeployment, storeWorkload.Kind)
+	assert.Equal(t, "apps", storeWorkload.APIGroup)
+	assert.Equal(t, "deployments", storeWorkload.Resource)
+	assert.Equal(t, "app-monitors", storeWorkload.ServiceAccount)
+	assert.Equal(t, storeWorkload.K8.UID, input.UID)
+	assert.Equal(t, storeWorkload.Runtime.Cluster.Name, testConfig.Dynamic.Cluster.Name)
+	assert.Equal(t, storeWorkload.Runtime.RunID, testConfig.Dynamic.RunID.String())
+
+	// Store model -> graph model
+	graphWorkload, err := NewGraph(testConfig).Workload(storeWorkload)
+	assert.NoError(t, err, "graph workload convert error")
+
+	assert.Equal(t, storeWorkload.Id.Hex(), graphWorkload.StoreID)
+	assert.Equal(t, graphWorkload.App, "test-app")
+	assert.Equal(t, graphWorkload.Service, "test-service")
+	assert.Equal(t, graphWorkload.Team, "test-team")
+	assert.True(t, graphWorkload.IsNamespaced)
+	assert.Equal(t, shared.WorkloadTypeDeployment, graphWorkload.Kind)
+	assert.Equal(t, "app-monitors", graphWorkload.ServiceAccount)
+
+	// Pods run as the default service account unless otherwise specified
+	input.Spec.Template.Spec.ServiceAccountName = ""
+	storeWorkload, err = NewStore(testConfig).Deployment(t.Context(), input)
+	assert.NoError(t, err, "store deployment convert error")
+	assert.Equal(t, "default", storeWorkload.ServiceAccount)
+}
+
+func TestConverter_NetworkPolicy(t *testing.T) {
+	t.Parallel()
+
+	input, err := loadTestObject[types.NetworkPolicyType]("testdata/networkpolicy.json")
+	assert.NoError(t, err, "network policy load error")
+
+	// Collector input -> store model
+	storePolicy, err := NewStore(testConfig).NetworkPolicy(t.Context(), input)
+	assert.NoError(t, err, "store network policy convert error")
+
+	assert.Equal(t, input.Name, storePolicy.Name)
+	assert.True(t, storePolicy.IsNamespaced)
+	assert.Equal(t, input.Namespace, storePolicy.Namespace)
+	assert.Equal(t, "test-app", storePolicy.Ownership.Application)
+	assert.Equal(t, input.Spec, storePolicy.K8.Spec)
+	assert.Equal(t, storePolicy.Runtime.Cluster.Name, testConfig.Dynamic.Cluster.Name)
+	assert.Equal(t, storePolicy.Runtime.RunID, testConfig.Dynamic.RunID.String())
+}
+
+func TestConverter_PersistentVolume(t *testing.T) {
+	t.Parallel()
+
+	input, err := loadTestObject[types.PersistentVolumeType]("testdata/persistentvolume.json")
+	assert.NoError(t, err, "persistent volume load error")
+
+	// Collector input -> store model
+	storeVolume, err := NewStore(testConfig).PersistentVolume(t.Context(), input)
+	assert.NoError(t, err, "store persistent volume convert error")
+
+	assert.Equal(t, input.Name, storeVolume.Name)
+	assert.Equal(t, shared.VolumeTypeHost, storeVolume.Type)
+	assert.Equal(t, "/var/lib/test-app", storeVolume.SourcePath)
+	assert.Equal(t, "test-app-data", storeVolume.ClaimName)
+	assert.Equal(t, "test-app", storeVolume.ClaimNamespace)
+	assert.Equal(t, storeVolume.Runtime.Cluster.Name, testConfig.Dynamic.Cluster.Name)
+
+	claim, err := loadTestObject[types.PersistentVolumeClaimType]("testdata/persistentvolumeclaim.json")
+	assert.NoError(t, err, "persistent volume claim load error")
+
+	storeClaim, err := NewStore(testConfig).PersistentVolumeClaim(t.Context(), claim)
+	assert.NoError(t, err, "store persistent volume claim convert error")
+
+	assert.Equal(t, claim.Name, storeClaim.Name)
+	assert.True(t, storeClaim.IsNamespaced)
+	assert.Equal(t, claim.Namespace, storeClaim.Namespace)
+	assert.Equal(t, "test-app-data", storeClaim.VolumeName)
+	assert.Equal(t, storeClaim.Runtime.RunID, testConfig.Dynamic.RunID.String())
+}
+
+func TestConverter_VolumeTypes(t *testing.T) {
+	t.Parallel()
+
+	pod := &store.Pod{
+		Id:     store.ObjectID(),
+		NodeId: store.ObjectID(),
+		K8: v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-pod",
+				Namespace: "test-app",
+				UID:       "5a9fc508-8410-444a-bf63-9f11e5979bee",
+			},
+			Spec: v1.PodSpec{
+				Volumes: []v1.Volume{
+					{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{
+						LocalObjectReference: v1.LocalObjectReference{Name: "test-config"},
+					}}},
+					{Name: "scratch", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+					{Name: "secrets-store", VolumeSource: v1.VolumeSource{CSI: &v1.CSIVolumeSource{
+						Driver: "secrets-store.csi.k8s.io",
+					}}},
+					{Name: "shared", VolumeSource: v1.VolumeSource{NFS: &v1.NFSVolumeSource{
+						Server: "nfs.test.local",
+						Path:   "/exports/shared",
+					}}},
+					{Name: "data", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "test-app-data",
+						ReadOnly:  true,
+					}}},
+					{Name: "unbound", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "test-app-pending",
+					}}},
+				},
+			},
+		},
+	}
+	container := &store.Container{Id: store.ObjectID()}
+
+	c := mocks.NewCacheReader(t)
+	c.EXPECT().Get(mock.Anything, cachekey.PersistentVolumeClaim("test-app-data", "test-app")).Return(&cache.CacheResult{
+		Value: "test-app-data",
+		Err:   nil,
+	})
+	c.EXPECT().Get(mock.Anything, cachekey.PersistentVolume("test-app-data")).Return(&cache.CacheResult{
+		Value: store.PersistentVolume{
+			Name:       "test-app-data",
+			Type:       shared.VolumeTypeHost,
+			SourcePath: "/var/lib/test-app",
+		},
+		Err: nil,
+	})
+	c.EXPECT().Get(mock.Anything, cachekey.PersistentVolumeClaim("test-app-pending", "test-app")).Return(&cache.CacheResult{
+		Value: nil,
+		Err:   cache.ErrNoEntry,
+	})
+
+	sc := NewStoreWithCache(testConfig, c)
+
+	// ConfigMap
+	v, err := sc.Volume(t.Context(), &v1.VolumeMount{Name: "config", MountPath: "/etc/config"}, pod, container)
+	assert.NoError(t, err, "store volume convert error")
+	assert.Equal(t, shared.VolumeTypeConfigMap, v.Type)
+	assert.Equal(t, "test-config", v.TargetName)
+	assert.Equal(t, "test-app", v.TargetNamespace)
+	assert.Equal(t, "/var/lib/kubelet/pods/5a9fc508-8410-444a-bf63-9f11e5979bee/volumes/kubernetes.io~configmap/config", v.SourcePath)
+	assert.False(t, v.HostBacked())
+
+	// EmptyDir
+	v, err = sc.Volume(t.Context(), &v1.VolumeMount{Name: "scratch", MountPath: "/scratch"}, pod, container)
+	assert.NoError(t, err, "store volume convert error")
+	assert.Equal(t, shared.VolumeTypeEmptyDir, v.Type)
+	assert.Equal(t, "/var/lib/kubelet/pods/5a9fc508-8410-444a-bf63-9f11e5979bee/volumes/kubernetes.io~empty-dir/scratch", v.SourcePath)
+
+	// CSI
+	v, err = sc.Volume(t.Context(), &v1.VolumeMount{Name: "secrets-store", MountPath: "/mnt/secrets"}, pod, container)
+	assert.NoError(t, err, "store volume convert error")
+	assert.Equal(t, shared.VolumeTypeCSI, v.Type)
+	assert.Equal(t, "secrets-store.csi.k8s.io", v.TargetName)
+	assert.Equal(t, "/var/lib/kubelet/pods/5a9fc508-8410-444a-bf63-9f11e5979bee/volumes/kubernetes.io~csi/secrets-store/mount", v.SourcePath)
+
+	// NFS
+	v, err = sc.Volume(t.Context(), &v1.VolumeMount{Name: "shared", MountPath: "/shared", ReadOnly: true}, pod, container)
+	assert.NoError(t, err, "store volume convert error")
+	assert.Equal(t, shared.VolumeTypeNFS, v.Type)
+	assert.Equal(t, "nfs.test.local:/exports/shared", v.SourcePath)
+	assert.True(t, v.ReadOnly)
+
+	// PersistentVolumeClaim bound to a hostPath persistent volume
+	v, err = sc.Volume(t.Context(), &v1.VolumeMount{Name: "data", MountPath: "/data"}, pod, container)
+	assert.NoError(t, err, "store volume convert error")
+	assert.Equal(t, shared.VolumeTypePersistentVolumeClaim, v.Type)
+	assert.Equal(t, "test-app-data", v.TargetName)
+	assert.Equal(t, shared.VolumeTypeHost, v.BackingType)
+	assert.Equal(t, "test-app-data", v.BackingName)
+	assert.Equal(t, "/var/lib/test-app", v.SourcePath)
+	assert.True(t, v.ReadOnly)
+	assert.True(t, v.HostBacked())
+
+	graphVolume, err := NewGraph(testConfig).Volume(v, pod)
+	assert.NoError(t, err, "graph volume convert error")
+	assert.Equal(t, shared.VolumeTypePersistentVolumeClaim, graphVolume.Type)
+	assert.Equal(t, "test-app-data", graphVolume.ClaimName)
+	assert.Equal(t, shared.VolumeTypeHost, graphVolume.BackingType)
+	assert.Equal(t, "/var/lib/test-app", graphVolume.SourcePath)
+
+	// Unresolved PersistentVolumeClaim
+	v, err = sc.Volume(t.Context(), &v1.VolumeMount{Name: "unbound", MountPath: "/pending"}, pod, container)
+	assert.NoError(t, err, "store volume convert error")
+	assert.Equal(t, shared.VolumeTypePersistentVolumeClaim, v.Type)
+	assert.Empty(t, v.BackingType)
+	assert.Empty(t, v.SourcePath)
+	assert.False(t, v.HostBacked())
+}
+
+func TestConverter_WebhookPipeline(t *testing.T) {
+	t.Parallel()
+
+	input, err := loadTestObject[types.MutatingWebhookConfigurationType]("testdata/mutatingwebhookconfiguration.json")
+	assert.NoError(t, err, "mutating webhook configuration load error")
+
+	// Collector input -> store model
+	storeWebhook, err := NewStore(testConfig).MutatingWebhook(t.Context(), &input.Webhooks[0], input)
+	assert.NoError(t, err, "store mutating webhook convert error")
+
+	assert.Equal(t, "inject.app.example.com", storeWebhook.Name)
+	assert.Equal(t, input.Name, storeWebhook.Configuration)
+	assert.Equal(t, shared.WebhookTypeMutating, storeWebhook.Type)
+	assert.Equal(t, "Ignore", storeWebhook.FailurePolicy)
+	assert.Equal(t, "test-app", storeWebhook.ServiceNamespace)
+	assert.Equal(t, "app-injector", storeWebhook.ServiceName)
+	assert.Empty(t, storeWebhook.URL)
+	assert.Len(t, storeWebhook.Rules, 2)
+	assert.Equal(t, map[string]string{"injection": "enabled"}, storeWebhook.NamespaceSelector.MatchLabels)
+	assert.Len(t, storeWebhook.ObjectSelector.MatchExpressions, 1)
+	assert.Equal(t, storeWebhook.Runtime.Cluster.Name, testConfig.Dynamic.Cluster.Name)
+	assert.Equal(t, storeWebhook.Runtime.RunID, testConfig.Dynamic.RunID.String())
+
+	// Store model -> graph model
+	graphWebhook, err := NewGraph(testConfig).Webhook(storeWebhook)
+	assert.NoError(t, err, "graph webhook convert error")
+
+	assert.Equal(t, storeWebhook.Id.Hex(), graphWebhook.StoreID)
+	assert.Equal(t, graphWebhook.App, "test-app")
+	assert.Equal(t, graphWebhook.Service, "test-service")
+	assert.Equal(t, graphWebhook.Team, "test-team")
+	assert.False(t, graphWebhook.IsNamespaced)
+	assert.Equal(t, "inject.app.example.com", graphWebhook.Name)
+	assert.Equal(t, "app-injector", graphWebhook.Configuration)
+	assert.Equal(t, shared.WebhookTypeMutating, graphWebhook.Type)
+	assert.Equal(t, "Ignore", graphWebhook.FailurePolicy)
+	assert.Equal(t, []string{"pods", "pods/ephemeralcontainers", "deployments"}, graphWebhook.Resources)
+	assert.Equal(t, []string{"CREATE", "UPDATE"}, graphWebhook.Operations)
+}
+
+func TestConverter_ValidatingWebhook(t *testing.T) {
+	t.Parallel()
+
+	url := "https://validate.example.com"
+	input := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "policy",
+		},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{
+				Name: "validate.example.com",
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					URL: &url,
+				},
+			},
+		},
+	}
+
+	// Webhooks served by an URL have no backing service and fail closed by default
+	storeWebhook, err := NewStore(testConfig).ValidatingWebhook(t.Context(), &input.Webhooks[0], input)
+	assert.NoError(t, err, "store validating webhook convert error")
+
+	assert.Equal(t, shared.WebhookTypeValidating, storeWebhook.Type)
+	assert.Equal(t, "Fail", storeWebhook.FailurePolicy)
+	assert.Equal(t, url, storeWebhook.URL)
+	assert.Empty(t, storeWebhook.ServiceName)
+	assert.Nil(t, storeWebhook.ObjectSelector)
+}
+
+func TestConverter_NamespacePipeline(t *testing.T) {
+	t.Parallel()
+
+	input, err := loadTestObject[types.NamespaceType]("testdata/namespace.json")
+	assert.NoError(t, err, "namespace load error")
+
+	// Collector input -> store model
+	storeNamespace, err := NewStore(testConfig).Namespace(t.Context(), input)
+	assert.NoError(t, err, "store namespace convert error")
+
+	assert.Equal(t, input.Name, storeNamespace.K8.Name)
+	assert.Equal(t, shared.PodSecurityLevelBaseline, storeNamespace.PSAEnforce)
+	assert.Equal(t, shared.PodSecurityLevelPrivileged, storeNamespace.PSAAudit)
+	assert.Equal(t, shared.PodSecurityLevelRestricted, storeNamespace.PSAWarn)
+	assert.Equal(t, storeNamespace.Runtime.Cluster.Name, testConfig.Dynamic.Cluster.Name)
+	assert.Equal(t, storeNamespace.Runtime.RunID, testConfig.Dynamic.RunID.String())
+
+	// Store model -> graph model
+	graphNamespace, err := NewGraph(testConfig).Namespace(storeNamespace)
+	assert.NoError(t, err, "graph namespace convert error")
+
+	assert.Equal(t, storeNamespace.Id.Hex(), graphNamespace.StoreID)
+	assert.Equal(t, graphNamespace.App, "test-app")
+	assert.Equal(t, graphNamespace.Service, "test-service")
+	assert.Equal(t, graphNamespace.Team, "test-team")
+	assert.False(t, graphNamespace.IsNamespaced)
+	assert.Equal(t, "test-app", graphNamespace.Name)
+	assert.Equal(t, shared.PodSecurityLevelBaseline, graphNamespace.PSAEnforce)
+	assert.Equal(t, "v1.29", graphNamespace.PSAEnforceVersion)
+	assert.Equal(t, shared.PodSecurityLevelPrivileged, graphNamespace.PSAAudit)
+	assert.Equal(t, shared.PodSecurityLevelRestricted, graphNamespace.PSAWarn)
+}
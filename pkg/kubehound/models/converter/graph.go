@@ -1,13 +1,18 @@
 package converter
 
 import (
+	"slices"
 	"strconv"
 	"strings"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 
 	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/hostmount"
+	"github.com/DataDog/KubeHound/pkg/kubehound/libkube"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/graph"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/kubehound/risk"
 )
@@ -44,6 +49,8 @@ func (c *GraphConverter) Container(input *store.Container, parent *store.Pod) (*
 		Pod:         input.Inherited.PodName,
 		Node:        input.Inherited.NodeName,
 		RunAsUser:   input.Inherited.RunAsUser,
+		Init:        input.Init,
+		Ephemeral:   input.Ephemeral,
 	}
 
 	// Determine if a user is set in the security context
@@ -88,15 +95,15 @@ func (c *GraphConverter) Container(input *store.Container, parent *store.Pod) (*
 // Node returns the graph representation of a node vertex from a store node model input.
 func (c *GraphConverter) Node(input *store.Node) (*graph.Node, error) {
 	output := &graph.Node{
-		StoreID:  input.Id.Hex(),
-		App:      input.Ownership.Application,
-		Team:     input.Ownership.Team,
-		Service:  input.Ownership.Service,
-		RunID:    c.runtime.RunID.String(),
-		Cluster:  c.runtime.Cluster.Name,
-		Name:     input.K8.Name,
-		Critical: risk.Engine().IsCritical(input),
+		StoreID: input.Id.Hex(),
+		App:     input.Ownership.Application,
+		Team:    input.Ownership.Team,
+		Service: input.Ownership.Service,
+		RunID:   c.runtime.RunID.String(),
+		Cluster: c.runtime.Cluster.Name,
+		Name:    input.K8.Name,
 	}
+	output.CriticalRule, output.Critical = risk.Engine().CriticalRule(input)
 
 	if input.IsNamespaced {
 		output.IsNamespaced = true
@@ -119,8 +126,9 @@ func (c *GraphConverter) Pod(input *store.Pod) (*graph.Pod, error) {
 		Namespace:      input.K8.GetNamespace(),
 		ServiceAccount: input.K8.Spec.ServiceAccountName,
 		Node:           input.K8.Spec.NodeName,
-		Critical:       risk.Engine().IsCritical(input),
 	}
+	output.CriticalRule, output.Critical = risk.Engine().CriticalRule(input)
+
 	if input.K8.Spec.ShareProcessNamespace != nil {
 		output.ShareProcessNamespace = *input.K8.Spec.ShareProcessNamespace
 	}
@@ -152,6 +160,17 @@ func (c *GraphConverter) Volume(input *store.Volume, parent *store.Pod) (*graph.
 		output.IsNamespaced = true
 	}
 
+	// Persistent volume claims are resolved to the storage backing their bound persistent volume
+	if input.Type == shared.VolumeTypePersistentVolumeClaim {
+		output.ClaimName = input.TargetName
+		output.BackingType = input.BackingType
+	}
+
+	// Record why a host mount is deemed safe (writable) or dangerous (read-only)
+	if input.HostBacked() {
+		output.MountReason, _ = hostmount.Instance().Reason(c.runtime.Cluster.Name, input.SourcePath, input.ReadOnly)
+	}
+
 	return output, nil
 }
 
@@ -188,19 +207,20 @@ func (c *GraphConverter) flattenPolicyRules(input []rbacv1.PolicyRule) []string
 // PermissionSet returns the graph representation of a role vertex from a store role model input.
 func (c *GraphConverter) PermissionSet(input *store.PermissionSet) (*graph.PermissionSet, error) {
 	output := &graph.PermissionSet{
-		StoreID:     input.Id.Hex(),
-		App:         input.Ownership.Application,
-		Team:        input.Ownership.Team,
-		Service:     input.Ownership.Service,
-		RunID:       c.runtime.RunID.String(),
-		Cluster:     c.runtime.Cluster.Name,
-		Name:        input.Name,
-		Namespace:   input.Namespace,
-		Role:        input.RoleName,
-		RoleBinding: input.RoleBindingName,
-		Rules:       c.flattenPolicyRules(input.Rules),
-		Critical:    risk.Engine().IsCritical(input),
+		StoreID:        input.Id.Hex(),
+		App:            input.Ownership.Application,
+		Team:           input.Ownership.Team,
+		Service:        input.Ownership.Service,
+		RunID:          c.runtime.RunID.String(),
+		Cluster:        c.runtime.Cluster.Name,
+		Name:           input.Name,
+		Namespace:      input.Namespace,
+		Role:           input.RoleName,
+		RoleBinding:    input.RoleBindingName,
+		Rules:          c.flattenPolicyRules(input.Rules),
+		AggregatedFrom: append(make([]string, 0, len(input.AggregatedFrom)), input.AggregatedFrom...),
 	}
+	output.CriticalRule, output.Critical = risk.Engine().CriticalRule(input)
 
 	if output.Namespace != "" {
 		output.IsNamespaced = true
@@ -212,17 +232,20 @@ func (c *GraphConverter) PermissionSet(input *store.PermissionSet) (*graph.Permi
 // Identity returns the graph representation of an identity vertex from a store identity model input.
 func (c *GraphConverter) Identity(input *store.Identity) (*graph.Identity, error) {
 	output := &graph.Identity{
-		StoreID:   input.Id.Hex(),
-		App:       input.Ownership.Application,
-		Team:      input.Ownership.Team,
-		Service:   input.Ownership.Service,
-		RunID:     c.runtime.RunID.String(),
-		Cluster:   c.runtime.Cluster.Name,
-		Name:      input.Name,
-		Namespace: input.Namespace,
-		Type:      input.Type,
-		Critical:  risk.Engine().IsCritical(input),
+		StoreID:          input.Id.Hex(),
+		App:              input.Ownership.Application,
+		Team:             input.Ownership.Team,
+		Service:          input.Ownership.Service,
+		RunID:            c.runtime.RunID.String(),
+		Cluster:          c.runtime.Cluster.Name,
+		Name:             input.Name,
+		Namespace:        input.Namespace,
+		Type:             input.Type,
+		Automount:        input.Automount,
+		ImagePullSecrets: append(make([]string, 0, len(input.ImagePullSecrets)), input.ImagePullSecrets...),
+		TokenSecrets:     append(make([]string, 0, len(input.TokenSecrets)), input.TokenSecrets...),
 	}
+	output.CriticalRule, output.Critical = risk.Engine().CriticalRule(input)
 
 	if output.Namespace != "" {
 		output.IsNamespaced = true
@@ -231,6 +254,22 @@ func (c *GraphConverter) Identity(input *store.Identity) (*graph.Identity, error
 	return output, nil
 }
 
+// CloudIdentity returns the graph representation of a cloud identity vertex from a store cloud identity model input.
+func (c *GraphConverter) CloudIdentity(input *store.CloudIdentity) (*graph.CloudIdentity, error) {
+	output := &graph.CloudIdentity{
+		StoreID:      input.Id.Hex(),
+		RunID:        c.runtime.RunID.String(),
+		Cluster:      c.runtime.Cluster.Name,
+		IsNamespaced: false,
+		Name:         input.Name,
+		Provider:     input.Provider,
+		Type:         input.Type,
+		Account:      input.Account,
+	}
+
+	return output, nil
+}
+
 // Endpoint returns the graph representation of an endpoint vertex from a store endpoint model input.
 func (c *GraphConverter) Endpoint(input *store.Endpoint) (*graph.Endpoint, error) {
 	output := &graph.Endpoint{
@@ -255,3 +294,112 @@ func (c *GraphConverter) Endpoint(input *store.Endpoint) (*graph.Endpoint, error
 
 	return output, nil
 }
+
+// Secret returns the graph representation of a secret vertex from a store secret model input.
+func (c *GraphConverter) Secret(input *store.Secret) (*graph.Secret, error) {
+	output := &graph.Secret{
+		StoreID:        input.Id.Hex(),
+		App:            input.Ownership.Application,
+		Team:           input.Ownership.Team,
+		Service:        input.Ownership.Service,
+		RunID:          c.runtime.RunID.String(),
+		Cluster:        c.runtime.Cluster.Name,
+		IsNamespaced:   input.IsNamespaced,
+		Namespace:      input.Namespace,
+		Name:           input.Name,
+		Type:           string(input.Type),
+		ServiceAccount: input.ServiceAccount,
+	}
+
+	return output, nil
+}
+
+// Workload returns the graph representation of a workload controller vertex from a store workload model input.
+func (c *GraphConverter) Workload(input *store.Workload) (*graph.Workload, error) {
+	output := &graph.Workload{
+		StoreID:        input.Id.Hex(),
+		App:            input.Ownership.Application,
+		Team:           input.Ownership.Team,
+		Service:        input.Ownership.Service,
+		RunID:          c.runtime.RunID.String(),
+		Cluster:        c.runtime.Cluster.Name,
+		IsNamespaced:   input.IsNamespaced,
+		Namespace:      input.Namespace,
+		Name:           input.Name,
+		Kind:           input.Kind,
+		ServiceAccount: input.ServiceAccount,
+	}
+
+	return output, nil
+}
+
+// flattenWebhookRules returns the distinct resources and operations intercepted by the rules of a webhook.
+// This is necessary as graph databases cannot typically handle complex data type attributes on nodes.
+func (c *GraphConverter) flattenWebhookRules(input []admissionregistrationv1.RuleWithOperations) ([]string, []string) {
+	resources := make([]string, 0)
+	operations := make([]string, 0)
+
+	for _, rule := range input {
+		for _, r := range rule.Resources {
+			if !slices.Contains(resources, r) {
+				resources = append(resources, r)
+			}
+		}
+
+		for _, op := range rule.Operations {
+			if !slices.Contains(operations, string(op)) {
+				operations = append(operations, string(op))
+			}
+		}
+	}
+
+	return resources, operations
+}
+
+// Webhook returns the graph representation of an admission webhook vertex from a store webhook model input.
+func (c *GraphConverter) Webhook(input *store.Webhook) (*graph.Webhook, error) {
+	output := &graph.Webhook{
+		StoreID:          input.Id.Hex(),
+		App:              input.Ownership.Application,
+		Team:             input.Ownership.Team,
+		Service:          input.Ownership.Service,
+		RunID:            c.runtime.RunID.String(),
+		Cluster:          c.runtime.Cluster.Name,
+		IsNamespaced:     false,
+		Name:             input.Name,
+		Configuration:    input.Configuration,
+		Type:             input.Type,
+		FailurePolicy:    input.FailurePolicy,
+		ServiceNamespace: input.ServiceNamespace,
+		ServiceName:      input.ServiceName,
+		URL:              input.URL,
+	}
+
+	output.Resources, output.Operations = c.flattenWebhookRules(input.Rules)
+
+	return output, nil
+}
+
+// Namespace returns the graph representation of a namespace vertex from a store namespace model input.
+func (c *GraphConverter) Namespace(input *store.Namespace) (*graph.Namespace, error) {
+	output := &graph.Namespace{
+		StoreID:           input.Id.Hex(),
+		App:               input.Ownership.Application,
+		Team:              input.Ownership.Team,
+		Service:           input.Ownership.Service,
+		RunID:             c.runtime.RunID.String(),
+		Cluster:           c.runtime.Cluster.Name,
+		IsNamespaced:      false,
+		Name:              input.K8.Name,
+		PSAEnforce:        input.PSAEnforce,
+		PSAEnforceVersion: "latest",
+		PSAAudit:          input.PSAAudit,
+		PSAWarn:           input.PSAWarn,
+	}
+
+	if version, ok := input.K8.Labels[libkube.LabelPodSecurityEnforceVersion]; ok {
+		output.PSAEnforceVersion = version
+	}
+
+	return output, nil
+}
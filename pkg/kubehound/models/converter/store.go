@@ -4,11 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/DataDog/KubeHound/pkg/config"
 	"github.com/DataDog/KubeHound/pkg/globals/types"
@@ -29,6 +34,7 @@ var (
 	ErrNoCacheInitialized    = errors.New("cache reader required for conversion")
 	ErrDanglingRoleBinding   = errors.New("role binding found with no matching role")
 	ErrProjectedDefaultToken = errors.New("projected volume grant no access (default serviceaccount)")
+	ErrProjectedNoAutomount  = errors.New("projected token volume not mounted (automount disabled)")
 	ErrEndpointTarget        = errors.New("target reference for an endpoint could not be resolved")
 	ErrRoleCacheMiss         = errors.New("missing role in cache")
 	ErrRoleBindProperties    = errors.New("incorrect combination of (cluster) role and (cluster) role binding properties")
@@ -69,6 +75,7 @@ func (c *StoreConverter) Container(_ context.Context, input types.ContainerType,
 			HostIPC:        parent.K8.Spec.HostIPC,
 			HostNetwork:    parent.K8.Spec.HostNetwork,
 			ServiceAccount: parent.K8.Spec.ServiceAccountName,
+			Automount:      parent.Automount,
 		},
 		K8:        *input,
 		Ownership: store.ExtractOwnership(parent.K8.Labels),
@@ -86,6 +93,32 @@ func (c *StoreConverter) Container(_ context.Context, input types.ContainerType,
 	return output, nil
 }
 
+// InitContainer returns the store representation of a K8s init container from an input K8s container object.
+func (c *StoreConverter) InitContainer(ctx context.Context, input types.ContainerType, parent *store.Pod) (*store.Container, error) {
+	output, err := c.Container(ctx, input, parent)
+	if err != nil {
+		return nil, err
+	}
+	output.Init = true
+
+	return output, nil
+}
+
+// EphemeralContainer returns the store representation of a K8s ephemeral container (e.g injected via kubectl debug)
+// from an input K8s ephemeral container object. Ephemeral containers share the same specification as regular containers.
+func (c *StoreConverter) EphemeralContainer(ctx context.Context, input types.EphemeralContainerType,
+	parent *store.Pod) (*store.Container, error) {
+	container := corev1.Container(input.EphemeralContainerCommon)
+
+	output, err := c.Container(ctx, &container, parent)
+	if err != nil {
+		return nil, err
+	}
+	output.Ephemeral = true
+
+	return output, nil
+}
+
 // Node returns the store representation of a K8s node from an input K8s node object.
 func (c *StoreConverter) Node(ctx context.Context, input types.NodeType) (*store.Node, error) {
 	if c.cache == nil {
@@ -103,6 +136,12 @@ func (c *StoreConverter) Node(ctx context.Context, input types.NodeType) (*store
 		output.IsNamespaced = true
 	}
 
+	// Cloud IAM principal whose credentials are available to the node via the instance metadata service
+	output.CloudIdentities = make([]store.CloudPrincipal, 0, 1)
+	if principal, ok := libkube.NodeCloudPrincipal(input); ok {
+		output.CloudIdentities = append(output.CloudIdentities, principal)
+	}
+
 	// Retrieve the associated identity store ID from the cache
 	uid, err := libkube.NodeIdentity(ctx, c.cache, input.Name)
 	switch {
@@ -119,7 +158,7 @@ func (c *StoreConverter) Node(ctx context.Context, input types.NodeType) (*store
 }
 
 // Pod returns the store representation of a K8s pod from an input K8s pod object.
-// NOTE: requires cache access (NodeKey).
+// NOTE: requires cache access (NodeKey, AutomountKey).
 func (c *StoreConverter) Pod(ctx context.Context, input types.PodType) (*store.Pod, error) {
 	if c.cache == nil {
 		return nil, ErrNoCacheInitialized
@@ -130,12 +169,24 @@ func (c *StoreConverter) Pod(ctx context.Context, input types.PodType) (*store.P
 		return nil, err
 	}
 
+	automount, err := c.automount(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	wid, err := c.podWorkload(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
 	output := &store.Pod{
-		Id:        store.ObjectID(),
-		NodeId:    nid,
-		K8:        *input,
-		Ownership: store.ExtractOwnership(input.Labels),
-		Runtime:   store.Runtime(c.runtime),
+		Id:         store.ObjectID(),
+		NodeId:     nid,
+		WorkloadId: wid,
+		Automount:  automount,
+		K8:         *input,
+		Ownership:  store.ExtractOwnership(input.Labels),
+		Runtime:    store.Runtime(c.runtime),
 	}
 
 	if len(input.Namespace) != 0 {
@@ -145,10 +196,69 @@ func (c *StoreConverter) Pod(ctx context.Context, input types.PodType) (*store.P
 	return output, nil
 }
 
+// automount returns whether the service account token is automatically mounted into the provided pod. The pod setting
+// takes precedence over the service account setting and automount is enabled unless explicitly disabled.
+func (c *StoreConverter) automount(ctx context.Context, pod types.PodType) (bool, error) {
+	if pod.Spec.AutomountServiceAccountToken != nil {
+		return *pod.Spec.AutomountServiceAccountToken, nil
+	}
+
+	automount, err := c.cache.Get(ctx, cachekey.Automount(pod.Spec.ServiceAccountName, pod.Namespace)).Bool()
+	switch {
+	case err == nil:
+		return automount, nil
+	case errors.Is(err, cache.ErrNoEntry):
+		// Service account was not collected, fallback to the K8s default
+		return true, nil
+	default:
+		return false, err
+	}
+}
+
+// podWorkload returns the store id of the workload controller managing the pod, or a nil id if the pod is not managed
+// by a collected workload. Deployment pods are owned by an intermediate replica set named <deployment>-<pod-template-hash>.
+func (c *StoreConverter) podWorkload(ctx context.Context, pod types.PodType) (primitive.ObjectID, error) {
+	owner := metav1.GetControllerOfNoCopy(&pod.ObjectMeta)
+	if owner == nil {
+		return primitive.NilObjectID, nil
+	}
+
+	kind, name := owner.Kind, owner.Name
+	switch owner.Kind {
+	case "ReplicaSet":
+		hash, ok := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+		if !ok {
+			// Standalone replica sets are not collected
+			return primitive.NilObjectID, nil
+		}
+
+		kind = shared.WorkloadTypeDeployment
+		name = strings.TrimSuffix(owner.Name, "-"+hash)
+	case shared.WorkloadTypeDaemonSet, shared.WorkloadTypeStatefulSet, shared.WorkloadTypeJob:
+	default:
+		return primitive.NilObjectID, nil
+	}
+
+	wid, err := c.cache.Get(ctx, cachekey.Workload(kind, name, pod.Namespace)).ObjectID()
+	switch {
+	case err == nil:
+		return wid, nil
+	case errors.Is(err, cache.ErrNoEntry):
+		return primitive.NilObjectID, nil
+	default:
+		return primitive.NilObjectID, err
+	}
+}
+
 // handleProjectedToken returns the identity store ID and source path corresponding to a projected token volume mount.
 func (c *StoreConverter) handleProjectedToken(ctx context.Context, input types.VolumeMountType,
 	volume *corev1.Volume, pod *store.Pod) (primitive.ObjectID, string, error) {
 
+	// The service account token is not automatically mounted if automount has been disabled on the pod or service account
+	if libkube.IsAutomountTokenVolume(volume.Name) && !pod.Automount {
+		return primitive.NilObjectID, "", ErrProjectedNoAutomount
+	}
+
 	// Retrieve the associated identity store ID from the cache
 	said, err := c.cache.Get(ctx, cachekey.Identity(pod.K8.Spec.ServiceAccountName, pod.K8.Namespace)).ObjectID()
 	switch {
@@ -222,6 +332,32 @@ func (c *StoreConverter) Volume(ctx context.Context, input types.VolumeMountType
 				output.Type = shared.VolumeTypeProjected
 				output.SourcePath = source
 				output.ProjectedId = said
+			case v.ConfigMap != nil:
+				output.Type = shared.VolumeTypeConfigMap
+				output.SourcePath = libkube.ConfigMapVolumePath(string(pod.K8.UID), v.Name)
+				output.TargetName = v.ConfigMap.Name
+				output.TargetNamespace = pod.K8.Namespace
+			case v.EmptyDir != nil:
+				output.Type = shared.VolumeTypeEmptyDir
+				output.SourcePath = libkube.EmptyDirVolumePath(string(pod.K8.UID), v.Name)
+			case v.CSI != nil:
+				output.Type = shared.VolumeTypeCSI
+				output.SourcePath = libkube.CSIVolumePath(string(pod.K8.UID), v.Name)
+				output.TargetName = v.CSI.Driver
+			case v.NFS != nil:
+				output.Type = shared.VolumeTypeNFS
+				output.SourcePath = fmt.Sprintf("%s:%s", v.NFS.Server, v.NFS.Path)
+				output.ReadOnly = input.ReadOnly || v.NFS.ReadOnly
+			case v.PersistentVolumeClaim != nil:
+				output.Type = shared.VolumeTypePersistentVolumeClaim
+				output.TargetName = v.PersistentVolumeClaim.ClaimName
+				output.TargetNamespace = pod.K8.Namespace
+				output.ReadOnly = input.ReadOnly || v.PersistentVolumeClaim.ReadOnly
+
+				err := c.resolvePersistentVolumeClaim(ctx, output)
+				if err != nil {
+					return nil, fmt.Errorf("persistent volume claim volume (%s) processing: %w", v.Name, err)
+				}
 			default:
 				return nil, ErrUnsupportedVolume
 			}
@@ -237,6 +373,35 @@ func (c *StoreConverter) Volume(ctx context.Context, input types.VolumeMountType
 	return output, nil
 }
 
+// resolvePersistentVolumeClaim resolves the persistent volume bound to a persistent volume claim volume and records
+// its backing storage in the volume. Claims that are not bound or whose persistent volume was not collected are left
+// unresolved.
+func (c *StoreConverter) resolvePersistentVolumeClaim(ctx context.Context, volume *store.Volume) error {
+	pvName, err := c.cache.Get(ctx, cachekey.PersistentVolumeClaim(volume.TargetName, volume.TargetNamespace)).Text()
+	if err != nil {
+		if errors.Is(err, cache.ErrNoEntry) {
+			return nil
+		}
+
+		return err
+	}
+
+	pv, err := c.cache.Get(ctx, cachekey.PersistentVolume(pvName)).PersistentVolume()
+	if err != nil {
+		if errors.Is(err, cache.ErrNoEntry) {
+			return nil
+		}
+
+		return err
+	}
+
+	volume.BackingType = pv.Type
+	volume.BackingName = pv.Name
+	volume.SourcePath = pv.SourcePath
+
+	return nil
+}
+
 // Role returns the store representation of a K8s role from an input K8s Role object.
 func (c *StoreConverter) Role(_ context.Context, input types.RoleType) (*store.Role, error) {
 	return &store.Role{
@@ -380,6 +545,7 @@ func (c *StoreConverter) Identity(ctx context.Context, input *store.BindSubject,
 		Name:      input.Subject.Name,
 		Namespace: "",
 		Type:      input.Subject.Kind,
+		Automount: input.Subject.Kind == shared.IdentityTypeSA,
 		Ownership: parent.Ownership,
 		Runtime:   store.Runtime(c.runtime),
 	}
@@ -470,6 +636,7 @@ func (c *StoreConverter) PermissionSet(ctx context.Context, roleBinding *store.R
 		IsNamespaced:    role.IsNamespaced,
 		Namespace:       role.Namespace,
 		Rules:           role.Rules,
+		AggregatedFrom:  role.AggregatedFrom,
 		Ownership:       role.Ownership,
 		Runtime:         store.Runtime(c.runtime),
 	}
@@ -520,6 +687,7 @@ func (c *StoreConverter) PermissionSetCluster(ctx context.Context, clusterRoleBi
 		IsNamespaced:    role.IsNamespaced,
 		Namespace:       role.Namespace,
 		Rules:           role.Rules,
+		AggregatedFrom:  role.AggregatedFrom,
 		Ownership:       role.Ownership,
 		Runtime:         store.Runtime(c.runtime),
 	}
@@ -641,3 +809,297 @@ func (c *StoreConverter) EndpointPrivate(_ context.Context, port *corev1.Contain
 
 	return output, nil
 }
+
+// Secret returns the store representation of a K8s secret from an input K8s Secret object.
+// NOTE: only the secret metadata and type are retained, the secret data is never stored.
+func (c *StoreConverter) Secret(_ context.Context, input types.SecretType) (*store.Secret, error) {
+	output := &store.Secret{
+		Id:           store.ObjectID(),
+		IsNamespaced: true,
+		Namespace:    input.Namespace,
+		Name:         input.Name,
+		Type:         input.Type,
+		K8:           *input.ObjectMeta.DeepCopy(),
+		Ownership:    store.ExtractOwnership(input.Labels),
+		Runtime:      store.Runtime(c.runtime),
+	}
+
+	// Service account token secrets reference the service account they have been generated for
+	if input.Type == corev1.SecretTypeServiceAccountToken {
+		output.ServiceAccount = input.Annotations[corev1.ServiceAccountNameKey]
+	}
+
+	return output, nil
+}
+
+// NetworkPolicy returns the store representation of a K8s network policy from an input K8s NetworkPolicy object.
+func (c *StoreConverter) NetworkPolicy(_ context.Context, input types.NetworkPolicyType) (*store.NetworkPolicy, error) {
+	output := &store.NetworkPolicy{
+		Id:           store.ObjectID(),
+		IsNamespaced: true,
+		Namespace:    input.Namespace,
+		Name:         input.Name,
+		K8:           *input,
+		Ownership:    store.ExtractOwnership(input.Labels),
+		Runtime:      store.Runtime(c.runtime),
+	}
+
+	// Managed fields are of no use to evaluate the policy and only bloat the store
+	output.K8.ManagedFields = nil
+
+	return output, nil
+}
+
+// PersistentVolume returns the store representation of a K8s persistent volume from an input K8s PersistentVolume
+// object. Only the hostPath, local, NFS and CSI backing storages are resolved.
+func (c *StoreConverter) PersistentVolume(_ context.Context, input types.PersistentVolumeType) (*store.PersistentVolume, error) {
+	output := &store.PersistentVolume{
+		Id:        store.ObjectID(),
+		Name:      input.Name,
+		K8:        *input,
+		Ownership: store.ExtractOwnership(input.Labels),
+		Runtime:   store.Runtime(c.runtime),
+	}
+
+	// Managed fields are of no use to resolve the backing storage and only bloat the store
+	output.K8.ManagedFields = nil
+
+	src := input.Spec.PersistentVolumeSource
+	switch {
+	case src.HostPath != nil:
+		output.Type = shared.VolumeTypeHost
+		output.SourcePath = src.HostPath.Path
+	case src.Local != nil:
+		output.Type = shared.VolumeTypeLocal
+		output.SourcePath = src.Local.Path
+	case src.NFS != nil:
+		output.Type = shared.VolumeTypeNFS
+		output.SourcePath = fmt.Sprintf("%s:%s", src.NFS.Server, src.NFS.Path)
+	case src.CSI != nil:
+		output.Type = shared.VolumeTypeCSI
+		output.SourcePath = src.CSI.VolumeHandle
+	}
+
+	if input.Spec.ClaimRef != nil {
+		output.ClaimName = input.Spec.ClaimRef.Name
+		output.ClaimNamespace = input.Spec.ClaimRef.Namespace
+	}
+
+	return output, nil
+}
+
+// PersistentVolumeClaim returns the store representation of a K8s persistent volume claim from an input K8s
+// PersistentVolumeClaim object.
+func (c *StoreConverter) PersistentVolumeClaim(_ context.Context,
+	input types.PersistentVolumeClaimType) (*store.PersistentVolumeClaim, error) {
+
+	output := &store.PersistentVolumeClaim{
+		Id:           store.ObjectID(),
+		IsNamespaced: true,
+		Namespace:    input.Namespace,
+		Name:         input.Name,
+		VolumeName:   input.Spec.VolumeName,
+		K8:           *input,
+		Ownership:    store.ExtractOwnership(input.Labels),
+		Runtime:      store.Runtime(c.runtime),
+	}
+
+	// Managed fields are of no use to resolve the bound volume and only bloat the store
+	output.K8.ManagedFields = nil
+
+	return output, nil
+}
+
+// ServiceAccount returns the store representation of a K8s service account from an input K8s ServiceAccount object.
+func (c *StoreConverter) ServiceAccount(_ context.Context, input types.ServiceAccountType) (*store.ServiceAccount, error) {
+	output := &store.ServiceAccount{
+		Id:           store.ObjectID(),
+		IdentityId:   store.ObjectID(),
+		IsNamespaced: true,
+		Namespace:    input.Namespace,
+		Name:         input.Name,
+		Automount:    true,
+		K8:           *input,
+		Ownership:    store.ExtractOwnership(input.Labels),
+		Runtime:      store.Runtime(c.runtime),
+	}
+
+	// Automount is enabled by default and must be explicitly disabled
+	if input.AutomountServiceAccountToken != nil {
+		output.Automount = *input.AutomountServiceAccountToken
+	}
+
+	output.ImagePullSecrets = make([]string, 0, len(input.ImagePullSecrets))
+	for _, ref := range input.ImagePullSecrets {
+		output.ImagePullSecrets = append(output.ImagePullSecrets, ref.Name)
+	}
+
+	// Legacy (non-projected) token secrets are only referenced by service accounts created prior to K8s v1.24
+	output.TokenSecrets = make([]string, 0, len(input.Secrets))
+	for _, ref := range input.Secrets {
+		output.TokenSecrets = append(output.TokenSecrets, ref.Name)
+	}
+
+	// Cloud IAM principals granted to the service account via workload identity
+	output.CloudIdentities = libkube.ServiceAccountCloudPrincipals(input.Annotations)
+
+	return output, nil
+}
+
+// IdentityServiceAccount returns the store representation of an identity from a store service account input.
+func (c *StoreConverter) IdentityServiceAccount(_ context.Context, input *store.ServiceAccount) (*store.Identity, error) {
+	return &store.Identity{
+		Id:               input.IdentityId,
+		Name:             input.Name,
+		IsNamespaced:     true,
+		Namespace:        input.Namespace,
+		Type:             shared.IdentityTypeSA,
+		Automount:        input.Automount,
+		ImagePullSecrets: input.ImagePullSecrets,
+		TokenSecrets:     input.TokenSecrets,
+		Ownership:        input.Ownership,
+		Runtime:          store.Runtime(c.runtime),
+	}, nil
+}
+
+// CloudIdentity returns the store representation of a cloud identity from a cloud IAM principal granted to a
+// service account or node.
+func (c *StoreConverter) CloudIdentity(_ context.Context, input store.CloudPrincipal) (*store.CloudIdentity, error) {
+	return &store.CloudIdentity{
+		Id:       store.ObjectID(),
+		Provider: input.Provider,
+		Type:     input.Type,
+		Name:     input.Name,
+		Account:  input.Account,
+		Runtime:  store.Runtime(c.runtime),
+	}, nil
+}
+
+// workload returns the store representation of a workload controller from its metadata and pod template.
+func (c *StoreConverter) workload(kind string, apiGroup string, resource string,
+	meta *metav1.ObjectMeta, template *corev1.PodTemplateSpec) *store.Workload {
+
+	output := &store.Workload{
+		Id:             store.ObjectID(),
+		IsNamespaced:   true,
+		Namespace:      meta.Namespace,
+		Name:           meta.Name,
+		Kind:           kind,
+		APIGroup:       apiGroup,
+		Resource:       resource,
+		ServiceAccount: template.Spec.ServiceAccountName,
+		K8:             *meta.DeepCopy(),
+		Ownership:      store.ExtractOwnership(meta.Labels),
+		Runtime:        store.Runtime(c.runtime),
+	}
+
+	// Pods run as the default service account unless otherwise specified
+	if len(output.ServiceAccount) == 0 {
+		output.ServiceAccount = "default"
+	}
+
+	// Managed fields are of no use to KubeHound
+	output.K8.ManagedFields = nil
+
+	return output
+}
+
+// Deployment returns the store representation of a K8s deployment.
+func (c *StoreConverter) Deployment(_ context.Context, input types.DeploymentType) (*store.Workload, error) {
+	return c.workload(shared.WorkloadTypeDeployment, appsv1.GroupName, "deployments",
+		&input.ObjectMeta, &input.Spec.Template), nil
+}
+
+// DaemonSet returns the store representation of a K8s daemon set.
+func (c *StoreConverter) DaemonSet(_ context.Context, input types.DaemonSetType) (*store.Workload, error) {
+	return c.workload(shared.WorkloadTypeDaemonSet, appsv1.GroupName, "daemonsets",
+		&input.ObjectMeta, &input.Spec.Template), nil
+}
+
+// StatefulSet returns the store representation of a K8s stateful set.
+func (c *StoreConverter) StatefulSet(_ context.Context, input types.StatefulSetType) (*store.Workload, error) {
+	return c.workload(shared.WorkloadTypeStatefulSet, appsv1.GroupName, "statefulsets",
+		&input.ObjectMeta, &input.Spec.Template), nil
+}
+
+// Job returns the store representation of a K8s job.
+func (c *StoreConverter) Job(_ context.Context, input types.JobType) (*store.Workload, error) {
+	return c.workload(shared.WorkloadTypeJob, batchv1.GroupName, "jobs",
+		&input.ObjectMeta, &input.Spec.Template), nil
+}
+
+// CronJob returns the store representation of a K8s cron job.
+func (c *StoreConverter) CronJob(_ context.Context, input types.CronJobType) (*store.Workload, error) {
+	return c.workload(shared.WorkloadTypeCronJob, batchv1.GroupName, "cronjobs",
+		&input.ObjectMeta, &input.Spec.JobTemplate.Spec.Template), nil
+}
+
+// webhook returns the store representation of an admission webhook from its client configuration and matching criteria.
+func (c *StoreConverter) webhook(webhookType string, name string, config *metav1.ObjectMeta,
+	clientConfig admissionregistrationv1.WebhookClientConfig, rules []admissionregistrationv1.RuleWithOperations,
+	failurePolicy *admissionregistrationv1.FailurePolicyType, namespaceSelector *metav1.LabelSelector,
+	objectSelector *metav1.LabelSelector) *store.Webhook {
+
+	output := &store.Webhook{
+		Id:                store.ObjectID(),
+		Name:              name,
+		Configuration:     config.Name,
+		Type:              webhookType,
+		FailurePolicy:     string(admissionregistrationv1.Fail),
+		Rules:             rules,
+		NamespaceSelector: namespaceSelector,
+		ObjectSelector:    objectSelector,
+		Ownership:         store.ExtractOwnership(config.Labels),
+		Runtime:           store.Runtime(c.runtime),
+	}
+
+	// The API server rejects requests when the webhook fails unless otherwise specified
+	if failurePolicy != nil {
+		output.FailurePolicy = string(*failurePolicy)
+	}
+
+	// Webhooks are either served by an in-cluster service or by an arbitrary URL
+	if clientConfig.Service != nil {
+		output.ServiceNamespace = clientConfig.Service.Namespace
+		output.ServiceName = clientConfig.Service.Name
+	}
+
+	if clientConfig.URL != nil {
+		output.URL = *clientConfig.URL
+	}
+
+	return output
+}
+
+// MutatingWebhook returns the store representation of a K8s mutating admission webhook from an input K8s
+// MutatingWebhook object and its parent MutatingWebhookConfiguration.
+func (c *StoreConverter) MutatingWebhook(_ context.Context, input types.MutatingWebhookType,
+	parent types.MutatingWebhookConfigurationType) (*store.Webhook, error) {
+
+	return c.webhook(shared.WebhookTypeMutating, input.Name, &parent.ObjectMeta, input.ClientConfig, input.Rules,
+		input.FailurePolicy, input.NamespaceSelector, input.ObjectSelector), nil
+}
+
+// ValidatingWebhook returns the store representation of a K8s validating admission webhook from an input K8s
+// ValidatingWebhook object and its parent ValidatingWebhookConfiguration.
+func (c *StoreConverter) ValidatingWebhook(_ context.Context, input types.ValidatingWebhookType,
+	parent types.ValidatingWebhookConfigurationType) (*store.Webhook, error) {
+
+	return c.webhook(shared.WebhookTypeValidating, input.Name, &parent.ObjectMeta, input.ClientConfig, input.Rules,
+		input.FailurePolicy, input.NamespaceSelector, input.ObjectSelector), nil
+}
+
+// Namespace returns the store representation of a K8s namespace from an input K8s Namespace object.
+func (c *StoreConverter) Namespace(_ context.Context, input types.NamespaceType) (*store.Namespace, error) {
+	output := &store.Namespace{
+		Id:         store.ObjectID(),
+		K8:         *input,
+		PSAEnforce: libkube.PodSecurityLevel(input.Labels, libkube.LabelPodSecurityEnforce),
+		PSAAudit:   libkube.PodSecurityLevel(input.Labels, libkube.LabelPodSecurityAudit),
+		PSAWarn:    libkube.PodSecurityLevel(input.Labels, libkube.LabelPodSecurityWarn),
+		Ownership:  store.ExtractOwnership(input.Labels),
+		Runtime:    store.Runtime(c.runtime),
+	}
+
+	return output, nil
+}
@@ -0,0 +1,15 @@
+package graph
+
+type CloudIdentity struct {
+	StoreID      string `json:"storeID" mapstructure:"storeID"`
+	App          string `json:"app" mapstructure:"app"`
+	Team         string `json:"team" mapstructure:"team"`
+	Service      string `json:"service" mapstructure:"service"`
+	RunID        string `json:"runID" mapstructure:"runID"`
+	Cluster      string `json:"cluster" mapstructure:"cluster"`
+	IsNamespaced bool   `json:"isNamespaced" mapstructure:"isNamespaced"`
+	Name         string `json:"name" mapstructure:"name"`
+	Provider     string `json:"provider" mapstructure:"provider"`
+	Type         string `json:"type" mapstructure:"type"`
+	Account      string `json:"account" mapstructure:"account"`
+}
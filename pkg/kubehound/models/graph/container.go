@@ -27,5 +27,7 @@ type Container struct {
 	Ports        []string              `json:"ports" mapstructure:"ports"`
 	Pod          string                `json:"pod" mapstructure:"pod"`
 	Node         string                `json:"node" mapstructure:"node"`
+	Init         bool                  `json:"init" mapstructure:"init"`
+	Ephemeral    bool                  `json:"ephemeral" mapstructure:"ephemeral"`
 	Compromised  shared.CompromiseType `json:"compromised" mapstructure:"compromised"`
 }
@@ -0,0 +1,16 @@
+package graph
+
+type Namespace struct {
+	StoreID           string `json:"storeID" mapstructure:"storeID"`
+	App               string `json:"app" mapstructure:"app"`
+	Team              string `json:"team" mapstructure:"team"`
+	Service           string `json:"service" mapstructure:"service"`
+	RunID             string `json:"runID" mapstructure:"runID"`
+	Cluster           string `json:"cluster" mapstructure:"cluster"`
+	IsNamespaced      bool   `json:"isNamespaced" mapstructure:"isNamespaced"`
+	Name              string `json:"name" mapstructure:"name"`
+	PSAEnforce        string `json:"psaEnforce" mapstructure:"psaEnforce"`
+	PSAEnforceVersion string `json:"psaEnforceVersion" mapstructure:"psaEnforceVersion"`
+	PSAAudit          string `json:"psaAudit" mapstructure:"psaAudit"`
+	PSAWarn           string `json:"psaWarn" mapstructure:"psaWarn"`
+}
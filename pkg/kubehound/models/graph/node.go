@@ -16,4 +16,5 @@ type Node struct {
 	Namespace    string                `json:"namespace" mapstructure:"namespace"`
 	Compromised  shared.CompromiseType `json:"compromised" mapstructure:"compromised"`
 	Critical     bool                  `json:"critical" mapstructure:"critical"`
+	CriticalRule string                `json:"criticalRule" mapstructure:"criticalRule"`
 }
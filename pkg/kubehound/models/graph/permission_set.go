@@ -1,17 +1,19 @@
 package graph
 
 type PermissionSet struct {
-	StoreID      string   `json:"storeID" mapstructure:"storeID"`
-	App          string   `json:"app" mapstructure:"app"`
-	Team         string   `json:"team" mapstructure:"team"`
-	Service      string   `json:"service" mapstructure:"service"`
-	RunID        string   `json:"runID" mapstructure:"runID"`
-	Cluster      string   `json:"cluster" mapstructure:"cluster"`
-	Name         string   `json:"name" mapstructure:"name"`
-	Role         string   `json:"role" mapstructure:"role"`
-	RoleBinding  string   `json:"roleBinding" mapstructure:"roleBinding"`
-	IsNamespaced bool     `json:"isNamespaced" mapstructure:"isNamespaced"`
-	Namespace    string   `json:"namespace" mapstructure:"namespace"`
-	Rules        []string `json:"rules" mapstructure:"rules"`
-	Critical     bool     `json:"critical" mapstructure:"critical"`
+	StoreID        string   `json:"storeID" mapstructure:"storeID"`
+	App            string   `json:"app" mapstructure:"app"`
+	Team           string   `json:"team" mapstructure:"team"`
+	Service        string   `json:"service" mapstructure:"service"`
+	RunID          string   `json:"runID" mapstructure:"runID"`
+	Cluster        string   `json:"cluster" mapstructure:"cluster"`
+	Name           string   `json:"name" mapstructure:"name"`
+	Role           string   `json:"role" mapstructure:"role"`
+	RoleBinding    string   `json:"roleBinding" mapstructure:"roleBinding"`
+	IsNamespaced   bool     `json:"isNamespaced" mapstructure:"isNamespaced"`
+	Namespace      string   `json:"namespace" mapstructure:"namespace"`
+	Rules          []string `json:"rules" mapstructure:"rules"`
+	AggregatedFrom []string `json:"aggregatedFrom" mapstructure:"aggregatedFrom"`
+	Critical       bool     `json:"critical" mapstructure:"critical"`
+	CriticalRule   string   `json:"criticalRule" mapstructure:"criticalRule"`
 }
@@ -19,4 +19,5 @@ type Pod struct {
 	Node                  string                `json:"node" mapstructure:"node"`
 	Compromised           shared.CompromiseType `json:"compromised" mapstructure:"compromised"`
 	Critical              bool                  `json:"critical" mapstructure:"critical"`
+	CriticalRule          string                `json:"criticalRule" mapstructure:"criticalRule"`
 }
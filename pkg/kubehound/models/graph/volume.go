@@ -14,4 +14,7 @@ type Volume struct {
 	SourcePath   string `json:"sourcePath" mapstructure:"sourcePath"`
 	MountPath    string `json:"mountPath" mapstructure:"mountPath"`
 	Readonly     bool   `json:"readonly" mapstructure:"readonly"`
+	MountReason  string `json:"mountReason" mapstructure:"mountReason"`
+	ClaimName    string `json:"claimName" mapstructure:"claimName"`
+	BackingType  string `json:"backingType" mapstructure:"backingType"`
 }
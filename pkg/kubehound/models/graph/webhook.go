@@ -0,0 +1,20 @@
+package graph
+
+type Webhook struct {
+	StoreID          string   `json:"storeID" mapstructure:"storeID"`
+	App              string   `json:"app" mapstructure:"app"`
+	Team             string   `json:"team" mapstructure:"team"`
+	Service          string   `json:"service" mapstructure:"service"`
+	RunID            string   `json:"runID" mapstructure:"runID"`
+	Cluster          string   `json:"cluster" mapstructure:"cluster"`
+	IsNamespaced     bool     `json:"isNamespaced" mapstructure:"isNamespaced"`
+	Name             string   `json:"name" mapstructure:"name"`
+	Configuration    string   `json:"configuration" mapstructure:"configuration"`
+	Type             string   `json:"type" mapstructure:"type"`
+	FailurePolicy    string   `json:"failurePolicy" mapstructure:"failurePolicy"`
+	ServiceNamespace string   `json:"serviceNamespace" mapstructure:"serviceNamespace"`
+	ServiceName      string   `json:"serviceName" mapstructure:"serviceName"`
+	URL              string   `json:"url" mapstructure:"url"`
+	Resources        []string `json:"resources" mapstructure:"resources"`
+	Operations       []string `json:"operations" mapstructure:"operations"`
+}
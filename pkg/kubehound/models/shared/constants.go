@@ -1,9 +1,15 @@
 package shared
 
 const (
-	VolumeTypeHost      = "HostPath"
-	VolumeTypeProjected = "Projected"
-	VolumeTypeSecret    = "Secret"
+	VolumeTypeHost                  = "HostPath"
+	VolumeTypeProjected             = "Projected"
+	VolumeTypeSecret                = "Secret"
+	VolumeTypeConfigMap             = "ConfigMap"
+	VolumeTypeEmptyDir              = "EmptyDir"
+	VolumeTypeCSI                   = "CSI"
+	VolumeTypePersistentVolumeClaim = "PersistentVolumeClaim"
+	VolumeTypeLocal                 = "Local"
+	VolumeTypeNFS                   = "NFS"
 )
 
 const (
@@ -18,6 +24,38 @@ const (
 	IdentityTypeGroup = "Group"
 )
 
+const (
+	WorkloadTypeDeployment  = "Deployment"
+	WorkloadTypeDaemonSet   = "DaemonSet"
+	WorkloadTypeStatefulSet = "StatefulSet"
+	WorkloadTypeJob         = "Job"
+	WorkloadTypeCronJob     = "CronJob"
+)
+
+const (
+	WebhookTypeMutating   = "Mutating"
+	WebhookTypeValidating = "Validating"
+)
+
+const (
+	PodSecurityLevelPrivileged = "privileged"
+	PodSecurityLevelBaseline   = "baseline"
+	PodSecurityLevelRestricted = "restricted"
+)
+
+const (
+	CloudProviderAWS   = "AWS"
+	CloudProviderGCP   = "GCP"
+	CloudProviderAzure = "Azure"
+)
+
+const (
+	CloudIdentityTypeRole            = "Role"            // AWS IAM role (IRSA)
+	CloudIdentityTypeServiceAccount  = "ServiceAccount"  // GCP service account (GKE workload identity)
+	CloudIdentityTypeManagedIdentity = "ManagedIdentity" // Azure managed identity or app registration (Azure workload identity)
+	CloudIdentityTypeInstance        = "Instance"        // Node credentials (instance profile, node service account, kubelet identity)
+)
+
 type CompromiseType int
 
 const (
@@ -0,0 +1,24 @@
+package store
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CloudPrincipal references a cloud IAM principal granted to a K8s service account or node.
+type CloudPrincipal struct {
+	Provider string `bson:"provider"`
+	Type     string `bson:"type"`
+	Name     string `bson:"name"`
+	Account  string `bson:"account"`
+}
+
+// CloudIdentity holds a cloud IAM principal reachable from the cluster. A single cloud identity is created for each
+// distinct principal, regardless of the number of service accounts or nodes it is granted to.
+type CloudIdentity struct {
+	Id       primitive.ObjectID `bson:"_id"`
+	Provider string             `bson:"provider"`
+	Type     string             `bson:"type"`
+	Name     string             `bson:"name"`
+	Account  string             `bson:"account"`
+	Runtime  RuntimeInfo        `bson:"runtime"`
+}
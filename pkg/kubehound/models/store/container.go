@@ -17,6 +17,7 @@ type ContainerInherited struct {
 	HostIPC        bool   `bson:"host_ipc"`
 	HostNetwork    bool   `bson:"host_net"`
 	ServiceAccount string `bson:"service_account"`
+	Automount      bool   `bson:"automount"`
 	RunAsUser      int64  `bson:"run_as_user"`
 }
 
@@ -26,6 +27,8 @@ type Container struct {
 	NodeId    primitive.ObjectID `bson:"node_id"`
 	Inherited ContainerInherited `bson:"inherited"`
 	K8        corev1.Container   `bson:"k8"`
+	Init      bool               `bson:"init"`
+	Ephemeral bool               `bson:"ephemeral"`
 	Ownership OwnershipInfo      `bson:"ownership"`
 	Runtime   RuntimeInfo        `bson:"runtime"`
 }
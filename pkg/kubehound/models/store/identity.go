@@ -5,11 +5,14 @@ import (
 )
 
 type Identity struct {
-	Id           primitive.ObjectID `bson:"_id"`
-	Name         string             `bson:"name"`
-	IsNamespaced bool               `bson:"is_namespaced"`
-	Namespace    string             `bson:"namespace"`
-	Type         string             `bson:"type"`
-	Ownership    OwnershipInfo      `bson:"ownership"`
-	Runtime      RuntimeInfo        `bson:"runtime"`
+	Id               primitive.ObjectID `bson:"_id"`
+	Name             string             `bson:"name"`
+	IsNamespaced     bool               `bson:"is_namespaced"`
+	Namespace        string             `bson:"namespace"`
+	Type             string             `bson:"type"`
+	Automount        bool               `bson:"automount"`
+	ImagePullSecrets []string           `bson:"image_pull_secrets"`
+	TokenSecrets     []string           `bson:"token_secrets"`
+	Ownership        OwnershipInfo      `bson:"ownership"`
+	Runtime          RuntimeInfo        `bson:"runtime"`
 }
@@ -0,0 +1,18 @@
+package store
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Namespace holds a K8s namespace along with the Pod Security Standard levels applied by the Pod Security Admission
+// controller to the pods of the namespace.
+type Namespace struct {
+	Id         primitive.ObjectID `bson:"_id"`
+	K8         corev1.Namespace   `bson:"k8"`
+	PSAEnforce string             `bson:"psa_enforce"`
+	PSAAudit   string             `bson:"psa_audit"`
+	PSAWarn    string             `bson:"psa_warn"`
+	Ownership  OwnershipInfo      `bson:"ownership"`
+	Runtime    RuntimeInfo        `bson:"runtime"`
+}
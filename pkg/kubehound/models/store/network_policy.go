@@ -0,0 +1,18 @@
+package store
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	netv1 "k8s.io/api/networking/v1"
+)
+
+// NetworkPolicy holds a K8s network policy. Network policies are not represented in the graph and are only used
+// to evaluate the reachability of endpoints when building edges.
+type NetworkPolicy struct {
+	Id           primitive.ObjectID  `bson:"_id"`
+	IsNamespaced bool                `bson:"is_namespaced"`
+	Namespace    string              `bson:"namespace"`
+	Name         string              `bson:"name"`
+	K8           netv1.NetworkPolicy `bson:"k8"`
+	Ownership    OwnershipInfo       `bson:"ownership"`
+	Runtime      RuntimeInfo         `bson:"runtime"`
+}
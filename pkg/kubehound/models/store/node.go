@@ -6,10 +6,11 @@ import (
 )
 
 type Node struct {
-	Id           primitive.ObjectID `bson:"_id"`
-	UserId       primitive.ObjectID `bson:"user_id"`
-	IsNamespaced bool               `bson:"is_namespaced"`
-	K8           corev1.Node        `bson:"k8"`
-	Ownership    OwnershipInfo      `bson:"ownership"`
-	Runtime      RuntimeInfo        `bson:"runtime"`
+	Id              primitive.ObjectID `bson:"_id"`
+	UserId          primitive.ObjectID `bson:"user_id"`
+	IsNamespaced    bool               `bson:"is_namespaced"`
+	K8              corev1.Node        `bson:"k8"`
+	CloudIdentities []CloudPrincipal   `bson:"cloud_identities"`
+	Ownership       OwnershipInfo      `bson:"ownership"`
+	Runtime         RuntimeInfo        `bson:"runtime"`
 }
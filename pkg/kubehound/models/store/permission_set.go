@@ -15,6 +15,7 @@ type PermissionSet struct {
 	IsNamespaced    bool                `bson:"is_namespaced"`
 	Namespace       string              `bson:"namespace"`
 	Rules           []rbacv1.PolicyRule `bson:"rules"`
+	AggregatedFrom  []string            `bson:"aggregated_from"`
 	Ownership       OwnershipInfo       `bson:"ownership"`
 	Runtime         RuntimeInfo         `bson:"runtime"`
 }
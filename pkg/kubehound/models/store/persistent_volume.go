@@ -0,0 +1,32 @@
+package store
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PersistentVolume holds a K8s persistent volume. Persistent volumes are not represented in the graph and are only
+// used to resolve the storage backing the persistent volume claims mounted by pods.
+type PersistentVolume struct {
+	Id             primitive.ObjectID      `bson:"_id"`
+	Name           string                  `bson:"name"`
+	Type           string                  `bson:"type"`
+	SourcePath     string                  `bson:"source"`
+	ClaimName      string                  `bson:"claim_name"`
+	ClaimNamespace string                  `bson:"claim_namespace"`
+	K8             corev1.PersistentVolume `bson:"k8"`
+	Ownership      OwnershipInfo           `bson:"ownership"`
+	Runtime        RuntimeInfo             `bson:"runtime"`
+}
+
+// PersistentVolumeClaim holds a K8s persistent volume claim and the name of the persistent volume it is bound to.
+type PersistentVolumeClaim struct {
+	Id           primitive.ObjectID           `bson:"_id"`
+	IsNamespaced bool                         `bson:"is_namespaced"`
+	Namespace    string                       `bson:"namespace"`
+	Name         string                       `bson:"name"`
+	VolumeName   string                       `bson:"volume_name"`
+	K8           corev1.PersistentVolumeClaim `bson:"k8"`
+	Ownership    OwnershipInfo                `bson:"ownership"`
+	Runtime      RuntimeInfo                  `bson:"runtime"`
+}
@@ -8,7 +8,9 @@ import (
 type Pod struct {
 	Id           primitive.ObjectID `bson:"_id"`
 	NodeId       primitive.ObjectID `bson:"node_id"`
+	WorkloadId   primitive.ObjectID `bson:"workload_id"`
 	IsNamespaced bool               `bson:"is_namespaced"`
+	Automount    bool               `bson:"automount"`
 	K8           corev1.Pod         `bson:"k8"`
 	Ownership    OwnershipInfo      `bson:"ownership"`
 	Runtime      RuntimeInfo        `bson:"runtime"`
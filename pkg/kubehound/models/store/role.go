@@ -6,11 +6,12 @@ import (
 )
 
 type Role struct {
-	Id           primitive.ObjectID  `bson:"_id"`
-	Name         string              `bson:"name"`
-	IsNamespaced bool                `bson:"is_namespaced"`
-	Namespace    string              `bson:"namespace"`
-	Rules        []rbacv1.PolicyRule `bson:"rules"`
-	Ownership    OwnershipInfo       `bson:"ownership"`
-	Runtime      RuntimeInfo         `bson:"runtime"`
+	Id             primitive.ObjectID  `bson:"_id"`
+	Name           string              `bson:"name"`
+	IsNamespaced   bool                `bson:"is_namespaced"`
+	Namespace      string              `bson:"namespace"`
+	Rules          []rbacv1.PolicyRule `bson:"rules"`
+	AggregatedFrom []string            `bson:"aggregated_from"`
+	Ownership      OwnershipInfo       `bson:"ownership"`
+	Runtime        RuntimeInfo         `bson:"runtime"`
 }
@@ -0,0 +1,20 @@
+package store
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Secret holds the metadata of a K8s secret. The secret data is never collected nor stored.
+type Secret struct {
+	Id             primitive.ObjectID `bson:"_id"`
+	IsNamespaced   bool               `bson:"is_namespaced"`
+	Namespace      string             `bson:"namespace"`
+	Name           string             `bson:"name"`
+	Type           corev1.SecretType  `bson:"type"`
+	ServiceAccount string             `bson:"service_account"`
+	K8             metav1.ObjectMeta  `bson:"k8"`
+	Ownership      OwnershipInfo      `bson:"ownership"`
+	Runtime        RuntimeInfo        `bson:"runtime"`
+}
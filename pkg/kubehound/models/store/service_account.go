@@ -0,0 +1,21 @@
+package store
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type ServiceAccount struct {
+	Id               primitive.ObjectID    `bson:"_id"`
+	IdentityId       primitive.ObjectID    `bson:"identity_id"`
+	IsNamespaced     bool                  `bson:"is_namespaced"`
+	Namespace        string                `bson:"namespace"`
+	Name             string                `bson:"name"`
+	Automount        bool                  `bson:"automount"`
+	ImagePullSecrets []string              `bson:"image_pull_secrets"`
+	TokenSecrets     []string              `bson:"token_secrets"`
+	CloudIdentities  []CloudPrincipal      `bson:"cloud_identities"`
+	K8               corev1.ServiceAccount `bson:"k8"`
+	Ownership        OwnershipInfo         `bson:"ownership"`
+	Runtime          RuntimeInfo           `bson:"runtime"`
+}
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -17,8 +18,23 @@ type Volume struct {
 	MountPath       string             `bson:"mount"`
 	TargetName      string             `bson:"target_name"`
 	TargetNamespace string             `bson:"target_namespace"`
+	BackingType     string             `bson:"backing_type"`
+	BackingName     string             `bson:"backing_name"`
 	ReadOnly        bool               `bson:"readonly"`
 	Ownership       OwnershipInfo      `bson:"ownership"`
 	Runtime         RuntimeInfo        `bson:"runtime"`
 	K8              corev1.Volume      `bson:"k8"`
 }
+
+// HostBacked returns whether the volume exposes a directory of the node filesystem, either directly via a hostPath
+// volume or via a persistent volume claim bound to a hostPath or local persistent volume.
+func (v *Volume) HostBacked() bool {
+	switch v.Type {
+	case shared.VolumeTypeHost:
+		return true
+	case shared.VolumeTypePersistentVolumeClaim:
+		return v.BackingType == shared.VolumeTypeHost || v.BackingType == shared.VolumeTypeLocal
+	default:
+		return false
+	}
+}
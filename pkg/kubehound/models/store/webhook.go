@@ -0,0 +1,26 @@
+package store
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Webhook holds a single admission webhook of a K8s mutating or validating webhook configuration. The service
+// backing the webhook, its rules and its selectors are retained to resolve the endpoints serving the webhook
+// and the pods it intercepts.
+type Webhook struct {
+	Id                primitive.ObjectID                           `bson:"_id"`
+	Name              string                                       `bson:"name"`
+	Configuration     string                                       `bson:"configuration"`
+	Type              string                                       `bson:"type"`
+	FailurePolicy     string                                       `bson:"failure_policy"`
+	ServiceNamespace  string                                       `bson:"service_namespace"`
+	ServiceName       string                                       `bson:"service_name"`
+	URL               string                                       `bson:"url"`
+	Rules             []admissionregistrationv1.RuleWithOperations `bson:"rules"`
+	NamespaceSelector *metav1.LabelSelector                        `bson:"namespace_selector"`
+	ObjectSelector    *metav1.LabelSelector                        `bson:"object_selector"`
+	Ownership         OwnershipInfo                                `bson:"ownership"`
+	Runtime           RuntimeInfo                                  `bson:"runtime"`
+}
@@ -0,0 +1,22 @@
+package store
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Workload holds a K8s workload controller (deployment, daemon set, stateful set, job or cron job) managing a set of pods.
+// The API group and resource are retained to match the workload against RBAC policy rules.
+type Workload struct {
+	Id             primitive.ObjectID `bson:"_id"`
+	IsNamespaced   bool               `bson:"is_namespaced"`
+	Namespace      string             `bson:"namespace"`
+	Name           string             `bson:"name"`
+	Kind           string             `bson:"kind"`
+	APIGroup       string             `bson:"api_group"`
+	Resource       string             `bson:"resource"`
+	ServiceAccount string             `bson:"service_account"`
+	K8             metav1.ObjectMeta  `bson:"k8"`
+	Ownership      OwnershipInfo      `bson:"ownership"`
+	Runtime        RuntimeInfo        `bson:"runtime"`
+}
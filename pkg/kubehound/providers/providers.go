@@ -9,6 +9,8 @@ import (
 	"github.com/DataDog/KubeHound/pkg/config"
 	"github.com/DataDog/KubeHound/pkg/ingestor"
 	"github.com/DataDog/KubeHound/pkg/kubehound/graph"
+	"github.com/DataDog/KubeHound/pkg/kubehound/hostmount"
+	"github.com/DataDog/KubeHound/pkg/kubehound/risk"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/graphdb"
 	"github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
@@ -84,6 +86,18 @@ func (p *ProvidersFactoryConfig) Close(ctx context.Context) {
 
 func (p *ProvidersFactoryConfig) IngestBuildData(ctx context.Context, khCfg *config.KubehoundConfig) error {
 	l := log.Logger(ctx)
+	// Load the critical asset rules before any vertex is converted
+	err := risk.Initialize(ctx, khCfg)
+	if err != nil {
+		return fmt.Errorf("risk engine initialization: %w", err)
+	}
+
+	// Load the host mount lists before any volume is converted
+	err = hostmount.Initialize(ctx, khCfg)
+	if err != nil {
+		return fmt.Errorf("host mount matcher initialization: %w", err)
+	}
+
 	// Create the collector instance
 	l.Info("Loading Kubernetes data collector client")
 	start := time.Now()
@@ -3,50 +3,130 @@ package risk
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
+	"github.com/DataDog/KubeHound/pkg/config"
 	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 	"github.com/DataDog/KubeHound/pkg/telemetry/log"
 )
 
 var engineInstance *RiskEngine
-var riOnce sync.Once
+var riMu sync.RWMutex
 
-// Engine returns the risk engine singleton instance.
+// Engine returns the risk engine singleton instance. If the engine has not been initialized
+// from the application configuration, the default rule set is used.
 func Engine() *RiskEngine {
-	var err error
-	riOnce.Do(func() {
+	riMu.RLock()
+	engine := engineInstance
+	riMu.RUnlock()
+	if engine != nil {
+		return engine
+	}
+
+	riMu.Lock()
+	defer riMu.Unlock()
+	if engineInstance == nil {
 		l := log.Logger(context.Background())
-		engineInstance, err = newEngine()
+		var err error
+		engineInstance, err = newEngine(&config.RiskConfig{})
 		if err != nil {
 			l.Fatal("Risk engine initialization", log.ErrorField(err))
 		}
-	})
+	}
 
 	return engineInstance
 }
 
+// Initialize (re)creates the risk engine singleton instance from the provided application configuration.
+func Initialize(ctx context.Context, cfg *config.KubehoundConfig) error {
+	l := log.Logger(ctx)
+
+	engine, err := newEngine(&cfg.Risk)
+	if err != nil {
+		return err
+	}
+
+	l.Info("Risk engine initialized", log.Int("rules", len(engine.rules)))
+
+	riMu.Lock()
+	engineInstance = engine
+	riMu.Unlock()
+
+	return nil
+}
+
 // RiskEngine computes which assets are deemed critical based on a set of pre-configured rules.
 type RiskEngine struct {
-	roleMap map[string]bool // Map of critical roles
+	rules []*rule // Ordered list of rules, the first matching rule wins
+}
+
+// NewEngine creates a new risk engine instance from the provided configuration. The singleton returned
+// by Engine() should be preferred outside of tests.
+func NewEngine(cfg *config.RiskConfig) (*RiskEngine, error) {
+	return newEngine(cfg)
 }
 
 // newEngine creates a new risk engine instance. Should not be called directly.
-func newEngine() (*RiskEngine, error) {
+func newEngine(cfg *config.RiskConfig) (*RiskEngine, error) {
+	defs := make([]config.RiskRule, 0, len(cfg.Rules))
+	defs = append(defs, cfg.Rules...)
+
+	if cfg.RulesFile != "" {
+		fileRules, err := LoadRulesFile(cfg.RulesFile)
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, fileRules...)
+	}
+
+	if !cfg.DisableDefaultRules {
+		defs = append(defs, DefaultRules()...)
+	}
+
+	rules := make([]*rule, 0, len(defs))
+	for i := range defs {
+		r, err := compileRule(&defs[i])
+		if err != nil {
+			return nil, fmt.Errorf("risk rule %q: %w", defs[i].Name, err)
+		}
+		rules = append(rules, r)
+	}
+
 	return &RiskEngine{
-		roleMap: CriticalRoleMap,
+		rules: rules,
 	}, nil
 }
 
 // IsCritical reports whether the provided asset should be marked as critical.
-// The function expects a single store model input and currently only supports Roles.
+// The function expects a single store model input and supports permission sets, identities, nodes and pods.
 func (ra *RiskEngine) IsCritical(model any) bool {
+	_, critical := ra.CriticalRule(model)
+
+	return critical
+}
+
+// CriticalRule returns the name of the first rule marking the provided asset as critical, if any.
+func (ra *RiskEngine) CriticalRule(model any) (string, bool) {
+	var a *asset
 	switch o := model.(type) {
 	case *store.PermissionSet:
-		if ra.roleMap[o.RoleName] && !o.IsNamespaced {
-			return true
+		a = permissionSetAsset(o)
+	case *store.Identity:
+		a = identityAsset(o)
+	case *store.Node:
+		a = nodeAsset(o)
+	case *store.Pod:
+		a = podAsset(o)
+	default:
+		return "", false
+	}
+
+	for _, r := range ra.rules {
+		if r.match(a) {
+			return r.name, true
 		}
 	}
 
-	return false
+	return "", false
 }
@@ -0,0 +1,227 @@
+package risk
+
+import (
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRiskEngine_DefaultRules(t *testing.T) {
+	t.Parallel()
+
+	engine, err := NewEngine(&config.RiskConfig{})
+	require.NoError(t, err)
+
+	rule, critical := engine.CriticalRule(&store.PermissionSet{RoleName: "cluster-admin"})
+	assert.True(t, critical)
+	assert.Equal(t, DefaultRuleName, rule)
+
+	assert.False(t, engine.IsCritical(&store.PermissionSet{RoleName: "cluster-admin", IsNamespaced: true, Namespace: "default"}))
+	assert.False(t, engine.IsCritical(&store.PermissionSet{RoleName: "custom-role"}))
+	assert.False(t, engine.IsCritical(&store.Identity{Name: "system:masters", Type: "Group"}))
+	assert.False(t, engine.IsCritical(&store.Container{}))
+
+	engine, err = NewEngine(&config.RiskConfig{DisableDefaultRules: true})
+	require.NoError(t, err)
+	assert.False(t, engine.IsCritical(&store.PermissionSet{RoleName: "cluster-admin"}))
+}
+
+func TestRiskEngine_CriticalRule(t *testing.T) {
+	t.Parallel()
+
+	namespaced := false
+	engine, err := NewEngine(&config.RiskConfig{
+		Rules: []config.RiskRule{
+			{
+				Name:         "system-masters",
+				Target:       config.RiskTargetIdentity,
+				Names:        []string{"system:masters"},
+				IdentityType: "Group",
+			},
+			{
+				Name:       "vault-service-accounts",
+				Target:     config.RiskTargetIdentity,
+				Names:      []string{"vault-*"},
+				Namespaces: []string{"vault"},
+			},
+			{
+				Name:   "rbac-wildcard",
+				Target: config.RiskTargetRole,
+				RBAC: []config.RiskRBACMatcher{
+					{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+				},
+			},
+			{
+				Name:       "clusterrole-escalate",
+				Target:     config.RiskTargetRole,
+				Namespaced: &namespaced,
+				RBAC: []config.RiskRBACMatcher{
+					{APIGroups: []string{"rbac.authorization.k8s.io"}, Resources: []string{"clusterroles"}, Verbs: []string{"escalate"}},
+				},
+			},
+			{
+				Name:     "team-pods",
+				Target:   config.RiskTargetPod,
+				Selector: "team=crown-jewels",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		model any
+		want  string
+	}{
+		{
+			name:  "group identity",
+			model: &store.Identity{Name: "system:masters", Type: "Group"},
+			want:  "system-masters",
+		},
+		{
+			name:  "user identity with the same name",
+			model: &store.Identity{Name: "system:masters", Type: "User"},
+			want:  "",
+		},
+		{
+			name:  "namespaced service account",
+			model: &store.Identity{Name: "vault-server", IsNamespaced: true, Namespace: "vault", Type: "ServiceAccount"},
+			want:  "vault-service-accounts",
+		},
+		{
+			name:  "service account in another namespace",
+			model: &store.Identity{Name: "vault-server", IsNamespaced: true, Namespace: "default", Type: "ServiceAccount"},
+			want:  "",
+		},
+		{
+			name: "wildcard rbac rule",
+			model: &store.PermissionSet{RoleName: "custom-admin", IsNamespaced: true, Namespace: "default", Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+			}},
+			want: "rbac-wildcard",
+		},
+		{
+			name: "escalate granted via a verb wildcard",
+			model: &store.PermissionSet{RoleName: "rbac-manager", Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{"rbac.authorization.k8s.io"}, Resources: []string{"clusterroles", "roles"}, Verbs: []string{"*"}},
+			}},
+			want: "clusterrole-escalate",
+		},
+		{
+			name: "escalate scoped to resource names",
+			model: &store.PermissionSet{RoleName: "rbac-manager", Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{"rbac.authorization.k8s.io"}, Resources: []string{"clusterroles"}, Verbs: []string{"escalate"}, ResourceNames: []string{"view"}},
+			}},
+			want: "",
+		},
+		{
+			name: "partial wildcard rbac rule",
+			model: &store.PermissionSet{RoleName: "pod-admin", Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"*"}},
+			}},
+			want: "",
+		},
+		{
+			name:  "default rules are evaluated last",
+			model: &store.PermissionSet{RoleName: "cluster-admin", Rules: []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}}},
+			want:  "rbac-wildcard",
+		},
+		{
+			name: "labelled pod",
+			model: &store.Pod{K8: corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name: "db", Namespace: "default", Labels: map[string]string{"team": "crown-jewels"},
+			}}},
+			want: "team-pods",
+		},
+		{
+			name:  "unlabelled pod",
+			model: &store.Pod{K8: corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}},
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rule, critical := engine.CriticalRule(tt.model)
+			assert.Equal(t, tt.want, rule)
+			assert.Equal(t, tt.want != "", critical)
+		})
+	}
+}
+
+func TestRiskEngine_RulesFile(t *testing.T) {
+	t.Parallel()
+
+	engine, err := NewEngine(&config.RiskConfig{RulesFile: "./testdata/rules.yaml"})
+	require.NoError(t, err)
+
+	rule, critical := engine.CriticalRule(&store.Node{K8: corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name: "control-plane", Labels: map[string]string{"node-role.kubernetes.io/control-plane": ""},
+	}}})
+	assert.True(t, critical)
+	assert.Equal(t, "control-plane-nodes", rule)
+
+	assert.False(t, engine.IsCritical(&store.Node{K8: corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}}))
+
+	rule, critical = engine.CriticalRule(&store.Pod{IsNamespaced: true, K8: corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "kube-system"}}})
+	assert.True(t, critical)
+	assert.Equal(t, "kube-system-pods", rule)
+
+	// Default rules still apply
+	assert.True(t, engine.IsCritical(&store.PermissionSet{RoleName: "cluster-admin"}))
+}
+
+func TestRiskEngine_InvalidRules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  *config.RiskConfig
+	}{
+		{
+			name: "missing rules file",
+			cfg:  &config.RiskConfig{RulesFile: "./testdata/does-not-exist.yaml"},
+		},
+		{
+			name: "invalid rules file",
+			cfg:  &config.RiskConfig{RulesFile: "./testdata/rules-invalid.yaml"},
+		},
+		{
+			name: "unsupported target",
+			cfg:  &config.RiskConfig{Rules: []config.RiskRule{{Name: "secrets", Target: "secret"}}},
+		},
+		{
+			name: "selector on identity",
+			cfg:  &config.RiskConfig{Rules: []config.RiskRule{{Name: "sa", Target: config.RiskTargetIdentity, Selector: "app=web"}}},
+		},
+		{
+			name: "rbac matcher on pod",
+			cfg: &config.RiskConfig{Rules: []config.RiskRule{{Name: "pods", Target: config.RiskTargetPod, RBAC: []config.RiskRBACMatcher{
+				{Verbs: []string{"*"}},
+			}}}},
+		},
+		{
+			name: "invalid name pattern",
+			cfg:  &config.RiskConfig{Rules: []config.RiskRule{{Name: "bad", Target: config.RiskTargetRole, Names: []string{"[admin"}}}},
+		},
+		{
+			name: "invalid label selector",
+			cfg:  &config.RiskConfig{Rules: []config.RiskRule{{Name: "bad", Target: config.RiskTargetNode, Selector: "a b c"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewEngine(tt.cfg)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package risk
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// asset is the normalized view of a store model evaluated by the risk rules.
+type asset struct {
+	target       string
+	name         string
+	namespace    string
+	namespaced   bool
+	labels       labels.Set
+	identityType string
+	policyRules  []rbacv1.PolicyRule
+}
+
+func permissionSetAsset(ps *store.PermissionSet) *asset {
+	return &asset{
+		target:      config.RiskTargetRole,
+		name:        ps.RoleName,
+		namespace:   ps.Namespace,
+		namespaced:  ps.IsNamespaced,
+		policyRules: ps.Rules,
+	}
+}
+
+func identityAsset(id *store.Identity) *asset {
+	return &asset{
+		target:       config.RiskTargetIdentity,
+		name:         id.Name,
+		namespace:    id.Namespace,
+		namespaced:   id.IsNamespaced,
+		identityType: id.Type,
+	}
+}
+
+func nodeAsset(node *store.Node) *asset {
+	return &asset{
+		target:     config.RiskTargetNode,
+		name:       node.K8.Name,
+		namespace:  node.K8.Namespace,
+		namespaced: node.IsNamespaced,
+		labels:     node.K8.Labels,
+	}
+}
+
+func podAsset(pod *store.Pod) *asset {
+	return &asset{
+		target:     config.RiskTargetPod,
+		name:       pod.K8.Name,
+		namespace:  pod.K8.Namespace,
+		namespaced: pod.IsNamespaced,
+		labels:     pod.K8.Labels,
+	}
+}
+
+// globSet matches a value against a list of exact names and glob patterns.
+type globSet struct {
+	exact    map[string]bool
+	patterns []string
+}
+
+func newGlobSet(values []string) (*globSet, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	gs := &globSet{
+		exact: make(map[string]bool, len(values)),
+	}
+	for _, v := range values {
+		if !strings.ContainsAny(v, `*?[\`) {
+			gs.exact[v] = true
+
+			continue
+		}
+
+		if _, err := path.Match(v, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", v, err)
+		}
+		gs.patterns = append(gs.patterns, v)
+	}
+
+	return gs, nil
+}
+
+func (gs *globSet) match(value string) bool {
+	if gs.exact[value] {
+		return true
+	}
+
+	for _, p := range gs.patterns {
+		if ok, _ := path.Match(p, value); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rule is the compiled representation of a config.RiskRule.
+type rule struct {
+	name         string
+	target       string
+	names        *globSet
+	namespaces   *globSet
+	namespaced   *bool
+	selector     labels.Selector
+	identityType string
+	rbac         []config.RiskRBACMatcher
+}
+
+// compileRule validates a rule definition and pre-computes its matchers.
+func compileRule(def *config.RiskRule) (*rule, error) {
+	if def.Name == "" {
+		return nil, errors.New("missing rule name")
+	}
+
+	r := &rule{
+		name:         def.Name,
+		target:       def.Target,
+		namespaced:   def.Namespaced,
+		identityType: def.IdentityType,
+		rbac:         def.RBAC,
+	}
+
+	switch def.Target {
+	case config.RiskTargetRole, config.RiskTargetIdentity, config.RiskTargetNode, config.RiskTargetPod:
+	default:
+		return nil, fmt.Errorf("unsupported target %q", def.Target)
+	}
+
+	var err error
+	if r.names, err = newGlobSet(def.Names); err != nil {
+		return nil, fmt.Errorf("names: %w", err)
+	}
+	if r.namespaces, err = newGlobSet(def.Namespaces); err != nil {
+		return nil, fmt.Errorf("namespaces: %w", err)
+	}
+
+	if def.Selector != "" {
+		if def.Target != config.RiskTargetNode && def.Target != config.RiskTargetPod {
+			return nil, fmt.Errorf("label selector not supported for target %q", def.Target)
+		}
+
+		if r.selector, err = labels.Parse(def.Selector); err != nil {
+			return nil, fmt.Errorf("selector: %w", err)
+		}
+	}
+
+	if def.IdentityType != "" && def.Target != config.RiskTargetIdentity {
+		return nil, fmt.Errorf("identity type not supported for target %q", def.Target)
+	}
+
+	if len(def.RBAC) != 0 && def.Target != config.RiskTargetRole {
+		return nil, fmt.Errorf("rbac matchers not supported for target %q", def.Target)
+	}
+	for _, m := range def.RBAC {
+		if len(m.APIGroups) == 0 && len(m.Resources) == 0 && len(m.Verbs) == 0 {
+			return nil, errors.New("empty rbac matcher")
+		}
+	}
+
+	return r, nil
+}
+
+// match reports whether all the matchers of the rule match the provided asset.
+func (r *rule) match(a *asset) bool {
+	if r.target != a.target {
+		return false
+	}
+
+	if r.names != nil && !r.names.match(a.name) {
+		return false
+	}
+
+	if r.namespaces != nil && (!a.namespaced || !r.namespaces.match(a.namespace)) {
+		return false
+	}
+
+	if r.namespaced != nil && *r.namespaced != a.namespaced {
+		return false
+	}
+
+	if r.selector != nil && !r.selector.Matches(a.labels) {
+		return false
+	}
+
+	if r.identityType != "" && r.identityType != a.identityType {
+		return false
+	}
+
+	if len(r.rbac) != 0 && !r.rbacMatch(a.policyRules) {
+		return false
+	}
+
+	return true
+}
+
+// rbacMatch reports whether any of the rule RBAC matchers is granted by a single policy rule.
+// Policy rules restricted to a set of resource names are ignored as they do not grant blanket access.
+func (r *rule) rbacMatch(policyRules []rbacv1.PolicyRule) bool {
+	for _, m := range r.rbac {
+		for _, pr := range policyRules {
+			if len(pr.ResourceNames) != 0 {
+				continue
+			}
+
+			if grants(pr.APIGroups, m.APIGroups) && grants(pr.Resources, m.Resources) && grants(pr.Verbs, m.Verbs) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// grants reports whether every wanted value is granted by the list, either explicitly or via a wildcard.
+func grants(granted []string, wanted []string) bool {
+	if len(wanted) != 0 && slices.Contains(granted, rbacv1.ResourceAll) {
+		return true
+	}
+
+	for _, w := range wanted {
+		if !slices.Contains(granted, w) {
+			return false
+		}
+	}
+
+	return true
+}
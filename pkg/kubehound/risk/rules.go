@@ -1,5 +1,20 @@
 package risk
 
+import (
+	"fmt"
+	"sort"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/viper"
+)
+
+const (
+	// DefaultRuleName is the name of the built-in rule flagging the roles in CriticalRoleMap.
+	DefaultRuleName = "default-critical-roles"
+)
+
+// CriticalRoleMap lists the cluster roles granting a path to complete cluster compromise.
 var CriticalRoleMap = map[string]bool{
 	"admin":                       true,
 	"cluster-admin":               true,
@@ -67,4 +82,48 @@ var CriticalRoleMap = map[string]bool{
 	"view":                                                                 true,
 }
 
-// TODO identities map
+// DefaultRules returns the built-in rule set, flagging the cluster-wide permission sets of the roles in CriticalRoleMap.
+func DefaultRules() []config.RiskRule {
+	names := make([]string, 0, len(CriticalRoleMap))
+	for name, critical := range CriticalRoleMap {
+		if critical {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	namespaced := false
+
+	return []config.RiskRule{
+		{
+			Name:       DefaultRuleName,
+			Target:     config.RiskTargetRole,
+			Names:      names,
+			Namespaced: &namespaced,
+		},
+	}
+}
+
+// LoadRulesFile loads the risk rules defined under the top level "rules" key of the provided YAML file.
+func LoadRulesFile(path string) ([]config.RiskRule, error) {
+	v := viper.New()
+	v.SetConfigType(config.DefaultConfigType)
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("loading risk rules file: %w", err)
+	}
+
+	var rules []config.RiskRule
+	if err := v.UnmarshalKey("rules", &rules); err != nil {
+		return nil, fmt.Errorf("unmarshaling risk rules: %w", err)
+	}
+
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	for i := range rules {
+		if err := validate.Struct(&rules[i]); err != nil {
+			return nil, fmt.Errorf("validating risk rule %q: %w", rules[i].Name, err)
+		}
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,31 @@
+package cachekey
+
+import (
+	"strings"
+)
+
+const (
+	automountCacheName = "k8s-automount"
+)
+
+type automountCacheKey struct {
+	baseCacheKey
+}
+
+var _ CacheKey = (*automountCacheKey)(nil) // Ensure interface compliance
+
+func Automount(serviceAccountName string, namespace string) *automountCacheKey {
+	var sb strings.Builder
+
+	sb.WriteString(namespace)
+	sb.WriteString(CacheKeySeparator)
+	sb.WriteString(serviceAccountName)
+
+	return &automountCacheKey{
+		baseCacheKey{sb.String()},
+	}
+}
+
+func (k *automountCacheKey) Shard() string {
+	return automountCacheName
+}
@@ -0,0 +1,48 @@
+package cachekey
+
+import (
+	"strings"
+)
+
+const (
+	persistentVolumeCacheName      = "k8s-persistent-volume"
+	persistentVolumeClaimCacheName = "k8s-persistent-volume-claim"
+)
+
+type persistentVolumeCacheKey struct {
+	baseCacheKey
+}
+
+var _ CacheKey = (*persistentVolumeCacheKey)(nil) // Ensure interface compliance
+
+func PersistentVolume(volumeName string) *persistentVolumeCacheKey {
+	return &persistentVolumeCacheKey{
+		baseCacheKey{volumeName},
+	}
+}
+
+func (k *persistentVolumeCacheKey) Shard() string {
+	return persistentVolumeCacheName
+}
+
+type persistentVolumeClaimCacheKey struct {
+	baseCacheKey
+}
+
+var _ CacheKey = (*persistentVolumeClaimCacheKey)(nil) // Ensure interface compliance
+
+func PersistentVolumeClaim(claimName string, namespace string) *persistentVolumeClaimCacheKey {
+	var sb strings.Builder
+
+	sb.WriteString(namespace)
+	sb.WriteString(CacheKeySeparator)
+	sb.WriteString(claimName)
+
+	return &persistentVolumeClaimCacheKey{
+		baseCacheKey{sb.String()},
+	}
+}
+
+func (k *persistentVolumeClaimCacheKey) Shard() string {
+	return persistentVolumeClaimCacheName
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Type tags prefixing the encoded cache values, so that entries decode back to the concrete type expected by the
+// CacheResult accessors.
+const (
+	diskValueString byte = iota + 1
+	diskValueBool
+	diskValueInt64
+	diskValueRole
+	diskValuePersistentVolume
+)
+
+var errUnsupportedValue = errors.New("unsupported disk cache value type")
+
+// encodeValue serializes a cache value to its on-disk representation. Store models are encoded as BSON, the same way
+// they are persisted in the store database.
+func encodeValue(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case string:
+		return append([]byte{diskValueString}, v...), nil
+	case bool:
+		if v {
+			return []byte{diskValueBool, 1}, nil
+		}
+
+		return []byte{diskValueBool, 0}, nil
+	case int64:
+		return binary.BigEndian.AppendUint64([]byte{diskValueInt64}, uint64(v)), nil
+	case store.Role:
+		return encodeModel(diskValueRole, v)
+	case store.PersistentVolume:
+		return encodeModel(diskValuePersistentVolume, v)
+	default:
+		return nil, fmt.Errorf("%w: %T", errUnsupportedValue, value)
+	}
+}
+
+func encodeModel(tag byte, model any) ([]byte, error) {
+	raw, err := bson.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{tag}, raw...), nil
+}
+
+// decodeValue deserializes a cache value from its on-disk representation. The input is not retained.
+func decodeValue(data []byte) (any, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty disk cache value")
+	}
+
+	tag, raw := data[0], data[1:]
+	switch tag {
+	case diskValueString:
+		return string(raw), nil
+	case diskValueBool:
+		return len(raw) == 1 && raw[0] == 1, nil
+	case diskValueInt64:
+		if len(raw) != 8 {
+			return nil, fmt.Errorf("invalid disk cache int64 value length: %d", len(raw))
+		}
+
+		return int64(binary.BigEndian.Uint64(raw)), nil
+	case diskValueRole:
+		var role store.Role
+		if err := bson.Unmarshal(raw, &role); err != nil {
+			return nil, err
+		}
+
+		return role, nil
+	case diskValuePersistentVolume:
+		var pv store.PersistentVolume
+		if err := bson.Unmarshal(raw, &pv); err != nil {
+			return nil, err
+		}
+
+		return pv, nil
+	default:
+		return nil, fmt.Errorf("%w: tag %d", errUnsupportedValue, tag)
+	}
+}
@@ -0,0 +1,263 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	"github.com/DataDog/KubeHound/pkg/telemetry/log"
+	"github.com/DataDog/KubeHound/pkg/telemetry/metric"
+	"github.com/DataDog/KubeHound/pkg/telemetry/statsd"
+	"github.com/DataDog/KubeHound/pkg/telemetry/tag"
+	"go.etcd.io/bbolt"
+)
+
+const (
+	DiskCacheProviderName = "diskcache"
+
+	// Number of queued entries buffered in memory before being written to disk in a single transaction.
+	diskCacheBatchSize = 1000
+
+	diskCacheOpenTimeout = 10 * time.Second
+)
+
+var _ CacheProvider = (*DiskCacheProvider)(nil)
+
+// DiskCacheProvider is a cache provider backed by a local file, keeping the memory footprint of the cache bounded
+// on very large clusters. Each cache key shard is a bbolt bucket. Queued entries are buffered and written to disk in
+// batches, and are visible to readers as soon as they are queued.
+type DiskCacheProvider struct {
+	db      *bbolt.DB                    // Cache file handle
+	temp    bool                         // Whether the cache file is removed on close
+	mu      *sync.RWMutex                // Protects the pending entries and serializes writes
+	pending map[string]map[string][]byte // Encoded entries not yet written to disk, per shard
+	size    int                          // Number of pending entries
+}
+
+// NewDiskCacheProvider returns a new cache provider backed by the configured local file.
+func NewDiskCacheProvider(ctx context.Context, cfg *config.KubehoundConfig) (*DiskCacheProvider, error) {
+	path := cfg.DiskCache.Path
+	temp := path == ""
+	if temp {
+		f, err := os.CreateTemp("", "kubehound-cache-*.db")
+		if err != nil {
+			return nil, fmt.Errorf("disk cache temporary file: %w", err)
+		}
+		path = f.Name()
+		_ = f.Close()
+	} else if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("disk cache directory: %w", err)
+	}
+
+	// The cache is rebuilt on every run, so durability only matters when the file outlives the process
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: diskCacheOpenTimeout, NoSync: temp})
+	if err != nil {
+		return nil, fmt.Errorf("opening disk cache %s: %w", path, err)
+	}
+
+	log.Trace(ctx).Infof("Opened disk cache at %s", path)
+
+	var mu sync.RWMutex
+
+	return &DiskCacheProvider{
+		db:      db,
+		temp:    temp,
+		mu:      &mu,
+		pending: make(map[string]map[string][]byte),
+	}, nil
+}
+
+func (d *DiskCacheProvider) Name() string {
+	return DiskCacheProviderName
+}
+
+// Close writes any pending entries to disk and closes the cache file, removing it if temporary.
+func (d *DiskCacheProvider) Close(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	path := d.db.Path()
+	if !d.temp {
+		if err := d.flushLocked(); err != nil {
+			log.Trace(ctx).Errorf("flushing disk cache: %v", err)
+		}
+	}
+
+	if err := d.db.Close(); err != nil {
+		return err
+	}
+
+	if d.temp {
+		return os.Remove(path)
+	}
+
+	return nil
+}
+
+// HealthCheck verifies the cache file is open.
+func (d *DiskCacheProvider) HealthCheck(_ context.Context) (bool, error) {
+	if err := d.db.View(func(*bbolt.Tx) error { return nil }); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Prepare drops all the entries from the cache file.
+func (d *DiskCacheProvider) Prepare(_ context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	clear(d.pending)
+	d.size = 0
+
+	return d.db.Update(func(tx *bbolt.Tx) error {
+		var names [][]byte
+		err := tx.ForEach(func(name []byte, _ *bbolt.Bucket) error {
+			names = append(names, append([]byte(nil), name...))
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, name := range names {
+			if err := tx.DeleteBucket(name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (d *DiskCacheProvider) Get(ctx context.Context, key cachekey.CacheKey) *CacheResult {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	tagCacheKey := tag.GetBaseTagsWith(tag.CacheKey(key.Shard()))
+	var data any
+	found, err := d.lookupLocked(key, func(raw []byte) error {
+		var err error
+		data, err = decodeValue(raw)
+
+		return err
+	})
+
+	switch {
+	case err != nil:
+		log.Trace(ctx).Errorf("reading disk cache entry %s: %v", computeKey(key), err)
+	case !found:
+		_ = statsd.Incr(ctx, metric.CacheMiss, tagCacheKey, 1)
+		log.Trace(ctx).Debugf("entry not found in cache: %s", computeKey(key))
+	default:
+		_ = statsd.Incr(ctx, metric.CacheHit, tagCacheKey, 1)
+	}
+
+	return &CacheResult{
+		Value: data,
+		Err:   err,
+	}
+}
+
+func (d *DiskCacheProvider) BulkWriter(ctx context.Context, opts ...WriterOption) (AsyncWriter, error) {
+	wOpts := &writerOptions{}
+	for _, o := range opts {
+		o(wOpts)
+	}
+
+	if wOpts.ExpectOverwrite && wOpts.Test {
+		return nil, fmt.Errorf("mutually exclusive cache writer options: %#v", wOpts)
+	}
+
+	return &DiskCacheAsyncWriter{
+		provider: d,
+		opts:     wOpts,
+	}, nil
+}
+
+// lookupLocked calls fn with the encoded entry for the key, from the pending entries or the cache file. The encoded
+// entry is only valid for the duration of the call. Must be called with the lock held.
+func (d *DiskCacheProvider) lookupLocked(key cachekey.CacheKey, fn func(raw []byte) error) (bool, error) {
+	if raw, ok := d.pending[key.Shard()][key.Key()]; ok {
+		return true, fn(raw)
+	}
+
+	found := false
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(key.Shard()))
+		if b == nil {
+			return nil
+		}
+
+		raw := b.Get([]byte(key.Key()))
+		if raw == nil {
+			return nil
+		}
+		found = true
+
+		return fn(raw)
+	})
+
+	return found, err
+}
+
+// putLocked adds an encoded entry to the pending entries, writing them to disk once the batch is full. Must be
+// called with the write lock held.
+func (d *DiskCacheProvider) putLocked(key cachekey.CacheKey, raw []byte) error {
+	shard, ok := d.pending[key.Shard()]
+	if !ok {
+		shard = make(map[string][]byte)
+		d.pending[key.Shard()] = shard
+	}
+
+	if _, exists := shard[key.Key()]; !exists {
+		d.size++
+	}
+	shard[key.Key()] = raw
+
+	if d.size < diskCacheBatchSize {
+		return nil
+	}
+
+	return d.flushLocked()
+}
+
+// flushLocked writes all the pending entries to disk in a single transaction. Must be called with the write lock
+// held.
+func (d *DiskCacheProvider) flushLocked() error {
+	if d.size == 0 {
+		return nil
+	}
+
+	err := d.db.Update(func(tx *bbolt.Tx) error {
+		for shard, entries := range d.pending {
+			b, err := tx.CreateBucketIfNotExists([]byte(shard))
+			if err != nil {
+				return err
+			}
+
+			for key, raw := range entries {
+				if err := b.Put([]byte(key), raw); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("writing disk cache batch: %w", err)
+	}
+
+	clear(d.pending)
+	d.size = 0
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func newDiskCacheTestProvider(t *testing.T, path string) *DiskCacheProvider {
+	t.Helper()
+
+	cfg := &config.KubehoundConfig{
+		DiskCache: config.DiskCacheConfig{Path: path},
+	}
+
+	provider, err := NewDiskCacheProvider(t.Context(), cfg)
+	require.NoError(t, err)
+
+	return provider
+}
+
+func TestDiskCacheProvider_Values(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	provider := newDiskCacheTestProvider(t, "")
+	defer provider.Close(ctx)
+
+	role := store.Role{
+		Id:        primitive.NewObjectID(),
+		Name:      "reader",
+		Namespace: "default",
+		Rules: []rbacv1.PolicyRule{
+			{Verbs: []string{"get"}, Resources: []string{"pods"}, APIGroups: []string{""}},
+		},
+	}
+	pv := store.PersistentVolume{
+		Id:         primitive.NewObjectID(),
+		Name:       "pv",
+		SourcePath: "/var/lib/data",
+	}
+	oid := primitive.NewObjectID()
+
+	writer, err := provider.BulkWriter(ctx)
+	require.NoError(t, err)
+	require.NoError(t, writer.Queue(ctx, cachekey.ObjectID("object"), oid.Hex()))
+	require.NoError(t, writer.Queue(ctx, cachekey.Automount("sa", "default"), true))
+	require.NoError(t, writer.Queue(ctx, cachekey.ObjectID("vertex"), int64(42)))
+	require.NoError(t, writer.Queue(ctx, cachekey.Role("reader", "default"), role))
+	require.NoError(t, writer.Queue(ctx, cachekey.PersistentVolume("pv"), pv))
+
+	err = writer.Queue(ctx, cachekey.ObjectID("invalid"), 3.14)
+	assert.ErrorIs(t, err, errUnsupportedValue)
+
+	check := func() {
+		gotOid, err := provider.Get(ctx, cachekey.ObjectID("object")).ObjectID()
+		require.NoError(t, err)
+		assert.Equal(t, oid, gotOid)
+
+		automount, err := provider.Get(ctx, cachekey.Automount("sa", "default")).Bool()
+		require.NoError(t, err)
+		assert.True(t, automount)
+
+		vertex, err := provider.Get(ctx, cachekey.ObjectID("vertex")).Int64()
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), vertex)
+
+		gotRole, err := provider.Get(ctx, cachekey.Role("reader", "default")).Role()
+		require.NoError(t, err)
+		assert.Equal(t, role.Id, gotRole.Id)
+		assert.Equal(t, role.Rules, gotRole.Rules)
+
+		gotPv, err := provider.Get(ctx, cachekey.PersistentVolume("pv")).PersistentVolume()
+		require.NoError(t, err)
+		assert.Equal(t, pv.SourcePath, gotPv.SourcePath)
+
+		_, err = provider.Get(ctx, cachekey.ObjectID("missing")).Text()
+		assert.ErrorIs(t, err, ErrNoEntry)
+	}
+
+	// Entries are visible before and after being written to disk
+	check()
+	require.NoError(t, writer.Flush(ctx))
+	assert.Zero(t, provider.size)
+	check()
+}
+
+func TestDiskCacheAsyncWriter_Queue(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	provider := newDiskCacheTestProvider(t, "")
+	defer provider.Close(ctx)
+
+	_, err := provider.BulkWriter(ctx, WithTest(), WithExpectedOverwrite())
+	require.Error(t, err)
+
+	writer, err := provider.BulkWriter(ctx)
+	require.NoError(t, err)
+
+	// Overflow a batch to write the first entries to disk
+	for i := range diskCacheBatchSize + 1 {
+		require.NoError(t, writer.Queue(ctx, cachekey.Container("pod", fmt.Sprintf("container%d", i), "test"), "id"))
+	}
+	assert.Equal(t, 1, provider.size)
+
+	require.NoError(t, writer.Queue(ctx, cachekey.Node("node"), "first"))
+	require.NoError(t, writer.Flush(ctx))
+
+	// Test & set returns the existing entry from disk
+	tsWriter, err := provider.BulkWriter(ctx, WithTest())
+	require.NoError(t, err)
+
+	err = tsWriter.Queue(ctx, cachekey.Node("node"), "second")
+	var overwriteErr *OverwriteError
+	require.True(t, errors.As(err, &overwriteErr))
+	existing, err := overwriteErr.Existing().Text()
+	require.NoError(t, err)
+	assert.Equal(t, "first", existing)
+
+	// Overwrites replace the existing entry
+	require.NoError(t, writer.Queue(ctx, cachekey.Node("node"), "second"))
+	got, err := provider.Get(ctx, cachekey.Node("node")).Text()
+	require.NoError(t, err)
+	assert.Equal(t, "second", got)
+
+	// Prepare drops both pending and written entries
+	require.NoError(t, writer.Queue(ctx, cachekey.Node("pending"), "pending"))
+	require.NoError(t, provider.Prepare(ctx))
+	assert.Nil(t, provider.Get(ctx, cachekey.Node("node")).Value)
+	assert.Nil(t, provider.Get(ctx, cachekey.Node("pending")).Value)
+}
+
+func TestDiskCacheProvider_Persist(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	path := filepath.Join(t.TempDir(), "cache", "cache.db")
+
+	provider := newDiskCacheTestProvider(t, path)
+	writer, err := provider.BulkWriter(ctx)
+	require.NoError(t, err)
+	require.NoError(t, writer.Queue(ctx, cachekey.Node("node"), "id"))
+	require.NoError(t, provider.Close(ctx))
+
+	// Pending entries are written on close and the file is kept
+	provider = newDiskCacheTestProvider(t, path)
+	defer provider.Close(ctx)
+
+	got, err := provider.Get(ctx, cachekey.Node("node")).Text()
+	require.NoError(t, err)
+	assert.Equal(t, "id", got)
+}
+
+func TestFactory(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	provider, err := Factory(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "memcache", provider.Name())
+
+	cfg := &config.KubehoundConfig{
+		Storage:   config.StorageConfig{CacheProvider: config.CacheProviderDisk},
+		DiskCache: config.DiskCacheConfig{Path: filepath.Join(t.TempDir(), "cache.db")},
+	}
+	provider, err = Factory(ctx, cfg)
+	require.NoError(t, err)
+	defer provider.Close(ctx)
+	assert.Equal(t, DiskCacheProviderName, provider.Name())
+}
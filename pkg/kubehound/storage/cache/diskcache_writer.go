@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	"github.com/DataDog/KubeHound/pkg/telemetry/log"
+	"github.com/DataDog/KubeHound/pkg/telemetry/metric"
+	"github.com/DataDog/KubeHound/pkg/telemetry/statsd"
+	"github.com/DataDog/KubeHound/pkg/telemetry/tag"
+)
+
+type DiskCacheAsyncWriter struct {
+	provider *DiskCacheProvider
+	opts     *writerOptions
+}
+
+// Queue encodes the value and adds it to the pending entries of the provider, visible to readers immediately.
+func (w *DiskCacheAsyncWriter) Queue(ctx context.Context, key cachekey.CacheKey, value any) error {
+	raw, err := encodeValue(value)
+	if err != nil {
+		return fmt.Errorf("disk cache entry %s: %w", computeKey(key), err)
+	}
+
+	d := w.provider
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	tagCacheKey := tag.GetBaseTagsWith(tag.CacheKey(key.Shard()))
+	_ = statsd.Incr(ctx, metric.CacheWrite, tagCacheKey, 1)
+
+	// Existing values are only decoded when needed (test & set error or overwrite log)
+	var entry any
+	found, err := d.lookupLocked(key, func(existing []byte) error {
+		if !w.opts.Test && w.opts.ExpectOverwrite {
+			return nil
+		}
+
+		var err error
+		entry, err = decodeValue(existing)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("disk cache entry %s: %w", computeKey(key), err)
+	}
+
+	if found {
+		if w.opts.Test {
+			// if test & set behaviour is specified, return an error containing the existing value in the cache
+			return NewOverwriteError(&CacheResult{Value: entry})
+		}
+
+		if !w.opts.ExpectOverwrite {
+			// if overwrite is expected (e.g fast tracking of existence regardless of value), suppress metrics and logs
+			_ = statsd.Incr(ctx, metric.CacheDuplicate, tagCacheKey, 1)
+			log.Trace(ctx).Warnf("overwriting cache entry key=%s old=%#v new=%#v", computeKey(key), entry, value)
+		}
+	}
+
+	return d.putLocked(key, raw)
+}
+
+// Flush writes the pending entries of the provider to disk. Blocks until the operation completes.
+func (w *DiskCacheAsyncWriter) Flush(_ context.Context) error {
+	w.provider.mu.Lock()
+	defer w.provider.mu.Unlock()
+
+	return w.provider.flushLocked()
+}
+
+func (w *DiskCacheAsyncWriter) Close(_ context.Context) error {
+	// Pending entries are owned by the provider object
+	return nil
+}
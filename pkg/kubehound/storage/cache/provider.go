@@ -55,6 +55,15 @@ type AsyncWriter interface {
 
 // Factory returns an initialized instance of a cache provider from the provided application config.
 func Factory(ctx context.Context, cfg *config.KubehoundConfig) (CacheProvider, error) {
+	if cfg != nil && cfg.Storage.CacheProvider == config.CacheProviderDisk {
+		provider, err := NewDiskCacheProvider(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		return provider, nil
+	}
+
 	provider, err := NewMemCacheProvider(ctx)
 	if err != nil {
 		return nil, err
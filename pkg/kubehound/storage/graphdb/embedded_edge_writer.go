@@ -0,0 +1,114 @@
+package graphdb
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/edge"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/telemetry/log"
+	"github.com/DataDog/KubeHound/pkg/telemetry/metric"
+	"github.com/DataDog/KubeHound/pkg/telemetry/span"
+	"github.com/DataDog/KubeHound/pkg/telemetry/statsd"
+	"github.com/DataDog/KubeHound/pkg/telemetry/tag"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+var _ AsyncEdgeWriter = (*EmbeddedEdgeWriter)(nil)
+
+type EmbeddedEdgeWriter struct {
+	builder         string           // Qualified name of the edge being written
+	writes          types.EdgeWrites // Backend-neutral writes generator function
+	graph           *EmbeddedGraph   // Embedded graph to write to
+	writingInFlight *sync.WaitGroup  // Wait group tracking current unfinished writes
+	qcounter        int32            // Track items queued
+	wcounter        int32            // Track items written
+	tags            []string         // Telemetry tags
+	mb              *microBatcher    // Micro batcher to batch writes
+}
+
+// NewEmbeddedAsyncEdgeWriter creates a new bulk edge writer instance.
+func NewEmbeddedAsyncEdgeWriter(ctx context.Context, graph *EmbeddedGraph,
+	e edge.Builder, opts ...WriterOption,
+) (*EmbeddedEdgeWriter, error) {
+	options := &writerOptions{
+		WriterWorkerCount: defaultEmbeddedWriterWorkerCount,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	builder := fmt.Sprintf("%s::%s", e.Name(), e.Label())
+	ew := EmbeddedEdgeWriter{
+		builder:         builder,
+		writes:          e.Writes(),
+		graph:           graph,
+		writingInFlight: &sync.WaitGroup{},
+		tags:            append(options.Tags, tag.Label(e.Label()), tag.Builder(builder)),
+	}
+
+	ew.mb = newMicroBatcher(log.Trace(ctx), e.BatchSize(), options.WriterWorkerCount, func(ctx context.Context, a []any) error {
+		ew.writingInFlight.Add(1)
+		defer ew.writingInFlight.Done()
+
+		return ew.batchWrite(ctx, a)
+	})
+	ew.mb.Start(ctx)
+
+	return &ew, nil
+}
+
+// batchWrite will write a batch of entries into the embedded graph and block until the write completes.
+func (ege *EmbeddedEdgeWriter) batchWrite(ctx context.Context, data []any) error {
+	span, ctx := span.SpanRunFromContext(ctx, span.EmbeddedGraphBatchWrite)
+	span.SetTag(tag.LabelTag, ege.builder)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	datalen := len(data)
+	_ = statsd.Count(ctx, metric.EdgeWrite, int64(datalen), ege.tags, 1)
+	log.Trace(ctx).Debugf("Batch write EmbeddedEdgeWriter with %d elements", datalen)
+	atomic.AddInt32(&ege.wcounter, int32(datalen)) //nolint:gosec // disable G115
+
+	writes, err := ege.writes(data)
+	if err != nil {
+		return fmt.Errorf("%s edge writes: %w", ege.builder, err)
+	}
+	ege.graph.AddEdges(writes)
+
+	return nil
+}
+
+func (ege *EmbeddedEdgeWriter) Close(ctx context.Context) error {
+	return nil
+}
+
+// Flush triggers writes of any remaining items in the queue.
+// This is blocking
+func (ege *EmbeddedEdgeWriter) Flush(ctx context.Context) error {
+	span, ctx := span.SpanRunFromContext(ctx, span.EmbeddedGraphFlush)
+	span.SetTag(tag.LabelTag, ege.builder)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	err = ege.mb.Flush(ctx)
+	if err != nil {
+		return fmt.Errorf("micro batcher flush: %w", err)
+	}
+
+	// Wait for all writes to complete.
+	ege.writingInFlight.Wait()
+
+	log.Trace(ctx).Debugf("Edge writer %d %s queued", ege.qcounter, ege.builder)
+	log.Trace(ctx).Infof("Edge writer %d %s written", ege.wcounter, ege.builder)
+
+	return nil
+}
+
+func (ege *EmbeddedEdgeWriter) Queue(ctx context.Context, e any) error {
+	atomic.AddInt32(&ege.qcounter, 1)
+
+	return ege.mb.Enqueue(ctx, e)
+}
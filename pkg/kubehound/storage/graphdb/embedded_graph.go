@@ -0,0 +1,512 @@
+package graphdb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+)
+
+// EmbeddedVertex is a vertex of the embedded graph.
+type EmbeddedVertex struct {
+	ID         int64          `json:"id"`
+	Label      string         `json:"label"`
+	Properties map[string]any `json:"properties"`
+}
+
+// EmbeddedEdge is an edge of the embedded graph.
+type EmbeddedEdge struct {
+	ID         int64          `json:"id"`
+	Label      string         `json:"label"`
+	Out        int64          `json:"out"`
+	In         int64          `json:"in"`
+	Properties map[string]any `json:"properties"`
+}
+
+// embeddedSnapshot is the on-disk representation of the embedded graph.
+type embeddedSnapshot struct {
+	NextID   int64             `json:"next_id"`
+	Vertices []*EmbeddedVertex `json:"vertices"`
+	Edges    []*EmbeddedEdge   `json:"edges"`
+}
+
+// EmbeddedGraph is an in-process property graph applying the backend-neutral writes of the vertex and edge builders.
+// The graph is held in memory and optionally persisted to a file.
+type EmbeddedGraph struct {
+	mu       sync.Mutex
+	path     string                    // File used to persist the graph (in memory only if empty)
+	nextID   int64                     // Last allocated element id
+	vertices map[int64]*EmbeddedVertex // Vertices indexed by id
+	edges    map[int64]*EmbeddedEdge   // Edges indexed by id
+	outE     map[int64][]*EmbeddedEdge // Outgoing edges indexed by vertex id
+	inE      map[int64][]*EmbeddedEdge // Incoming edges indexed by vertex id
+}
+
+// NewEmbeddedGraph creates a new embedded graph, loading the previously persisted graph from the provided path if it exists.
+func NewEmbeddedGraph(path string) (*EmbeddedGraph, error) {
+	eg := &EmbeddedGraph{
+		path: path,
+	}
+	eg.reset()
+
+	if path == "" {
+		return eg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return eg, nil
+		}
+
+		return nil, fmt.Errorf("reading embedded graph file: %w", err)
+	}
+
+	var snapshot embeddedSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("decoding embedded graph file %s: %w", path, err)
+	}
+
+	eg.nextID = snapshot.NextID
+	for _, v := range snapshot.Vertices {
+		eg.vertices[v.ID] = v
+	}
+	for _, e := range snapshot.Edges {
+		eg.indexEdge(e)
+	}
+
+	return eg, nil
+}
+
+// AddVertices creates a vertex for each of the backend-neutral writes and returns the created vertices, in order.
+func (eg *EmbeddedGraph) AddVertices(writes []types.VertexWrite) []*EmbeddedVertex {
+	eg.mu.Lock()
+	defer eg.mu.Unlock()
+
+	res := make([]*EmbeddedVertex, 0, len(writes))
+	for _, w := range writes {
+		v := eg.addVertex(w.Label)
+		setProperties(v.Properties, w.Properties)
+		res = append(res, v.clone())
+	}
+
+	return res
+}
+
+// AddEdges creates the edges described by the backend-neutral writes and returns the number of edges written.
+func (eg *EmbeddedGraph) AddEdges(writes []types.EdgeWrite) int {
+	eg.mu.Lock()
+	defer eg.mu.Unlock()
+
+	count := 0
+	for _, w := range writes {
+		outs := eg.selectVertices(w.Out)
+		outs = eg.walk(outs, w.OutPath)
+
+		var targets []*EmbeddedVertex
+		if w.In != nil && len(w.InPath) == 0 {
+			targets = eg.selectVertices(*w.In)
+		}
+
+		for _, out := range outs {
+			ins := []*EmbeddedVertex{out}
+			switch {
+			case len(w.InPath) > 0:
+				ins = eg.walk(ins, w.InPath)
+			case w.In != nil:
+				ins = targets
+			}
+
+			for _, in := range ins {
+				e := eg.existingEdge(w, out, in)
+				if e == nil {
+					e = eg.addEdge(w.Label, out, in)
+				}
+				setProperties(e.Properties, w.Properties)
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// Vertices returns the vertices selected by the filter, ordered by id.
+func (eg *EmbeddedGraph) Vertices(f types.VertexFilter) []*EmbeddedVertex {
+	eg.mu.Lock()
+	defer eg.mu.Unlock()
+
+	selected := eg.filterVertices(f)
+	res := make([]*EmbeddedVertex, 0, len(selected))
+	for _, v := range selected {
+		res = append(res, v.clone())
+	}
+
+	return res
+}
+
+// Edges returns the edges with the provided label going out of the vertex, ordered by id.
+func (eg *EmbeddedGraph) Edges(out int64, label string) []*EmbeddedEdge {
+	eg.mu.Lock()
+	defer eg.mu.Unlock()
+
+	res := make([]*EmbeddedEdge, 0)
+	for _, e := range eg.outE[out] {
+		if e.Label == label {
+			res = append(res, e.clone())
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+
+	return res
+}
+
+// Persist writes the graph to its backing file. This is a no-op if the graph is in memory only.
+func (eg *EmbeddedGraph) Persist() error {
+	if eg.path == "" {
+		return nil
+	}
+
+	eg.mu.Lock()
+	snapshot := embeddedSnapshot{
+		NextID:   eg.nextID,
+		Vertices: eg.sortedVertices(),
+		Edges:    eg.sortedEdges(),
+	}
+	data, err := json.Marshal(snapshot)
+	eg.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("encoding embedded graph: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated graph behind.
+	tmp, err := os.CreateTemp(filepath.Dir(eg.path), filepath.Base(eg.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating embedded graph file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("writing embedded graph file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing embedded graph file: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), eg.path)
+}
+
+// Drop removes all vertices and edges from the graph.
+func (eg *EmbeddedGraph) Drop() {
+	eg.mu.Lock()
+	defer eg.mu.Unlock()
+
+	eg.reset()
+}
+
+// DropCluster removes all vertices (and their edges) belonging to the provided cluster and returns the number of
+// vertices removed.
+func (eg *EmbeddedGraph) DropCluster(cluster string) int {
+	eg.mu.Lock()
+	defer eg.mu.Unlock()
+
+	count := 0
+	for _, v := range eg.sortedVertices() {
+		if valuesEqual(v.Properties["cluster"], cluster) {
+			eg.dropVertex(v)
+			count++
+		}
+	}
+
+	return count
+}
+
+// VertexCount returns the number of vertices in the graph.
+func (eg *EmbeddedGraph) VertexCount() int {
+	eg.mu.Lock()
+	defer eg.mu.Unlock()
+
+	return len(eg.vertices)
+}
+
+// EdgeCount returns the number of edges in the graph.
+func (eg *EmbeddedGraph) EdgeCount() int {
+	eg.mu.Lock()
+	defer eg.mu.Unlock()
+
+	return len(eg.edges)
+}
+
+func (eg *EmbeddedGraph) reset() {
+	eg.nextID = 0
+	eg.vertices = make(map[int64]*EmbeddedVertex)
+	eg.edges = make(map[int64]*EmbeddedEdge)
+	eg.outE = make(map[int64][]*EmbeddedEdge)
+	eg.inE = make(map[int64][]*EmbeddedEdge)
+}
+
+func (eg *EmbeddedGraph) addVertex(label string) *EmbeddedVertex {
+	eg.nextID++
+	v := &EmbeddedVertex{
+		ID:         eg.nextID,
+		Label:      label,
+		Properties: make(map[string]any),
+	}
+	eg.vertices[v.ID] = v
+
+	return v
+}
+
+func (eg *EmbeddedGraph) addEdge(label string, out *EmbeddedVertex, in *EmbeddedVertex) *EmbeddedEdge {
+	eg.nextID++
+	e := &EmbeddedEdge{
+		ID:         eg.nextID,
+		Label:      label,
+		Out:        out.ID,
+		In:         in.ID,
+		Properties: make(map[string]any),
+	}
+	eg.indexEdge(e)
+
+	return e
+}
+
+func (eg *EmbeddedGraph) indexEdge(e *EmbeddedEdge) {
+	eg.edges[e.ID] = e
+	eg.outE[e.Out] = append(eg.outE[e.Out], e)
+	eg.inE[e.In] = append(eg.inE[e.In], e)
+}
+
+func (eg *EmbeddedGraph) dropVertex(v *EmbeddedVertex) {
+	for _, e := range append(eg.outE[v.ID], eg.inE[v.ID]...) {
+		eg.dropEdge(e)
+	}
+	delete(eg.outE, v.ID)
+	delete(eg.inE, v.ID)
+	delete(eg.vertices, v.ID)
+}
+
+func (eg *EmbeddedGraph) dropEdge(e *EmbeddedEdge) {
+	if _, ok := eg.edges[e.ID]; !ok {
+		return
+	}
+
+	delete(eg.edges, e.ID)
+	eg.outE[e.Out] = removeEdge(eg.outE[e.Out], e)
+	eg.inE[e.In] = removeEdge(eg.inE[e.In], e)
+}
+
+func removeEdge(edges []*EmbeddedEdge, e *EmbeddedEdge) []*EmbeddedEdge {
+	for i, candidate := range edges {
+		if candidate == e {
+			return append(edges[:i], edges[i+1:]...)
+		}
+	}
+
+	return edges
+}
+
+// sortedVertices returns all vertices ordered by id so traversals are deterministic.
+func (eg *EmbeddedGraph) sortedVertices() []*EmbeddedVertex {
+	res := make([]*EmbeddedVertex, 0, len(eg.vertices))
+	for _, v := range eg.vertices {
+		res = append(res, v)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+
+	return res
+}
+
+// sortedEdges returns all edges ordered by id so traversals are deterministic.
+func (eg *EmbeddedGraph) sortedEdges() []*EmbeddedEdge {
+	res := make([]*EmbeddedEdge, 0, len(eg.edges))
+	for _, e := range eg.edges {
+		res = append(res, e)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+
+	return res
+}
+
+// filterVertices returns the vertices matching the filter, ordered by id.
+func (eg *EmbeddedGraph) filterVertices(f types.VertexFilter) []*EmbeddedVertex {
+	var candidates []*EmbeddedVertex
+	if len(f.IDs) == 0 {
+		candidates = eg.sortedVertices()
+	} else {
+		for _, id := range f.IDs {
+			if v, ok := eg.vertices[id]; ok {
+				candidates = append(candidates, v)
+			}
+		}
+	}
+
+	res := make([]*EmbeddedVertex, 0, len(candidates))
+	for _, v := range candidates {
+		if propertiesMatch(v.Properties, f.Properties, nil) {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
+
+// selectVertices returns the vertices matching the filter of a write, after applying the filter updates.
+func (eg *EmbeddedGraph) selectVertices(f types.VertexFilter) []*EmbeddedVertex {
+	selected := eg.filterVertices(f)
+	for _, v := range selected {
+		setProperties(v.Properties, f.Set)
+	}
+
+	return selected
+}
+
+// walk returns the vertices reached by walking the hops of a path from the provided vertices, one per path.
+func (eg *EmbeddedGraph) walk(vertices []*EmbeddedVertex, hops []types.VertexHop) []*EmbeddedVertex {
+	for _, h := range hops {
+		next := make([]*EmbeddedVertex, 0, len(vertices))
+		for _, v := range vertices {
+			edges, other := eg.outE[v.ID], func(e *EmbeddedEdge) int64 { return e.In }
+			if h.Inbound {
+				edges, other = eg.inE[v.ID], func(e *EmbeddedEdge) int64 { return e.Out }
+			}
+
+			for _, e := range edges {
+				adjacent := eg.vertices[other(e)]
+				if e.Label == h.Label && propertiesMatch(adjacent.Properties, h.Properties, h.Within) {
+					next = append(next, adjacent)
+				}
+			}
+		}
+		vertices = next
+	}
+
+	return vertices
+}
+
+// existingEdge returns the edge of a merge write already linking the vertices, or nil if there is none.
+func (eg *EmbeddedGraph) existingEdge(w types.EdgeWrite, out *EmbeddedVertex, in *EmbeddedVertex) *EmbeddedEdge {
+	if !w.Merge {
+		return nil
+	}
+
+	for _, e := range eg.outE[out.ID] {
+		if e.Label == w.Label && e.In == in.ID {
+			return e
+		}
+	}
+
+	return nil
+}
+
+func (v *EmbeddedVertex) clone() *EmbeddedVertex {
+	c := *v
+	c.Properties = maps.Clone(v.Properties)
+
+	return &c
+}
+
+func (e *EmbeddedEdge) clone() *EmbeddedEdge {
+	c := *e
+	c.Properties = maps.Clone(e.Properties)
+
+	return &c
+}
+
+// propertiesMatch returns whether the properties hold all the expected values, and one of the values of each within
+// constraint.
+func propertiesMatch(props map[string]any, expected map[string]any, within map[string][]any) bool {
+	for k, want := range expected {
+		if !valuesEqual(props[k], want) {
+			return false
+		}
+	}
+
+	for k, candidates := range within {
+		if !slices.ContainsFunc(candidates, func(want any) bool { return valuesEqual(props[k], want) }) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// setProperties copies the values onto a property map, removing the nil ones.
+func setProperties(props map[string]any, values map[string]any) {
+	for k, v := range values {
+		if v == nil {
+			delete(props, k)
+
+			continue
+		}
+		props[k] = normalizeValue(v)
+	}
+}
+
+// normalizeValue converts the values of the writes into plain values that can be persisted.
+func normalizeValue(value any) any {
+	switch v := value.(type) {
+	case map[any]any:
+		res := make(map[string]any, len(v))
+		for k, val := range v {
+			res[fmt.Sprint(k)] = normalizeValue(val)
+		}
+
+		return res
+	case []any:
+		res := make([]any, 0, len(v))
+		for _, val := range v {
+			res = append(res, normalizeValue(val))
+		}
+
+		return res
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.String && rv.Type() != reflect.TypeOf("") {
+		return rv.String()
+	}
+
+	return value
+}
+
+func toFloat64(value any) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+
+	return 0, false
+}
+
+// valuesEqual compares two property values, ignoring the numeric and string type differences introduced by the
+// JSON conversion of the models and by persistence.
+func valuesEqual(a any, b any) bool {
+	if fa, ok := toFloat64(a); ok {
+		fb, ok := toFloat64(b)
+
+		return ok && fa == fb
+	}
+
+	ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if ra.Kind() == reflect.String && rb.Kind() == reflect.String {
+		return ra.String() == rb.String()
+	}
+
+	return reflect.DeepEqual(normalizeValue(a), normalizeValue(b))
+}
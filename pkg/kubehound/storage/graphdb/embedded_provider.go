@@ -0,0 +1,117 @@
+package graphdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/edge"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/telemetry/log"
+	"github.com/DataDog/KubeHound/pkg/telemetry/span"
+	"github.com/DataDog/KubeHound/pkg/telemetry/tag"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+const (
+	EmbeddedStorageProviderName = "embedded"
+
+	// All writes to the embedded graph are serialized, a single worker per writer avoids lock contention.
+	defaultEmbeddedWriterWorkerCount = 1
+)
+
+var (
+	_ Provider = (*EmbeddedGraphProvider)(nil)
+)
+
+// EmbeddedGraphProvider is a graphdb provider storing the graph in process, without any external dependency.
+type EmbeddedGraphProvider struct {
+	graph *EmbeddedGraph          // In-process graph
+	tags  []string                // Tags to be applied for telemetry
+	cfg   *config.KubehoundConfig // Application configuration
+}
+
+// NewEmbeddedGraphProvider creates a new embedded graph provider, loading the graph persisted at the configured path (if any).
+func NewEmbeddedGraphProvider(ctx context.Context, cfg *config.KubehoundConfig) (*EmbeddedGraphProvider, error) {
+	graph, err := NewEmbeddedGraph(cfg.Embedded.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Embedded.Path != "" {
+		log.Trace(ctx).Infof("Loaded embedded graph from %s (%d vertices, %d edges)",
+			cfg.Embedded.Path, graph.VertexCount(), graph.EdgeCount())
+	}
+
+	return &EmbeddedGraphProvider{
+		graph: graph,
+		cfg:   cfg,
+		tags:  tag.GetBaseTagsWith(tag.Storage(EmbeddedStorageProviderName)),
+	}, nil
+}
+
+func (egp *EmbeddedGraphProvider) Name() string {
+	return EmbeddedStorageProviderName
+}
+
+func (egp *EmbeddedGraphProvider) Prepare(ctx context.Context) error {
+	if !egp.cfg.Storage.Wipe {
+		log.Trace(ctx).Warn("Skipping graph vertex wipe")
+
+		return nil
+	}
+
+	egp.graph.Drop()
+
+	return nil
+}
+
+// HealthCheck always succeeds as the embedded graph has no remote dependency.
+func (egp *EmbeddedGraphProvider) HealthCheck(_ context.Context) (bool, error) {
+	return true, nil
+}
+
+// Raw returns a handle to the underlying provider to allow implementation specific operations e.g graph queries.
+func (egp *EmbeddedGraphProvider) Raw() any {
+	return egp.graph
+}
+
+// VertexWriter creates a new AsyncVertexWriter instance to enable asynchronous bulk inserts of vertices.
+func (egp *EmbeddedGraphProvider) VertexWriter(ctx context.Context, v vertex.Builder,
+	c cache.CacheProvider, opts ...WriterOption) (AsyncVertexWriter, error) {
+
+	opts = append(opts, WithTags(egp.tags))
+
+	return NewEmbeddedAsyncVertexWriter(ctx, egp.graph, v, c, opts...)
+}
+
+// EdgeWriter creates a new AsyncEdgeWriter instance to enable asynchronous bulk inserts of edges.
+func (egp *EmbeddedGraphProvider) EdgeWriter(ctx context.Context, e edge.Builder, opts ...WriterOption) (AsyncEdgeWriter, error) {
+	opts = append(opts, WithTags(egp.tags))
+
+	return NewEmbeddedAsyncEdgeWriter(ctx, egp.graph, e, opts...)
+}
+
+// Close persists the graph to disk (if configured). Provider cannot be reused after this call.
+func (egp *EmbeddedGraphProvider) Close(_ context.Context) error {
+	if err := egp.graph.Persist(); err != nil {
+		return fmt.Errorf("persisting embedded graph: %w", err)
+	}
+
+	return nil
+}
+
+// Clean removes all vertices in the graph for the given cluster.
+func (egp *EmbeddedGraphProvider) Clean(ctx context.Context, cluster string) error {
+	var err error
+	span, ctx := span.SpanRunFromContext(ctx, span.IngestorClean)
+	defer func() { span.Finish(tracer.WithError(err)) }()
+	l := log.Trace(ctx)
+	l.Info("Cleaning cluster", log.String(log.FieldClusterKey, cluster))
+
+	count := egp.graph.DropCluster(cluster)
+	l.Infof("Removed %d vertices", count)
+
+	return nil
+}
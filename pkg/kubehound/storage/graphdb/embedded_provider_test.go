@@ -0,0 +1,336 @@
+package graphdb
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/edge"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/graph"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	cache "github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/mocks"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	embeddedTestCluster = "test-cluster"
+)
+
+func embeddedTestConfig(t *testing.T, path string) *config.KubehoundConfig {
+	t.Helper()
+
+	return &config.KubehoundConfig{
+		Storage: config.StorageConfig{
+			Wipe:          true,
+			GraphProvider: config.GraphProviderEmbedded,
+		},
+		Embedded: config.EmbeddedGraphConfig{
+			Path: path,
+		},
+		Builder: config.BuilderConfig{
+			Vertex: config.VertexBuilderConfig{
+				BatchSize: 2,
+			},
+			Edge: config.EdgeBuilderConfig{
+				BatchSize: 2,
+			},
+		},
+		Dynamic: config.DynamicConfig{
+			RunID: config.NewRunID(),
+			Cluster: config.DynamicClusterInfo{
+				Name: embeddedTestCluster,
+			},
+		},
+	}
+}
+
+// writeEmbeddedVertices writes the provided models via the vertex builder and returns the cached store id -> vertex id mappings.
+func writeEmbeddedVertices(t *testing.T, p *EmbeddedGraphProvider, v vertex.Builder, models ...any) map[string]int64 {
+	t.Helper()
+	ctx := context.Background()
+
+	ids := make(map[string]int64)
+	cw := cache.NewAsyncWriter(t)
+	cw.EXPECT().Queue(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(
+		func(_ context.Context, key cachekey.CacheKey, value any) error {
+			id, ok := value.(int64)
+			require.True(t, ok)
+			ids[key.Key()] = id
+
+			return nil
+		})
+	cw.EXPECT().Flush(mock.Anything).Return(nil)
+	cw.EXPECT().Close(mock.Anything).Return(nil)
+
+	c := cache.NewCacheProvider(t)
+	c.EXPECT().BulkWriter(mock.Anything, mock.Anything).Return(cw, nil)
+
+	w, err := p.VertexWriter(ctx, v, c)
+	require.NoError(t, err)
+
+	for _, m := range models {
+		insert, err := v.Processor(ctx, m)
+		require.NoError(t, err)
+		require.NoError(t, w.Queue(ctx, insert))
+	}
+
+	require.NoError(t, w.Flush(ctx))
+	require.NoError(t, w.Close(ctx))
+
+	return ids
+}
+
+func writeEmbeddedEdges(t *testing.T, p *EmbeddedGraphProvider, e edge.Builder, inserts ...any) {
+	t.Helper()
+	ctx := context.Background()
+
+	w, err := p.EdgeWriter(ctx, e)
+	require.NoError(t, err)
+
+	for _, insert := range inserts {
+		require.NoError(t, w.Queue(ctx, insert))
+	}
+
+	require.NoError(t, w.Flush(ctx))
+	require.NoError(t, w.Close(ctx))
+}
+
+func embeddedTestVertices(t *testing.T, p *EmbeddedGraphProvider, cfg *config.KubehoundConfig) (map[string]int64, map[string]int64) {
+	t.Helper()
+
+	node := &vertex.Node{}
+	require.NoError(t, node.Initialize(cfg))
+	nodes := writeEmbeddedVertices(t, p, node,
+		&graph.Node{StoreID: "node-1", Name: "node-1", RunID: cfg.Dynamic.RunID.String(), Cluster: embeddedTestCluster},
+		&graph.Node{StoreID: "node-2", Name: "node-2", RunID: cfg.Dynamic.RunID.String(), Cluster: embeddedTestCluster},
+		&graph.Node{StoreID: "node-3", Name: "node-3", RunID: cfg.Dynamic.RunID.String(), Cluster: "other-cluster"},
+	)
+
+	permissionSet := &vertex.PermissionSet{}
+	require.NoError(t, permissionSet.Initialize(cfg))
+	permissionSets := writeEmbeddedVertices(t, p, permissionSet,
+		&graph.PermissionSet{StoreID: "ps-1", Name: "ps-1", RunID: cfg.Dynamic.RunID.String(), Cluster: embeddedTestCluster, Critical: false},
+		&graph.PermissionSet{StoreID: "ps-2", Name: "ps-2", RunID: cfg.Dynamic.RunID.String(), Cluster: embeddedTestCluster, Critical: true},
+	)
+
+	return nodes, permissionSets
+}
+
+// vertexNames returns the names of the vertices with the provided ids.
+func vertexNames(p *EmbeddedGraphProvider, ids ...int64) []any {
+	names := make([]any, 0, len(ids))
+	for _, v := range p.graph.Vertices(types.VertexFilter{IDs: ids}) {
+		names = append(names, v.Properties["name"])
+	}
+
+	return names
+}
+
+func TestEmbeddedGraphProvider_VertexWriter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cfg := embeddedTestConfig(t, "")
+
+	p, err := NewEmbeddedGraphProvider(ctx, cfg)
+	require.NoError(t, err)
+
+	nodes, permissionSets := embeddedTestVertices(t, p, cfg)
+	assert.Len(t, nodes, 3)
+	assert.Len(t, permissionSets, 2)
+	assert.Equal(t, 5, p.graph.VertexCount())
+	assert.Equal(t, []any{"node-2"}, vertexNames(p, nodes["node-2"]))
+
+	res := p.graph.Vertices(types.VertexFilter{
+		Properties: map[string]any{"class": vertex.NodeLabel, "cluster": embeddedTestCluster},
+	})
+	assert.Len(t, res, 2)
+}
+
+func TestEmbeddedGraphProvider_EdgeWriter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cfg := embeddedTestConfig(t, "")
+
+	p, err := NewEmbeddedGraphProvider(ctx, cfg)
+	require.NoError(t, err)
+	nodes, permissionSets := embeddedTestVertices(t, p, cfg)
+
+	// Default merged edge writes
+	defaultEdge := &edge.PermissionDiscover{}
+	require.NoError(t, defaultEdge.Initialize(&cfg.Builder.Edge, &cfg.Dynamic))
+	merge := map[any]any{
+		gremlin.T.Label:       defaultEdge.Label(),
+		gremlin.Direction.Out: permissionSets["ps-1"],
+		gremlin.Direction.In:  nodes["node-1"],
+	}
+	writeEmbeddedEdges(t, p, defaultEdge, merge, merge)
+	assert.Equal(t, 1, p.graph.EdgeCount(), "merged edges should not be duplicated")
+
+	// Custom writes linking each non critical permission set to all nodes of the run
+	podCreate := &edge.PodCreate{}
+	require.NoError(t, podCreate.Initialize(&cfg.Builder.Edge, &cfg.Dynamic))
+	writeEmbeddedEdges(t, p, podCreate, permissionSets["ps-1"], permissionSets["ps-2"])
+
+	edges := p.graph.Edges(permissionSets["ps-1"], podCreate.Label())
+	ins := make([]int64, 0, len(edges))
+	for _, e := range edges {
+		ins = append(ins, e.In)
+		assert.Equal(t, string(podCreate.AttckTechniqueID()), e.Properties["attckTechniqueID"])
+	}
+	assert.ElementsMatch(t, []any{"node-1", "node-2"}, vertexNames(p, ins...))
+	assert.Empty(t, p.graph.Edges(permissionSets["ps-2"], podCreate.Label()))
+}
+
+func TestEmbeddedGraphProvider_EdgeWriter_LargeCluster(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cfg := embeddedTestConfig(t, "")
+	cfg.Builder.Edge.LargeClusterOptimizations = true
+
+	p, err := NewEmbeddedGraphProvider(ctx, cfg)
+	require.NoError(t, err)
+	_, permissionSets := embeddedTestVertices(t, p, cfg)
+
+	podCreate := &edge.PodCreate{}
+	require.NoError(t, podCreate.Initialize(&cfg.Builder.Edge, &cfg.Dynamic))
+	writeEmbeddedEdges(t, p, podCreate,
+		map[any]any{gremlin.T.Label: vertex.PermissionSetLabel, gremlin.T.Id: permissionSets["ps-1"]},
+		// Missing vertices are ignored
+		map[any]any{gremlin.T.Label: vertex.PermissionSetLabel, gremlin.T.Id: int64(12345)},
+	)
+
+	res := p.graph.Vertices(types.VertexFilter{IDs: []int64{permissionSets["ps-1"]}})
+	require.Len(t, res, 1)
+	assert.Equal(t, true, res[0].Properties["critical"])
+
+	edges := p.graph.Edges(permissionSets["ps-1"], podCreate.Label())
+	require.Len(t, edges, 1)
+	assert.Equal(t, permissionSets["ps-1"], edges[0].In)
+	assert.Equal(t, 1, p.graph.EdgeCount())
+}
+
+func TestEmbeddedGraphProvider_EdgeWriter_Path(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cfg := embeddedTestConfig(t, "")
+
+	p, err := NewEmbeddedGraphProvider(ctx, cfg)
+	require.NoError(t, err)
+
+	// permission set <- identity <- containers -> volumes <- node
+	vertices := p.graph.AddVertices([]types.VertexWrite{
+		{Label: vertex.PermissionSetLabel, Properties: map[string]any{"class": vertex.PermissionSetLabel}},
+		{Label: vertex.IdentityLabel, Properties: map[string]any{"class": vertex.IdentityLabel}},
+		{Label: vertex.ContainerLabel, Properties: map[string]any{"class": vertex.ContainerLabel, "pod": "a", "namespace": "default"}},
+		{Label: vertex.ContainerLabel, Properties: map[string]any{"class": vertex.ContainerLabel, "pod": "b", "namespace": "default"}},
+		{Label: vertex.VolumeLabel, Properties: map[string]any{"class": vertex.VolumeLabel, "type": "HostPath", "sourcePath": "/var/log"}},
+		{Label: vertex.VolumeLabel, Properties: map[string]any{"class": vertex.VolumeLabel, "type": "HostPath", "sourcePath": "/etc"}},
+		{Label: vertex.NodeLabel, Properties: map[string]any{"class": vertex.NodeLabel}},
+	})
+	ids := make([]int64, 0, len(vertices))
+	for _, v := range vertices {
+		ids = append(ids, v.ID)
+	}
+	ps, identity, containerA, containerB, varLog, etc, node := ids[0], ids[1], ids[2], ids[3], ids[4], ids[5], ids[6]
+	link := func(label string, out int64, in int64) types.EdgeWrite {
+		return types.EdgeWrite{
+			Label: label,
+			Out:   types.VertexFilter{IDs: []int64{out}},
+			In:    &types.VertexFilter{IDs: []int64{in}},
+		}
+	}
+	p.graph.AddEdges([]types.EdgeWrite{
+		link("PERMISSION_DISCOVER", identity, ps),
+		link("IDENTITY_ASSUME", containerA, identity),
+		link("IDENTITY_ASSUME", containerB, identity),
+		link("VOLUME_DISCOVER", containerA, varLog),
+		link("VOLUME_DISCOVER", containerA, etc),
+		link("VOLUME_DISCOVER", containerB, varLog),
+		link("VOLUME_ACCESS", node, varLog),
+		link("VOLUME_ACCESS", node, etc),
+	})
+
+	symlink := &edge.EscapeVarLogSymlink{}
+	require.NoError(t, symlink.Initialize(&cfg.Builder.Edge, &cfg.Dynamic))
+	writeEmbeddedEdges(t, p, symlink, ps)
+
+	for _, c := range []int64{containerA, containerB} {
+		edges := p.graph.Edges(c, symlink.Label())
+		require.Len(t, edges, 1, "only the /var/log mount is affected")
+		assert.Equal(t, node, edges[0].In)
+		assert.Equal(t, false, edges[0].Properties["resourceScoped"])
+	}
+}
+
+func TestEmbeddedGraphProvider_Clean(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cfg := embeddedTestConfig(t, "")
+
+	p, err := NewEmbeddedGraphProvider(ctx, cfg)
+	require.NoError(t, err)
+	nodes, permissionSets := embeddedTestVertices(t, p, cfg)
+	discover := &edge.PermissionDiscover{}
+	require.NoError(t, discover.Initialize(&cfg.Builder.Edge, &cfg.Dynamic))
+	writeEmbeddedEdges(t, p, discover, map[any]any{
+		gremlin.T.Label:       discover.Label(),
+		gremlin.Direction.Out: permissionSets["ps-1"],
+		gremlin.Direction.In:  nodes["node-1"],
+	})
+
+	require.NoError(t, p.Clean(ctx, embeddedTestCluster))
+	assert.Equal(t, 1, p.graph.VertexCount())
+	assert.Equal(t, 0, p.graph.EdgeCount())
+
+	require.NoError(t, p.Prepare(ctx))
+	assert.Equal(t, 0, p.graph.VertexCount())
+}
+
+func TestEmbeddedGraphProvider_Persist(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "graph.json")
+	cfg := embeddedTestConfig(t, path)
+
+	p, err := NewEmbeddedGraphProvider(ctx, cfg)
+	require.NoError(t, err)
+	nodes, permissionSets := embeddedTestVertices(t, p, cfg)
+	discover := &edge.PermissionDiscover{}
+	require.NoError(t, discover.Initialize(&cfg.Builder.Edge, &cfg.Dynamic))
+	writeEmbeddedEdges(t, p, discover, map[any]any{
+		gremlin.T.Label:       discover.Label(),
+		gremlin.Direction.Out: permissionSets["ps-1"],
+		gremlin.Direction.In:  nodes["node-1"],
+	})
+	require.NoError(t, p.Close(ctx))
+
+	reloaded, err := NewEmbeddedGraphProvider(ctx, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 5, reloaded.graph.VertexCount())
+	assert.Equal(t, 1, reloaded.graph.EdgeCount())
+
+	// Reloaded values must still match the (non JSON) values used by the writes
+	res := reloaded.graph.Vertices(types.VertexFilter{
+		Properties: map[string]any{"runID": cfg.Dynamic.RunID.String(), "critical": false},
+	})
+	assert.Len(t, res, 4)
+
+	// New elements must not reuse persisted ids
+	added := reloaded.graph.AddVertices([]types.VertexWrite{{Label: "Test"}})
+	require.Len(t, added, 1)
+	assert.Greater(t, added[0].ID, permissionSets["ps-2"])
+
+	require.NoError(t, reloaded.Prepare(ctx))
+	require.NoError(t, reloaded.Close(ctx))
+
+	wiped, err := NewEmbeddedGraphProvider(ctx, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 0, wiped.graph.VertexCount())
+
+}
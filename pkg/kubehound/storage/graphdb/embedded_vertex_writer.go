@@ -0,0 +1,156 @@
+package graphdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	"github.com/DataDog/KubeHound/pkg/telemetry/log"
+	"github.com/DataDog/KubeHound/pkg/telemetry/metric"
+	"github.com/DataDog/KubeHound/pkg/telemetry/span"
+	"github.com/DataDog/KubeHound/pkg/telemetry/statsd"
+	"github.com/DataDog/KubeHound/pkg/telemetry/tag"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+var _ AsyncVertexWriter = (*EmbeddedVertexWriter)(nil)
+
+type EmbeddedVertexWriter struct {
+	builder         string             // Name of the graph entity being written
+	writes          types.VertexWrites // Backend-neutral writes generator function
+	graph           *EmbeddedGraph     // Embedded graph to write to
+	writingInFlight *sync.WaitGroup    // Wait group tracking current unfinished writes
+	qcounter        int32              // Track items queued
+	wcounter        int32              // Track items written
+	tags            []string           // Telemetry tags
+	cache           cache.AsyncWriter  // Cache writer to cache store id -> vertex id mappings
+	mb              *microBatcher      // Micro batcher to batch writes
+}
+
+// NewEmbeddedAsyncVertexWriter creates a new bulk vertex writer instance.
+func NewEmbeddedAsyncVertexWriter(ctx context.Context, graph *EmbeddedGraph,
+	v vertex.Builder, c cache.CacheProvider, opts ...WriterOption,
+) (*EmbeddedVertexWriter, error) {
+	options := &writerOptions{
+		WriterWorkerCount: defaultEmbeddedWriterWorkerCount,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	cw, err := c.BulkWriter(ctx, cache.WithTest())
+	if err != nil {
+		return nil, fmt.Errorf("embedded vertex writer cache creation: %w", err)
+	}
+
+	ew := EmbeddedVertexWriter{
+		builder:         v.Label(),
+		writes:          v.Writes(),
+		graph:           graph,
+		writingInFlight: &sync.WaitGroup{},
+		tags:            append(options.Tags, tag.Label(v.Label()), tag.Builder(v.Label())),
+		cache:           cw,
+	}
+
+	ew.mb = newMicroBatcher(log.Trace(ctx), v.BatchSize(), options.WriterWorkerCount, func(ctx context.Context, a []any) error {
+		ew.writingInFlight.Add(1)
+		defer ew.writingInFlight.Done()
+
+		return ew.batchWrite(ctx, a)
+	})
+	ew.mb.Start(ctx)
+
+	return &ew, nil
+}
+
+func (egv *EmbeddedVertexWriter) cacheIds(ctx context.Context, vertices []*EmbeddedVertex) error {
+	for _, v := range vertices {
+		storeID, ok := v.Properties["storeID"].(string)
+		if !ok {
+			return errors.New("vertex store id type conversion")
+		}
+
+		err := egv.cache.Queue(ctx, cachekey.ObjectID(storeID), v.ID)
+		if err != nil {
+			return fmt.Errorf("vertex id cache write: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// batchWrite will write a batch of entries into the embedded graph and block until the write completes.
+func (egv *EmbeddedVertexWriter) batchWrite(ctx context.Context, data []any) error {
+	_ = statsd.Count(ctx, metric.BackgroundWriterCall, 1, egv.tags, 1)
+
+	span, ctx := span.SpanRunFromContext(ctx, span.EmbeddedGraphBatchWrite)
+	span.SetTag(tag.LabelTag, egv.builder)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	datalen := len(data)
+	_ = statsd.Count(ctx, metric.VertexWrite, int64(datalen), egv.tags, 1)
+	log.Trace(ctx).Debugf("Batch write EmbeddedVertexWriter with %d elements", datalen)
+	atomic.AddInt32(&egv.wcounter, int32(datalen)) //nolint:gosec // disable G115
+
+	writes, err := egv.writes(data)
+	if err != nil {
+		return fmt.Errorf("%s vertex writes: %w", egv.builder, err)
+	}
+
+	// The embedded graph returns the inserted vertices directly, no need for an id/storeID projection.
+	if err = egv.cacheIds(ctx, egv.graph.AddVertices(writes)); err != nil {
+		return fmt.Errorf("cache ids: %w", err)
+	}
+
+	return nil
+}
+
+func (egv *EmbeddedVertexWriter) Close(ctx context.Context) error {
+	if egv.cache != nil {
+		if err := egv.cache.Close(ctx); err != nil {
+			return fmt.Errorf("closing cache: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Flush triggers writes of any remaining items in the queue.
+// This is blocking
+func (egv *EmbeddedVertexWriter) Flush(ctx context.Context) error {
+	span, ctx := span.SpanRunFromContext(ctx, span.EmbeddedGraphFlush)
+	span.SetTag(tag.LabelTag, egv.builder)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	err = egv.mb.Flush(ctx)
+	if err != nil {
+		return fmt.Errorf("micro batcher flush: %w", err)
+	}
+
+	// Wait for all writes to complete.
+	egv.writingInFlight.Wait()
+
+	err = egv.cache.Flush(ctx)
+	if err != nil {
+		return fmt.Errorf("vertex id cache flush: %w", err)
+	}
+
+	log.Trace(ctx).Debugf("Batch writer %d %s queued", egv.qcounter, egv.builder)
+	log.Trace(ctx).Infof("Batch writer %d %s written", egv.wcounter, egv.builder)
+
+	return nil
+}
+
+func (egv *EmbeddedVertexWriter) Queue(ctx context.Context, v any) error {
+	atomic.AddInt32(&egv.qcounter, 1)
+
+	return egv.mb.Enqueue(ctx, v)
+}
@@ -0,0 +1,475 @@
+package graphdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Bolt message tags handled by the fake server.
+const (
+	boltMsgHello    = 0x01
+	boltMsgGoodbye  = 0x02
+	boltMsgRun      = 0x10
+	boltMsgPull     = 0x3F
+	boltMsgDiscard  = 0x2F
+	boltMsgSuccess  = 0x70
+	boltMsgRecord   = 0x71
+	boltMsgFailure  = 0x7F
+	boltHandshakeSz = 20
+)
+
+// fakeBoltQuery is a query received by the fake Bolt server.
+type fakeBoltQuery struct {
+	query  string
+	params map[string]any
+}
+
+// fakeBoltResult is the result returned by the fake Bolt server for a query.
+type fakeBoltResult struct {
+	fields  []string
+	records [][]any
+}
+
+// fakeBoltHandler computes the result of a query received by the fake Bolt server.
+type fakeBoltHandler func(query string, params map[string]any) (fakeBoltResult, error)
+
+// fakeBoltServer is a minimal Bolt 4.4 server recording the received queries, allowing to test the Neo4j provider
+// without a database.
+type fakeBoltServer struct {
+	t        *testing.T
+	listener net.Listener
+	handler  fakeBoltHandler
+	mu       sync.Mutex
+	queries  []fakeBoltQuery
+	wg       sync.WaitGroup
+}
+
+// boltStruct is a PackStream structure (i.e a Bolt message).
+type boltStruct struct {
+	tag    byte
+	fields []any
+}
+
+func newFakeBoltServer(t *testing.T, handler fakeBoltHandler) *fakeBoltServer {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &fakeBoltServer{
+		t:        t,
+		listener: l,
+		handler:  handler,
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	t.Cleanup(func() {
+		_ = s.listener.Close()
+		s.wg.Wait()
+	})
+
+	return s
+}
+
+// URL returns the Bolt URL of the server.
+func (s *fakeBoltServer) URL() string {
+	return fmt.Sprintf("bolt://%s", s.listener.Addr().String())
+}
+
+// Queries returns all the queries received by the server.
+func (s *fakeBoltServer) Queries() []fakeBoltQuery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]fakeBoltQuery{}, s.queries...)
+}
+
+func (s *fakeBoltServer) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+
+			if err := s.serveConn(conn); err != nil && !errors.Is(err, io.EOF) {
+				s.t.Logf("fake bolt server: %v", err)
+			}
+		}()
+	}
+}
+
+func (s *fakeBoltServer) serveConn(conn net.Conn) error {
+	handshake := make([]byte, boltHandshakeSz)
+	if _, err := io.ReadFull(conn, handshake); err != nil {
+		return err
+	}
+	if !bytes.Equal(handshake[:4], []byte{0x60, 0x60, 0xB0, 0x17}) {
+		return errors.New("invalid handshake")
+	}
+
+	// Always select Bolt 4.4
+	if _, err := conn.Write([]byte{0x00, 0x00, 0x04, 0x04}); err != nil {
+		return err
+	}
+
+	var pending *fakeBoltResult
+	for {
+		msg, err := readBoltMessage(conn)
+		if err != nil {
+			return err
+		}
+
+		switch msg.tag {
+		case boltMsgGoodbye:
+			return nil
+		case boltMsgHello:
+			err = writeBoltMessage(conn, boltMsgSuccess, map[string]any{
+				"connection_id": "bolt-1",
+				"server":        "Neo4j/4.4.0",
+			})
+		case boltMsgRun:
+			query, _ := msg.fields[0].(string)
+			params, _ := msg.fields[1].(map[string]any)
+
+			s.mu.Lock()
+			s.queries = append(s.queries, fakeBoltQuery{query: query, params: params})
+			s.mu.Unlock()
+
+			res, herr := s.handler(query, params)
+			if herr != nil {
+				pending = nil
+				err = writeBoltMessage(conn, boltMsgFailure, map[string]any{
+					"code":    "Neo.ClientError.Statement.SyntaxError",
+					"message": herr.Error(),
+				})
+
+				break
+			}
+
+			pending = &res
+			fields := make([]any, 0, len(res.fields))
+			for _, f := range res.fields {
+				fields = append(fields, f)
+			}
+			err = writeBoltMessage(conn, boltMsgSuccess, map[string]any{"fields": fields, "t_first": int64(0)})
+		case boltMsgPull:
+			if pending != nil {
+				for _, r := range pending.records {
+					if err = writeBoltMessage(conn, boltMsgRecord, r); err != nil {
+						return err
+					}
+				}
+				pending = nil
+			}
+			err = writeBoltMessage(conn, boltMsgSuccess, map[string]any{"has_more": false, "type": "w"})
+		case boltMsgDiscard:
+			pending = nil
+			err = writeBoltMessage(conn, boltMsgSuccess, map[string]any{"has_more": false})
+		default:
+			// BEGIN, COMMIT, ROLLBACK, RESET
+			err = writeBoltMessage(conn, boltMsgSuccess, map[string]any{})
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readBoltMessage reads a chunked Bolt message.
+func readBoltMessage(r io.Reader) (*boltStruct, error) {
+	var buf bytes.Buffer
+	header := make([]byte, 2)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			return nil, err
+		}
+
+		size := binary.BigEndian.Uint16(header)
+		if size == 0 {
+			if buf.Len() == 0 {
+				// NOOP chunk
+				continue
+			}
+
+			break
+		}
+
+		if _, err := io.CopyN(&buf, r, int64(size)); err != nil {
+			return nil, err
+		}
+	}
+
+	v, err := unpackBolt(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	msg, ok := v.(*boltStruct)
+	if !ok {
+		return nil, fmt.Errorf("unexpected bolt message %T", v)
+	}
+
+	return msg, nil
+}
+
+// writeBoltMessage writes a chunked Bolt message.
+func writeBoltMessage(w io.Writer, tag byte, fields ...any) error {
+	var buf bytes.Buffer
+	packBolt(&buf, &boltStruct{tag: tag, fields: fields})
+
+	var out bytes.Buffer
+	data := buf.Bytes()
+	for len(data) > 0 {
+		size := min(len(data), math.MaxUint16)
+		_ = binary.Write(&out, binary.BigEndian, uint16(size))
+		out.Write(data[:size])
+		data = data[size:]
+	}
+	out.Write([]byte{0x00, 0x00})
+
+	_, err := w.Write(out.Bytes())
+
+	return err
+}
+
+func packBoltHeader(buf *bytes.Buffer, size int, tiny byte, markers ...byte) {
+	switch {
+	case size < 0x10 && tiny != 0:
+		buf.WriteByte(tiny + byte(size))
+	case size <= math.MaxUint8:
+		buf.WriteByte(markers[0])
+		buf.WriteByte(byte(size))
+	case size <= math.MaxUint16:
+		buf.WriteByte(markers[1])
+		_ = binary.Write(buf, binary.BigEndian, uint16(size))
+	default:
+		buf.WriteByte(markers[2])
+		_ = binary.Write(buf, binary.BigEndian, uint32(size)) //nolint:gosec // test sizes fit
+	}
+}
+
+// packBolt encodes a value using PackStream.
+func packBolt(buf *bytes.Buffer, v any) {
+	switch val := v.(type) {
+	case nil:
+		buf.WriteByte(0xC0)
+	case bool:
+		if val {
+			buf.WriteByte(0xC3)
+		} else {
+			buf.WriteByte(0xC2)
+		}
+	case int:
+		packBolt(buf, int64(val))
+	case int64:
+		switch {
+		case val >= -16 && val <= 127:
+			buf.WriteByte(byte(int8(val)))
+		case val >= math.MinInt32 && val <= math.MaxInt32:
+			buf.WriteByte(0xCA)
+			_ = binary.Write(buf, binary.BigEndian, int32(val))
+		default:
+			buf.WriteByte(0xCB)
+			_ = binary.Write(buf, binary.BigEndian, val)
+		}
+	case float64:
+		buf.WriteByte(0xC1)
+		_ = binary.Write(buf, binary.BigEndian, math.Float64bits(val))
+	case string:
+		packBoltHeader(buf, len(val), 0x80, 0xD0, 0xD1, 0xD2)
+		buf.WriteString(val)
+	case []any:
+		packBoltHeader(buf, len(val), 0x90, 0xD4, 0xD5, 0xD6)
+		for _, e := range val {
+			packBolt(buf, e)
+		}
+	case map[string]any:
+		packBoltHeader(buf, len(val), 0xA0, 0xD8, 0xD9, 0xDA)
+		for k, e := range val {
+			packBolt(buf, k)
+			packBolt(buf, e)
+		}
+	case *boltStruct:
+		buf.WriteByte(0xB0 + byte(len(val.fields)))
+		buf.WriteByte(val.tag)
+		for _, f := range val.fields {
+			packBolt(buf, f)
+		}
+	default:
+		panic(fmt.Sprintf("unsupported packstream type %T", v))
+	}
+}
+
+func unpackBoltSize(r *bytes.Reader, marker byte, base byte) (int, error) {
+	switch marker - base {
+	case 0:
+		b, err := r.ReadByte()
+
+		return int(b), err
+	case 1:
+		var size uint16
+		err := binary.Read(r, binary.BigEndian, &size)
+
+		return int(size), err
+	default:
+		var size uint32
+		err := binary.Read(r, binary.BigEndian, &size)
+
+		return int(size), err
+	}
+}
+
+// unpackBolt decodes a PackStream value.
+func unpackBolt(r *bytes.Reader) (any, error) {
+	marker, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	var size int
+	switch {
+	case marker <= 0x7F || marker >= 0xF0:
+		return int64(int8(marker)), nil
+	case marker >= 0x80 && marker <= 0x8F:
+		return unpackBoltString(r, int(marker&0x0F))
+	case marker >= 0x90 && marker <= 0x9F:
+		return unpackBoltList(r, int(marker&0x0F))
+	case marker >= 0xA0 && marker <= 0xAF:
+		return unpackBoltMap(r, int(marker&0x0F))
+	case marker >= 0xB0 && marker <= 0xBF:
+		tag, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		fields, err := unpackBoltList(r, int(marker&0x0F))
+		if err != nil {
+			return nil, err
+		}
+
+		return &boltStruct{tag: tag, fields: fields}, nil
+	}
+
+	switch marker {
+	case 0xC0:
+		return nil, nil
+	case 0xC1:
+		var bits uint64
+		err = binary.Read(r, binary.BigEndian, &bits)
+
+		return math.Float64frombits(bits), err
+	case 0xC2:
+		return false, nil
+	case 0xC3:
+		return true, nil
+	case 0xC8:
+		var i int8
+		err = binary.Read(r, binary.BigEndian, &i)
+
+		return int64(i), err
+	case 0xC9:
+		var i int16
+		err = binary.Read(r, binary.BigEndian, &i)
+
+		return int64(i), err
+	case 0xCA:
+		var i int32
+		err = binary.Read(r, binary.BigEndian, &i)
+
+		return int64(i), err
+	case 0xCB:
+		var i int64
+		err = binary.Read(r, binary.BigEndian, &i)
+
+		return i, err
+	case 0xCC, 0xCD, 0xCE:
+		if size, err = unpackBoltSize(r, marker, 0xCC); err != nil {
+			return nil, err
+		}
+		b := make([]byte, size)
+		_, err = io.ReadFull(r, b)
+
+		return b, err
+	case 0xD0, 0xD1, 0xD2:
+		if size, err = unpackBoltSize(r, marker, 0xD0); err != nil {
+			return nil, err
+		}
+
+		return unpackBoltString(r, size)
+	case 0xD4, 0xD5, 0xD6:
+		if size, err = unpackBoltSize(r, marker, 0xD4); err != nil {
+			return nil, err
+		}
+
+		return unpackBoltList(r, size)
+	case 0xD8, 0xD9, 0xDA:
+		if size, err = unpackBoltSize(r, marker, 0xD8); err != nil {
+			return nil, err
+		}
+
+		return unpackBoltMap(r, size)
+	}
+
+	return nil, fmt.Errorf("unsupported packstream marker 0x%X", marker)
+}
+
+func unpackBoltString(r *bytes.Reader, size int) (string, error) {
+	b := make([]byte, size)
+	_, err := io.ReadFull(r, b)
+
+	return string(b), err
+}
+
+func unpackBoltList(r *bytes.Reader, size int) ([]any, error) {
+	list := make([]any, 0, size)
+	for range size {
+		v, err := unpackBolt(r)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+
+	return list, nil
+}
+
+func unpackBoltMap(r *bytes.Reader, size int) (map[string]any, error) {
+	m := make(map[string]any, size)
+	for range size {
+		k, err := unpackBolt(r)
+		if err != nil {
+			return nil, err
+		}
+
+		key, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected packstream map key %T", k)
+		}
+
+		m[key], err = unpackBolt(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
@@ -0,0 +1,211 @@
+package graphdb
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+)
+
+// cypherStatement is an openCypher query along with its parameters.
+type cypherStatement struct {
+	query  string
+	params map[string]any
+}
+
+// cypherIdentifier escapes a label or property name for use in an openCypher query.
+func cypherIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// vertexStatements converts backend-neutral vertex writes to openCypher statements, one per vertex label. Each
+// statement returns the graph id and store id of the created vertices.
+func vertexStatements(writes []types.VertexWrite) []cypherStatement {
+	rows := make(map[string][]any)
+	labels := make([]string, 0)
+	for _, w := range writes {
+		if _, ok := rows[w.Label]; !ok {
+			labels = append(labels, w.Label)
+		}
+		rows[w.Label] = append(rows[w.Label], w.Properties)
+	}
+
+	statements := make([]cypherStatement, 0, len(labels))
+	for _, label := range labels {
+		statements = append(statements, cypherStatement{
+			query: fmt.Sprintf("UNWIND $rows AS row CREATE (v:%s) SET v = row RETURN id(v) AS id, v.storeID AS storeID",
+				cypherIdentifier(label)),
+			params: map[string]any{"rows": rows[label]},
+		})
+	}
+
+	return statements
+}
+
+// edgeStatements converts backend-neutral edge writes to openCypher statements. Writes sharing the same shape (label,
+// filtered properties, etc.) are batched in a single statement.
+func edgeStatements(writes []types.EdgeWrite) []cypherStatement {
+	statements := make([]cypherStatement, 0)
+	index := make(map[string]int)
+	for _, w := range writes {
+		query := edgeQuery(w)
+		i, ok := index[query]
+		if !ok {
+			i = len(statements)
+			index[query] = i
+			statements = append(statements, cypherStatement{
+				query:  query,
+				params: map[string]any{"rows": make([]any, 0)},
+			})
+		}
+
+		row := map[string]any{
+			"outIds": w.Out.IDs,
+			"out":    w.Out.Properties,
+			"outSet": w.Out.Set,
+			"props":  w.Properties,
+		}
+		if w.In != nil && len(w.InPath) == 0 {
+			row["inIds"] = w.In.IDs
+			row["in"] = w.In.Properties
+			row["inSet"] = w.In.Set
+		}
+		if len(w.OutPath) > 0 {
+			row["outPath"] = hopParams(w.OutPath)
+		}
+		if len(w.InPath) > 0 {
+			row["inPath"] = hopParams(w.InPath)
+		}
+
+		statements[i].params["rows"] = append(statements[i].params["rows"].([]any), row)
+	}
+
+	return statements
+}
+
+// edgeQuery builds the openCypher query for all edge writes sharing the shape of the provided write.
+func edgeQuery(w types.EdgeWrite) string {
+	var sb strings.Builder
+	sb.WriteString("UNWIND $rows AS row ")
+
+	out := "o"
+	if len(w.OutPath) > 0 {
+		out = "s"
+	}
+	sb.WriteString(matchClause(out, "out", w.Out))
+	sb.WriteString(hopClauses(out, "o", "outPath", w.OutPath))
+
+	in := "o"
+	switch {
+	case len(w.InPath) > 0:
+		in = "i"
+		sb.WriteString(hopClauses("o", in, "inPath", w.InPath))
+	case w.In != nil:
+		in = "i"
+		sb.WriteString(matchClause(in, "in", *w.In))
+	}
+
+	verb := "CREATE"
+	if w.Merge {
+		verb = "MERGE"
+	}
+	fmt.Fprintf(&sb, "%s (o)-[r:%s]->(%s) SET r += row.props", verb, cypherIdentifier(w.Label), in)
+
+	return sb.String()
+}
+
+// hopParams returns the row parameters of the hops of a path.
+func hopParams(hops []types.VertexHop) []any {
+	params := make([]any, 0, len(hops))
+	for _, h := range hops {
+		params = append(params, map[string]any{
+			"props":  h.Properties,
+			"within": h.Within,
+		})
+	}
+
+	return params
+}
+
+// hopClauses builds the MATCH clauses walking the hops of a path from the start variable. The vertices reached by the
+// last hop are bound to the end variable.
+func hopClauses(start string, end string, key string, hops []types.VertexHop) string {
+	var sb strings.Builder
+	previous := start
+	for i, h := range hops {
+		variable := fmt.Sprintf("%s%d", end, i)
+		if i == len(hops)-1 {
+			variable = end
+		}
+
+		node := variable
+		if class, ok := h.Properties["class"].(string); ok {
+			node += ":" + cypherIdentifier(class)
+		}
+
+		if h.Inbound {
+			fmt.Fprintf(&sb, "MATCH (%s)<-[:%s]-(%s)", previous, cypherIdentifier(h.Label), node)
+		} else {
+			fmt.Fprintf(&sb, "MATCH (%s)-[:%s]->(%s)", previous, cypherIdentifier(h.Label), node)
+		}
+
+		conditions := make([]string, 0, len(h.Properties)+len(h.Within))
+		for _, p := range sortedKeys(h.Properties) {
+			conditions = append(conditions, fmt.Sprintf("%s.%s = row.%s[%d].props.%s",
+				variable, cypherIdentifier(p), key, i, cypherIdentifier(p)))
+		}
+		for _, p := range sortedKeys(h.Within) {
+			conditions = append(conditions, fmt.Sprintf("%s.%s IN row.%s[%d].within.%s",
+				variable, cypherIdentifier(p), key, i, cypherIdentifier(p)))
+		}
+		if len(conditions) > 0 {
+			fmt.Fprintf(&sb, " WHERE %s", strings.Join(conditions, " AND "))
+		}
+		sb.WriteString(" ")
+
+		previous = variable
+	}
+
+	return sb.String()
+}
+
+// matchClause builds the MATCH (and optional SET) clause selecting the vertices of a filter. The class property
+// mirrors the vertex label so it is also used as a label to benefit from label indexes.
+func matchClause(variable string, key string, f types.VertexFilter) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "MATCH (%s", variable)
+	if class, ok := f.Properties["class"].(string); ok {
+		fmt.Fprintf(&sb, ":%s", cypherIdentifier(class))
+	}
+	sb.WriteString(")")
+
+	conditions := make([]string, 0, len(f.Properties)+1)
+	if len(f.IDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf("id(%s) IN row.%sIds", variable, key))
+	}
+	for _, p := range sortedKeys(f.Properties) {
+		conditions = append(conditions, fmt.Sprintf("%s.%s = row.%s.%s", variable, cypherIdentifier(p), key, cypherIdentifier(p)))
+	}
+	if len(conditions) > 0 {
+		fmt.Fprintf(&sb, " WHERE %s", strings.Join(conditions, " AND "))
+	}
+	sb.WriteString(" ")
+
+	if len(f.Set) > 0 {
+		// A WITH clause is required between an update and a subsequent MATCH.
+		fmt.Fprintf(&sb, "SET %s += row.%sSet WITH * ", variable, key)
+	}
+
+	return sb.String()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,127 @@
+package graphdb
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/edge"
+	"github.com/DataDog/KubeHound/pkg/telemetry/log"
+	"github.com/DataDog/KubeHound/pkg/telemetry/metric"
+	"github.com/DataDog/KubeHound/pkg/telemetry/span"
+	"github.com/DataDog/KubeHound/pkg/telemetry/statsd"
+	"github.com/DataDog/KubeHound/pkg/telemetry/tag"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+var _ AsyncEdgeWriter = (*Neo4jEdgeWriter)(nil)
+
+type Neo4jEdgeWriter struct {
+	builder         string                  // Qualified name of the edge being written
+	edge            edge.Builder            // Edge builder providing the writes
+	driver          neo4j.DriverWithContext // Bolt driver
+	database        string                  // Database to write to
+	writingInFlight *sync.WaitGroup         // Wait group tracking current unfinished writes
+	qcounter        int32                   // Track items queued
+	wcounter        int32                   // Track items written
+	tags            []string                // Telemetry tags
+	writerTimeout   time.Duration           // Timeout for the writer
+	mb              *microBatcher           // Micro batcher to batch writes
+}
+
+// NewNeo4jAsyncEdgeWriter creates a new bulk edge writer instance.
+func NewNeo4jAsyncEdgeWriter(ctx context.Context, driver neo4j.DriverWithContext, database string,
+	e edge.Builder, opts ...WriterOption,
+) (*Neo4jEdgeWriter, error) {
+	options := &writerOptions{
+		WriterTimeout:     defaultWriterTimeout,
+		WriterWorkerCount: defaultWriterWorkerCount,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	builder := fmt.Sprintf("%s::%s", e.Name(), e.Label())
+	nw := Neo4jEdgeWriter{
+		builder:         builder,
+		edge:            e,
+		driver:          driver,
+		database:        database,
+		writingInFlight: &sync.WaitGroup{},
+		tags:            append(options.Tags, tag.Label(e.Label()), tag.Builder(builder)),
+		writerTimeout:   options.WriterTimeout,
+	}
+
+	nw.mb = newMicroBatcher(log.Trace(ctx), e.BatchSize(), options.WriterWorkerCount, func(ctx context.Context, a []any) error {
+		nw.writingInFlight.Add(1)
+		defer nw.writingInFlight.Done()
+
+		return nw.batchWrite(ctx, a)
+	})
+	nw.mb.Start(ctx)
+
+	return &nw, nil
+}
+
+// batchWrite will write a batch of entries into the graph DB and block until the write completes.
+func (nwe *Neo4jEdgeWriter) batchWrite(ctx context.Context, data []any) error {
+	span, ctx := span.SpanRunFromContext(ctx, span.Neo4jBatchWrite)
+	span.SetTag(tag.LabelTag, nwe.builder)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	datalen := len(data)
+	_ = statsd.Count(ctx, metric.EdgeWrite, int64(datalen), nwe.tags, 1)
+	log.Trace(ctx).Debugf("Batch write Neo4jEdgeWriter with %d elements", datalen)
+	atomic.AddInt32(&nwe.wcounter, int32(datalen)) //nolint:gosec // disable G115
+
+	writes, err := nwe.edge.Writes()(data)
+	if err != nil {
+		return fmt.Errorf("%s edge writes: %w", nwe.builder, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, nwe.writerTimeout)
+	defer cancel()
+
+	_, err = runStatements(ctx, nwe.driver, nwe.database, edgeStatements(writes))
+	if err != nil {
+		return fmt.Errorf("%s edge insert: %w", nwe.builder, err)
+	}
+
+	return nil
+}
+
+func (nwe *Neo4jEdgeWriter) Close(ctx context.Context) error {
+	return nil
+}
+
+// Flush triggers writes of any remaining items in the queue.
+// This is blocking
+func (nwe *Neo4jEdgeWriter) Flush(ctx context.Context) error {
+	span, ctx := span.SpanRunFromContext(ctx, span.Neo4jFlush)
+	span.SetTag(tag.LabelTag, nwe.builder)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	err = nwe.mb.Flush(ctx)
+	if err != nil {
+		return fmt.Errorf("micro batcher flush: %w", err)
+	}
+
+	// Wait for all writes to complete.
+	nwe.writingInFlight.Wait()
+
+	log.Trace(ctx).Debugf("Edge writer %d %s queued", nwe.qcounter, nwe.builder)
+	log.Trace(ctx).Infof("Edge writer %d %s written", nwe.wcounter, nwe.builder)
+
+	return nil
+}
+
+func (nwe *Neo4jEdgeWriter) Queue(ctx context.Context, e any) error {
+	atomic.AddInt32(&nwe.qcounter, 1)
+
+	return nwe.mb.Enqueue(ctx, e)
+}
@@ -0,0 +1,183 @@
+package graphdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/edge"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/telemetry/log"
+	"github.com/DataDog/KubeHound/pkg/telemetry/span"
+	"github.com/DataDog/KubeHound/pkg/telemetry/tag"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	neo4jconfig "github.com/neo4j/neo4j-go-driver/v5/neo4j/config"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+const (
+	Neo4jStorageProviderName = "neo4j"
+)
+
+var (
+	_ Provider = (*Neo4jProvider)(nil)
+)
+
+// Neo4jProvider is a graphdb provider writing the graph to a Bolt protocol database (Neo4j, Memgraph) using the
+// backend-neutral writes of the vertex and edge builders.
+type Neo4jProvider struct {
+	driver neo4j.DriverWithContext // Bolt driver
+	tags   []string                // Tags to be applied for telemetry
+	cfg    *config.KubehoundConfig // Application configuration
+}
+
+func NewNeo4jProvider(ctx context.Context, cfg *config.KubehoundConfig) (*Neo4jProvider, error) {
+	if cfg.Neo4j.URL == "" {
+		return nil, errors.New("Neo4j URL is not set")
+	}
+
+	auth := neo4j.NoAuth()
+	if cfg.Neo4j.Username != "" {
+		auth = neo4j.BasicAuth(cfg.Neo4j.Username, cfg.Neo4j.Password, "")
+	}
+
+	driver, err := neo4j.NewDriverWithContext(cfg.Neo4j.URL, auth, func(c *neo4jconfig.Config) {
+		if cfg.Neo4j.ConnectionTimeout > 0 {
+			c.SocketConnectTimeout = cfg.Neo4j.ConnectionTimeout
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating neo4j driver: %w", err)
+	}
+
+	return &Neo4jProvider{
+		cfg:    cfg,
+		driver: driver,
+		tags:   tag.GetBaseTagsWith(tag.Storage(Neo4jStorageProviderName)),
+	}, nil
+}
+
+func (np *Neo4jProvider) Name() string {
+	return Neo4jStorageProviderName
+}
+
+func (np *Neo4jProvider) Prepare(ctx context.Context) error {
+	if !np.cfg.Storage.Wipe {
+		log.Trace(ctx).Warn("Skipping graph vertex wipe")
+
+		return nil
+	}
+
+	// These vertex types are defined in the schema.
+	for _, vertexType := range vertex.Labels {
+		err := np.deleteVertices(ctx, "MATCH (n) WHERE n.class = $value", vertexType)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HealthCheck verifies the driver can establish a working connection to the database.
+func (np *Neo4jProvider) HealthCheck(ctx context.Context) (bool, error) {
+	if np.driver == nil {
+		return false, errors.New("get neo4j driver (nil)")
+	}
+
+	if err := np.driver.VerifyConnectivity(ctx); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Raw returns a handle to the underlying provider to allow implementation specific operations e.g graph queries.
+func (np *Neo4jProvider) Raw() any {
+	return np.driver
+}
+
+// VertexWriter creates a new AsyncVertexWriter instance to enable asynchronous bulk inserts of vertices.
+func (np *Neo4jProvider) VertexWriter(ctx context.Context, v vertex.Builder,
+	c cache.CacheProvider, opts ...WriterOption) (AsyncVertexWriter, error) {
+
+	opts = append(opts, WithTags(np.tags))
+	opts = append(opts, WithWriterWorkerCount(np.cfg.Neo4j.WriterWorkerCount))
+	opts = append(opts, WithWriterTimeout(np.cfg.Neo4j.WriterTimeout))
+
+	return NewNeo4jAsyncVertexWriter(ctx, np.driver, np.cfg.Neo4j.Database, v, c, opts...)
+}
+
+// EdgeWriter creates a new AsyncEdgeWriter instance to enable asynchronous bulk inserts of edges.
+func (np *Neo4jProvider) EdgeWriter(ctx context.Context, e edge.Builder, opts ...WriterOption) (AsyncEdgeWriter, error) {
+	opts = append(opts, WithTags(np.tags))
+	opts = append(opts, WithWriterWorkerCount(np.cfg.Neo4j.WriterWorkerCount))
+	opts = append(opts, WithWriterTimeout(np.cfg.Neo4j.WriterTimeout))
+
+	return NewNeo4jAsyncEdgeWriter(ctx, np.driver, np.cfg.Neo4j.Database, e, opts...)
+}
+
+// Close cleans up any resources used by the Provider implementation. Provider cannot be reused after this call.
+func (np *Neo4jProvider) Close(ctx context.Context) error {
+	return np.driver.Close(ctx)
+}
+
+// Clean removes all vertices in the graph for the given cluster.
+func (np *Neo4jProvider) Clean(ctx context.Context, cluster string) error {
+	var err error
+	span, ctx := span.SpanRunFromContext(ctx, span.IngestorClean)
+	defer func() { span.Finish(tracer.WithError(err)) }()
+	l := log.Trace(ctx)
+	l.Info("Cleaning cluster", log.String(log.FieldClusterKey, cluster))
+
+	err = np.deleteVertices(ctx, "MATCH (n) WHERE n.cluster = $value", cluster)
+
+	return err
+}
+
+// deleteVertices deletes the vertices (and their edges) matched by the provided clause in batches.
+func (np *Neo4jProvider) deleteVertices(ctx context.Context, match string, value string) error {
+	session := np.driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName: np.cfg.Neo4j.Database,
+		AccessMode:   neo4j.AccessModeWrite,
+	})
+	defer session.Close(ctx)
+
+	query := fmt.Sprintf("%s WITH n LIMIT %d DETACH DELETE n RETURN count(*) AS deleted", match, deleteBatchSize)
+	for {
+		deleted, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+			res, err := tx.Run(ctx, query, map[string]any{"value": value})
+			if err != nil {
+				return nil, err
+			}
+
+			record, err := res.Single(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			count, _ := record.Get("deleted")
+
+			return count, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// If there are no more vertices to delete, break the loop.
+		if count, ok := deleted.(int64); !ok || count == 0 {
+			break
+		}
+
+		// Check context for cancellation.
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,300 @@
+package graphdb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/edge"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/graph"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	cache "github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/mocks"
+	gremlin "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	neo4jTestCluster = "test-cluster"
+)
+
+func neo4jTestConfig(t *testing.T, url string) *config.KubehoundConfig {
+	t.Helper()
+
+	return &config.KubehoundConfig{
+		Storage: config.StorageConfig{
+			Wipe:          true,
+			GraphProvider: config.GraphProviderNeo4j,
+		},
+		Neo4j: config.Neo4jConfig{
+			URL:               url,
+			WriterTimeout:     defaultWriterTimeout,
+			WriterWorkerCount: 1,
+		},
+		Builder: config.BuilderConfig{
+			Vertex: config.VertexBuilderConfig{
+				BatchSize: 2,
+			},
+			Edge: config.EdgeBuilderConfig{
+				BatchSize: 2,
+			},
+		},
+		Dynamic: config.DynamicConfig{
+			RunID: config.NewRunID(),
+			Cluster: config.DynamicClusterInfo{
+				Name: neo4jTestCluster,
+			},
+		},
+	}
+}
+
+// neo4jTestProvider returns a provider connected to a fake Bolt server answering queries with the provided handler.
+func neo4jTestProvider(t *testing.T, handler fakeBoltHandler) (*Neo4jProvider, *fakeBoltServer, *config.KubehoundConfig) {
+	t.Helper()
+	ctx := context.Background()
+
+	s := newFakeBoltServer(t, handler)
+	cfg := neo4jTestConfig(t, s.URL())
+
+	p, err := NewNeo4jProvider(ctx, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = p.Close(ctx) })
+
+	return p, s, cfg
+}
+
+// noRecordsHandler answers all queries with an empty result.
+func noRecordsHandler(_ string, _ map[string]any) (fakeBoltResult, error) {
+	return fakeBoltResult{}, nil
+}
+
+// queriesWith returns the queries received by the server containing the provided substring.
+func queriesWith(s *fakeBoltServer, substr string) []fakeBoltQuery {
+	res := make([]fakeBoltQuery, 0)
+	for _, q := range s.Queries() {
+		if strings.Contains(q.query, substr) {
+			res = append(res, q)
+		}
+	}
+
+	return res
+}
+
+func writeNeo4jEdges(t *testing.T, p *Neo4jProvider, e edge.Builder, inserts ...any) {
+	t.Helper()
+	ctx := context.Background()
+
+	w, err := p.EdgeWriter(ctx, e)
+	require.NoError(t, err)
+
+	for _, insert := range inserts {
+		require.NoError(t, w.Queue(ctx, insert))
+	}
+
+	require.NoError(t, w.Flush(ctx))
+	require.NoError(t, w.Close(ctx))
+}
+
+func TestNeo4jProvider_HealthCheck(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	p, _, _ := neo4jTestProvider(t, noRecordsHandler)
+	ok, err := p.HealthCheck(ctx)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = NewNeo4jProvider(ctx, neo4jTestConfig(t, ""))
+	assert.Error(t, err)
+}
+
+func TestNeo4jProvider_VertexWriter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Assign sequential ids to the created vertices
+	var mu sync.Mutex
+	nextID := int64(100)
+	p, s, cfg := neo4jTestProvider(t, func(query string, params map[string]any) (fakeBoltResult, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		res := fakeBoltResult{fields: []string{"id", "storeID"}}
+		rows, _ := params["rows"].([]any)
+		for _, r := range rows {
+			row, _ := r.(map[string]any)
+			res.records = append(res.records, []any{nextID, row["storeID"]})
+			nextID++
+		}
+
+		return res, nil
+	})
+
+	ids := make(map[string]int64)
+	cw := cache.NewAsyncWriter(t)
+	cw.EXPECT().Queue(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(
+		func(_ context.Context, key cachekey.CacheKey, value any) error {
+			id, ok := value.(int64)
+			require.True(t, ok)
+			ids[key.Key()] = id
+
+			return nil
+		})
+	cw.EXPECT().Flush(mock.Anything).Return(nil)
+	cw.EXPECT().Close(mock.Anything).Return(nil)
+
+	c := cache.NewCacheProvider(t)
+	c.EXPECT().BulkWriter(mock.Anything, mock.Anything).Return(cw, nil)
+
+	node := &vertex.Node{}
+	require.NoError(t, node.Initialize(cfg))
+	w, err := p.VertexWriter(ctx, node, c)
+	require.NoError(t, err)
+
+	for _, name := range []string{"node-1", "node-2", "node-3"} {
+		insert, err := node.Processor(ctx, &graph.Node{
+			StoreID: name,
+			Name:    name,
+			RunID:   cfg.Dynamic.RunID.String(),
+			Cluster: neo4jTestCluster,
+		})
+		require.NoError(t, err)
+		require.NoError(t, w.Queue(ctx, insert))
+	}
+	require.NoError(t, w.Flush(ctx))
+	require.NoError(t, w.Close(ctx))
+
+	assert.Len(t, ids, 3)
+	assert.ElementsMatch(t, []int64{100, 101, 102}, []int64{ids["node-1"], ids["node-2"], ids["node-3"]})
+
+	queries := queriesWith(s, "CREATE (v:`Node`) SET v = row")
+	require.Len(t, queries, 2, "vertices should be written in batches of 2")
+	for _, q := range queries {
+		rows, _ := q.params["rows"].([]any)
+		for _, r := range rows {
+			row, _ := r.(map[string]any)
+			assert.Equal(t, vertex.NodeLabel, row["class"])
+			assert.Equal(t, cfg.Dynamic.RunID.String(), row["runID"])
+		}
+	}
+}
+
+func TestNeo4jProvider_EdgeWriter(t *testing.T) {
+	t.Parallel()
+
+	p, s, cfg := neo4jTestProvider(t, noRecordsHandler)
+
+	// Default writes merging edges between known vertices
+	discover := &edge.PermissionDiscover{}
+	require.NoError(t, discover.Initialize(&cfg.Builder.Edge, &cfg.Dynamic))
+	writeNeo4jEdges(t, p, discover, map[any]any{
+		gremlin.T.Label:       discover.Label(),
+		gremlin.Direction.Out: int64(1),
+		gremlin.Direction.In:  int64(2),
+	})
+
+	queries := queriesWith(s, "MERGE (o)-[r:`PERMISSION_DISCOVER`]->(i)")
+	require.Len(t, queries, 1)
+	assert.Contains(t, queries[0].query, "MATCH (o) WHERE id(o) IN row.outIds")
+	rows, _ := queries[0].params["rows"].([]any)
+	require.Len(t, rows, 1)
+	row, _ := rows[0].(map[string]any)
+	assert.Equal(t, []any{int64(1)}, row["outIds"])
+	assert.Equal(t, []any{int64(2)}, row["inIds"])
+
+	// Custom writes linking each non critical permission set to all nodes of the run
+	podCreate := &edge.PodCreate{}
+	require.NoError(t, podCreate.Initialize(&cfg.Builder.Edge, &cfg.Dynamic))
+	writeNeo4jEdges(t, p, podCreate, int64(1), int64(2))
+
+	queries = queriesWith(s, "CREATE (o)-[r:`POD_CREATE`]->(i)")
+	require.Len(t, queries, 1)
+	assert.Contains(t, queries[0].query, "o.`critical` = row.out.`critical`")
+	assert.Contains(t, queries[0].query, "MATCH (i:`Node`) WHERE i.`class` = row.in.`class`")
+	rows, _ = queries[0].params["rows"].([]any)
+	require.Len(t, rows, 1)
+	row, _ = rows[0].(map[string]any)
+	assert.Equal(t, []any{int64(1), int64(2)}, row["outIds"])
+	assert.Equal(t, map[string]any{
+		"class":   vertex.NodeLabel,
+		"runID":   cfg.Dynamic.RunID.String(),
+		"cluster": neo4jTestCluster,
+	}, row["in"])
+	props, _ := row["props"].(map[string]any)
+	assert.Equal(t, string(podCreate.AttckTechniqueID()), props["attckTechniqueID"])
+
+	// Edges following a path in the graph
+	symlink := &edge.EscapeVarLogSymlink{}
+	require.NoError(t, symlink.Initialize(&cfg.Builder.Edge, &cfg.Dynamic))
+	writeNeo4jEdges(t, p, symlink, int64(1))
+
+	queries = queriesWith(s, "CREATE (o)-[r:`CE_VAR_LOG_SYMLINK`]->(i)")
+	require.Len(t, queries, 1)
+	assert.Contains(t, queries[0].query, "MATCH (s:`PermissionSet`) WHERE id(s) IN row.outIds")
+	assert.Contains(t, queries[0].query, "MATCH (s)<-[:`PERMISSION_DISCOVER`]-(o0) MATCH (o0)<-[:`IDENTITY_ASSUME`]-(o:`Container`)")
+	assert.Contains(t, queries[0].query, "MATCH (o)-[:`VOLUME_DISCOVER`]->(i0) WHERE i0.`type` = row.inPath[0].props.`type`"+
+		" AND i0.`sourcePath` IN row.inPath[0].within.`sourcePath`")
+	assert.Contains(t, queries[0].query, "MATCH (i0)<-[:`VOLUME_ACCESS`]-(i:`Node`)")
+	rows, _ = queries[0].params["rows"].([]any)
+	require.Len(t, rows, 1)
+	row, _ = rows[0].(map[string]any)
+	assert.Equal(t, []any{int64(1)}, row["outIds"])
+}
+
+func TestNeo4jProvider_EdgeWriter_LargeCluster(t *testing.T) {
+	t.Parallel()
+
+	p, s, cfg := neo4jTestProvider(t, noRecordsHandler)
+	cfg.Builder.Edge.LargeClusterOptimizations = true
+
+	podCreate := &edge.PodCreate{}
+	require.NoError(t, podCreate.Initialize(&cfg.Builder.Edge, &cfg.Dynamic))
+	writeNeo4jEdges(t, p, podCreate, map[any]any{gremlin.T.Label: vertex.PermissionSetLabel, gremlin.T.Id: int64(1)})
+
+	queries := queriesWith(s, "POD_CREATE")
+	require.Len(t, queries, 1)
+	assert.Contains(t, queries[0].query, "SET o += row.outSet WITH * CREATE (o)-[r:`POD_CREATE`]->(o)")
+	rows, _ := queries[0].params["rows"].([]any)
+	require.Len(t, rows, 1)
+	row, _ := rows[0].(map[string]any)
+	assert.Equal(t, map[string]any{"critical": true}, row["outSet"])
+}
+
+func TestNeo4jProvider_Clean(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Report a single batch of deleted vertices per query
+	var mu sync.Mutex
+	deleted := make(map[string]bool)
+	p, s, _ := neo4jTestProvider(t, func(query string, params map[string]any) (fakeBoltResult, error) {
+		if !strings.Contains(query, "DETACH DELETE") {
+			return fakeBoltResult{}, errors.New("unexpected query")
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		value, _ := params["value"].(string)
+		count := int64(0)
+		if !deleted[value] {
+			deleted[value] = true
+			count = 2
+		}
+
+		return fakeBoltResult{fields: []string{"deleted"}, records: [][]any{{count}}}, nil
+	})
+
+	require.NoError(t, p.Clean(ctx, neo4jTestCluster))
+	queries := queriesWith(s, "WHERE n.cluster = $value")
+	require.Len(t, queries, 2, "deletion should loop until no vertex is left")
+	assert.Equal(t, neo4jTestCluster, queries[0].params["value"])
+
+	require.NoError(t, p.Prepare(ctx))
+	assert.Len(t, queriesWith(s, "WHERE n.class = $value"), 2*len(vertex.Labels))
+}
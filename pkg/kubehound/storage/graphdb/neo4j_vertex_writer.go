@@ -0,0 +1,210 @@
+package graphdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/types"
+	"github.com/DataDog/KubeHound/pkg/kubehound/graph/vertex"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache"
+	"github.com/DataDog/KubeHound/pkg/kubehound/storage/cache/cachekey"
+	"github.com/DataDog/KubeHound/pkg/telemetry/log"
+	"github.com/DataDog/KubeHound/pkg/telemetry/metric"
+	"github.com/DataDog/KubeHound/pkg/telemetry/span"
+	"github.com/DataDog/KubeHound/pkg/telemetry/statsd"
+	"github.com/DataDog/KubeHound/pkg/telemetry/tag"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+var _ AsyncVertexWriter = (*Neo4jVertexWriter)(nil)
+
+type Neo4jVertexWriter struct {
+	builder         string                  // Name of the graph entity being written
+	writes          types.VertexWrites      // Backend-neutral writes generator function
+	driver          neo4j.DriverWithContext // Bolt driver
+	database        string                  // Database to write to
+	writingInFlight *sync.WaitGroup         // Wait group tracking current unfinished writes
+	qcounter        int32                   // Track items queued
+	wcounter        int32                   // Track items written
+	tags            []string                // Telemetry tags
+	cache           cache.AsyncWriter       // Cache writer to cache store id -> vertex id mappings
+	writerTimeout   time.Duration           // Timeout for the writer
+	mb              *microBatcher           // Micro batcher to batch writes
+}
+
+// NewNeo4jAsyncVertexWriter creates a new bulk vertex writer instance.
+func NewNeo4jAsyncVertexWriter(ctx context.Context, driver neo4j.DriverWithContext, database string,
+	v vertex.Builder, c cache.CacheProvider, opts ...WriterOption,
+) (*Neo4jVertexWriter, error) {
+	options := &writerOptions{
+		WriterTimeout:     defaultWriterTimeout,
+		WriterWorkerCount: defaultWriterWorkerCount,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	cw, err := c.BulkWriter(ctx, cache.WithTest())
+	if err != nil {
+		return nil, fmt.Errorf("neo4j vertex writer cache creation: %w", err)
+	}
+
+	nw := Neo4jVertexWriter{
+		builder:         v.Label(),
+		writes:          v.Writes(),
+		driver:          driver,
+		database:        database,
+		writingInFlight: &sync.WaitGroup{},
+		tags:            append(options.Tags, tag.Label(v.Label()), tag.Builder(v.Label())),
+		cache:           cw,
+		writerTimeout:   options.WriterTimeout,
+	}
+
+	nw.mb = newMicroBatcher(log.Trace(ctx), v.BatchSize(), options.WriterWorkerCount, func(ctx context.Context, a []any) error {
+		nw.writingInFlight.Add(1)
+		defer nw.writingInFlight.Done()
+
+		return nw.batchWrite(ctx, a)
+	})
+	nw.mb.Start(ctx)
+
+	return &nw, nil
+}
+
+func (nvw *Neo4jVertexWriter) cacheIds(ctx context.Context, records []*neo4j.Record) error {
+	for _, r := range records {
+		rawStoreID, _ := r.Get("storeID")
+		rawVertexID, _ := r.Get("id")
+
+		storeID, sOk := rawStoreID.(string)
+		vertexID, vOk := rawVertexID.(int64)
+		if !sOk || !vOk {
+			return errors.New("vertex id type conversion")
+		}
+
+		err := nvw.cache.Queue(ctx, cachekey.ObjectID(storeID), vertexID)
+		if err != nil {
+			return fmt.Errorf("vertex id cache write: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// batchWrite will write a batch of entries into the graph DB and block until the write completes.
+func (nvw *Neo4jVertexWriter) batchWrite(ctx context.Context, data []any) error {
+	_ = statsd.Count(ctx, metric.BackgroundWriterCall, 1, nvw.tags, 1)
+
+	span, ctx := span.SpanRunFromContext(ctx, span.Neo4jBatchWrite)
+	span.SetTag(tag.LabelTag, nvw.builder)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	datalen := len(data)
+	_ = statsd.Count(ctx, metric.VertexWrite, int64(datalen), nvw.tags, 1)
+	log.Trace(ctx).Debugf("Batch write Neo4jVertexWriter with %d elements", datalen)
+	atomic.AddInt32(&nvw.wcounter, int32(datalen)) //nolint:gosec // disable G115
+
+	writes, err := nvw.writes(data)
+	if err != nil {
+		return fmt.Errorf("%s vertex writes: %w", nvw.builder, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, nvw.writerTimeout)
+	defer cancel()
+
+	records, err := runStatements(ctx, nvw.driver, nvw.database, vertexStatements(writes))
+	if err != nil {
+		return fmt.Errorf("%s vertex insert: %w", nvw.builder, err)
+	}
+
+	if err = nvw.cacheIds(ctx, records); err != nil {
+		return fmt.Errorf("cache ids: %w", err)
+	}
+
+	return nil
+}
+
+func (nvw *Neo4jVertexWriter) Close(ctx context.Context) error {
+	if nvw.cache != nil {
+		if err := nvw.cache.Close(ctx); err != nil {
+			return fmt.Errorf("closing cache: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Flush triggers writes of any remaining items in the queue.
+// This is blocking
+func (nvw *Neo4jVertexWriter) Flush(ctx context.Context) error {
+	span, ctx := span.SpanRunFromContext(ctx, span.Neo4jFlush)
+	span.SetTag(tag.LabelTag, nvw.builder)
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	err = nvw.mb.Flush(ctx)
+	if err != nil {
+		return fmt.Errorf("micro batcher flush: %w", err)
+	}
+
+	// Wait for all writes to complete.
+	nvw.writingInFlight.Wait()
+
+	err = nvw.cache.Flush(ctx)
+	if err != nil {
+		return fmt.Errorf("vertex id cache flush: %w", err)
+	}
+
+	log.Trace(ctx).Debugf("Batch writer %d %s queued", nvw.qcounter, nvw.builder)
+	log.Trace(ctx).Infof("Batch writer %d %s written", nvw.wcounter, nvw.builder)
+
+	return nil
+}
+
+func (nvw *Neo4jVertexWriter) Queue(ctx context.Context, v any) error {
+	atomic.AddInt32(&nvw.qcounter, 1)
+
+	return nvw.mb.Enqueue(ctx, v)
+}
+
+// runStatements runs the provided statements in a single write transaction and returns all the resulting records.
+func runStatements(ctx context.Context, driver neo4j.DriverWithContext, database string,
+	statements []cypherStatement) ([]*neo4j.Record, error) {
+
+	session := driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName: database,
+		AccessMode:   neo4j.AccessModeWrite,
+	})
+	defer session.Close(ctx)
+
+	res, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		records := make([]*neo4j.Record, 0)
+		for _, s := range statements {
+			res, err := tx.Run(ctx, s.query, s.params)
+			if err != nil {
+				return nil, err
+			}
+
+			collected, err := res.Collect(ctx)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, collected...)
+		}
+
+		return records, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records, _ := res.([]*neo4j.Record)
+
+	return records, nil
+}
@@ -109,6 +109,13 @@ type AsyncEdgeWriter interface {
 
 // Factory returns an initialized instance of a graphdb provider from the provided application config.
 func Factory(ctx context.Context, cfg *config.KubehoundConfig) (Provider, error) {
+	switch cfg.Storage.GraphProvider {
+	case config.GraphProviderEmbedded:
+		return NewEmbeddedGraphProvider(ctx, cfg)
+	case config.GraphProviderNeo4j:
+		return storage.Retrier(NewNeo4jProvider, cfg.Storage.Retry, cfg.Storage.RetryDelay)(ctx, cfg)
+	}
+
 	r := storage.Retrier(NewGraphDriver, cfg.Storage.Retry, cfg.Storage.RetryDelay)
 
 	return r(ctx, cfg)
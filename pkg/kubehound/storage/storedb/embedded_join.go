@@ -0,0 +1,600 @@
+package storedb
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// embeddedKey identifies a namespaced K8s object (or a cloud principal) in the hash joins of the embedded store.
+type embeddedKey struct {
+	namespace string
+	name      string
+}
+
+// embeddedGrantor holds a permission set granting access to the resources of a ResourceGrantQuery.
+type embeddedGrantor struct {
+	id         primitive.ObjectID
+	namespaced bool
+	rules      []rbacv1.PolicyRule
+	unscoped   bool     // Access is granted to all the resources in reach
+	names      []string // Resources named in the matching rules restricted via resourceNames
+}
+
+// embeddedResource holds the fields of a resource evaluated by a ResourceGrantQuery.
+type embeddedResource struct {
+	id           primitive.ObjectID
+	name         string
+	namespace    string
+	global       bool     // Non namespaced pods and identities are in reach of all the permission sets
+	tokenSecrets []string // Legacy token secrets of the service account identities
+	apiGroup     string   // API group of the workloads
+	resource     string   // Resource of the workloads
+}
+
+// embeddedGrants evaluates a ResourceGrantQuery against the resources, with the permission sets indexed by reach.
+type embeddedGrants struct {
+	q          ResourceGrantQuery
+	all        []*embeddedGrantor
+	cluster    []*embeddedGrantor
+	namespaced map[string][]*embeddedGrantor
+}
+
+// add registers a permission set matching the query.
+func (g *embeddedGrants) add(ps *store.PermissionSet) {
+	grantor := &embeddedGrantor{
+		id:         ps.Id,
+		namespaced: ps.IsNamespaced,
+		rules:      ps.Rules,
+		unscoped:   true,
+	}
+
+	if r := g.q.Rule; r != nil && g.q.Resource != WorkloadResource {
+		grantor.unscoped = r.Scope != ResourceScopedRules && r.Unscoped(ps.Rules)
+		if r.Scope != UnscopedRules {
+			grantor.names = r.ResourceNames(ps.Rules)
+		}
+	}
+
+	g.all = append(g.all, grantor)
+	if ps.IsNamespaced {
+		g.namespaced[ps.Namespace] = append(g.namespaced[ps.Namespace], grantor)
+	} else {
+		g.cluster = append(g.cluster, grantor)
+	}
+}
+
+// named returns whether the resource is one of the provided names.
+func (g *embeddedGrants) named(r *embeddedResource, names []string) bool {
+	if g.q.Resource == ServiceAccountTokenResource {
+		return slices.ContainsFunc(names, func(name string) bool {
+			return slices.Contains(r.tokenSecrets, name) || strings.HasPrefix(name, r.name+"-token-")
+		})
+	}
+
+	return slices.Contains(names, r.name)
+}
+
+// grant returns the grant of the resource by the permission set, if any.
+func (g *embeddedGrants) grant(grantor *embeddedGrantor, r *embeddedResource) (*ResourceGrant, bool) {
+	unscoped, named := grantor.unscoped, g.named(r, grantor.names)
+	if g.q.Resource == WorkloadResource && g.q.Rule != nil {
+		// The API group and resource of the rules are evaluated against each workload
+		rule := RuleMatch{
+			APIGroups:     []string{r.apiGroup},
+			Resources:     []string{r.resource},
+			Verbs:         g.q.Rule.Verbs,
+			ExplicitVerbs: g.q.Rule.ExplicitVerbs,
+		}
+		unscoped = g.q.Rule.Scope != ResourceScopedRules && rule.Unscoped(grantor.rules)
+		named = g.q.Rule.Scope != UnscopedRules && slices.Contains(rule.ResourceNames(grantor.rules), r.name)
+	}
+
+	if !unscoped && !named {
+		return nil, false
+	}
+
+	return &ResourceGrant{
+		PermissionSet:  grantor.id,
+		Namespaced:     grantor.namespaced,
+		Resource:       r.id,
+		Name:           r.name,
+		Namespace:      r.namespace,
+		ResourceScoped: !unscoped,
+		Named:          named,
+	}, true
+}
+
+// stream streams the grants of the resource by the permission sets in reach to the callback.
+func (g *embeddedGrants) stream(ctx context.Context, r *embeddedResource,
+	callback func(ctx context.Context, g *ResourceGrant) error) error {
+
+	reach := [][]*embeddedGrantor{g.cluster, g.namespaced[r.namespace]}
+	if r.global {
+		reach = [][]*embeddedGrantor{g.all}
+	}
+
+	for _, grantors := range reach {
+		for _, grantor := range grantors {
+			grant, ok := g.grant(grantor, r)
+			if !ok {
+				continue
+			}
+
+			if err := callback(ctx, grant); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// grantedResources streams the resources of the provided kind to the callback.
+func (ep *EmbeddedProvider) grantedResources(ctx context.Context, runtime *config.DynamicConfig, kind ResourceKind,
+	callback func(ctx context.Context, r *embeddedResource) error) error {
+
+	identities := func(identityType string, namespaced bool) error {
+		q := IdentityQuery{Types: []string{identityType}}
+
+		return embeddedFind(ctx, ep, collections.IdentityName, runtime, q.Matches, func(ctx context.Context, i *store.Identity) error {
+			r := &embeddedResource{id: i.Id, name: i.Name, tokenSecrets: i.TokenSecrets}
+			if namespaced {
+				r.namespace, r.global = i.Namespace, !i.IsNamespaced
+			}
+
+			return callback(ctx, r)
+		})
+	}
+
+	permissionSets := func(matches func(ps *store.PermissionSet) bool) error {
+		return embeddedFind(ctx, ep, collections.PermissionSetName, runtime, matches, func(ctx context.Context, ps *store.PermissionSet) error {
+			return callback(ctx, &embeddedResource{id: ps.Id, name: ps.RoleName, namespace: ps.Namespace})
+		})
+	}
+
+	switch kind {
+	case PodResource:
+		return embeddedFind(ctx, ep, collections.PodName, runtime, embeddedAll[store.Pod], func(ctx context.Context, p *store.Pod) error {
+			return callback(ctx, &embeddedResource{id: p.Id, name: p.K8.Name, namespace: p.K8.Namespace, global: !p.IsNamespaced})
+		})
+	case SecretResource:
+		return embeddedFind(ctx, ep, collections.SecretName, runtime, embeddedAll[store.Secret], func(ctx context.Context, s *store.Secret) error {
+			return callback(ctx, &embeddedResource{id: s.Id, name: s.Name, namespace: s.Namespace})
+		})
+	case ServiceAccountTokenResource, ServiceAccountResource:
+		return identities(shared.IdentityTypeSA, true)
+	case UserResource:
+		return identities(shared.IdentityTypeUser, false)
+	case GroupResource:
+		return identities(shared.IdentityTypeGroup, false)
+	case NodeResource:
+		return embeddedFind(ctx, ep, collections.NodeName, runtime, embeddedAll[store.Node], func(ctx context.Context, n *store.Node) error {
+			return callback(ctx, &embeddedResource{id: n.Id, name: n.K8.Name})
+		})
+	case NamespaceResource:
+		return embeddedFind(ctx, ep, collections.NamespaceName, runtime, embeddedAll[store.Namespace], func(ctx context.Context, ns *store.Namespace) error {
+			return callback(ctx, &embeddedResource{id: ns.Id, name: ns.K8.Name, namespace: ns.K8.Name})
+		})
+	case WorkloadResource:
+		return embeddedFind(ctx, ep, collections.WorkloadName, runtime, embeddedAll[store.Workload], func(ctx context.Context, w *store.Workload) error {
+			return callback(ctx, &embeddedResource{
+				id:        w.Id,
+				name:      w.Name,
+				namespace: w.Namespace,
+				apiGroup:  w.APIGroup,
+				resource:  w.Resource,
+			})
+		})
+	case ClusterPermissionSetResource:
+		return permissionSets(PermissionSetQuery{Scope: ClusterPermissionSets}.Matches)
+	case NamespacedPermissionSetResource:
+		return permissionSets(PermissionSetQuery{Scope: NamespacedPermissionSets}.Matches)
+	case RolePermissionSetResource:
+		// Permission sets binding a cluster role within a namespace are out of scope
+		roles := make(map[primitive.ObjectID]struct{})
+		err := embeddedFind(ctx, ep, collections.RoleName, runtime, embeddedAll[store.Role], func(_ context.Context, r *store.Role) error {
+			if r.IsNamespaced {
+				roles[r.Id] = struct{}{}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return permissionSets(func(ps *store.PermissionSet) bool {
+			_, ok := roles[ps.RoleId]
+
+			return ps.IsNamespaced && ok
+		})
+	default:
+		return fmt.Errorf("unsupported resource kind: %d", kind)
+	}
+}
+
+// ResourceGrants streams a grant for each resource of the query kind the matching permission sets have access to.
+func (ep *EmbeddedProvider) ResourceGrants(ctx context.Context, runtime *config.DynamicConfig, q ResourceGrantQuery,
+	callback func(ctx context.Context, g *ResourceGrant) error) error {
+
+	grants := &embeddedGrants{
+		q:          q,
+		namespaced: make(map[string][]*embeddedGrantor),
+	}
+
+	err := embeddedFind(ctx, ep, collections.PermissionSetName, runtime, q.permissions().Matches,
+		func(_ context.Context, ps *store.PermissionSet) error {
+			grants.add(ps)
+
+			return nil
+		})
+	if err != nil || len(grants.all) == 0 {
+		return err
+	}
+
+	return ep.grantedResources(ctx, runtime, q.Resource, func(ctx context.Context, r *embeddedResource) error {
+		return grants.stream(ctx, r, callback)
+	})
+}
+
+// Links streams a link for each pair of related entries of the provided kind.
+func (ep *EmbeddedProvider) Links(ctx context.Context, runtime *config.DynamicConfig, kind LinkKind,
+	callback func(ctx context.Context, l *Link) error) error {
+
+	switch kind {
+	case ContainerIdentityLink:
+		return ep.containerIdentityLinks(ctx, runtime, callback)
+	case EndpointContainerLink:
+		return ep.endpointContainerLinks(ctx, runtime, callback)
+	case MountedSecretLink:
+		return ep.mountedSecretLinks(ctx, runtime, callback)
+	case WebhookEndpointLink:
+		return ep.webhookEndpointLinks(ctx, runtime, callback)
+	case WorkloadChildLink:
+		return ep.workloadChildLinks(ctx, runtime, callback)
+	case FederatedIdentityLink:
+		return ep.federatedIdentityLinks(ctx, runtime, callback)
+	case FederatedNodeLink:
+		return ep.federatedNodeLinks(ctx, runtime, callback)
+	case BoundIdentityLink:
+		return ep.boundIdentityLinks(ctx, runtime, callback)
+	default:
+		return fmt.Errorf("unsupported link kind: %d", kind)
+	}
+}
+
+// emitLinks streams a link from the entry to each of the provided entries to the callback.
+func emitLinks(ctx context.Context, from primitive.ObjectID, to []primitive.ObjectID,
+	callback func(ctx context.Context, l *Link) error) error {
+
+	for _, id := range to {
+		if err := callback(ctx, &Link{From: from, To: id}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// serviceAccountIdentities returns the ids of the service account identities of the ingestion run by namespace/name.
+func (ep *EmbeddedProvider) serviceAccountIdentities(ctx context.Context,
+	runtime *config.DynamicConfig) (map[embeddedKey][]primitive.ObjectID, error) {
+
+	identities := make(map[embeddedKey][]primitive.ObjectID)
+	q := IdentityQuery{Types: []string{shared.IdentityTypeSA}}
+	err := embeddedFind(ctx, ep, collections.IdentityName, runtime, q.Matches, func(_ context.Context, i *store.Identity) error {
+		key := embeddedKey{namespace: i.Namespace, name: i.Name}
+		identities[key] = append(identities[key], i.Id)
+
+		return nil
+	})
+
+	return identities, err
+}
+
+// cloudIdentities returns the ids of the cloud identities of the ingestion run by provider/name.
+func (ep *EmbeddedProvider) cloudIdentities(ctx context.Context,
+	runtime *config.DynamicConfig) (map[embeddedKey][]primitive.ObjectID, error) {
+
+	clouds := make(map[embeddedKey][]primitive.ObjectID)
+	err := embeddedFind(ctx, ep, collections.CloudIdentityName, runtime, embeddedAll[store.CloudIdentity],
+		func(_ context.Context, c *store.CloudIdentity) error {
+			key := embeddedKey{namespace: c.Provider, name: c.Name}
+			clouds[key] = append(clouds[key], c.Id)
+
+			return nil
+		})
+
+	return clouds, err
+}
+
+func (ep *EmbeddedProvider) containerIdentityLinks(ctx context.Context, runtime *config.DynamicConfig,
+	callback func(ctx context.Context, l *Link) error) error {
+
+	identities, err := ep.serviceAccountIdentities(ctx, runtime)
+	if err != nil {
+		return err
+	}
+
+	// Service account tokens explicitly mounted in a container via a projected token volume
+	tokens := make(map[Link]struct{})
+	q := VolumeQuery{Types: []string{shared.VolumeTypeProjected}}
+	err = embeddedFind(ctx, ep, collections.VolumeName, runtime, q.Matches, func(_ context.Context, v *store.Volume) error {
+		tokens[Link{From: v.ContainerId, To: v.ProjectedId}] = struct{}{}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return embeddedFind(ctx, ep, collections.ContainerName, runtime, embeddedAll[store.Container],
+		func(ctx context.Context, c *store.Container) error {
+			key := embeddedKey{namespace: c.Inherited.Namespace, name: c.Inherited.ServiceAccount}
+			for _, id := range identities[key] {
+				link := Link{From: c.Id, To: id}
+				if _, mounted := tokens[link]; !c.Inherited.Automount && !mounted {
+					continue
+				}
+
+				if err := callback(ctx, &link); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+}
+
+func (ep *EmbeddedProvider) endpointContainerLinks(ctx context.Context, runtime *config.DynamicConfig,
+	callback func(ctx context.Context, l *Link) error) error {
+
+	type containerPorts struct {
+		id    primitive.ObjectID
+		ports []corev1.ContainerPort
+	}
+
+	containers := make(map[embeddedKey][]containerPorts)
+	err := embeddedFind(ctx, ep, collections.ContainerName, runtime, embeddedAll[store.Container],
+		func(_ context.Context, c *store.Container) error {
+			if len(c.K8.Ports) == 0 {
+				return nil
+			}
+
+			key := embeddedKey{namespace: c.Inherited.Namespace, name: c.Inherited.PodName}
+			containers[key] = append(containers[key], containerPorts{id: c.Id, ports: c.K8.Ports})
+
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	hasSlice := true
+	q := EndpointQuery{HasSlice: &hasSlice}
+
+	return embeddedFind(ctx, ep, collections.EndpointName, runtime, q.Matches, func(ctx context.Context, e *store.Endpoint) error {
+		if e.Port.Port == nil || e.Port.Protocol == nil {
+			return nil
+		}
+
+		// The container must match the namespace/pod/port/protocol of the endpoint
+		for _, c := range containers[embeddedKey{namespace: e.PodNamespace, name: e.PodName}] {
+			if !slices.ContainsFunc(c.ports, func(p corev1.ContainerPort) bool {
+				return p.ContainerPort == *e.Port.Port && p.Protocol == *e.Port.Protocol
+			}) {
+				continue
+			}
+
+			if err := callback(ctx, &Link{From: e.Id, To: c.id}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (ep *EmbeddedProvider) mountedSecretLinks(ctx context.Context, runtime *config.DynamicConfig,
+	callback func(ctx context.Context, l *Link) error) error {
+
+	secrets := make(map[embeddedKey][]primitive.ObjectID)
+	err := embeddedFind(ctx, ep, collections.SecretName, runtime, embeddedAll[store.Secret], func(_ context.Context, s *store.Secret) error {
+		key := embeddedKey{namespace: s.Namespace, name: s.Name}
+		secrets[key] = append(secrets[key], s.Id)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	q := VolumeQuery{Types: []string{shared.VolumeTypeSecret}}
+
+	return embeddedFind(ctx, ep, collections.VolumeName, runtime, q.Matches, func(ctx context.Context, v *store.Volume) error {
+		return emitLinks(ctx, v.Id, secrets[embeddedKey{namespace: v.TargetNamespace, name: v.TargetName}], callback)
+	})
+}
+
+func (ep *EmbeddedProvider) webhookEndpointLinks(ctx context.Context, runtime *config.DynamicConfig,
+	callback func(ctx context.Context, l *Link) error) error {
+
+	webhooks := make(map[embeddedKey][]primitive.ObjectID)
+	err := embeddedFind(ctx, ep, collections.WebhookName, runtime, embeddedAll[store.Webhook], func(_ context.Context, w *store.Webhook) error {
+		if w.ServiceName == "" {
+			return nil
+		}
+
+		key := embeddedKey{namespace: w.ServiceNamespace, name: w.ServiceName}
+		webhooks[key] = append(webhooks[key], w.Id)
+
+		return nil
+	})
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+
+	hasSlice := true
+	q := EndpointQuery{HasSlice: &hasSlice}
+
+	return embeddedFind(ctx, ep, collections.EndpointName, runtime, q.Matches, func(ctx context.Context, e *store.Endpoint) error {
+		for _, id := range webhooks[embeddedKey{namespace: e.Namespace, name: e.ServiceName}] {
+			if err := callback(ctx, &Link{From: id, To: e.Id}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (ep *EmbeddedProvider) workloadChildLinks(ctx context.Context, runtime *config.DynamicConfig,
+	callback func(ctx context.Context, l *Link) error) error {
+
+	type ownedWorkload struct {
+		id        primitive.ObjectID
+		namespace string
+		owners    []types.UID
+	}
+
+	var workloads []ownedWorkload
+	ids := make(map[primitive.ObjectID]struct{})
+	byUID := make(map[types.UID][]ownedWorkload)
+	err := embeddedFind(ctx, ep, collections.WorkloadName, runtime, embeddedAll[store.Workload], func(_ context.Context, w *store.Workload) error {
+		workload := ownedWorkload{id: w.Id, namespace: w.Namespace}
+		for _, ref := range w.K8.OwnerReferences {
+			workload.owners = append(workload.owners, ref.UID)
+		}
+
+		ids[w.Id] = struct{}{}
+		byUID[w.K8.UID] = append(byUID[w.K8.UID], workload)
+		workloads = append(workloads, workload)
+
+		return nil
+	})
+	if err != nil || len(workloads) == 0 {
+		return err
+	}
+
+	err = embeddedFind(ctx, ep, collections.PodName, runtime, embeddedAll[store.Pod], func(ctx context.Context, p *store.Pod) error {
+		if _, ok := ids[p.WorkloadId]; !ok {
+			return nil
+		}
+
+		return callback(ctx, &Link{From: p.WorkloadId, To: p.Id})
+	})
+	if err != nil {
+		return err
+	}
+
+	// Workloads own the workloads of the same namespace referencing them (e.g a cron job owns its jobs)
+	for _, child := range workloads {
+		for _, uid := range child.owners {
+			for _, owner := range byUID[uid] {
+				if owner.namespace != child.namespace {
+					continue
+				}
+
+				if err := callback(ctx, &Link{From: owner.id, To: child.id}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (ep *EmbeddedProvider) federatedIdentityLinks(ctx context.Context, runtime *config.DynamicConfig,
+	callback func(ctx context.Context, l *Link) error) error {
+
+	clouds, err := ep.cloudIdentities(ctx, runtime)
+	if err != nil || len(clouds) == 0 {
+		return err
+	}
+
+	principals := make(map[embeddedKey][]store.CloudPrincipal)
+	err = embeddedFind(ctx, ep, collections.ServiceAccountName, runtime, embeddedAll[store.ServiceAccount],
+		func(_ context.Context, sa *store.ServiceAccount) error {
+			key := embeddedKey{namespace: sa.Namespace, name: sa.Name}
+			principals[key] = append(principals[key], sa.CloudIdentities...)
+
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	q := IdentityQuery{Types: []string{shared.IdentityTypeSA}}
+
+	return embeddedFind(ctx, ep, collections.IdentityName, runtime, q.Matches, func(ctx context.Context, i *store.Identity) error {
+		for _, p := range principals[embeddedKey{namespace: i.Namespace, name: i.Name}] {
+			if err := emitLinks(ctx, i.Id, clouds[embeddedKey{namespace: p.Provider, name: p.Name}], callback); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (ep *EmbeddedProvider) federatedNodeLinks(ctx context.Context, runtime *config.DynamicConfig,
+	callback func(ctx context.Context, l *Link) error) error {
+
+	clouds, err := ep.cloudIdentities(ctx, runtime)
+	if err != nil || len(clouds) == 0 {
+		return err
+	}
+
+	return embeddedFind(ctx, ep, collections.NodeName, runtime, embeddedAll[store.Node], func(ctx context.Context, n *store.Node) error {
+		for _, p := range n.CloudIdentities {
+			if err := emitLinks(ctx, n.Id, clouds[embeddedKey{namespace: p.Provider, name: p.Name}], callback); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (ep *EmbeddedProvider) boundIdentityLinks(ctx context.Context, runtime *config.DynamicConfig,
+	callback func(ctx context.Context, l *Link) error) error {
+
+	subjects := make(map[primitive.ObjectID][]store.BindSubject)
+	err := embeddedFind(ctx, ep, collections.RoleBindingName, runtime, embeddedAll[store.RoleBinding],
+		func(_ context.Context, rb *store.RoleBinding) error {
+			subjects[rb.Id] = rb.Subjects
+
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	return embeddedFind(ctx, ep, collections.PermissionSetName, runtime, embeddedAll[store.PermissionSet],
+		func(ctx context.Context, ps *store.PermissionSet) error {
+			for _, s := range subjects[ps.RoleBindingId] {
+				// The identity must share the namespace of a namespaced role binding, unless it has no namespace
+				if ps.IsNamespaced && s.Subject.Namespace != "" && s.Subject.Namespace != ps.Namespace {
+					continue
+				}
+
+				if err := callback(ctx, &Link{From: ps.Id, To: s.IdentityId}); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+}
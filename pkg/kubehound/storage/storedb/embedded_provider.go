@@ -0,0 +1,379 @@
+package storedb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"github.com/DataDog/KubeHound/pkg/telemetry/log"
+	"github.com/DataDog/KubeHound/pkg/telemetry/tag"
+	"go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	EmbeddedStorageProviderName = "embedded"
+
+	// Bucket holding the run index of each collection, created by the index builder.
+	embeddedIndexBucket = "__indices__"
+
+	embeddedOpenTimeout = 10 * time.Second
+)
+
+var (
+	_ Provider = (*EmbeddedProvider)(nil)
+)
+
+// EmbeddedProvider is a storedb provider storing the normalized K8s data in a local file, without any external
+// dependency. Each collection is a bbolt bucket of BSON documents, in insertion order. Documents are indexed by
+// ingestion run so that queries and cleanups only read the documents of the requested run. Queries are evaluated
+// natively against the decoded models (see Querier).
+type EmbeddedProvider struct {
+	db   *bbolt.DB               // Database file handle
+	temp bool                    // Whether the database file is removed on close
+	cfg  *config.KubehoundConfig // Application configuration
+	tags []string                // Tags to be applied for telemetry
+}
+
+// NewEmbeddedProvider creates a new instance of the embedded store provider, opening (or creating) the configured
+// database file.
+func NewEmbeddedProvider(ctx context.Context, cfg *config.KubehoundConfig) (*EmbeddedProvider, error) {
+	path := cfg.EmbeddedStore.Path
+	temp := path == ""
+	if temp {
+		f, err := os.CreateTemp("", "kubehound-store-*.db")
+		if err != nil {
+			return nil, fmt.Errorf("embedded store temporary file: %w", err)
+		}
+		path = f.Name()
+		_ = f.Close()
+	} else if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("embedded store directory: %w", err)
+	}
+
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: embeddedOpenTimeout, NoSync: temp})
+	if err != nil {
+		return nil, fmt.Errorf("opening embedded store %s: %w", path, err)
+	}
+
+	log.Trace(ctx).Infof("Opened embedded store at %s", path)
+
+	return &EmbeddedProvider{
+		db:   db,
+		temp: temp,
+		cfg:  cfg,
+		tags: tag.GetBaseTagsWith(tag.Storage(EmbeddedStorageProviderName)),
+	}, nil
+}
+
+func (ep *EmbeddedProvider) Name() string {
+	return EmbeddedStorageProviderName
+}
+
+// HealthCheck verifies the database file is open.
+func (ep *EmbeddedProvider) HealthCheck(_ context.Context) (bool, error) {
+	if err := ep.db.View(func(*bbolt.Tx) error { return nil }); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (ep *EmbeddedProvider) Prepare(ctx context.Context) error {
+	if !ep.cfg.Storage.Wipe {
+		log.Trace(ctx).Warn("Skipping embedded store wipe")
+
+		return nil
+	}
+
+	err := ep.db.Update(func(tx *bbolt.Tx) error {
+		var names [][]byte
+		err := tx.ForEach(func(name []byte, _ *bbolt.Bucket) error {
+			names = append(names, bytes.Clone(name))
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, name := range names {
+			if err := tx.DeleteBucket(name); err != nil {
+				return fmt.Errorf("deleting embedded store collection %s: %w", name, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ib := &IndexBuilder{writer: &embeddedIndexWriter{ep: ep}}
+	if err := ib.BuildAll(ctx); err != nil {
+		return fmt.Errorf("embedded store index builder run: %w", err)
+	}
+
+	return nil
+}
+
+func (ep *EmbeddedProvider) Clean(ctx context.Context, runId string, clusterName string) error {
+	l := log.Logger(ctx)
+
+	var deleted map[string]int
+	err := ep.db.Update(func(tx *bbolt.Tx) error {
+		deleted = make(map[string]int)
+
+		return tx.ForEach(func(name []byte, b *bbolt.Bucket) error {
+			if string(name) == embeddedIndexBucket {
+				return nil
+			}
+
+			var keys [][]byte
+			err := scanRun(tx, string(name), runId, clusterName, func(k, _ []byte) error {
+				keys = append(keys, bytes.Clone(k))
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			index := runIndex(tx, string(name))
+			for _, k := range keys {
+				if index != nil {
+					if err := index.Delete(runIndexKey(runId, clusterName, k)); err != nil {
+						return fmt.Errorf("deleting embedded store index entry %s: %w", name, err)
+					}
+				}
+
+				if err := b.Delete(k); err != nil {
+					return fmt.Errorf("deleting embedded store collection %s: %w", name, err)
+				}
+			}
+			deleted[string(name)] = len(keys)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	for collectionName, count := range deleted {
+		l.Info("Deleted elements from collection", log.Int("count", count), log.String("collection", collectionName))
+	}
+
+	return nil
+}
+
+// Reader returns the underlying bbolt database handle.
+func (ep *EmbeddedProvider) Reader() any {
+	return ep.db
+}
+
+func (ep *EmbeddedProvider) Count(_ context.Context, collection string, runId string, clusterName string) (int64, error) {
+	var count int64
+	err := ep.db.View(func(tx *bbolt.Tx) error {
+		return scanRun(tx, collection, runId, clusterName, func(_, _ []byte) error {
+			count++
+
+			return nil
+		})
+	})
+
+	return count, err
+}
+
+func (ep *EmbeddedProvider) BulkWriter(ctx context.Context, collection collections.Collection, opts ...WriterOption) (AsyncWriter, error) {
+	opts = append(opts, WithTags(ep.tags))
+	writer := NewEmbeddedAsyncWriter(ctx, ep, collection, opts...)
+
+	return writer, nil
+}
+
+// Close closes the database file, removing it if temporary. Provider cannot be reused after this call.
+func (ep *EmbeddedProvider) Close(_ context.Context) error {
+	path := ep.db.Path()
+	if err := ep.db.Close(); err != nil {
+		return err
+	}
+
+	if ep.temp {
+		return os.Remove(path)
+	}
+
+	return nil
+}
+
+// sequenceKey encodes a bucket sequence number into a key preserving the insertion order.
+func sequenceKey(seq uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+
+	return key
+}
+
+// runIndexPrefix returns the prefix of the run index keys of an ingestion run.
+func runIndexPrefix(runID string, clusterName string) []byte {
+	return []byte(runID + "\x00" + clusterName + "\x00")
+}
+
+// runIndexKey returns the run index key of a document.
+func runIndexKey(runID string, clusterName string, key []byte) []byte {
+	return append(runIndexPrefix(runID, clusterName), key...)
+}
+
+// documentRun returns the ingestion run of a raw document.
+func documentRun(raw bson.Raw) (string, string, bool) {
+	runID, ok := raw.Lookup("runtime", "runID").StringValueOK()
+	if !ok {
+		return "", "", false
+	}
+
+	clusterName, ok := raw.Lookup("runtime", "cluster", "name").StringValueOK()
+
+	return runID, clusterName, ok
+}
+
+// runIndex returns the run index of a collection, or nil if the collection is not indexed.
+func runIndex(tx *bbolt.Tx, collection string) *bbolt.Bucket {
+	indices := tx.Bucket([]byte(embeddedIndexBucket))
+	if indices == nil {
+		return nil
+	}
+
+	return indices.Bucket([]byte(collection))
+}
+
+// scanRun invokes the callback with the key and raw document of each document of the collection belonging to the
+// ingestion run, in insertion order. Collections without a run index are fully scanned. Keys and values are only
+// valid for the lifetime of the transaction.
+func scanRun(tx *bbolt.Tx, collection string, runID string, clusterName string, callback func(k, raw []byte) error) error {
+	b := tx.Bucket([]byte(collection))
+	if b == nil {
+		return nil
+	}
+
+	index := runIndex(tx, collection)
+	if index == nil {
+		return b.ForEach(func(k, raw []byte) error {
+			if id, name, ok := documentRun(raw); !ok || id != runID || name != clusterName {
+				return nil
+			}
+
+			return callback(k, raw)
+		})
+	}
+
+	prefix := runIndexPrefix(runID, clusterName)
+	c := index.Cursor()
+	for ik, _ := c.Seek(prefix); ik != nil && bytes.HasPrefix(ik, prefix); ik, _ = c.Next() {
+		k := ik[len(prefix):]
+		raw := b.Get(k)
+		if raw == nil {
+			return fmt.Errorf("embedded store collection %s: missing indexed document", collection)
+		}
+
+		if err := callback(k, raw); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// insert writes the raw BSON documents to the collection in a single transaction, along with their run index entries.
+func (ep *EmbeddedProvider) insert(collection string, raws [][]byte) error {
+	return ep.db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(collection))
+		if err != nil {
+			return err
+		}
+
+		index := runIndex(tx, collection)
+		for _, raw := range raws {
+			seq, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+
+			key := sequenceKey(seq)
+			if err := b.Put(key, raw); err != nil {
+				return err
+			}
+
+			// Documents without runtime information are not part of any run
+			runID, clusterName, ok := documentRun(raw)
+			if index == nil || !ok {
+				continue
+			}
+
+			if err := index.Put(runIndexKey(runID, clusterName, key), nil); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// embeddedIndexWriter creates the indices declared by the index builder. The queries are evaluated natively, so only
+// the run index (runtime.runID, runtime.cluster.name) is materialized, as a bucket mapping the run to the documents.
+type embeddedIndexWriter struct {
+	ep *EmbeddedProvider
+}
+
+// isRunIndex returns whether the index keys are the ingestion run fields.
+func isRunIndex(keys any) (bool, error) {
+	switch keys := keys.(type) {
+	case bson.D:
+		if len(keys) == 0 {
+			return false, errors.New("embedded store index without keys")
+		}
+
+		return len(keys) == 2 && keys[0].Key == "runtime.runID" && keys[1].Key == "runtime.cluster.name", nil
+	case bson.M:
+		if len(keys) != 1 {
+			return false, errors.New("embedded store compound indices must be defined as ordered documents")
+		}
+
+		return false, nil
+	default:
+		return false, fmt.Errorf("unsupported embedded store index keys type: %T", keys)
+	}
+}
+
+func (w *embeddedIndexWriter) CreateMany(_ context.Context, collection string, indices []mongo.IndexModel) error {
+	indexed := false
+	for _, index := range indices {
+		ok, err := isRunIndex(index.Keys)
+		if err != nil {
+			return err
+		}
+		indexed = indexed || ok
+	}
+
+	if !indexed {
+		return nil
+	}
+
+	return w.ep.db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(embeddedIndexBucket))
+		if err != nil {
+			return err
+		}
+
+		_, err = b.CreateBucketIfNotExists([]byte(collection))
+
+		return err
+	})
+}
@@ -0,0 +1,323 @@
+package storedb
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const (
+	embeddedTestCluster = "test-cluster"
+)
+
+func embeddedTestConfig(t *testing.T, path string) *config.KubehoundConfig {
+	t.Helper()
+
+	return &config.KubehoundConfig{
+		Storage: config.StorageConfig{
+			Wipe:          true,
+			StoreProvider: config.StoreProviderEmbedded,
+		},
+		EmbeddedStore: config.EmbeddedStoreConfig{
+			Path: path,
+		},
+		Dynamic: config.DynamicConfig{
+			RunID: config.NewRunID(),
+			Cluster: config.DynamicClusterInfo{
+				Name:         embeddedTestCluster,
+				VersionMajor: "1",
+				VersionMinor: "29",
+			},
+		},
+	}
+}
+
+func newEmbeddedTestProvider(t *testing.T, cfg *config.KubehoundConfig) *EmbeddedProvider {
+	t.Helper()
+
+	ep, err := NewEmbeddedProvider(t.Context(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, ep.Prepare(t.Context()))
+
+	return ep
+}
+
+// writeEmbedded writes the models to the collection via the bulk writer.
+func writeEmbedded(t *testing.T, ep *EmbeddedProvider, collection collections.Collection, models ...any) {
+	t.Helper()
+
+	w, err := ep.BulkWriter(t.Context(), collection)
+	require.NoError(t, err)
+
+	for _, m := range models {
+		require.NoError(t, w.Queue(t.Context(), m))
+	}
+	require.NoError(t, w.Flush(t.Context()))
+	require.NoError(t, w.Close(t.Context()))
+}
+
+// embeddedDocuments returns the documents of the collection, in insertion order.
+func embeddedDocuments(t *testing.T, ep *EmbeddedProvider, collection string) []bson.M {
+	t.Helper()
+
+	var docs []bson.M
+	err := ep.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(collection))
+		if b == nil {
+			return nil
+		}
+
+		return b.ForEach(func(_, raw []byte) error {
+			var doc bson.M
+			if err := bson.Unmarshal(raw, &doc); err != nil {
+				return err
+			}
+			docs = append(docs, doc)
+
+			return nil
+		})
+	})
+	require.NoError(t, err)
+
+	return docs
+}
+
+// embeddedIndexed returns whether the collection has a run index.
+func embeddedIndexed(t *testing.T, ep *EmbeddedProvider, collection string) bool {
+	t.Helper()
+
+	indexed := false
+	err := ep.db.View(func(tx *bbolt.Tx) error {
+		indexed = runIndex(tx, collection) != nil
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	return indexed
+}
+
+func TestEmbeddedProvider_BulkWriter(t *testing.T) {
+	t.Parallel()
+
+	cfg := embeddedTestConfig(t, "")
+	ep := newEmbeddedTestProvider(t, cfg)
+	t.Cleanup(func() { _ = ep.Close(context.Background()) })
+
+	// More elements than the batch size to go through the background writer
+	models := make([]any, 0, 2*collections.TestBatchSize+1)
+	for i := 0; i < cap(models); i++ {
+		models = append(models, FakeElement{FieldA: i, FieldB: "lol"})
+	}
+	writeEmbedded(t, ep, collections.FakeCollection{}, models...)
+
+	// All the elements are written and an _id is generated for each document
+	docs := embeddedDocuments(t, ep, collections.FakeCollection{}.Name())
+	require.Len(t, docs, len(models))
+
+	written := make([]int, 0, len(docs))
+	for _, doc := range docs {
+		written = append(written, int(doc["fielda"].(int32)))
+		assert.IsType(t, primitive.ObjectID{}, doc["_id"])
+	}
+	slices.Sort(written)
+	for i, v := range written {
+		assert.Equal(t, i, v)
+	}
+}
+
+func TestEmbeddedProvider_Clean(t *testing.T) {
+	t.Parallel()
+
+	cfg := embeddedTestConfig(t, "")
+	ep := newEmbeddedTestProvider(t, cfg)
+	t.Cleanup(func() { _ = ep.Close(context.Background()) })
+
+	runtime := store.Runtime(&cfg.Dynamic)
+	other := config.NewRunID()
+	writeEmbedded(t, ep, collections.Node{},
+		&store.Node{Id: primitive.NewObjectID(), Runtime: runtime},
+		&store.Node{Id: primitive.NewObjectID(), Runtime: runtime},
+		&store.Node{Id: primitive.NewObjectID(), Runtime: store.RuntimeInfo{RunID: other.String(), Cluster: runtime.Cluster}},
+	)
+
+	count, err := ep.Count(t.Context(), collections.NodeName, cfg.Dynamic.RunID.String(), embeddedTestCluster)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	require.NoError(t, ep.Clean(t.Context(), cfg.Dynamic.RunID.String(), embeddedTestCluster))
+
+	count, err = ep.Count(t.Context(), collections.NodeName, cfg.Dynamic.RunID.String(), embeddedTestCluster)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	count, err = ep.Count(t.Context(), collections.NodeName, other.String(), embeddedTestCluster)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count, "only the documents of the cleaned run should be removed")
+	assert.Len(t, embeddedDocuments(t, ep, collections.NodeName), 1)
+}
+
+func TestEmbeddedProvider_Persist(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "store", "kubehound.db")
+	cfg := embeddedTestConfig(t, path)
+	ep := newEmbeddedTestProvider(t, cfg)
+	assert.True(t, embeddedIndexed(t, ep, collections.PodName))
+
+	writeEmbedded(t, ep, collections.FakeCollection{}, FakeElement{FieldA: 1})
+	require.NoError(t, ep.Close(t.Context()))
+
+	// Data and indices are kept across runs unless wiped
+	cfg.Storage.Wipe = false
+	ep = newEmbeddedTestProvider(t, cfg)
+	assert.True(t, embeddedIndexed(t, ep, collections.PodName))
+	assert.Len(t, embeddedDocuments(t, ep, collections.FakeCollection{}.Name()), 1)
+	require.NoError(t, ep.Close(t.Context()))
+
+	cfg.Storage.Wipe = true
+	ep = newEmbeddedTestProvider(t, cfg)
+	t.Cleanup(func() { _ = ep.Close(context.Background()) })
+	assert.Empty(t, embeddedDocuments(t, ep, collections.FakeCollection{}.Name()))
+}
+
+// TestEmbeddedProvider_Querier verifies the typed queries evaluated by the embedded store against the ingestion run.
+func TestEmbeddedProvider_Querier(t *testing.T) {
+	t.Parallel()
+
+	cfg := embeddedTestConfig(t, "")
+	ep := newEmbeddedTestProvider(t, cfg)
+	t.Cleanup(func() { _ = ep.Close(context.Background()) })
+	runtime := store.Runtime(&cfg.Dynamic)
+
+	privileged := true
+	unconfined := &corev1.SecurityContext{
+		AppArmorProfile: &corev1.AppArmorProfile{Type: corev1.AppArmorProfileTypeUnconfined},
+	}
+	containers := []*store.Container{
+		{K8: corev1.Container{Name: "default"}},
+		{K8: corev1.Container{Name: "privileged", SecurityContext: &corev1.SecurityContext{Privileged: &privileged}}},
+		{K8: corev1.Container{Name: "ptrace", SecurityContext: &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"CAP_SYS_PTRACE"}},
+		}}},
+		{K8: corev1.Container{Name: "all", SecurityContext: &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"ALL"}},
+		}}},
+		{K8: corev1.Container{Name: "unconfined", SecurityContext: unconfined}},
+		{K8: corev1.Container{Name: "bpf", SecurityContext: &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"BPF", "CAP_PERFMON"}},
+		}}},
+		{K8: corev1.Container{Name: "hostpid"}, Inherited: store.ContainerInherited{HostPID: true}},
+	}
+
+	models := make([]any, 0, len(containers))
+	for _, c := range containers {
+		c.Id = primitive.NewObjectID()
+		c.Runtime = runtime
+		models = append(models, c)
+	}
+	writeEmbedded(t, ep, collections.Container{}, models...)
+
+	permissionSets := []*store.PermissionSet{
+		{Name: "secrets", Rules: []rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get", "list"}},
+		}},
+		{Name: "scoped-secrets", IsNamespaced: true, Namespace: "default", Rules: []rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"list"}, ResourceNames: []string{"token"}},
+		}},
+		{Name: "exec", IsNamespaced: true, Namespace: "default", Rules: []rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}},
+			{APIGroups: []string{"*"}, Resources: []string{"pods/*"}, Verbs: []string{"create"}},
+		}},
+		{Name: "admin", Rules: []rbacv1.PolicyRule{
+			{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+		}},
+	}
+
+	models = make([]any, 0, len(permissionSets))
+	for _, ps := range permissionSets {
+		ps.Id = primitive.NewObjectID()
+		ps.Runtime = runtime
+		models = append(models, ps)
+	}
+	writeEmbedded(t, ep, collections.PermissionSet{}, models...)
+
+	// Containers of another ingestion run are never matched
+	writeEmbedded(t, ep, collections.Container{}, &store.Container{
+		Id:      primitive.NewObjectID(),
+		K8:      corev1.Container{Name: "other-run", SecurityContext: &corev1.SecurityContext{Privileged: &privileged}},
+		Runtime: store.RuntimeInfo{RunID: config.NewRunID().String(), Cluster: runtime.Cluster},
+	})
+
+	containerQueries := map[string]struct {
+		q    ContainerQuery
+		want []string
+	}{
+		"privileged":          {ContainerQuery{Privileged: &privileged}, []string{"privileged"}},
+		"unprivileged ptrace": {ContainerQuery{Privileged: boolPtr(false), Capabilities: []string{"SYS_PTRACE"}}, []string{"ptrace", "all"}},
+		"ptrace":              {ContainerQuery{Capabilities: []string{"SYS_PTRACE"}}, []string{"privileged", "ptrace", "all"}},
+		"hostpid":             {ContainerQuery{HostPID: &privileged}, []string{"hostpid"}},
+		"explicit ptrace":     {ContainerQuery{ExplicitCapabilities: true, Capabilities: []string{"CAP_SYS_PTRACE"}}, []string{"ptrace"}},
+		"apparmor":            {ContainerQuery{AppArmorUnconfined: true, Capabilities: []string{"SYS_ADMIN"}}, []string{"privileged", "all"}},
+		"any capabilities": {
+			ContainerQuery{Privileged: boolPtr(false), AnyCapabilities: [][]string{{"SYS_PTRACE"}, {"BPF", "PERFMON"}}},
+			[]string{"ptrace", "all", "bpf"},
+		},
+	}
+
+	for name, tt := range containerQueries {
+		var got []string
+		err := ep.Containers(t.Context(), &cfg.Dynamic, tt.q, func(_ context.Context, c *store.Container) error {
+			got = append(got, c.K8.Name)
+
+			return nil
+		})
+		require.NoError(t, err, name)
+		assert.Equal(t, tt.want, got, name)
+	}
+
+	secretList := RuleMatch{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"list"}}
+	podExec := RuleMatch{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}}
+	podGet := RuleMatch{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}
+	permissionSetQueries := map[string]struct {
+		q    PermissionSetQuery
+		want []string
+	}{
+		"any scope": {PermissionSetQuery{AnyRule: []RuleMatch{secretList}}, []string{"secrets", "scoped-secrets", "admin"}},
+		"unscoped":  {PermissionSetQuery{AnyRule: []RuleMatch{secretList.WithScope(UnscopedRules)}}, []string{"secrets", "admin"}},
+		"scoped": {
+			PermissionSetQuery{Scope: NamespacedPermissionSets, AnyRule: []RuleMatch{secretList.WithScope(ResourceScopedRules)}},
+			[]string{"scoped-secrets"},
+		},
+		"all rules":  {PermissionSetQuery{AllRules: []RuleMatch{podExec, podGet}}, []string{"exec", "admin"}},
+		"cluster":    {PermissionSetQuery{Scope: ClusterPermissionSets}, []string{"secrets", "admin"}},
+		"any or all": {PermissionSetQuery{AnyRule: []RuleMatch{secretList, podExec}, AllRules: []RuleMatch{podGet}}, []string{"exec", "admin"}},
+	}
+
+	for name, tt := range permissionSetQueries {
+		var got []string
+		err := ep.PermissionSets(t.Context(), &cfg.Dynamic, tt.q, func(_ context.Context, ps *store.PermissionSet) error {
+			got = append(got, ps.Name)
+
+			return nil
+		})
+		require.NoError(t, err, name)
+		assert.Equal(t, tt.want, got, name)
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,125 @@
+package storedb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var (
+	_ Querier = (*EmbeddedProvider)(nil)
+)
+
+// embeddedFind streams the entries of the collection belonging to the ingestion run and satisfying the predicate to
+// the callback. Queries are evaluated natively against the decoded models. The matching entries are decoded within a
+// read transaction and streamed once it is released, so that callbacks can run nested queries or writes.
+func embeddedFind[T any](ctx context.Context, ep *EmbeddedProvider, collection string, runtime *config.DynamicConfig,
+	matches func(entry *T) bool, callback func(ctx context.Context, entry *T) error) error {
+
+	var entries []*T
+	err := ep.db.View(func(tx *bbolt.Tx) error {
+		return scanRun(tx, collection, runtime.RunID.String(), runtime.Cluster.Name, func(_, raw []byte) error {
+			var entry T
+			if err := bson.Unmarshal(raw, &entry); err != nil {
+				return err
+			}
+
+			if matches(&entry) {
+				entries = append(entries, &entry)
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("querying embedded store collection %s: %w", collection, err)
+	}
+
+	for _, entry := range entries {
+		if err := callback(ctx, entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// embeddedAll is the predicate of the queries matching all the entries of the ingestion run.
+func embeddedAll[T any](*T) bool {
+	return true
+}
+
+// PermissionSets streams the permission sets of the ingestion run matching the query to the callback.
+func (ep *EmbeddedProvider) PermissionSets(ctx context.Context, runtime *config.DynamicConfig, q PermissionSetQuery,
+	callback func(ctx context.Context, ps *store.PermissionSet) error) error {
+
+	return embeddedFind(ctx, ep, collections.PermissionSetName, runtime, q.Matches, callback)
+}
+
+// Containers streams the containers of the ingestion run matching the query to the callback.
+func (ep *EmbeddedProvider) Containers(ctx context.Context, runtime *config.DynamicConfig, q ContainerQuery,
+	callback func(ctx context.Context, c *store.Container) error) error {
+
+	return embeddedFind(ctx, ep, collections.ContainerName, runtime, q.Matches, callback)
+}
+
+// Pods streams the pods of the ingestion run matching the query to the callback.
+func (ep *EmbeddedProvider) Pods(ctx context.Context, runtime *config.DynamicConfig, q PodQuery,
+	callback func(ctx context.Context, p *store.Pod) error) error {
+
+	return embeddedFind(ctx, ep, collections.PodName, runtime, q.Matches, callback)
+}
+
+// Nodes streams the nodes of the ingestion run to the callback.
+func (ep *EmbeddedProvider) Nodes(ctx context.Context, runtime *config.DynamicConfig,
+	callback func(ctx context.Context, n *store.Node) error) error {
+
+	return embeddedFind(ctx, ep, collections.NodeName, runtime, embeddedAll[store.Node], callback)
+}
+
+// Namespaces streams the namespaces of the ingestion run to the callback.
+func (ep *EmbeddedProvider) Namespaces(ctx context.Context, runtime *config.DynamicConfig,
+	callback func(ctx context.Context, ns *store.Namespace) error) error {
+
+	return embeddedFind(ctx, ep, collections.NamespaceName, runtime, embeddedAll[store.Namespace], callback)
+}
+
+// Identities streams the identities of the ingestion run matching the query to the callback.
+func (ep *EmbeddedProvider) Identities(ctx context.Context, runtime *config.DynamicConfig, q IdentityQuery,
+	callback func(ctx context.Context, i *store.Identity) error) error {
+
+	return embeddedFind(ctx, ep, collections.IdentityName, runtime, q.Matches, callback)
+}
+
+// Volumes streams the volumes of the ingestion run matching the query to the callback.
+func (ep *EmbeddedProvider) Volumes(ctx context.Context, runtime *config.DynamicConfig, q VolumeQuery,
+	callback func(ctx context.Context, v *store.Volume) error) error {
+
+	return embeddedFind(ctx, ep, collections.VolumeName, runtime, q.Matches, callback)
+}
+
+// Endpoints streams the endpoints of the ingestion run matching the query to the callback.
+func (ep *EmbeddedProvider) Endpoints(ctx context.Context, runtime *config.DynamicConfig, q EndpointQuery,
+	callback func(ctx context.Context, e *store.Endpoint) error) error {
+
+	return embeddedFind(ctx, ep, collections.EndpointName, runtime, q.Matches, callback)
+}
+
+// Webhooks streams the admission webhooks of the ingestion run matching the query to the callback.
+func (ep *EmbeddedProvider) Webhooks(ctx context.Context, runtime *config.DynamicConfig, q WebhookQuery,
+	callback func(ctx context.Context, w *store.Webhook) error) error {
+
+	return embeddedFind(ctx, ep, collections.WebhookName, runtime, q.Matches, callback)
+}
+
+// NetworkPolicies streams the network policies of the ingestion run to the callback.
+func (ep *EmbeddedProvider) NetworkPolicies(ctx context.Context, runtime *config.DynamicConfig,
+	callback func(ctx context.Context, np *store.NetworkPolicy) error) error {
+
+	return embeddedFind(ctx, ep, collections.NetworkPolicyName, runtime, embeddedAll[store.NetworkPolicy], callback)
+}
@@ -0,0 +1,186 @@
+package storedb
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"github.com/DataDog/KubeHound/pkg/telemetry/log"
+	"github.com/DataDog/KubeHound/pkg/telemetry/metric"
+	"github.com/DataDog/KubeHound/pkg/telemetry/span"
+	"github.com/DataDog/KubeHound/pkg/telemetry/statsd"
+	"github.com/DataDog/KubeHound/pkg/telemetry/tag"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+var _ AsyncWriter = (*EmbeddedAsyncWriter)(nil)
+
+type EmbeddedAsyncWriter struct {
+	collection      collections.Collection
+	ops             [][]byte
+	opsLock         *sync.RWMutex
+	store           *EmbeddedProvider
+	batchSize       int
+	consumerChan    chan [][]byte
+	writingInFlight *sync.WaitGroup
+	tags            []string
+}
+
+func NewEmbeddedAsyncWriter(ctx context.Context, store *EmbeddedProvider, collection collections.Collection, opts ...WriterOption) *EmbeddedAsyncWriter {
+	wOpts := &writerOptions{}
+	for _, o := range opts {
+		o(wOpts)
+	}
+
+	eaw := EmbeddedAsyncWriter{
+		store:           store,
+		batchSize:       collection.BatchSize(),
+		tags:            append(wOpts.Tags, tag.Collection(collection.Name())),
+		collection:      collection,
+		writingInFlight: &sync.WaitGroup{},
+		ops:             make([][]byte, 0),
+		opsLock:         &sync.RWMutex{},
+	}
+	eaw.consumerChan = make(chan [][]byte, consumerChanSize)
+	eaw.startBackgroundWriter(ctx)
+
+	return &eaw
+}
+
+// startBackgroundWriter starts a background go routine
+func (eaw *EmbeddedAsyncWriter) startBackgroundWriter(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case data := <-eaw.consumerChan:
+				// closing the channel shoud stop the go routine
+				if data == nil {
+					return
+				}
+
+				_ = statsd.Count(ctx, metric.BackgroundWriterCall, 1, eaw.tags, 1)
+				err := eaw.batchWrite(ctx, data)
+				if err != nil {
+					log.Trace(ctx).Errorf("write data in background batch writer: %v", err)
+				}
+
+				_ = statsd.Decr(ctx, metric.QueueSize, eaw.tags, 1)
+			case <-ctx.Done():
+				log.Trace(ctx).Debug("Closed background embedded store worker")
+
+				return
+			}
+		}
+	}()
+}
+
+// batchWrite blocks until the write is complete
+func (eaw *EmbeddedAsyncWriter) batchWrite(ctx context.Context, ops [][]byte) error {
+	span, ctx := span.SpanRunFromContext(ctx, span.EmbeddedStoreBatchWrite)
+	span.SetTag(tag.CollectionTag, eaw.collection.Name())
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+	defer eaw.writingInFlight.Done()
+
+	_ = statsd.Count(ctx, metric.ObjectWrite, int64(len(ops)), eaw.tags, 1)
+
+	err = eaw.store.insert(eaw.collection.Name(), ops)
+	if err != nil {
+		return fmt.Errorf("could not write in bulk to embedded store: %w", err)
+	}
+
+	return nil
+}
+
+// marshalDocument marshals a model to BSON, generating an _id if the model does not define one.
+func marshalDocument(model any) ([]byte, error) {
+	raw, err := bson.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := bson.Raw(raw).LookupErr("_id"); err == nil {
+		return raw, nil
+	}
+
+	var doc bson.D
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	return bson.Marshal(append(bson.D{{Key: "_id", Value: primitive.NewObjectID()}}, doc...))
+}
+
+// Queue add a model to an asynchronous write queue. Non-blocking.
+func (eaw *EmbeddedAsyncWriter) Queue(ctx context.Context, model any) error {
+	// Marshal eagerly so that models can be reused by the caller once queued
+	raw, err := marshalDocument(model)
+	if err != nil {
+		return fmt.Errorf("embedded store document marshal: %w", err)
+	}
+
+	eaw.opsLock.Lock()
+	defer eaw.opsLock.Unlock()
+
+	eaw.ops = append(eaw.ops, raw)
+	if len(eaw.ops) > eaw.batchSize {
+		copied := make([][]byte, len(eaw.ops))
+		copy(copied, eaw.ops)
+
+		eaw.writingInFlight.Add(1)
+		eaw.consumerChan <- copied
+		_ = statsd.Incr(ctx, metric.QueueSize, eaw.tags, 1)
+
+		// cleanup the ops array after we have copied it to the channel
+		eaw.ops = nil
+	}
+
+	return nil
+}
+
+// Flush triggers writes of any remaining items in the queue.
+// This is blocking
+func (eaw *EmbeddedAsyncWriter) Flush(ctx context.Context) error {
+	span, ctx := span.SpanRunFromContext(ctx, span.EmbeddedStoreFlush)
+	span.SetTag(tag.CollectionTag, eaw.collection.Name())
+	var err error
+	defer func() { span.Finish(tracer.WithError(err)) }()
+
+	if eaw.store == nil {
+		return fmt.Errorf("embedded store is not initialized")
+	}
+
+	eaw.opsLock.Lock()
+	defer eaw.opsLock.Unlock()
+
+	if len(eaw.ops) != 0 {
+		eaw.writingInFlight.Add(1)
+		err = eaw.batchWrite(ctx, eaw.ops)
+		if err != nil {
+			log.Trace(ctx).Errorf("batch write %s: %+v", eaw.collection.Name(), err)
+			eaw.writingInFlight.Wait()
+
+			return err
+		}
+
+		eaw.ops = nil
+	}
+
+	eaw.writingInFlight.Wait()
+
+	return nil
+}
+
+// Close cleans up any resources used by the AsyncWriter implementation. Writer cannot be reused after this call.
+func (eaw *EmbeddedAsyncWriter) Close(ctx context.Context) error {
+	if eaw.store == nil {
+		return nil
+	}
+
+	eaw.ops = nil
+
+	return nil
+}
@@ -10,20 +10,41 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// IndexWriter creates indices on a store collection.
+type IndexWriter interface {
+	// CreateMany creates the provided indices on the collection.
+	CreateMany(ctx context.Context, collection string, indices []mongo.IndexModel) error
+}
+
+// mongoIndexWriter creates indices on the collections of a MongoDB database.
+type mongoIndexWriter struct {
+	db *mongo.Database
+}
+
+func (w *mongoIndexWriter) CreateMany(ctx context.Context, collection string, indices []mongo.IndexModel) error {
+	_, err := w.db.Collection(collection).Indexes().CreateMany(ctx, indices)
+
+	return err
+}
+
 // IndexBuilder handles the creation of indices for the store collections.
 type IndexBuilder struct {
-	db *mongo.Database
+	writer IndexWriter
 }
 
 // NewIndexBuilder creates a new index builder instance for the provided DB.
 func NewIndexBuilder(db *mongo.Database) (*IndexBuilder, error) {
 	return &IndexBuilder{
-		db: db,
+		writer: &mongoIndexWriter{db: db},
 	}, nil
 }
 
 // BuildAll builds all the store indices.
 func (ib *IndexBuilder) BuildAll(ctx context.Context) error {
+	if err := ib.cloudIdentities(ctx); err != nil {
+		return fmt.Errorf("build cloud identity indices: %w", err)
+	}
+
 	if err := ib.containers(ctx); err != nil {
 		return fmt.Errorf("build container indices: %w", err)
 	}
@@ -36,10 +57,22 @@ func (ib *IndexBuilder) BuildAll(ctx context.Context) error {
 		return fmt.Errorf("build identity indices: %w", err)
 	}
 
+	if err := ib.networkPolicies(ctx); err != nil {
+		return fmt.Errorf("build network policy indices: %w", err)
+	}
+
 	if err := ib.nodes(ctx); err != nil {
 		return fmt.Errorf("build node indices: %w", err)
 	}
 
+	if err := ib.persistentVolumes(ctx); err != nil {
+		return fmt.Errorf("build persistent volume indices: %w", err)
+	}
+
+	if err := ib.persistentVolumeClaims(ctx); err != nil {
+		return fmt.Errorf("build persistent volume claim indices: %w", err)
+	}
+
 	if err := ib.permissionsets(ctx); err != nil {
 		return fmt.Errorf("build permission set indices: %w", err)
 	}
@@ -48,16 +81,57 @@ func (ib *IndexBuilder) BuildAll(ctx context.Context) error {
 		return fmt.Errorf("build pod indices: %w", err)
 	}
 
+	if err := ib.secrets(ctx); err != nil {
+		return fmt.Errorf("build secret indices: %w", err)
+	}
+
+	if err := ib.serviceAccounts(ctx); err != nil {
+		return fmt.Errorf("build service account indices: %w", err)
+	}
+
+	if err := ib.workloads(ctx); err != nil {
+		return fmt.Errorf("build workload indices: %w", err)
+	}
+
 	if err := ib.volumes(ctx); err != nil {
 		return fmt.Errorf("build volume indices: %w", err)
 	}
 
+	if err := ib.webhooks(ctx); err != nil {
+		return fmt.Errorf("build webhook indices: %w", err)
+	}
+
+	if err := ib.namespaces(ctx); err != nil {
+		return fmt.Errorf("build namespace indices: %w", err)
+	}
+
 	return nil
 }
 
+// cloudIdentities builds the store indices for the cloud identities collection.
+func (ib *IndexBuilder) cloudIdentities(ctx context.Context) error {
+	indices := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "provider", Value: 1},
+				{Key: "name", Value: 1},
+			},
+			Options: options.Index().SetName("byPrincipal"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "runtime.runID", Value: 1},
+				{Key: "runtime.cluster.name", Value: 1},
+			},
+			Options: options.Index().SetName("byRun"),
+		},
+	}
+
+	return ib.writer.CreateMany(ctx, collections.CloudIdentityName, indices)
+}
+
 // containers builds the store indices for the containers collection.
 func (ib *IndexBuilder) containers(ctx context.Context) error {
-	containers := ib.db.Collection(collections.ContainerName)
 	indices := []mongo.IndexModel{
 		{
 			Keys:    bson.M{"pod_id": 1},
@@ -67,6 +141,10 @@ func (ib *IndexBuilder) containers(ctx context.Context) error {
 			Keys:    bson.M{"node_id": 1},
 			Options: options.Index().SetName("byNode"),
 		},
+		{
+			Keys:    bson.M{"workload_id": 1},
+			Options: options.Index().SetName("byWorkload"),
+		},
 		{
 			Keys:    bson.M{"inherited.pod_name": 1},
 			Options: options.Index().SetName("byPodName"),
@@ -133,14 +211,11 @@ func (ib *IndexBuilder) containers(ctx context.Context) error {
 		},
 	}
 
-	_, err := containers.Indexes().CreateMany(ctx, indices)
-
-	return err
+	return ib.writer.CreateMany(ctx, collections.ContainerName, indices)
 }
 
 // endpoints builds the store indices for the endpoints collection.
 func (ib *IndexBuilder) endpoints(ctx context.Context) error {
-	endpoints := ib.db.Collection(collections.EndpointName)
 	indices := []mongo.IndexModel{
 		{
 			Keys: bson.D{
@@ -159,14 +234,11 @@ func (ib *IndexBuilder) endpoints(ctx context.Context) error {
 		},
 	}
 
-	_, err := endpoints.Indexes().CreateMany(ctx, indices)
-
-	return err
+	return ib.writer.CreateMany(ctx, collections.EndpointName, indices)
 }
 
 // identities builds the store indices for the identities collection.
 func (ib *IndexBuilder) identities(ctx context.Context) error {
-	identities := ib.db.Collection(collections.IdentityName)
 	indices := []mongo.IndexModel{
 		{
 			Keys:    bson.M{"namespace": 1},
@@ -201,14 +273,33 @@ func (ib *IndexBuilder) identities(ctx context.Context) error {
 		},
 	}
 
-	_, err := identities.Indexes().CreateMany(ctx, indices)
+	return ib.writer.CreateMany(ctx, collections.IdentityName, indices)
+}
+
+// networkPolicies builds the store indices for the network policies collection.
+func (ib *IndexBuilder) networkPolicies(ctx context.Context) error {
+	indices := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "namespace", Value: 1},
+				{Key: "name", Value: 1},
+			},
+			Options: options.Index().SetName("byNamespaceName"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "runtime.runID", Value: 1},
+				{Key: "runtime.cluster.name", Value: 1},
+			},
+			Options: options.Index().SetName("byRun"),
+		},
+	}
 
-	return err
+	return ib.writer.CreateMany(ctx, collections.NetworkPolicyName, indices)
 }
 
 // nodes builds the store indices for the nodes collection.
 func (ib *IndexBuilder) nodes(ctx context.Context) error {
-	nodes := ib.db.Collection(collections.NodeName)
 	indices := []mongo.IndexModel{
 		{
 
@@ -228,14 +319,56 @@ func (ib *IndexBuilder) nodes(ctx context.Context) error {
 		},
 	}
 
-	_, err := nodes.Indexes().CreateMany(ctx, indices)
+	return ib.writer.CreateMany(ctx, collections.NodeName, indices)
+}
 
-	return err
+// persistentVolumes builds the store indices for the persistent volumes collection.
+func (ib *IndexBuilder) persistentVolumes(ctx context.Context) error {
+	indices := []mongo.IndexModel{
+		{
+			Keys:    bson.M{"name": 1},
+			Options: options.Index().SetName("byName"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "runtime.runID", Value: 1},
+				{Key: "runtime.cluster.name", Value: 1},
+			},
+			Options: options.Index().SetName("byRun"),
+		},
+	}
+
+	return ib.writer.CreateMany(ctx, collections.PersistentVolumeName, indices)
+}
+
+// persistentVolumeClaims builds the store indices for the persistent volume claims collection.
+func (ib *IndexBuilder) persistentVolumeClaims(ctx context.Context) error {
+	indices := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "namespace", Value: 1},
+				{Key: "name", Value: 1},
+			},
+			Options: options.Index().SetName("byNamespaceName"),
+		},
+		{
+			Keys:    bson.M{"volume_name": 1},
+			Options: options.Index().SetName("byVolumeName"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "runtime.runID", Value: 1},
+				{Key: "runtime.cluster.name", Value: 1},
+			},
+			Options: options.Index().SetName("byRun"),
+		},
+	}
+
+	return ib.writer.CreateMany(ctx, collections.PersistentVolumeClaimName, indices)
 }
 
 // permissionsets builds the store indices for the permissionsets collection.
 func (ib *IndexBuilder) permissionsets(ctx context.Context) error {
-	permissions := ib.db.Collection(collections.PermissionSetName)
 	indices := []mongo.IndexModel{
 		{
 			Keys: bson.D{
@@ -294,14 +427,11 @@ func (ib *IndexBuilder) permissionsets(ctx context.Context) error {
 		},
 	}
 
-	_, err := permissions.Indexes().CreateMany(ctx, indices)
-
-	return err
+	return ib.writer.CreateMany(ctx, collections.PermissionSetName, indices)
 }
 
 // pods builds the store indices for the pods collection.
 func (ib *IndexBuilder) pods(ctx context.Context) error {
-	pods := ib.db.Collection(collections.PodName)
 	indices := []mongo.IndexModel{
 		{
 			Keys:    bson.M{"node_id": 1},
@@ -332,14 +462,63 @@ func (ib *IndexBuilder) pods(ctx context.Context) error {
 		},
 	}
 
-	_, err := pods.Indexes().CreateMany(ctx, indices)
+	return ib.writer.CreateMany(ctx, collections.PodName, indices)
+}
 
-	return err
+// secrets builds the store indices for the secrets collection.
+func (ib *IndexBuilder) secrets(ctx context.Context) error {
+	indices := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "namespace", Value: 1},
+				{Key: "name", Value: 1},
+			},
+			Options: options.Index().SetName("byNamespaceName"),
+		},
+		{
+			Keys:    bson.M{"type": 1},
+			Options: options.Index().SetName("byType"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "runtime.runID", Value: 1},
+				{Key: "runtime.cluster.name", Value: 1},
+			},
+			Options: options.Index().SetName("byRun"),
+		},
+	}
+
+	return ib.writer.CreateMany(ctx, collections.SecretName, indices)
+}
+
+// serviceAccounts builds the store indices for the service accounts collection.
+func (ib *IndexBuilder) serviceAccounts(ctx context.Context) error {
+	indices := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "namespace", Value: 1},
+				{Key: "name", Value: 1},
+			},
+			Options: options.Index().SetName("byNamespaceName"),
+		},
+		{
+			Keys:    bson.M{"identity_id": 1},
+			Options: options.Index().SetName("byIdentity"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "runtime.runID", Value: 1},
+				{Key: "runtime.cluster.name", Value: 1},
+			},
+			Options: options.Index().SetName("byRun"),
+		},
+	}
+
+	return ib.writer.CreateMany(ctx, collections.ServiceAccountName, indices)
 }
 
 // volumes builds the store indices for the volumes collection.
 func (ib *IndexBuilder) volumes(ctx context.Context) error {
-	volumes := ib.db.Collection(collections.VolumeName)
 	indices := []mongo.IndexModel{
 		{
 			Keys:    bson.M{"pod_id": 1},
@@ -365,6 +544,10 @@ func (ib *IndexBuilder) volumes(ctx context.Context) error {
 			Keys:    bson.M{"source": 1},
 			Options: options.Index().SetName("bySource"),
 		},
+		{
+			Keys:    bson.M{"backing_type": 1},
+			Options: options.Index().SetName("byBackingType"),
+		},
 		{
 			Keys:    bson.M{"readonly": 1},
 			Options: options.Index().SetName("byReadOnly"),
@@ -405,7 +588,80 @@ func (ib *IndexBuilder) volumes(ctx context.Context) error {
 		},
 	}
 
-	_, err := volumes.Indexes().CreateMany(ctx, indices)
+	return ib.writer.CreateMany(ctx, collections.VolumeName, indices)
+}
 
-	return err
+// workloads builds the store indices for the workload controllers collection.
+func (ib *IndexBuilder) workloads(ctx context.Context) error {
+	indices := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "namespace", Value: 1},
+				{Key: "name", Value: 1},
+			},
+			Options: options.Index().SetName("byNamespaceName"),
+		},
+		{
+			Keys:    bson.M{"k8.uid": 1},
+			Options: options.Index().SetName("byUID"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "runtime.runID", Value: 1},
+				{Key: "runtime.cluster.name", Value: 1},
+			},
+			Options: options.Index().SetName("byRun"),
+		},
+	}
+
+	return ib.writer.CreateMany(ctx, collections.WorkloadName, indices)
+}
+
+// webhooks builds the store indices for the admission webhooks collection.
+func (ib *IndexBuilder) webhooks(ctx context.Context) error {
+	indices := []mongo.IndexModel{
+		{
+			Keys:    bson.M{"type": 1},
+			Options: options.Index().SetName("byType"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "service_namespace", Value: 1},
+				{Key: "service_name", Value: 1},
+			},
+			Options: options.Index().SetName("byService"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "runtime.runID", Value: 1},
+				{Key: "runtime.cluster.name", Value: 1},
+			},
+			Options: options.Index().SetName("byRun"),
+		},
+	}
+
+	return ib.writer.CreateMany(ctx, collections.WebhookName, indices)
+}
+
+// namespaces builds the store indices for the namespaces collection.
+func (ib *IndexBuilder) namespaces(ctx context.Context) error {
+	indices := []mongo.IndexModel{
+		{
+			Keys:    bson.M{"k8.objectmeta.name": 1},
+			Options: options.Index().SetName("byName"),
+		},
+		{
+			Keys:    bson.M{"psa_enforce": 1},
+			Options: options.Index().SetName("byPSAEnforce"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "runtime.runID", Value: 1},
+				{Key: "runtime.cluster.name", Value: 1},
+			},
+			Options: options.Index().SetName("byRun"),
+		},
+	}
+
+	return ib.writer.CreateMany(ctx, collections.NamespaceName, indices)
 }
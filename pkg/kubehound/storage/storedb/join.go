@@ -0,0 +1,110 @@
+package storedb
+
+import (
+	"slices"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ResourceKind identifies the resources granted by the permission sets of a ResourceGrantQuery.
+type ResourceKind int
+
+const (
+	// PodResource grants access to pods, named by their name.
+	PodResource ResourceKind = iota
+	// SecretResource grants access to secrets, named by their name.
+	SecretResource
+	// ServiceAccountTokenResource grants access to the tokens of the service account identities, named by their legacy
+	// token secrets (referenced by the service account or following the <serviceaccount>-token-<suffix> convention).
+	ServiceAccountTokenResource
+	// ServiceAccountResource grants access to the service account identities, named by their name.
+	ServiceAccountResource
+	// UserResource grants access to the user identities, named by their name. Users are cluster wide resources.
+	UserResource
+	// GroupResource grants access to the group identities, named by their name. Groups are cluster wide resources.
+	GroupResource
+	// NodeResource grants access to nodes, named by their name. Nodes are cluster wide resources.
+	NodeResource
+	// NamespaceResource grants access to namespaces, named by their name. A namespace is in its own namespace.
+	NamespaceResource
+	// WorkloadResource grants access to workloads, named by their name. The API group and resource of the rule match
+	// are those of each workload.
+	WorkloadResource
+	// ClusterPermissionSetResource grants access to the cluster wide permission sets, named by their role name.
+	ClusterPermissionSetResource
+	// NamespacedPermissionSetResource grants access to the namespaced permission sets, named by their role name.
+	NamespacedPermissionSetResource
+	// RolePermissionSetResource grants access to the namespaced permission sets of roles (i.e NOT of cluster roles
+	// bound within a namespace), named by their role name.
+	RolePermissionSetResource
+)
+
+// ResourceGrantQuery selects the permission sets of an ingestion run granting access to some resources, and the
+// resources they grant access to. Namespaced permission sets only reach the resources of their namespace, cluster wide
+// permission sets reach all resources.
+type ResourceGrantQuery struct {
+	// Resource is the kind of the granted resources.
+	Resource ResourceKind
+
+	// Permissions selects the permission sets granting access to the resources.
+	Permissions PermissionSetQuery
+
+	// Rule matches the policy rules granting access to the resources. Rules NOT restricted via resourceNames grant
+	// access to all the resources in reach, rules restricted via resourceNames to the named resources in reach,
+	// depending on the rule scope. When nil, all the resources in reach are granted.
+	Rule *RuleMatch
+}
+
+// permissions returns the permission set query of the permission sets that can grant access to the resources.
+func (q ResourceGrantQuery) permissions() PermissionSetQuery {
+	permissions := q.Permissions
+	if q.Rule != nil && q.Resource != WorkloadResource {
+		permissions.AllRules = append(slices.Clone(permissions.AllRules), *q.Rule)
+	}
+
+	return permissions
+}
+
+// ResourceGrant holds a resource a permission set grants access to.
+type ResourceGrant struct {
+	PermissionSet primitive.ObjectID `bson:"_id"`
+	Namespaced    bool               `bson:"is_namespaced"`
+	Resource      primitive.ObjectID `bson:"resource"`
+	Name          string             `bson:"resource_name"`
+	Namespace     string             `bson:"resource_namespace"`
+
+	// ResourceScoped reports whether access is only granted via rules restricted via resourceNames.
+	ResourceScoped bool `bson:"resource_scoped"`
+
+	// Named reports whether the resource is named in the resourceNames of a matching rule.
+	Named bool `bson:"named"`
+}
+
+// LinkKind identifies a relationship between the entries of two collections of an ingestion run.
+type LinkKind int
+
+const (
+	// ContainerIdentityLink links containers to the service account identity whose token is available to them, i.e
+	// automatically mounted or explicitly mounted via a projected token volume.
+	ContainerIdentityLink LinkKind = iota
+	// EndpointContainerLink links the endpoints exposed via an endpoint slice to the containers exposing their port.
+	EndpointContainerLink
+	// MountedSecretLink links the secret volumes to the secret they mount.
+	MountedSecretLink
+	// WebhookEndpointLink links the admission webhooks served by an in-cluster service to the endpoints of the service.
+	WebhookEndpointLink
+	// WorkloadChildLink links workloads to the pods and workloads they own.
+	WorkloadChildLink
+	// FederatedIdentityLink links the service account identities to the cloud identities federated via workload identity.
+	FederatedIdentityLink
+	// FederatedNodeLink links nodes to the cloud identity of their instance.
+	FederatedNodeLink
+	// BoundIdentityLink links the permission sets to the identities bound by their role binding.
+	BoundIdentityLink
+)
+
+// Link holds a pair of related entries.
+type Link struct {
+	From primitive.ObjectID `bson:"_id"`
+	To   primitive.ObjectID `bson:"to"`
+}
@@ -7,9 +7,13 @@ import (
 
 	collections "github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
 
+	config "github.com/DataDog/KubeHound/pkg/config"
+
 	mock "github.com/stretchr/testify/mock"
 
 	storedb "github.com/DataDog/KubeHound/pkg/kubehound/storage/storedb"
+
+	store "github.com/DataDog/KubeHound/pkg/kubehound/models/store"
 )
 
 // Provider is an autogenerated mock type for the Provider type
@@ -193,6 +197,163 @@ func (_c *Provider_Close_Call) RunAndReturn(run func(context.Context) error) *Pr
 	return _c
 }
 
+// Containers provides a mock function with given fields: ctx, runtime, q, callback
+func (_m *Provider) Containers(ctx context.Context, runtime *config.DynamicConfig, q storedb.ContainerQuery, callback func(context.Context, *store.Container) error) error {
+	ret := _m.Called(ctx, runtime, q, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Containers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *config.DynamicConfig, storedb.ContainerQuery, func(context.Context, *store.Container) error) error); ok {
+		r0 = rf(ctx, runtime, q, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Provider_Containers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Containers'
+type Provider_Containers_Call struct {
+	*mock.Call
+}
+
+// Containers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runtime *config.DynamicConfig
+//   - q storedb.ContainerQuery
+//   - callback func(context.Context , *store.Container) error
+func (_e *Provider_Expecter) Containers(ctx interface{}, runtime interface{}, q interface{}, callback interface{}) *Provider_Containers_Call {
+	return &Provider_Containers_Call{Call: _e.mock.On("Containers", ctx, runtime, q, callback)}
+}
+
+func (_c *Provider_Containers_Call) Run(run func(ctx context.Context, runtime *config.DynamicConfig, q storedb.ContainerQuery, callback func(context.Context, *store.Container) error)) *Provider_Containers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*config.DynamicConfig), args[2].(storedb.ContainerQuery), args[3].(func(context.Context, *store.Container) error))
+	})
+	return _c
+}
+
+func (_c *Provider_Containers_Call) Return(_a0 error) *Provider_Containers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Provider_Containers_Call) RunAndReturn(run func(context.Context, *config.DynamicConfig, storedb.ContainerQuery, func(context.Context, *store.Container) error) error) *Provider_Containers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Count provides a mock function with given fields: ctx, collection, runId, clusterName
+func (_m *Provider) Count(ctx context.Context, collection string, runId string, clusterName string) (int64, error) {
+	ret := _m.Called(ctx, collection, runId, clusterName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (int64, error)); ok {
+		return rf(ctx, collection, runId, clusterName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) int64); ok {
+		r0 = rf(ctx, collection, runId, clusterName)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, collection, runId, clusterName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Provider_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type Provider_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - ctx context.Context
+//   - collection string
+//   - runId string
+//   - clusterName string
+func (_e *Provider_Expecter) Count(ctx interface{}, collection interface{}, runId interface{}, clusterName interface{}) *Provider_Count_Call {
+	return &Provider_Count_Call{Call: _e.mock.On("Count", ctx, collection, runId, clusterName)}
+}
+
+func (_c *Provider_Count_Call) Run(run func(ctx context.Context, collection string, runId string, clusterName string)) *Provider_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Provider_Count_Call) Return(_a0 int64, _a1 error) *Provider_Count_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Provider_Count_Call) RunAndReturn(run func(context.Context, string, string, string) (int64, error)) *Provider_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Endpoints provides a mock function with given fields: ctx, runtime, q, callback
+func (_m *Provider) Endpoints(ctx context.Context, runtime *config.DynamicConfig, q storedb.EndpointQuery, callback func(context.Context, *store.Endpoint) error) error {
+	ret := _m.Called(ctx, runtime, q, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Endpoints")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *config.DynamicConfig, storedb.EndpointQuery, func(context.Context, *store.Endpoint) error) error); ok {
+		r0 = rf(ctx, runtime, q, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Provider_Endpoints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Endpoints'
+type Provider_Endpoints_Call struct {
+	*mock.Call
+}
+
+// Endpoints is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runtime *config.DynamicConfig
+//   - q storedb.EndpointQuery
+//   - callback func(context.Context , *store.Endpoint) error
+func (_e *Provider_Expecter) Endpoints(ctx interface{}, runtime interface{}, q interface{}, callback interface{}) *Provider_Endpoints_Call {
+	return &Provider_Endpoints_Call{Call: _e.mock.On("Endpoints", ctx, runtime, q, callback)}
+}
+
+func (_c *Provider_Endpoints_Call) Run(run func(ctx context.Context, runtime *config.DynamicConfig, q storedb.EndpointQuery, callback func(context.Context, *store.Endpoint) error)) *Provider_Endpoints_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*config.DynamicConfig), args[2].(storedb.EndpointQuery), args[3].(func(context.Context, *store.Endpoint) error))
+	})
+	return _c
+}
+
+func (_c *Provider_Endpoints_Call) Return(_a0 error) *Provider_Endpoints_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Provider_Endpoints_Call) RunAndReturn(run func(context.Context, *config.DynamicConfig, storedb.EndpointQuery, func(context.Context, *store.Endpoint) error) error) *Provider_Endpoints_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // HealthCheck provides a mock function with given fields: ctx
 func (_m *Provider) HealthCheck(ctx context.Context) (bool, error) {
 	ret := _m.Called(ctx)
@@ -249,6 +410,104 @@ func (_c *Provider_HealthCheck_Call) RunAndReturn(run func(context.Context) (boo
 	return _c
 }
 
+// Identities provides a mock function with given fields: ctx, runtime, q, callback
+func (_m *Provider) Identities(ctx context.Context, runtime *config.DynamicConfig, q storedb.IdentityQuery, callback func(context.Context, *store.Identity) error) error {
+	ret := _m.Called(ctx, runtime, q, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Identities")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *config.DynamicConfig, storedb.IdentityQuery, func(context.Context, *store.Identity) error) error); ok {
+		r0 = rf(ctx, runtime, q, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Provider_Identities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Identities'
+type Provider_Identities_Call struct {
+	*mock.Call
+}
+
+// Identities is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runtime *config.DynamicConfig
+//   - q storedb.IdentityQuery
+//   - callback func(context.Context , *store.Identity) error
+func (_e *Provider_Expecter) Identities(ctx interface{}, runtime interface{}, q interface{}, callback interface{}) *Provider_Identities_Call {
+	return &Provider_Identities_Call{Call: _e.mock.On("Identities", ctx, runtime, q, callback)}
+}
+
+func (_c *Provider_Identities_Call) Run(run func(ctx context.Context, runtime *config.DynamicConfig, q storedb.IdentityQuery, callback func(context.Context, *store.Identity) error)) *Provider_Identities_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*config.DynamicConfig), args[2].(storedb.IdentityQuery), args[3].(func(context.Context, *store.Identity) error))
+	})
+	return _c
+}
+
+func (_c *Provider_Identities_Call) Return(_a0 error) *Provider_Identities_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Provider_Identities_Call) RunAndReturn(run func(context.Context, *config.DynamicConfig, storedb.IdentityQuery, func(context.Context, *store.Identity) error) error) *Provider_Identities_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Links provides a mock function with given fields: ctx, runtime, kind, callback
+func (_m *Provider) Links(ctx context.Context, runtime *config.DynamicConfig, kind storedb.LinkKind, callback func(context.Context, *storedb.Link) error) error {
+	ret := _m.Called(ctx, runtime, kind, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Links")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *config.DynamicConfig, storedb.LinkKind, func(context.Context, *storedb.Link) error) error); ok {
+		r0 = rf(ctx, runtime, kind, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Provider_Links_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Links'
+type Provider_Links_Call struct {
+	*mock.Call
+}
+
+// Links is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runtime *config.DynamicConfig
+//   - kind storedb.LinkKind
+//   - callback func(context.Context , *storedb.Link) error
+func (_e *Provider_Expecter) Links(ctx interface{}, runtime interface{}, kind interface{}, callback interface{}) *Provider_Links_Call {
+	return &Provider_Links_Call{Call: _e.mock.On("Links", ctx, runtime, kind, callback)}
+}
+
+func (_c *Provider_Links_Call) Run(run func(ctx context.Context, runtime *config.DynamicConfig, kind storedb.LinkKind, callback func(context.Context, *storedb.Link) error)) *Provider_Links_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*config.DynamicConfig), args[2].(storedb.LinkKind), args[3].(func(context.Context, *storedb.Link) error))
+	})
+	return _c
+}
+
+func (_c *Provider_Links_Call) Return(_a0 error) *Provider_Links_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Provider_Links_Call) RunAndReturn(run func(context.Context, *config.DynamicConfig, storedb.LinkKind, func(context.Context, *storedb.Link) error) error) *Provider_Links_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Name provides a mock function with no fields
 func (_m *Provider) Name() string {
 	ret := _m.Called()
@@ -294,6 +553,248 @@ func (_c *Provider_Name_Call) RunAndReturn(run func() string) *Provider_Name_Cal
 	return _c
 }
 
+// Namespaces provides a mock function with given fields: ctx, runtime, callback
+func (_m *Provider) Namespaces(ctx context.Context, runtime *config.DynamicConfig, callback func(context.Context, *store.Namespace) error) error {
+	ret := _m.Called(ctx, runtime, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Namespaces")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *config.DynamicConfig, func(context.Context, *store.Namespace) error) error); ok {
+		r0 = rf(ctx, runtime, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Provider_Namespaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Namespaces'
+type Provider_Namespaces_Call struct {
+	*mock.Call
+}
+
+// Namespaces is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runtime *config.DynamicConfig
+//   - callback func(context.Context , *store.Namespace) error
+func (_e *Provider_Expecter) Namespaces(ctx interface{}, runtime interface{}, callback interface{}) *Provider_Namespaces_Call {
+	return &Provider_Namespaces_Call{Call: _e.mock.On("Namespaces", ctx, runtime, callback)}
+}
+
+func (_c *Provider_Namespaces_Call) Run(run func(ctx context.Context, runtime *config.DynamicConfig, callback func(context.Context, *store.Namespace) error)) *Provider_Namespaces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*config.DynamicConfig), args[2].(func(context.Context, *store.Namespace) error))
+	})
+	return _c
+}
+
+func (_c *Provider_Namespaces_Call) Return(_a0 error) *Provider_Namespaces_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Provider_Namespaces_Call) RunAndReturn(run func(context.Context, *config.DynamicConfig, func(context.Context, *store.Namespace) error) error) *Provider_Namespaces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NetworkPolicies provides a mock function with given fields: ctx, runtime, callback
+func (_m *Provider) NetworkPolicies(ctx context.Context, runtime *config.DynamicConfig, callback func(context.Context, *store.NetworkPolicy) error) error {
+	ret := _m.Called(ctx, runtime, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NetworkPolicies")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *config.DynamicConfig, func(context.Context, *store.NetworkPolicy) error) error); ok {
+		r0 = rf(ctx, runtime, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Provider_NetworkPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NetworkPolicies'
+type Provider_NetworkPolicies_Call struct {
+	*mock.Call
+}
+
+// NetworkPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runtime *config.DynamicConfig
+//   - callback func(context.Context , *store.NetworkPolicy) error
+func (_e *Provider_Expecter) NetworkPolicies(ctx interface{}, runtime interface{}, callback interface{}) *Provider_NetworkPolicies_Call {
+	return &Provider_NetworkPolicies_Call{Call: _e.mock.On("NetworkPolicies", ctx, runtime, callback)}
+}
+
+func (_c *Provider_NetworkPolicies_Call) Run(run func(ctx context.Context, runtime *config.DynamicConfig, callback func(context.Context, *store.NetworkPolicy) error)) *Provider_NetworkPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*config.DynamicConfig), args[2].(func(context.Context, *store.NetworkPolicy) error))
+	})
+	return _c
+}
+
+func (_c *Provider_NetworkPolicies_Call) Return(_a0 error) *Provider_NetworkPolicies_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Provider_NetworkPolicies_Call) RunAndReturn(run func(context.Context, *config.DynamicConfig, func(context.Context, *store.NetworkPolicy) error) error) *Provider_NetworkPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Nodes provides a mock function with given fields: ctx, runtime, callback
+func (_m *Provider) Nodes(ctx context.Context, runtime *config.DynamicConfig, callback func(context.Context, *store.Node) error) error {
+	ret := _m.Called(ctx, runtime, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Nodes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *config.DynamicConfig, func(context.Context, *store.Node) error) error); ok {
+		r0 = rf(ctx, runtime, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Provider_Nodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Nodes'
+type Provider_Nodes_Call struct {
+	*mock.Call
+}
+
+// Nodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runtime *config.DynamicConfig
+//   - callback func(context.Context , *store.Node) error
+func (_e *Provider_Expecter) Nodes(ctx interface{}, runtime interface{}, callback interface{}) *Provider_Nodes_Call {
+	return &Provider_Nodes_Call{Call: _e.mock.On("Nodes", ctx, runtime, callback)}
+}
+
+func (_c *Provider_Nodes_Call) Run(run func(ctx context.Context, runtime *config.DynamicConfig, callback func(context.Context, *store.Node) error)) *Provider_Nodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*config.DynamicConfig), args[2].(func(context.Context, *store.Node) error))
+	})
+	return _c
+}
+
+func (_c *Provider_Nodes_Call) Return(_a0 error) *Provider_Nodes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Provider_Nodes_Call) RunAndReturn(run func(context.Context, *config.DynamicConfig, func(context.Context, *store.Node) error) error) *Provider_Nodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PermissionSets provides a mock function with given fields: ctx, runtime, q, callback
+func (_m *Provider) PermissionSets(ctx context.Context, runtime *config.DynamicConfig, q storedb.PermissionSetQuery, callback func(context.Context, *store.PermissionSet) error) error {
+	ret := _m.Called(ctx, runtime, q, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PermissionSets")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *config.DynamicConfig, storedb.PermissionSetQuery, func(context.Context, *store.PermissionSet) error) error); ok {
+		r0 = rf(ctx, runtime, q, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Provider_PermissionSets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PermissionSets'
+type Provider_PermissionSets_Call struct {
+	*mock.Call
+}
+
+// PermissionSets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runtime *config.DynamicConfig
+//   - q storedb.PermissionSetQuery
+//   - callback func(context.Context , *store.PermissionSet) error
+func (_e *Provider_Expecter) PermissionSets(ctx interface{}, runtime interface{}, q interface{}, callback interface{}) *Provider_PermissionSets_Call {
+	return &Provider_PermissionSets_Call{Call: _e.mock.On("PermissionSets", ctx, runtime, q, callback)}
+}
+
+func (_c *Provider_PermissionSets_Call) Run(run func(ctx context.Context, runtime *config.DynamicConfig, q storedb.PermissionSetQuery, callback func(context.Context, *store.PermissionSet) error)) *Provider_PermissionSets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*config.DynamicConfig), args[2].(storedb.PermissionSetQuery), args[3].(func(context.Context, *store.PermissionSet) error))
+	})
+	return _c
+}
+
+func (_c *Provider_PermissionSets_Call) Return(_a0 error) *Provider_PermissionSets_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Provider_PermissionSets_Call) RunAndReturn(run func(context.Context, *config.DynamicConfig, storedb.PermissionSetQuery, func(context.Context, *store.PermissionSet) error) error) *Provider_PermissionSets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Pods provides a mock function with given fields: ctx, runtime, q, callback
+func (_m *Provider) Pods(ctx context.Context, runtime *config.DynamicConfig, q storedb.PodQuery, callback func(context.Context, *store.Pod) error) error {
+	ret := _m.Called(ctx, runtime, q, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pods")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *config.DynamicConfig, storedb.PodQuery, func(context.Context, *store.Pod) error) error); ok {
+		r0 = rf(ctx, runtime, q, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Provider_Pods_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pods'
+type Provider_Pods_Call struct {
+	*mock.Call
+}
+
+// Pods is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runtime *config.DynamicConfig
+//   - q storedb.PodQuery
+//   - callback func(context.Context , *store.Pod) error
+func (_e *Provider_Expecter) Pods(ctx interface{}, runtime interface{}, q interface{}, callback interface{}) *Provider_Pods_Call {
+	return &Provider_Pods_Call{Call: _e.mock.On("Pods", ctx, runtime, q, callback)}
+}
+
+func (_c *Provider_Pods_Call) Run(run func(ctx context.Context, runtime *config.DynamicConfig, q storedb.PodQuery, callback func(context.Context, *store.Pod) error)) *Provider_Pods_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*config.DynamicConfig), args[2].(storedb.PodQuery), args[3].(func(context.Context, *store.Pod) error))
+	})
+	return _c
+}
+
+func (_c *Provider_Pods_Call) Return(_a0 error) *Provider_Pods_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Provider_Pods_Call) RunAndReturn(run func(context.Context, *config.DynamicConfig, storedb.PodQuery, func(context.Context, *store.Pod) error) error) *Provider_Pods_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Prepare provides a mock function with given fields: ctx
 func (_m *Provider) Prepare(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -387,6 +888,153 @@ func (_c *Provider_Reader_Call) RunAndReturn(run func() interface{}) *Provider_R
 	return _c
 }
 
+// ResourceGrants provides a mock function with given fields: ctx, runtime, q, callback
+func (_m *Provider) ResourceGrants(ctx context.Context, runtime *config.DynamicConfig, q storedb.ResourceGrantQuery, callback func(context.Context, *storedb.ResourceGrant) error) error {
+	ret := _m.Called(ctx, runtime, q, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResourceGrants")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *config.DynamicConfig, storedb.ResourceGrantQuery, func(context.Context, *storedb.ResourceGrant) error) error); ok {
+		r0 = rf(ctx, runtime, q, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Provider_ResourceGrants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResourceGrants'
+type Provider_ResourceGrants_Call struct {
+	*mock.Call
+}
+
+// ResourceGrants is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runtime *config.DynamicConfig
+//   - q storedb.ResourceGrantQuery
+//   - callback func(context.Context , *storedb.ResourceGrant) error
+func (_e *Provider_Expecter) ResourceGrants(ctx interface{}, runtime interface{}, q interface{}, callback interface{}) *Provider_ResourceGrants_Call {
+	return &Provider_ResourceGrants_Call{Call: _e.mock.On("ResourceGrants", ctx, runtime, q, callback)}
+}
+
+func (_c *Provider_ResourceGrants_Call) Run(run func(ctx context.Context, runtime *config.DynamicConfig, q storedb.ResourceGrantQuery, callback func(context.Context, *storedb.ResourceGrant) error)) *Provider_ResourceGrants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*config.DynamicConfig), args[2].(storedb.ResourceGrantQuery), args[3].(func(context.Context, *storedb.ResourceGrant) error))
+	})
+	return _c
+}
+
+func (_c *Provider_ResourceGrants_Call) Return(_a0 error) *Provider_ResourceGrants_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Provider_ResourceGrants_Call) RunAndReturn(run func(context.Context, *config.DynamicConfig, storedb.ResourceGrantQuery, func(context.Context, *storedb.ResourceGrant) error) error) *Provider_ResourceGrants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Volumes provides a mock function with given fields: ctx, runtime, q, callback
+func (_m *Provider) Volumes(ctx context.Context, runtime *config.DynamicConfig, q storedb.VolumeQuery, callback func(context.Context, *store.Volume) error) error {
+	ret := _m.Called(ctx, runtime, q, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Volumes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *config.DynamicConfig, storedb.VolumeQuery, func(context.Context, *store.Volume) error) error); ok {
+		r0 = rf(ctx, runtime, q, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Provider_Volumes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Volumes'
+type Provider_Volumes_Call struct {
+	*mock.Call
+}
+
+// Volumes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runtime *config.DynamicConfig
+//   - q storedb.VolumeQuery
+//   - callback func(context.Context , *store.Volume) error
+func (_e *Provider_Expecter) Volumes(ctx interface{}, runtime interface{}, q interface{}, callback interface{}) *Provider_Volumes_Call {
+	return &Provider_Volumes_Call{Call: _e.mock.On("Volumes", ctx, runtime, q, callback)}
+}
+
+func (_c *Provider_Volumes_Call) Run(run func(ctx context.Context, runtime *config.DynamicConfig, q storedb.VolumeQuery, callback func(context.Context, *store.Volume) error)) *Provider_Volumes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*config.DynamicConfig), args[2].(storedb.VolumeQuery), args[3].(func(context.Context, *store.Volume) error))
+	})
+	return _c
+}
+
+func (_c *Provider_Volumes_Call) Return(_a0 error) *Provider_Volumes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Provider_Volumes_Call) RunAndReturn(run func(context.Context, *config.DynamicConfig, storedb.VolumeQuery, func(context.Context, *store.Volume) error) error) *Provider_Volumes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Webhooks provides a mock function with given fields: ctx, runtime, q, callback
+func (_m *Provider) Webhooks(ctx context.Context, runtime *config.DynamicConfig, q storedb.WebhookQuery, callback func(context.Context, *store.Webhook) error) error {
+	ret := _m.Called(ctx, runtime, q, callback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Webhooks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *config.DynamicConfig, storedb.WebhookQuery, func(context.Context, *store.Webhook) error) error); ok {
+		r0 = rf(ctx, runtime, q, callback)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Provider_Webhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Webhooks'
+type Provider_Webhooks_Call struct {
+	*mock.Call
+}
+
+// Webhooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runtime *config.DynamicConfig
+//   - q storedb.WebhookQuery
+//   - callback func(context.Context , *store.Webhook) error
+func (_e *Provider_Expecter) Webhooks(ctx interface{}, runtime interface{}, q interface{}, callback interface{}) *Provider_Webhooks_Call {
+	return &Provider_Webhooks_Call{Call: _e.mock.On("Webhooks", ctx, runtime, q, callback)}
+}
+
+func (_c *Provider_Webhooks_Call) Run(run func(ctx context.Context, runtime *config.DynamicConfig, q storedb.WebhookQuery, callback func(context.Context, *store.Webhook) error)) *Provider_Webhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*config.DynamicConfig), args[2].(storedb.WebhookQuery), args[3].(func(context.Context, *store.Webhook) error))
+	})
+	return _c
+}
+
+func (_c *Provider_Webhooks_Call) Return(_a0 error) *Provider_Webhooks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Provider_Webhooks_Call) RunAndReturn(run func(context.Context, *config.DynamicConfig, storedb.WebhookQuery, func(context.Context, *store.Webhook) error) error) *Provider_Webhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewProvider creates a new instance of Provider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewProvider(t interface {
@@ -0,0 +1,727 @@
+package storedb
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// mongoGrantTarget describes the resources of a kind that can be granted by a permission set.
+type mongoGrantTarget struct {
+	collection string   // Collection of the resources
+	filter     bson.M   // Additional filter on the resources
+	stages     []bson.M // Additional stages filtering the resources
+	name       string   // Name field of the resources
+	namespace  any      // Namespace expression of the resources
+	namespaced string   // Field flagging namespaced resources, non namespaced resources are in reach of all permission sets
+
+	// Expression matching a resource against the restricting names, defaults to the name being one of the names
+	nameMatch func(names string) bson.M
+}
+
+var (
+	// mongoClusterNamespace is the namespace expression of the cluster wide resources.
+	mongoClusterNamespace = bson.M{"$literal": ""}
+
+	mongoGrantTargets = map[ResourceKind]*mongoGrantTarget{
+		PodResource: {
+			collection: collections.PodName,
+			name:       "$k8.objectmeta.name",
+			namespace:  "$k8.objectmeta.namespace",
+			namespaced: "$is_namespaced",
+		},
+		SecretResource: {
+			collection: collections.SecretName,
+			name:       "$name",
+			namespace:  "$namespace",
+		},
+		ServiceAccountTokenResource: {
+			collection: collections.IdentityName,
+			filter:     bson.M{"type": shared.IdentityTypeSA},
+			name:       "$name",
+			namespace:  "$namespace",
+			namespaced: "$is_namespaced",
+			nameMatch:  serviceAccountTokenNameMatch,
+		},
+		ServiceAccountResource: {
+			collection: collections.IdentityName,
+			filter:     bson.M{"type": shared.IdentityTypeSA},
+			name:       "$name",
+			namespace:  "$namespace",
+			namespaced: "$is_namespaced",
+		},
+		UserResource: {
+			collection: collections.IdentityName,
+			filter:     bson.M{"type": shared.IdentityTypeUser},
+			name:       "$name",
+			namespace:  mongoClusterNamespace,
+		},
+		GroupResource: {
+			collection: collections.IdentityName,
+			filter:     bson.M{"type": shared.IdentityTypeGroup},
+			name:       "$name",
+			namespace:  mongoClusterNamespace,
+		},
+		NodeResource: {
+			collection: collections.NodeName,
+			name:       "$k8.objectmeta.name",
+			namespace:  mongoClusterNamespace,
+		},
+		NamespaceResource: {
+			collection: collections.NamespaceName,
+			name:       "$k8.objectmeta.name",
+			namespace:  "$k8.objectmeta.name",
+		},
+		WorkloadResource: {
+			collection: collections.WorkloadName,
+			name:       "$name",
+			namespace:  "$namespace",
+		},
+		ClusterPermissionSetResource: {
+			collection: collections.PermissionSetName,
+			filter:     bson.M{"is_namespaced": false},
+			name:       "$role_name",
+			namespace:  "$namespace",
+		},
+		NamespacedPermissionSetResource: {
+			collection: collections.PermissionSetName,
+			filter:     bson.M{"is_namespaced": true},
+			name:       "$role_name",
+			namespace:  "$namespace",
+		},
+		RolePermissionSetResource: {
+			collection: collections.PermissionSetName,
+			filter:     bson.M{"is_namespaced": true},
+			stages: []bson.M{
+				// Permission sets binding a cluster role within a namespace are out of scope
+				{
+					"$lookup": bson.M{
+						"as":           "role",
+						"from":         collections.RoleName,
+						"localField":   "role_id",
+						"foreignField": "_id",
+					},
+				},
+				{
+					"$match": bson.M{
+						"role.is_namespaced": true,
+					},
+				},
+			},
+			name:      "$role_name",
+			namespace: "$namespace",
+		},
+	}
+)
+
+// anyOfExpr returns an aggregation expression evaluating to true if the array field contains any of the provided values.
+// Missing array fields (e.g non resource URL rules have no API groups) are treated as empty arrays.
+func anyOfExpr(field string, values []string) bson.M {
+	clauses := make(bson.A, 0, len(values))
+	for _, v := range values {
+		clauses = append(clauses, bson.M{
+			"$in": bson.A{v, bson.M{"$ifNull": bson.A{field, bson.A{}}}},
+		})
+	}
+
+	return bson.M{"$or": clauses}
+}
+
+// resourceNamesSize returns an aggregation expression evaluating to the number of resource names of a policy rule.
+func resourceNamesSize() bson.M {
+	return bson.M{"$size": bson.M{"$ifNull": bson.A{"$$rule.resourcenames", bson.A{}}}}
+}
+
+// resourceScopedNames returns an aggregation expression collecting the (deduplicated) resource names from all the
+// policy rules of a permission set restricted via resourceNames that satisfy the rule match.
+func resourceScopedNames(r RuleMatch) bson.M {
+	return bson.M{
+		"$reduce": bson.M{
+			"input": bson.M{
+				"$filter": bson.M{
+					"input": bson.M{"$ifNull": bson.A{"$rules", bson.A{}}},
+					"as":    "rule",
+					"cond": bson.M{
+						"$and": bson.A{
+							anyOfExpr("$$rule.apigroups", r.MatchedAPIGroups()),
+							anyOfExpr("$$rule.resources", r.MatchedResources()),
+							anyOfExpr("$$rule.verbs", r.MatchedVerbs()),
+							bson.M{"$gt": bson.A{resourceNamesSize(), 0}},
+						},
+					},
+				},
+			},
+			"initialValue": bson.A{},
+			"in": bson.M{
+				"$setUnion": bson.A{"$$value", "$$this.resourcenames"},
+			},
+		},
+	}
+}
+
+// unscopedRuleExists returns an aggregation expression evaluating to true if any policy rule of a permission set NOT
+// restricted via resourceNames satisfies the rule match.
+func unscopedRuleExists(r RuleMatch) bson.M {
+	return bson.M{
+		"$anyElementTrue": bson.A{
+			bson.M{
+				"$map": bson.M{
+					"input": bson.M{"$ifNull": bson.A{"$rules", bson.A{}}},
+					"as":    "rule",
+					"in": bson.M{
+						"$and": bson.A{
+							anyOfExpr("$$rule.apigroups", r.MatchedAPIGroups()),
+							anyOfExpr("$$rule.resources", r.MatchedResources()),
+							anyOfExpr("$$rule.verbs", r.MatchedVerbs()),
+							bson.M{"$eq": bson.A{resourceNamesSize(), 0}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// serviceAccountTokenNameMatch returns an aggregation expression evaluated against an identity document, that is true if
+// any of the provided secret names is a legacy token secret referenced by the identity's service account, or follows the
+// legacy service account token secret naming convention for the identity (i.e <serviceaccount>-token-<suffix>).
+func serviceAccountTokenNameMatch(names string) bson.M {
+	return bson.M{
+		"$or": bson.A{
+			bson.M{
+				"$gt": bson.A{
+					bson.M{"$size": bson.M{"$setIntersection": bson.A{
+						bson.M{"$ifNull": bson.A{"$token_secrets", bson.A{}}},
+						bson.M{"$ifNull": bson.A{names, bson.A{}}},
+					}}},
+					0,
+				},
+			},
+			bson.M{
+				"$anyElementTrue": bson.A{
+					bson.M{
+						"$map": bson.M{
+							"input": bson.M{"$ifNull": bson.A{names, bson.A{}}},
+							"as":    "secretName",
+							"in": bson.M{
+								"$eq": bson.A{
+									bson.M{"$indexOfCP": bson.A{"$$secretName", bson.M{"$concat": bson.A{"$name", "-token-"}}}},
+									0,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// workloadRuleMatch returns an aggregation expression evaluated against a workload document, that is true if any of the
+// provided policy rules grants one of the verbs of the rule match on the workload's API group and resource. Scoped rules
+// must list the workload name in their resourceNames, unscoped rules must not be restricted via resourceNames.
+func workloadRuleMatch(rules string, r RuleMatch, scoped bool) bson.M {
+	names := bson.M{"$eq": bson.A{resourceNamesSize(), 0}}
+	if scoped {
+		names = anyOfExpr("$$rule.resourcenames", []string{"$name"})
+	}
+
+	return bson.M{
+		"$anyElementTrue": bson.A{
+			bson.M{
+				"$map": bson.M{
+					"input": bson.M{"$ifNull": bson.A{rules, bson.A{}}},
+					"as":    "rule",
+					"in": bson.M{
+						"$and": bson.A{
+							anyOfExpr("$$rule.apigroups", []string{"$api_group", RBACWildcard}),
+							anyOfExpr("$$rule.resources", []string{"$resource", RBACWildcard}),
+							anyOfExpr("$$rule.verbs", r.MatchedVerbs()),
+							names,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// mongoResourceGrantPipeline returns the aggregation pipeline over the permission sets outputting a grant for each
+// resource of the query kind they have access to.
+func mongoResourceGrantPipeline(runtime *config.DynamicConfig, q ResourceGrantQuery) ([]bson.M, error) {
+	target, ok := mongoGrantTargets[q.Resource]
+	if !ok {
+		return nil, fmt.Errorf("unsupported resource kind: %d", q.Resource)
+	}
+
+	nameMatch := target.nameMatch
+	if nameMatch == nil {
+		nameMatch = func(names string) bson.M {
+			return bson.M{"$in": bson.A{target.name, names}}
+		}
+	}
+
+	// Whether the permission set grants access to all the resources in reach, or only to the named ones
+	var unscoped, names any = bson.M{"$literal": true}, bson.M{"$literal": bson.A{}}
+	if q.Rule != nil {
+		unscoped = bson.M{"$literal": false}
+		if q.Rule.Scope != ResourceScopedRules {
+			unscoped = unscopedRuleExists(*q.Rule)
+		}
+
+		if q.Rule.Scope != UnscopedRules {
+			names = resourceScopedNames(*q.Rule)
+		}
+	}
+
+	var unscopedMatch, namedMatch any = "$$roleUnscoped", nameMatch("$$roleNames")
+	if q.Resource == WorkloadResource {
+		// The API group and resource of the rules are evaluated against each workload
+		unscopedMatch, namedMatch = bson.M{"$literal": true}, bson.M{"$literal": false}
+		if q.Rule != nil {
+			unscopedMatch, namedMatch = bson.M{"$literal": false}, bson.M{"$literal": false}
+			if q.Rule.Scope != ResourceScopedRules {
+				unscopedMatch = workloadRuleMatch("$$roleRules", *q.Rule, false)
+			}
+
+			if q.Rule.Scope != UnscopedRules {
+				namedMatch = workloadRuleMatch("$$roleRules", *q.Rule, true)
+			}
+		}
+	}
+
+	reach := bson.A{
+		bson.M{"$eq": bson.A{"$$roleNamespaced", false}},
+		bson.M{"$eq": bson.A{target.namespace, "$$roleNamespace"}},
+	}
+	if target.namespaced != "" {
+		reach = append(reach, bson.M{"$eq": bson.A{target.namespaced, false}})
+	}
+
+	match := MongoRuntimeFilter(runtime)
+	maps.Copy(match, target.filter)
+	match["$expr"] = bson.M{
+		"$and": bson.A{
+			bson.M{"$or": reach},
+			bson.M{"$or": bson.A{unscopedMatch, namedMatch}},
+		},
+	}
+
+	resources := append([]bson.M{{"$match": match}}, target.stages...)
+	resources = append(resources, bson.M{
+		"$project": bson.M{
+			"_id":             1,
+			"name":            target.name,
+			"namespace":       target.namespace,
+			"named":           namedMatch,
+			"resource_scoped": bson.M{"$not": bson.A{unscopedMatch}},
+		},
+	})
+
+	return []bson.M{
+		{
+			"$match": MongoPermissionSetFilter(runtime, q.permissions()),
+		},
+		{
+			"$project": bson.M{
+				"_id":           1,
+				"namespace":     1,
+				"is_namespaced": 1,
+				"rules":         1,
+				"unscoped":      unscoped,
+				"names":         names,
+			},
+		},
+		{
+			"$lookup": bson.M{
+				"as":   "resource",
+				"from": target.collection,
+				"let": bson.M{
+					"roleNamespace":  "$namespace",
+					"roleNamespaced": "$is_namespaced",
+					"roleRules":      "$rules",
+					"roleUnscoped":   "$unscoped",
+					"roleNames":      "$names",
+				},
+				"pipeline": resources,
+			},
+		},
+		{
+			"$unwind": "$resource",
+		},
+		{
+			"$project": bson.M{
+				"_id":                1,
+				"is_namespaced":      1,
+				"resource":           "$resource._id",
+				"resource_name":      "$resource.name",
+				"resource_namespace": "$resource.namespace",
+				"resource_scoped":    "$resource.resource_scoped",
+				"named":              "$resource.named",
+			},
+		},
+	}, nil
+}
+
+// mongoJoin returns a $lookup stage of the documents of the ingestion run in the from collection satisfying the
+// filter and all the expressions (evaluated with the let variables), projected to their id.
+func mongoJoin(runtime *config.DynamicConfig, as string, from string, let bson.M, filter bson.M, exprs ...bson.M) bson.M {
+	match := MongoRuntimeFilter(runtime)
+	maps.Copy(match, filter)
+
+	clauses := make(bson.A, 0, len(exprs))
+	for _, expr := range exprs {
+		clauses = append(clauses, expr)
+	}
+	match["$expr"] = bson.M{"$and": clauses}
+
+	return bson.M{
+		"$lookup": bson.M{
+			"as":   as,
+			"from": from,
+			"let":  let,
+			"pipeline": []bson.M{
+				{
+					"$match": match,
+				},
+				{
+					"$project": bson.M{
+						"_id": 1,
+					},
+				},
+			},
+		},
+	}
+}
+
+// mongoEq returns an aggregation expression evaluating to true if the two values are equal.
+func mongoEq(a any, b any) bson.M {
+	return bson.M{"$eq": bson.A{a, b}}
+}
+
+// mongoCloudIdentityJoin returns a $lookup stage matching the unwound cloud_identities entry of the input documents
+// to the corresponding cloud identity, stored in the cid field.
+func mongoCloudIdentityJoin(runtime *config.DynamicConfig) bson.M {
+	return mongoJoin(runtime, "cid", collections.CloudIdentityName,
+		bson.M{
+			"cloudProvider": "$cloud_identities.provider",
+			"cloudName":     "$cloud_identities.name",
+		},
+		nil,
+		mongoEq("$provider", "$$cloudProvider"),
+		mongoEq("$name", "$$cloudName"),
+	)
+}
+
+// mongoLinkPipeline returns the collection and aggregation pipeline outputting the links of the provided kind.
+func mongoLinkPipeline(runtime *config.DynamicConfig, kind LinkKind) (string, []bson.M, error) {
+	match := func(filter bson.M) bson.M {
+		m := MongoRuntimeFilter(runtime)
+		maps.Copy(m, filter)
+
+		return bson.M{"$match": m}
+	}
+
+	switch kind {
+	case ContainerIdentityLink:
+		return collections.ContainerName, []bson.M{
+			match(nil),
+			mongoJoin(runtime, "idc", collections.IdentityName,
+				bson.M{
+					"idName":      "$inherited.service_account",
+					"idNamespace": "$inherited.namespace",
+				},
+				bson.M{"type": shared.IdentityTypeSA},
+				mongoEq("$name", "$$idName"),
+				mongoEq("$namespace", "$$idNamespace"),
+			),
+			{
+				"$unwind": "$idc",
+			},
+			mongoJoin(runtime, "tokens", collections.VolumeName,
+				bson.M{
+					"containerId": "$_id",
+					"identityId":  "$idc._id",
+				},
+				bson.M{"type": shared.VolumeTypeProjected},
+				mongoEq("$container_id", "$$containerId"),
+				mongoEq("$projected_id", "$$identityId"),
+			),
+			{
+				"$match": bson.M{
+					// The service account token is not available to the container when automount is disabled, unless
+					// explicitly mounted via a projected token volume
+					"$or": bson.A{
+						bson.M{"inherited.automount": true},
+						bson.M{"tokens.0": bson.M{"$exists": true}},
+					},
+				},
+			},
+			{
+				"$project": bson.M{
+					"_id": 1,
+					"to":  "$idc._id",
+				},
+			},
+		}, nil
+	case EndpointContainerLink:
+		return collections.EndpointName, []bson.M{
+			match(bson.M{"has_slice": true}),
+			// The container must match the namespace/pod/port/protocol of the endpoint
+			mongoJoin(runtime, "containers", collections.ContainerName,
+				bson.M{
+					"pod":   "$pod_name",
+					"podNS": "$pod_namespace",
+					"port":  "$port.port",
+					"proto": "$port.protocol",
+				},
+				nil,
+				mongoEq("$inherited.namespace", "$$podNS"),
+				mongoEq("$inherited.pod_name", "$$pod"),
+				// Cannot use an $elemMatch with pipeline variables so use the more convoluted $filter syntax
+				bson.M{"$gt": bson.A{
+					bson.M{"$size": bson.M{"$filter": bson.M{
+						"input": bson.M{"$ifNull": bson.A{"$k8.ports", bson.A{}}},
+						"as":    "p",
+						"cond": bson.M{"$and": bson.A{
+							mongoEq("$$p.containerport", "$$port"),
+							mongoEq("$$p.protocol", "$$proto"),
+						}},
+					}}},
+					0,
+				}},
+			),
+			{
+				"$unwind": "$containers",
+			},
+			{
+				"$project": bson.M{
+					"_id": 1,
+					"to":  "$containers._id",
+				},
+			},
+		}, nil
+	case MountedSecretLink:
+		return collections.VolumeName, []bson.M{
+			match(bson.M{"type": shared.VolumeTypeSecret}),
+			mongoJoin(runtime, "secrets", collections.SecretName,
+				bson.M{
+					"secretName":      "$target_name",
+					"secretNamespace": "$target_namespace",
+				},
+				nil,
+				mongoEq("$name", "$$secretName"),
+				mongoEq("$namespace", "$$secretNamespace"),
+			),
+			{
+				"$unwind": "$secrets",
+			},
+			{
+				"$project": bson.M{
+					"_id": 1,
+					"to":  "$secrets._id",
+				},
+			},
+		}, nil
+	case WebhookEndpointLink:
+		return collections.WebhookName, []bson.M{
+			match(bson.M{"service_name": bson.M{"$ne": ""}}),
+			mongoJoin(runtime, "endpoints", collections.EndpointName,
+				bson.M{
+					"serviceNamespace": "$service_namespace",
+					"serviceName":      "$service_name",
+				},
+				bson.M{"has_slice": true},
+				mongoEq("$namespace", "$$serviceNamespace"),
+				mongoEq("$service_name", "$$serviceName"),
+			),
+			{
+				"$unwind": "$endpoints",
+			},
+			{
+				"$project": bson.M{
+					"_id": 1,
+					"to":  "$endpoints._id",
+				},
+			},
+		}, nil
+	case WorkloadChildLink:
+		return collections.WorkloadName, []bson.M{
+			match(nil),
+			mongoJoin(runtime, "pods", collections.PodName,
+				bson.M{
+					"workloadId": "$_id",
+				},
+				nil,
+				mongoEq("$workload_id", "$$workloadId"),
+			),
+			mongoJoin(runtime, "workloads", collections.WorkloadName,
+				bson.M{
+					"workloadUID":       "$k8.uid",
+					"workloadNamespace": "$namespace",
+				},
+				nil,
+				mongoEq("$namespace", "$$workloadNamespace"),
+				bson.M{"$in": bson.A{"$$workloadUID", bson.M{"$ifNull": bson.A{"$k8.ownerreferences.uid", bson.A{}}}}},
+			),
+			{
+				"$project": bson.M{
+					"_id":      1,
+					"children": bson.M{"$concatArrays": bson.A{"$pods", "$workloads"}},
+				},
+			},
+			{
+				"$unwind": "$children",
+			},
+			{
+				"$project": bson.M{
+					"_id": 1,
+					"to":  "$children._id",
+				},
+			},
+		}, nil
+	case FederatedIdentityLink:
+		return collections.ServiceAccountName, []bson.M{
+			match(bson.M{"cloud_identities.0": bson.M{"$exists": true}}),
+			{
+				"$unwind": "$cloud_identities",
+			},
+			mongoJoin(runtime, "idc", collections.IdentityName,
+				bson.M{
+					"idName":      "$name",
+					"idNamespace": "$namespace",
+				},
+				bson.M{"type": shared.IdentityTypeSA},
+				mongoEq("$name", "$$idName"),
+				mongoEq("$namespace", "$$idNamespace"),
+			),
+			{
+				"$unwind": "$idc",
+			},
+			mongoCloudIdentityJoin(runtime),
+			{
+				"$unwind": "$cid",
+			},
+			{
+				"$project": bson.M{
+					"_id": "$idc._id",
+					"to":  "$cid._id",
+				},
+			},
+		}, nil
+	case FederatedNodeLink:
+		return collections.NodeName, []bson.M{
+			match(bson.M{"cloud_identities.0": bson.M{"$exists": true}}),
+			{
+				"$unwind": "$cloud_identities",
+			},
+			mongoCloudIdentityJoin(runtime),
+			{
+				"$unwind": "$cid",
+			},
+			{
+				"$project": bson.M{
+					"_id": 1,
+					"to":  "$cid._id",
+				},
+			},
+		}, nil
+	case BoundIdentityLink:
+		return collections.PermissionSetName, []bson.M{
+			match(nil),
+			{
+				"$lookup": bson.M{
+					"as":           "rb",
+					"from":         collections.RoleBindingName,
+					"localField":   "role_binding_id",
+					"foreignField": "_id",
+				},
+			},
+			{
+				"$unwind": "$rb",
+			},
+			{
+				"$unwind": "$rb.subjects",
+			},
+			{
+				"$match": bson.M{
+					"$expr": bson.M{
+						"$or": bson.A{
+							// The identity and role binding namespace need to match
+							bson.M{"$and": bson.A{
+								mongoEq("$namespace", "$rb.subjects.subject.namespace"),
+								mongoEq("$is_namespaced", true),
+							}},
+							// Identities with no namespace are cluster wide
+							mongoEq("$rb.subjects.subject.namespace", ""),
+							// Cluster role bindings need no namespace check
+							mongoEq("$is_namespaced", false),
+						},
+					},
+				},
+			},
+			{
+				"$project": bson.M{
+					"_id": 1,
+					"to":  "$rb.subjects.identity_id",
+				},
+			},
+		}, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported link kind: %d", kind)
+	}
+}
+
+// mongoAggregate streams the documents output by the aggregation pipeline over the collection to the callback.
+func mongoAggregate[T any](ctx context.Context, db *mongo.Database, collection string, pipeline []bson.M,
+	callback func(ctx context.Context, entry *T) error) error {
+
+	cur, err := db.Collection(collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var entry T
+		if err := cur.Decode(&entry); err != nil {
+			return err
+		}
+
+		if err := callback(ctx, &entry); err != nil {
+			return err
+		}
+	}
+
+	return cur.Err()
+}
+
+// ResourceGrants streams a grant for each resource of the query kind the matching permission sets have access to.
+func (mp *MongoProvider) ResourceGrants(ctx context.Context, runtime *config.DynamicConfig, q ResourceGrantQuery,
+	callback func(ctx context.Context, g *ResourceGrant) error) error {
+
+	pipeline, err := mongoResourceGrantPipeline(runtime, q)
+	if err != nil {
+		return err
+	}
+
+	return mongoAggregate(ctx, mp.reader.Database(MongoDatabaseName), collections.PermissionSetName, pipeline, callback)
+}
+
+// Links streams a link for each pair of related entries of the provided kind.
+func (mp *MongoProvider) Links(ctx context.Context, runtime *config.DynamicConfig, kind LinkKind,
+	callback func(ctx context.Context, l *Link) error) error {
+
+	collection, pipeline, err := mongoLinkPipeline(runtime, kind)
+	if err != nil {
+		return err
+	}
+
+	return mongoAggregate(ctx, mp.reader.Database(MongoDatabaseName), collection, pipeline, callback)
+}
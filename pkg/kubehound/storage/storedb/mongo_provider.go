@@ -138,6 +138,16 @@ func (mp *MongoProvider) Reader() any {
 	return mp.reader.Database(MongoDatabaseName)
 }
 
+func (mp *MongoProvider) Count(ctx context.Context, collection string, runId string, clusterName string) (int64, error) {
+	db := mp.reader.Database(MongoDatabaseName)
+	filter := bson.M{
+		"runtime.runID":        runId,
+		"runtime.cluster.name": clusterName,
+	}
+
+	return db.Collection(collection).CountDocuments(ctx, filter)
+}
+
 func (mp *MongoProvider) Name() string {
 	return StorageProviderName
 }
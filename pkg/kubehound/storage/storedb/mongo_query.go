@@ -0,0 +1,396 @@
+package storedb
+
+import (
+	"context"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/shared"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	"github.com/DataDog/KubeHound/pkg/kubehound/store/collections"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	_ Querier = (*MongoProvider)(nil)
+)
+
+// anyOf returns a query predicate matching documents where the field contains any of the provided values.
+func anyOf(field string, values []string) bson.M {
+	clauses := make(bson.A, 0, len(values))
+	for _, v := range values {
+		clauses = append(clauses, bson.M{field: v})
+	}
+
+	return bson.M{"$or": clauses}
+}
+
+// MongoRuntimeFilter returns a query predicate matching the documents of an ingestion run.
+func MongoRuntimeFilter(runtime *config.DynamicConfig) bson.M {
+	return bson.M{
+		"runtime.runID":        runtime.RunID.String(),
+		"runtime.cluster.name": runtime.Cluster.Name,
+	}
+}
+
+// MongoRuleFilter returns a query predicate on the rules array of a permission set, matching any permission set
+// containing a policy rule satisfying the rule match.
+func MongoRuleFilter(r RuleMatch) bson.M {
+	clauses := bson.A{
+		anyOf("apigroups", r.MatchedAPIGroups()),
+		anyOf("resources", r.MatchedResources()),
+		anyOf("verbs", r.MatchedVerbs()),
+	}
+
+	// Rules without resource names are stored with either a null or an empty array.
+	switch r.Scope {
+	case UnscopedRules:
+		clauses = append(clauses, bson.M{"resourcenames.0": bson.M{"$exists": false}})
+	case ResourceScopedRules:
+		clauses = append(clauses, bson.M{"resourcenames.0": bson.M{"$exists": true}})
+	case AnyRuleScope:
+	}
+
+	return bson.M{
+		"$elemMatch": bson.M{
+			"$and": clauses,
+		},
+	}
+}
+
+// MongoPermissionSetFilter returns a query predicate matching the permission sets of an ingestion run satisfying the query.
+func MongoPermissionSetFilter(runtime *config.DynamicConfig, q PermissionSetQuery) bson.M {
+	filter := MongoRuntimeFilter(runtime)
+	switch q.Scope {
+	case ClusterPermissionSets:
+		filter["is_namespaced"] = false
+	case NamespacedPermissionSets:
+		filter["is_namespaced"] = true
+	case AnyPermissionSetScope:
+	}
+
+	clauses := bson.A{}
+	if len(q.AnyRule) > 0 {
+		anyRule := make(bson.A, 0, len(q.AnyRule))
+		for _, r := range q.AnyRule {
+			anyRule = append(anyRule, bson.M{"rules": MongoRuleFilter(r)})
+		}
+		clauses = append(clauses, bson.M{"$or": anyRule})
+	}
+
+	for _, r := range q.AllRules {
+		clauses = append(clauses, bson.M{"rules": MongoRuleFilter(r)})
+	}
+
+	if len(clauses) > 0 {
+		filter["$and"] = clauses
+	}
+
+	return filter
+}
+
+// MongoCapabilityFilter returns a query predicate matching the containers explicitly granted the provided capability,
+// with or without the CAP_ prefix, or all capabilities.
+func MongoCapabilityFilter(capability string) bson.M {
+	return bson.M{
+		"k8.securitycontext.capabilities.add": bson.M{
+			"$in": MatchedCapabilities(capability),
+		},
+	}
+}
+
+// MongoAppArmorUnconfinedFilter returns a query predicate matching the containers not confined by the default AppArmor profile.
+func MongoAppArmorUnconfinedFilter() bson.M {
+	return bson.M{
+		"$or": bson.A{
+			// Before Kubernetes 1.31, AppArmor is disabled by default so we don't need to check for it.
+			// The AppArmor profile does not appear in the security context unless it is modified, so
+			// we just check if it was disabled. See the CE_SYS_PTRACE attack doc for more details.
+			bson.M{"k8.securitycontext.apparmorprofile.type": "Unconfined"},
+			// AppArmor is enabled by default since Kubernetes 1.31
+			bson.M{
+				"$and": bson.A{
+					// Ensure version fields exist and are not empty
+					bson.M{"runtime.cluster.version_major": bson.M{"$exists": true, "$ne": ""}},
+					bson.M{"runtime.cluster.version_minor": bson.M{"$exists": true, "$ne": ""}},
+					// Numerical comparison using $expr
+					bson.M{
+						"$expr": bson.M{
+							"$and": bson.A{
+								bson.M{"$lte": bson.A{bson.M{"$toInt": "$runtime.cluster.version_major"}, AppArmorEnabledMajorVersion}},
+								bson.M{"$lt": bson.A{bson.M{"$toInt": "$runtime.cluster.version_minor"}, AppArmorEnabledMinorVersion}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// mongoCapabilityClauses returns the query predicates matching the containers granted all the provided capabilities.
+func mongoCapabilityClauses(capabilities []string, explicit bool) bson.A {
+	clauses := make(bson.A, 0, len(capabilities))
+	for _, capability := range capabilities {
+		if explicit {
+			clauses = append(clauses, bson.M{"k8.securitycontext.capabilities.add": capability})
+
+			continue
+		}
+
+		clauses = append(clauses, bson.M{
+			"$or": bson.A{
+				bson.M{"k8.securitycontext.privileged": true},
+				MongoCapabilityFilter(capability),
+			},
+		})
+	}
+
+	return clauses
+}
+
+// MongoContainerFilter returns a query predicate matching the containers of an ingestion run satisfying the query.
+func MongoContainerFilter(runtime *config.DynamicConfig, q ContainerQuery) bson.M {
+	filter := MongoRuntimeFilter(runtime)
+	clauses := bson.A{}
+
+	if q.Privileged != nil {
+		if *q.Privileged {
+			clauses = append(clauses, bson.M{"k8.securitycontext.privileged": true})
+		} else {
+			clauses = append(clauses, bson.M{"k8.securitycontext.privileged": bson.M{"$ne": true}})
+		}
+	}
+
+	if q.HostPID != nil {
+		filter["inherited.host_pid"] = *q.HostPID
+	}
+
+	clauses = append(clauses, mongoCapabilityClauses(q.Capabilities, q.ExplicitCapabilities)...)
+
+	if len(q.AnyCapabilities) > 0 {
+		groups := make(bson.A, 0, len(q.AnyCapabilities))
+		for _, group := range q.AnyCapabilities {
+			groups = append(groups, bson.M{"$and": mongoCapabilityClauses(group, q.ExplicitCapabilities)})
+		}
+		clauses = append(clauses, bson.M{"$or": groups})
+	}
+
+	if q.AppArmorUnconfined {
+		clauses = append(clauses, MongoAppArmorUnconfinedFilter())
+	}
+
+	if q.RunAsUser != nil {
+		filter["k8.securitycontext.runasuser"] = *q.RunAsUser
+	}
+
+	if len(clauses) > 0 {
+		filter["$and"] = clauses
+	}
+
+	return filter
+}
+
+// mongoPodFilter returns a query predicate matching the pods of an ingestion run satisfying the query.
+func mongoPodFilter(runtime *config.DynamicConfig, q PodQuery) bson.M {
+	filter := MongoRuntimeFilter(runtime)
+	if q.ShareProcessNamespace {
+		filter["k8.spec.shareprocessnamespace"] = true
+	}
+
+	return filter
+}
+
+// mongoIdentityFilter returns a query predicate matching the identities of an ingestion run satisfying the query.
+func mongoIdentityFilter(runtime *config.DynamicConfig, q IdentityQuery) bson.M {
+	filter := MongoRuntimeFilter(runtime)
+	if len(q.Types) > 0 {
+		filter["type"] = bson.M{"$in": q.Types}
+	}
+
+	return filter
+}
+
+// mongoVolumeFilter returns a query predicate matching the volumes of an ingestion run satisfying the query.
+func mongoVolumeFilter(runtime *config.DynamicConfig, q VolumeQuery) bson.M {
+	filter := MongoRuntimeFilter(runtime)
+	if len(q.Types) > 0 {
+		filter["type"] = bson.M{"$in": q.Types}
+	}
+
+	if q.HostBacked {
+		// See store.Volume.HostBacked
+		filter["$or"] = bson.A{
+			bson.M{"type": shared.VolumeTypeHost},
+			bson.M{
+				"type":         shared.VolumeTypePersistentVolumeClaim,
+				"backing_type": bson.M{"$in": bson.A{shared.VolumeTypeHost, shared.VolumeTypeLocal}},
+			},
+		}
+	}
+
+	if q.ReadOnly != nil {
+		filter["readonly"] = *q.ReadOnly
+	}
+
+	return filter
+}
+
+// mongoEndpointFilter returns a query predicate matching the endpoints of an ingestion run satisfying the query.
+func mongoEndpointFilter(runtime *config.DynamicConfig, q EndpointQuery) bson.M {
+	filter := MongoRuntimeFilter(runtime)
+	if q.HasSlice != nil {
+		filter["has_slice"] = *q.HasSlice
+	}
+
+	return filter
+}
+
+// mongoWebhookFilter returns a query predicate matching the webhooks of an ingestion run satisfying the query.
+func mongoWebhookFilter(runtime *config.DynamicConfig, q WebhookQuery) bson.M {
+	filter := MongoRuntimeFilter(runtime)
+	if q.Type != "" {
+		filter["type"] = q.Type
+	}
+
+	return filter
+}
+
+// mongoFind streams the documents of the collection matching the filter to the callback. A nil projection returns
+// the whole documents.
+func mongoFind[T any](ctx context.Context, db *mongo.Database, collection string, filter bson.M, projection bson.M,
+	callback func(ctx context.Context, entry *T) error) error {
+
+	opts := options.Find()
+	if projection != nil {
+		opts.SetProjection(projection)
+	}
+
+	cur, err := db.Collection(collection).Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var entry T
+		if err := cur.Decode(&entry); err != nil {
+			return err
+		}
+
+		if err := callback(ctx, &entry); err != nil {
+			return err
+		}
+	}
+
+	return cur.Err()
+}
+
+// PermissionSets streams the permission sets of the ingestion run matching the query to the callback.
+func (mp *MongoProvider) PermissionSets(ctx context.Context, runtime *config.DynamicConfig, q PermissionSetQuery,
+	callback func(ctx context.Context, ps *store.PermissionSet) error) error {
+
+	return mongoFind(ctx, mp.reader.Database(MongoDatabaseName), collections.PermissionSetName,
+		MongoPermissionSetFilter(runtime, q), bson.M{
+			"_id":           1,
+			"name":          1,
+			"namespace":     1,
+			"is_namespaced": 1,
+			"role_id":       1,
+			"role_name":     1,
+			"rules":         1,
+		}, callback)
+}
+
+// Containers streams the containers of the ingestion run matching the query to the callback.
+func (mp *MongoProvider) Containers(ctx context.Context, runtime *config.DynamicConfig, q ContainerQuery,
+	callback func(ctx context.Context, c *store.Container) error) error {
+
+	return mongoFind(ctx, mp.reader.Database(MongoDatabaseName), collections.ContainerName,
+		MongoContainerFilter(runtime, q), bson.M{
+			"_id":                1,
+			"pod_id":             1,
+			"node_id":            1,
+			"k8.securitycontext": 1,
+		}, callback)
+}
+
+// Pods streams the pods of the ingestion run matching the query to the callback.
+func (mp *MongoProvider) Pods(ctx context.Context, runtime *config.DynamicConfig, q PodQuery,
+	callback func(ctx context.Context, p *store.Pod) error) error {
+
+	return mongoFind(ctx, mp.reader.Database(MongoDatabaseName), collections.PodName,
+		mongoPodFilter(runtime, q), bson.M{
+			"_id":                           1,
+			"node_id":                       1,
+			"workload_id":                   1,
+			"is_namespaced":                 1,
+			"automount":                     1,
+			"k8.objectmeta":                 1,
+			"k8.spec.shareprocessnamespace": 1,
+		}, callback)
+}
+
+// Nodes streams the nodes of the ingestion run to the callback.
+func (mp *MongoProvider) Nodes(ctx context.Context, runtime *config.DynamicConfig,
+	callback func(ctx context.Context, n *store.Node) error) error {
+
+	return mongoFind(ctx, mp.reader.Database(MongoDatabaseName), collections.NodeName,
+		MongoRuntimeFilter(runtime), bson.M{
+			"_id":                1,
+			"user_id":            1,
+			"is_namespaced":      1,
+			"k8.objectmeta":      1,
+			"k8.status.nodeinfo": 1,
+			"cloud_identities":   1,
+		}, callback)
+}
+
+// Namespaces streams the namespaces of the ingestion run to the callback.
+func (mp *MongoProvider) Namespaces(ctx context.Context, runtime *config.DynamicConfig,
+	callback func(ctx context.Context, ns *store.Namespace) error) error {
+
+	return mongoFind(ctx, mp.reader.Database(MongoDatabaseName), collections.NamespaceName,
+		MongoRuntimeFilter(runtime), nil, callback)
+}
+
+// Identities streams the identities of the ingestion run matching the query to the callback.
+func (mp *MongoProvider) Identities(ctx context.Context, runtime *config.DynamicConfig, q IdentityQuery,
+	callback func(ctx context.Context, i *store.Identity) error) error {
+
+	return mongoFind(ctx, mp.reader.Database(MongoDatabaseName), collections.IdentityName,
+		mongoIdentityFilter(runtime, q), nil, callback)
+}
+
+// Volumes streams the volumes of the ingestion run matching the query to the callback.
+func (mp *MongoProvider) Volumes(ctx context.Context, runtime *config.DynamicConfig, q VolumeQuery,
+	callback func(ctx context.Context, v *store.Volume) error) error {
+
+	return mongoFind(ctx, mp.reader.Database(MongoDatabaseName), collections.VolumeName,
+		mongoVolumeFilter(runtime, q), bson.M{"k8": 0}, callback)
+}
+
+// Endpoints streams the endpoints of the ingestion run matching the query to the callback.
+func (mp *MongoProvider) Endpoints(ctx context.Context, runtime *config.DynamicConfig, q EndpointQuery,
+	callback func(ctx context.Context, e *store.Endpoint) error) error {
+
+	return mongoFind(ctx, mp.reader.Database(MongoDatabaseName), collections.EndpointName,
+		mongoEndpointFilter(runtime, q), nil, callback)
+}
+
+// Webhooks streams the admission webhooks of the ingestion run matching the query to the callback.
+func (mp *MongoProvider) Webhooks(ctx context.Context, runtime *config.DynamicConfig, q WebhookQuery,
+	callback func(ctx context.Context, w *store.Webhook) error) error {
+
+	return mongoFind(ctx, mp.reader.Database(MongoDatabaseName), collections.WebhookName,
+		mongoWebhookFilter(runtime, q), nil, callback)
+}
+
+// NetworkPolicies streams the network policies of the ingestion run to the callback.
+func (mp *MongoProvider) NetworkPolicies(ctx context.Context, runtime *config.DynamicConfig,
+	callback func(ctx context.Context, np *store.NetworkPolicy) error) error {
+
+	return mongoFind(ctx, mp.reader.Database(MongoDatabaseName), collections.NetworkPolicyName,
+		MongoRuntimeFilter(runtime), nil, callback)
+}
@@ -34,6 +34,7 @@ func WithTags(tags []string) WriterOption {
 //go:generate mockery --name Provider --output mocks --case underscore --filename store_provider.go --with-expecter
 type Provider interface {
 	services.Dependency
+	Querier
 
 	// Prepare drops all collections from the database (usually to ensure a clean start) and recreates indices.
 	Prepare(ctx context.Context) error
@@ -41,9 +42,12 @@ type Provider interface {
 	// Droping all assets from the database (usually to ensure a clean start) from a runID and cluster name
 	Clean(ctx context.Context, runId string, clusterName string) error
 
-	// Reader returns a handle to the underlying provider to allow implementation specific queries against the mongo DB
+	// Reader returns a handle to the underlying provider to allow implementation specific queries against the store DB
 	Reader() any
 
+	// Count returns the number of elements of a collection from a runID and cluster name
+	Count(ctx context.Context, collection string, runId string, clusterName string) (int64, error)
+
 	// BulkWriter creates a new AsyncWriter instance to enable asynchronous bulk inserts.
 	BulkWriter(ctx context.Context, collection collections.Collection, opts ...WriterOption) (AsyncWriter, error)
 
@@ -67,6 +71,10 @@ type AsyncWriter interface {
 
 // Factory returns an initialized instance of a storedb provider from the provided application config.
 func Factory(ctx context.Context, cfg *config.KubehoundConfig) (Provider, error) {
+	if cfg.Storage.StoreProvider == config.StoreProviderEmbedded {
+		return NewEmbeddedProvider(ctx, cfg)
+	}
+
 	r := storage.Retrier(NewMongoProvider, cfg.Storage.Retry, cfg.Storage.RetryDelay)
 
 	return r(ctx, cfg)
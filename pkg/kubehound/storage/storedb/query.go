@@ -0,0 +1,462 @@
+package storedb
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/DataDog/KubeHound/pkg/kubehound/models/store"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const (
+	// RBACWildcard matches all API groups, resources or verbs in a policy rule.
+	RBACWildcard = "*"
+
+	// AppArmor confines containers with the runtime default profile since Kubernetes 1.31.
+	AppArmorEnabledMajorVersion = 1
+	AppArmorEnabledMinorVersion = 31
+)
+
+// RuleScope restricts the policy rules matched by a RuleMatch depending on their resourceNames.
+type RuleScope int
+
+const (
+	// AnyRuleScope matches policy rules regardless of their resourceNames.
+	AnyRuleScope RuleScope = iota
+	// UnscopedRules matches policy rules NOT restricted via resourceNames (i.e granting access to all resources).
+	UnscopedRules
+	// ResourceScopedRules matches policy rules restricted via resourceNames.
+	ResourceScopedRules
+)
+
+// RuleMatch is a predicate matching a policy rule granting any of the verbs on any of the resources of any of the API
+// groups. Wildcards are applied consistently across queries, so callers only list the requested values:
+//   - API groups and verbs are also granted by the "*" wildcard.
+//   - Resources are also granted by the "*" wildcard, and subresources (e.g pods/exec) by the "<resource>/*" and
+//     "*/<subresource>" wildcards.
+type RuleMatch struct {
+	APIGroups []string
+	Resources []string
+	Verbs     []string
+	Scope     RuleScope
+
+	// ExplicitVerbs restricts the match to policy rules listing one of the verbs explicitly, ignoring the "*" wildcard.
+	ExplicitVerbs bool
+}
+
+// WithScope returns a copy of the rule match restricted to the provided rule scope.
+func (r RuleMatch) WithScope(scope RuleScope) RuleMatch {
+	r.Scope = scope
+
+	return r
+}
+
+// dedup returns the values without duplicates, preserving their order.
+func dedup(values []string) []string {
+	res := make([]string, 0, len(values)+1)
+	for _, v := range values {
+		if !slices.Contains(res, v) {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
+
+// withWildcard returns the deduplicated values along with the "*" wildcard.
+func withWildcard(values []string) []string {
+	res := dedup(values)
+	if !slices.Contains(res, RBACWildcard) {
+		res = append(res, RBACWildcard)
+	}
+
+	return res
+}
+
+// MatchedAPIGroups returns all the API group values of a policy rule satisfying the match.
+func (r RuleMatch) MatchedAPIGroups() []string {
+	return withWildcard(r.APIGroups)
+}
+
+// MatchedVerbs returns all the verb values of a policy rule satisfying the match.
+func (r RuleMatch) MatchedVerbs() []string {
+	if r.ExplicitVerbs {
+		return dedup(r.Verbs)
+	}
+
+	return withWildcard(r.Verbs)
+}
+
+// MatchedResources returns all the resource values of a policy rule satisfying the match.
+func (r RuleMatch) MatchedResources() []string {
+	resources := make([]string, 0, len(r.Resources))
+	for _, resource := range r.Resources {
+		resources = append(resources, resource)
+
+		parent, subresource, ok := strings.Cut(resource, "/")
+		if ok && parent != RBACWildcard && subresource != RBACWildcard {
+			resources = append(resources, parent+"/"+RBACWildcard, RBACWildcard+"/"+subresource)
+		}
+	}
+
+	return withWildcard(resources)
+}
+
+// Matches returns whether the policy rule satisfies the match.
+func (r RuleMatch) Matches(rule rbacv1.PolicyRule) bool {
+	switch r.Scope {
+	case UnscopedRules:
+		if len(rule.ResourceNames) > 0 {
+			return false
+		}
+	case ResourceScopedRules:
+		if len(rule.ResourceNames) == 0 {
+			return false
+		}
+	case AnyRuleScope:
+	}
+
+	return containsAny(rule.APIGroups, r.MatchedAPIGroups()) &&
+		containsAny(rule.Resources, r.MatchedResources()) &&
+		containsAny(rule.Verbs, r.MatchedVerbs())
+}
+
+// Unscoped returns whether any of the policy rules NOT restricted via resourceNames satisfies the match.
+func (r RuleMatch) Unscoped(rules []rbacv1.PolicyRule) bool {
+	return slices.ContainsFunc(rules, r.WithScope(UnscopedRules).Matches)
+}
+
+// ResourceNames returns the (deduplicated) resource names of all the policy rules restricted via resourceNames that
+// satisfy the match. Resource names from rules not matching the verbs/resources are ignored.
+func (r RuleMatch) ResourceNames(rules []rbacv1.PolicyRule) []string {
+	scoped := r.WithScope(ResourceScopedRules)
+
+	var names []string
+	for _, rule := range rules {
+		if !scoped.Matches(rule) {
+			continue
+		}
+
+		for _, name := range rule.ResourceNames {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names
+}
+
+// containsAny returns whether any of the values is present in the list.
+func containsAny(list []string, values []string) bool {
+	for _, v := range values {
+		if slices.Contains(list, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PermissionSetScope restricts the permission sets matched by a PermissionSetQuery depending on their scope.
+type PermissionSetScope int
+
+const (
+	// AnyPermissionSetScope matches both cluster wide and namespaced permission sets.
+	AnyPermissionSetScope PermissionSetScope = iota
+	// ClusterPermissionSets matches permission sets granting permissions on the whole cluster.
+	ClusterPermissionSets
+	// NamespacedPermissionSets matches permission sets granting permissions within a single namespace.
+	NamespacedPermissionSets
+)
+
+// PermissionSetQuery selects the permission sets of an ingestion run granting some permissions.
+type PermissionSetQuery struct {
+	Scope PermissionSetScope
+
+	// AnyRule is satisfied if a policy rule of the permission set matches any of the rule matches.
+	AnyRule []RuleMatch
+
+	// AllRules is satisfied if, for each rule match, a policy rule of the permission set matches it.
+	AllRules []RuleMatch
+}
+
+// Matches returns whether the permission set satisfies the query.
+func (q PermissionSetQuery) Matches(ps *store.PermissionSet) bool {
+	switch q.Scope {
+	case ClusterPermissionSets:
+		if ps.IsNamespaced {
+			return false
+		}
+	case NamespacedPermissionSets:
+		if !ps.IsNamespaced {
+			return false
+		}
+	case AnyPermissionSetScope:
+	}
+
+	matches := func(r RuleMatch) bool {
+		return slices.ContainsFunc(ps.Rules, r.Matches)
+	}
+
+	if len(q.AnyRule) > 0 && !slices.ContainsFunc(q.AnyRule, matches) {
+		return false
+	}
+
+	for _, r := range q.AllRules {
+		if !matches(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ContainerQuery selects the containers of an ingestion run with some security context properties.
+type ContainerQuery struct {
+	// Privileged restricts the match to privileged (true) or unprivileged (false) containers.
+	Privileged *bool
+
+	// HostPID restricts the match to containers sharing (true) or not (false) the host PID namespace.
+	HostPID *bool
+
+	// Capabilities lists capabilities that must all be granted to the container. A capability is granted to privileged
+	// containers and to containers adding it explicitly (with or without the CAP_ prefix) or via ALL.
+	Capabilities []string
+
+	// AnyCapabilities lists alternative capability groups. The container must be granted all the capabilities of at
+	// least one of the groups, matched as Capabilities.
+	AnyCapabilities [][]string
+
+	// ExplicitCapabilities restricts the capability match to containers adding each capability by its exact name,
+	// ignoring the privileged flag, the CAP_ prefix and ALL.
+	ExplicitCapabilities bool
+
+	// AppArmorUnconfined restricts the match to containers not confined by the default AppArmor profile.
+	AppArmorUnconfined bool
+
+	// RunAsUser restricts the match to containers explicitly running as the provided user.
+	RunAsUser *int64
+}
+
+// Matches returns whether the container satisfies the query.
+func (q ContainerQuery) Matches(c *store.Container) bool {
+	sc := c.K8.SecurityContext
+	privileged := sc != nil && sc.Privileged != nil && *sc.Privileged
+
+	if q.Privileged != nil && *q.Privileged != privileged {
+		return false
+	}
+
+	if q.HostPID != nil && *q.HostPID != c.Inherited.HostPID {
+		return false
+	}
+
+	if !q.grantedAll(c, q.Capabilities) {
+		return false
+	}
+
+	if len(q.AnyCapabilities) > 0 && !slices.ContainsFunc(q.AnyCapabilities, func(group []string) bool {
+		return q.grantedAll(c, group)
+	}) {
+		return false
+	}
+
+	if q.AppArmorUnconfined && !appArmorUnconfined(c) {
+		return false
+	}
+
+	if q.RunAsUser != nil && (sc == nil || sc.RunAsUser == nil || *sc.RunAsUser != *q.RunAsUser) {
+		return false
+	}
+
+	return true
+}
+
+// grantedAll returns whether the container is granted all the provided capabilities.
+func (q ContainerQuery) grantedAll(c *store.Container, capabilities []string) bool {
+	sc := c.K8.SecurityContext
+	privileged := sc != nil && sc.Privileged != nil && *sc.Privileged
+
+	for _, capability := range capabilities {
+		if privileged && !q.ExplicitCapabilities {
+			continue
+		}
+
+		matched := MatchedCapabilities(capability)
+		if q.ExplicitCapabilities {
+			matched = []string{capability}
+		}
+
+		if sc == nil || sc.Capabilities == nil || !slices.ContainsFunc(sc.Capabilities.Add, func(added corev1.Capability) bool {
+			return slices.Contains(matched, string(added))
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// appArmorUnconfined returns whether the container is not confined by the default AppArmor profile, i.e the profile
+// was explicitly disabled or the cluster predates AppArmor being enabled by default. Unparsable versions are assumed
+// to be recent.
+func appArmorUnconfined(c *store.Container) bool {
+	sc := c.K8.SecurityContext
+	if sc != nil && sc.AppArmorProfile != nil && sc.AppArmorProfile.Type == corev1.AppArmorProfileTypeUnconfined {
+		return true
+	}
+
+	major, err := strconv.Atoi(c.Runtime.Cluster.VersionMajor)
+	if err != nil {
+		return false
+	}
+
+	minor, err := strconv.Atoi(c.Runtime.Cluster.VersionMinor)
+	if err != nil {
+		return false
+	}
+
+	return major <= AppArmorEnabledMajorVersion && minor < AppArmorEnabledMinorVersion
+}
+
+// MatchedCapabilities returns all the values of an added capability granting the provided capability.
+func MatchedCapabilities(capability string) []string {
+	capability = strings.TrimPrefix(capability, "CAP_")
+
+	return []string{capability, "CAP_" + capability, "ALL"}
+}
+
+// PodQuery selects the pods of an ingestion run.
+type PodQuery struct {
+	// ShareProcessNamespace restricts the match to pods sharing a single process namespace between their containers.
+	ShareProcessNamespace bool
+}
+
+// Matches returns whether the pod satisfies the query.
+func (q PodQuery) Matches(p *store.Pod) bool {
+	shared := p.K8.Spec.ShareProcessNamespace != nil && *p.K8.Spec.ShareProcessNamespace
+
+	return !q.ShareProcessNamespace || shared
+}
+
+// IdentityQuery selects the identities of an ingestion run.
+type IdentityQuery struct {
+	// Types restricts the match to the identities of the provided types (see shared.IdentityType*).
+	Types []string
+}
+
+// Matches returns whether the identity satisfies the query.
+func (q IdentityQuery) Matches(i *store.Identity) bool {
+	return len(q.Types) == 0 || slices.Contains(q.Types, i.Type)
+}
+
+// VolumeQuery selects the volumes of an ingestion run.
+type VolumeQuery struct {
+	// Types restricts the match to the volumes of the provided types (see shared.VolumeType*).
+	Types []string
+
+	// HostBacked restricts the match to the volumes exposing a directory of the node filesystem (see store.Volume.HostBacked).
+	HostBacked bool
+
+	// ReadOnly restricts the match to read-only (true) or writable (false) volume mounts.
+	ReadOnly *bool
+}
+
+// Matches returns whether the volume satisfies the query.
+func (q VolumeQuery) Matches(v *store.Volume) bool {
+	if len(q.Types) > 0 && !slices.Contains(q.Types, v.Type) {
+		return false
+	}
+
+	if q.HostBacked && !v.HostBacked() {
+		return false
+	}
+
+	return q.ReadOnly == nil || *q.ReadOnly == v.ReadOnly
+}
+
+// EndpointQuery selects the endpoints of an ingestion run.
+type EndpointQuery struct {
+	// HasSlice restricts the match to endpoints exposed via an endpoint slice (true) or created from a container port
+	// (false).
+	HasSlice *bool
+}
+
+// Matches returns whether the endpoint satisfies the query.
+func (q EndpointQuery) Matches(e *store.Endpoint) bool {
+	return q.HasSlice == nil || *q.HasSlice == e.HasSlice
+}
+
+// WebhookQuery selects the admission webhooks of an ingestion run.
+type WebhookQuery struct {
+	// Type restricts the match to the webhooks of the provided type (see shared.WebhookType*).
+	Type string
+}
+
+// Matches returns whether the webhook satisfies the query.
+func (q WebhookQuery) Matches(w *store.Webhook) bool {
+	return q.Type == "" || q.Type == w.Type
+}
+
+// Querier defines the typed queries used by the graph builders to read the normalized K8s data, independently of the
+// storedb implementation.
+type Querier interface {
+	// PermissionSets streams the permission sets of the ingestion run matching the query to the callback. Only the
+	// identifying fields (id, name, namespace) of the permission sets are populated.
+	PermissionSets(ctx context.Context, runtime *config.DynamicConfig, q PermissionSetQuery,
+		callback func(ctx context.Context, ps *store.PermissionSet) error) error
+
+	// Containers streams the containers of the ingestion run matching the query to the callback. Only the identifying
+	// fields (id, pod id, node id) of the containers are populated.
+	Containers(ctx context.Context, runtime *config.DynamicConfig, q ContainerQuery,
+		callback func(ctx context.Context, c *store.Container) error) error
+
+	// Pods streams the pods of the ingestion run matching the query to the callback. Only the identifying fields (id,
+	// node id, workload id), the object metadata and the process namespace sharing of the pods are populated.
+	Pods(ctx context.Context, runtime *config.DynamicConfig, q PodQuery,
+		callback func(ctx context.Context, p *store.Pod) error) error
+
+	// Nodes streams the nodes of the ingestion run to the callback. Only the identifying fields (id, user id), the
+	// object metadata, the node system info and the cloud identities of the nodes are populated.
+	Nodes(ctx context.Context, runtime *config.DynamicConfig,
+		callback func(ctx context.Context, n *store.Node) error) error
+
+	// Namespaces streams the namespaces of the ingestion run to the callback.
+	Namespaces(ctx context.Context, runtime *config.DynamicConfig,
+		callback func(ctx context.Context, ns *store.Namespace) error) error
+
+	// Identities streams the identities of the ingestion run matching the query to the callback.
+	Identities(ctx context.Context, runtime *config.DynamicConfig, q IdentityQuery,
+		callback func(ctx context.Context, i *store.Identity) error) error
+
+	// Volumes streams the volumes of the ingestion run matching the query to the callback. The K8s volume definition
+	// is not populated.
+	Volumes(ctx context.Context, runtime *config.DynamicConfig, q VolumeQuery,
+		callback func(ctx context.Context, v *store.Volume) error) error
+
+	// Endpoints streams the endpoints of the ingestion run matching the query to the callback.
+	Endpoints(ctx context.Context, runtime *config.DynamicConfig, q EndpointQuery,
+		callback func(ctx context.Context, e *store.Endpoint) error) error
+
+	// Webhooks streams the admission webhooks of the ingestion run matching the query to the callback.
+	Webhooks(ctx context.Context, runtime *config.DynamicConfig, q WebhookQuery,
+		callback func(ctx context.Context, w *store.Webhook) error) error
+
+	// NetworkPolicies streams the network policies of the ingestion run to the callback.
+	NetworkPolicies(ctx context.Context, runtime *config.DynamicConfig,
+		callback func(ctx context.Context, np *store.NetworkPolicy) error) error
+
+	// ResourceGrants streams to the callback a grant for each resource of the query kind the matching permission sets
+	// have access to.
+	ResourceGrants(ctx context.Context, runtime *config.DynamicConfig, q ResourceGrantQuery,
+		callback func(ctx context.Context, g *ResourceGrant) error) error
+
+	// Links streams to the callback a link for each pair of related entries of the ingestion run.
+	Links(ctx context.Context, runtime *config.DynamicConfig, kind LinkKind,
+		callback func(ctx context.Context, l *Link) error) error
+}
@@ -0,0 +1,202 @@
+package storedb
+
+import (
+	"testing"
+
+	"github.com/DataDog/KubeHound/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestRuleMatch_Matched(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		rule      RuleMatch
+		apiGroups []string
+		resources []string
+		verbs     []string
+	}{
+		{
+			name: "resource",
+			rule: RuleMatch{
+				APIGroups: []string{""},
+				Resources: []string{"secrets"},
+				Verbs:     []string{"get", "list"},
+			},
+			apiGroups: []string{"", "*"},
+			resources: []string{"secrets", "*"},
+			verbs:     []string{"get", "list", "*"},
+		},
+		{
+			name: "subresource",
+			rule: RuleMatch{
+				APIGroups: []string{""},
+				Resources: []string{"pods/exec"},
+				Verbs:     []string{"create"},
+			},
+			apiGroups: []string{"", "*"},
+			resources: []string{"pods/exec", "pods/*", "*/exec", "*"},
+			verbs:     []string{"create", "*"},
+		},
+		{
+			name: "duplicates and wildcards",
+			rule: RuleMatch{
+				APIGroups: []string{"apps", "*"},
+				Resources: []string{"deployments", "deployments", "pods/*"},
+				Verbs:     []string{"*", "patch"},
+			},
+			apiGroups: []string{"apps", "*"},
+			resources: []string{"deployments", "pods/*", "*"},
+			verbs:     []string{"*", "patch"},
+		},
+		{
+			name: "explicit verbs",
+			rule: RuleMatch{
+				APIGroups:     []string{""},
+				Resources:     []string{"secrets"},
+				Verbs:         []string{"get", "get"},
+				ExplicitVerbs: true,
+			},
+			apiGroups: []string{"", "*"},
+			resources: []string{"secrets", "*"},
+			verbs:     []string{"get"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.apiGroups, tt.rule.MatchedAPIGroups())
+			assert.Equal(t, tt.resources, tt.rule.MatchedResources())
+			assert.Equal(t, tt.verbs, tt.rule.MatchedVerbs())
+		})
+	}
+}
+
+func TestMatchedCapabilities(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"SYS_ADMIN", "CAP_SYS_ADMIN", "ALL"}, MatchedCapabilities("SYS_ADMIN"))
+	assert.Equal(t, []string{"SYS_ADMIN", "CAP_SYS_ADMIN", "ALL"}, MatchedCapabilities("CAP_SYS_ADMIN"))
+}
+
+func TestMongoPermissionSetFilter(t *testing.T) {
+	t.Parallel()
+
+	runtime := &config.DynamicConfig{
+		RunID: config.NewRunID(),
+		Cluster: config.DynamicClusterInfo{
+			Name: "test-cluster",
+		},
+	}
+
+	rule := RuleMatch{
+		APIGroups: []string{""},
+		Resources: []string{"secrets"},
+		Verbs:     []string{"list"},
+		Scope:     UnscopedRules,
+	}
+	expectedRule := bson.M{
+		"$elemMatch": bson.M{
+			"$and": bson.A{
+				bson.M{"$or": bson.A{bson.M{"apigroups": ""}, bson.M{"apigroups": "*"}}},
+				bson.M{"$or": bson.A{bson.M{"resources": "secrets"}, bson.M{"resources": "*"}}},
+				bson.M{"$or": bson.A{bson.M{"verbs": "list"}, bson.M{"verbs": "*"}}},
+				bson.M{"resourcenames.0": bson.M{"$exists": false}},
+			},
+		},
+	}
+	assert.Equal(t, expectedRule, MongoRuleFilter(rule))
+
+	filter := MongoPermissionSetFilter(runtime, PermissionSetQuery{
+		Scope:    NamespacedPermissionSets,
+		AnyRule:  []RuleMatch{rule},
+		AllRules: []RuleMatch{rule},
+	})
+	assert.Equal(t, bson.M{
+		"runtime.runID":        runtime.RunID.String(),
+		"runtime.cluster.name": "test-cluster",
+		"is_namespaced":        true,
+		"$and": bson.A{
+			bson.M{"$or": bson.A{bson.M{"rules": expectedRule}}},
+			bson.M{"rules": expectedRule},
+		},
+	}, filter)
+
+	// No rule constraint
+	filter = MongoPermissionSetFilter(runtime, PermissionSetQuery{})
+	assert.NotContains(t, filter, "$and")
+	assert.NotContains(t, filter, "is_namespaced")
+}
+
+func TestMongoContainerFilter(t *testing.T) {
+	t.Parallel()
+
+	runtime := &config.DynamicConfig{
+		RunID: config.NewRunID(),
+		Cluster: config.DynamicClusterInfo{
+			Name: "test-cluster",
+		},
+	}
+
+	unprivileged := false
+	hostPID := true
+	filter := MongoContainerFilter(runtime, ContainerQuery{
+		Privileged:   &unprivileged,
+		HostPID:      &hostPID,
+		Capabilities: []string{"SYS_PTRACE"},
+	})
+	assert.Equal(t, bson.M{
+		"runtime.runID":        runtime.RunID.String(),
+		"runtime.cluster.name": "test-cluster",
+		"inherited.host_pid":   true,
+		"$and": bson.A{
+			bson.M{"k8.securitycontext.privileged": bson.M{"$ne": true}},
+			bson.M{"$or": bson.A{
+				bson.M{"k8.securitycontext.privileged": true},
+				bson.M{"k8.securitycontext.capabilities.add": bson.M{
+					"$in": []string{"SYS_PTRACE", "CAP_SYS_PTRACE", "ALL"},
+				}},
+			}},
+		},
+	}, filter)
+}
+
+func TestMongoContainerFilter_AnyCapabilities(t *testing.T) {
+	t.Parallel()
+
+	runtime := &config.DynamicConfig{
+		RunID: config.NewRunID(),
+		Cluster: config.DynamicClusterInfo{
+			Name: "test-cluster",
+		},
+	}
+
+	unprivileged := false
+	filter := MongoContainerFilter(runtime, ContainerQuery{
+		Privileged:      &unprivileged,
+		AnyCapabilities: [][]string{{"SYS_ADMIN"}, {"BPF", "PERFMON"}},
+	})
+	capability := func(capability string) bson.M {
+		return bson.M{"$or": bson.A{
+			bson.M{"k8.securitycontext.privileged": true},
+			bson.M{"k8.securitycontext.capabilities.add": bson.M{
+				"$in": []string{capability, "CAP_" + capability, "ALL"},
+			}},
+		}}
+	}
+	assert.Equal(t, bson.M{
+		"runtime.runID":        runtime.RunID.String(),
+		"runtime.cluster.name": "test-cluster",
+		"$and": bson.A{
+			bson.M{"k8.securitycontext.privileged": bson.M{"$ne": true}},
+			bson.M{"$or": bson.A{
+				bson.M{"$and": bson.A{capability("SYS_ADMIN")}},
+				bson.M{"$and": bson.A{capability("BPF"), capability("PERFMON")}},
+			}},
+		},
+	}, filter)
+}
@@ -5,15 +5,24 @@ const (
 )
 
 const (
-	NodeName          = "nodes"
-	PodName           = "pods"
-	ContainerName     = "containers"
-	VolumeName        = "volumes"
-	RoleName          = "roles"
-	RoleBindingName   = "rolebindings"
-	IdentityName      = "identities"
-	PermissionSetName = "permissionsets"
-	EndpointName      = "endpoints"
+	NodeName                  = "nodes"
+	PodName                   = "pods"
+	ContainerName             = "containers"
+	VolumeName                = "volumes"
+	RoleName                  = "roles"
+	RoleBindingName           = "rolebindings"
+	IdentityName              = "identities"
+	PermissionSetName         = "permissionsets"
+	EndpointName              = "endpoints"
+	SecretName                = "secrets"
+	ServiceAccountName        = "serviceaccounts"
+	WorkloadName              = "workloads"
+	NetworkPolicyName         = "networkpolicies"
+	PersistentVolumeName      = "persistentvolumes"
+	PersistentVolumeClaimName = "persistentvolumeclaims"
+	WebhookName               = "webhooks"
+	NamespaceName             = "namespaces"
+	CloudIdentityName         = "cloudidentities"
 )
 
 // Collection provides a common abstraction of a SQL database table or a NoSQL object
@@ -37,5 +46,14 @@ func GetCollections() []string {
 		IdentityName,
 		PermissionSetName,
 		EndpointName,
+		SecretName,
+		ServiceAccountName,
+		WorkloadName,
+		NetworkPolicyName,
+		PersistentVolumeName,
+		PersistentVolumeClaimName,
+		WebhookName,
+		NamespaceName,
+		CloudIdentityName,
 	}
 }
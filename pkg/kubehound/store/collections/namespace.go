@@ -0,0 +1,14 @@
+package collections
+
+type Namespace struct {
+}
+
+var _ Collection = (*Namespace)(nil) // Ensure interface compliance
+
+func (c Namespace) Name() string {
+	return NamespaceName
+}
+
+func (c Namespace) BatchSize() int {
+	return DefaultBatchSize
+}
@@ -0,0 +1,14 @@
+package collections
+
+type PersistentVolume struct {
+}
+
+var _ Collection = (*PersistentVolume)(nil) // Ensure interface compliance
+
+func (c PersistentVolume) Name() string {
+	return PersistentVolumeName
+}
+
+func (c PersistentVolume) BatchSize() int {
+	return DefaultBatchSize
+}
@@ -0,0 +1,14 @@
+package collections
+
+type PersistentVolumeClaim struct {
+}
+
+var _ Collection = (*PersistentVolumeClaim)(nil) // Ensure interface compliance
+
+func (c PersistentVolumeClaim) Name() string {
+	return PersistentVolumeClaimName
+}
+
+func (c PersistentVolumeClaim) BatchSize() int {
+	return DefaultBatchSize
+}
@@ -0,0 +1,14 @@
+package collections
+
+type ServiceAccount struct {
+}
+
+var _ Collection = (*ServiceAccount)(nil) // Ensure interface compliance
+
+func (c ServiceAccount) Name() string {
+	return ServiceAccountName
+}
+
+func (c ServiceAccount) BatchSize() int {
+	return DefaultBatchSize
+}
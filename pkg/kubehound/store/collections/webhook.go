@@ -0,0 +1,14 @@
+package collections
+
+type Webhook struct {
+}
+
+var _ Collection = (*Webhook)(nil) // Ensure interface compliance
+
+func (c Webhook) Name() string {
+	return WebhookName
+}
+
+func (c Webhook) BatchSize() int {
+	return DefaultBatchSize
+}
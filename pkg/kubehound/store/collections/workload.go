@@ -0,0 +1,14 @@
+package collections
+
+type Workload struct {
+}
+
+var _ Collection = (*Workload)(nil) // Ensure interface compliance
+
+func (c Workload) Name() string {
+	return WorkloadName
+}
+
+func (c Workload) BatchSize() int {
+	return DefaultBatchSize
+}
@@ -22,12 +22,30 @@ const (
 	JanusGraphBatchWrite = "kubehound.janusgraph.batchwrite"
 )
 
+// Embedded graph provider spans
+const (
+	EmbeddedGraphFlush      = "kubehound.embeddedgraph.flush"
+	EmbeddedGraphBatchWrite = "kubehound.embeddedgraph.batchwrite"
+)
+
+// Neo4j provider spans
+const (
+	Neo4jFlush      = "kubehound.neo4j.flush"
+	Neo4jBatchWrite = "kubehound.neo4j.batchwrite"
+)
+
 // MongoDB provider spans
 const (
 	MongoDBFlush      = "kubehound.mongo.flush"
 	MongoDBBatchWrite = "kubehound.mongo.batchwrite"
 )
 
+// Embedded store provider spans
+const (
+	EmbeddedStoreFlush      = "kubehound.embeddedstore.flush"
+	EmbeddedStoreBatchWrite = "kubehound.embeddedstore.batchwrite"
+)
+
 // Collector/dumper component spans
 const (
 	CollectorStream = "kubehound.collector.stream"
@@ -46,6 +64,13 @@ const (
 	DumperNodes               = "kubehound.dumper.nodes"
 	DumperPods                = "kubehound.dumper.pods"
 	DumperEndpoints           = "kubehound.dumper.endpoints"
+	DumperSecrets             = "kubehound.dumper.secrets"
+	DumperServiceAccounts     = "kubehound.dumper.serviceaccounts"
+	DumperWorkloads           = "kubehound.dumper.workloads"
+	DumperNetworkPolicies     = "kubehound.dumper.networkpolicies"
+	DumperPersistentVolumes   = "kubehound.dumper.persistentvolumes"
+	DumperWebhooks            = "kubehound.dumper.webhooks"
+	DumperNamespaces          = "kubehound.dumper.namespaces"
 	DumperRoles               = "kubehound.dumper.roles"
 	DumperClusterRoles        = "kubehound.dumper.clusterroles"
 	DumperRoleBindings        = "kubehound.dumper.rolebindings"
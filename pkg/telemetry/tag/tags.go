@@ -43,6 +43,13 @@ const (
 	EntityRolebindings        = "rolebindings"
 	EntityNodes               = "nodes"
 	EntityEndpoints           = "endpoints"
+	EntitySecrets             = "secrets"
+	EntityServiceAccounts     = "serviceaccounts"
+	EntityWorkloads           = "workloads"
+	EntityNetworkPolicies     = "networkpolicies"
+	EntityPersistentVolumes   = "persistentvolumes"
+	EntityWebhooks            = "webhooks"
+	EntityNamespaces          = "namespaces"
 	EntityClusterRoles        = "clusterroles"
 	EntityClusterRolebindings = "clusterrolebindings"
 )
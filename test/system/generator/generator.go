@@ -234,7 +234,7 @@ func ProcessFile(ctx context.Context, basePath string, file os.FileInfo, cacheRo
 			}
 
 			for _, cont := range o.Spec.Containers {
-				err = AddContainerToList(&cont, &p)
+				err = AddContainerToList(&cont, &p, false)
 				if err != nil {
 					fmt.Println("Failed to add container to list:", err)
 				}
@@ -248,6 +248,20 @@ func ProcessFile(ctx context.Context, basePath string, file os.FileInfo, cacheRo
 
 			}
 
+			for _, cont := range o.Spec.InitContainers {
+				err = AddContainerToList(&cont, &p, true)
+				if err != nil {
+					fmt.Println("Failed to add init container to list:", err)
+				}
+
+				for _, vol := range cont.VolumeMounts {
+					err = AddVolumeToList(&vol, &p)
+					if err != nil {
+						fmt.Println("Failed to add volume to list:", err)
+					}
+				}
+			}
+
 		case *rbacv1.Role:
 			role, err := conv.Role(ctx, o)
 			if err != nil {
@@ -378,10 +392,14 @@ func AddNodeToList(node *corev1.Node) error {
 	return nil
 }
 
-func AddContainerToList(Container *corev1.Container, storePod *store.Pod) error {
+func AddContainerToList(Container *corev1.Container, storePod *store.Pod, isInit bool) error {
 	fmt.Printf("Container name: %s\n", Container.Name)
 	convStore := converter.NewStore(GeneratorConfig)
-	storeContainer, err := convStore.Container(context.Background(), Container, storePod)
+	convert := convStore.Container
+	if isInit {
+		convert = convStore.InitContainer
+	}
+	storeContainer, err := convert(context.Background(), Container, storePod)
 	if err != nil {
 		return err
 	}
@@ -522,6 +540,7 @@ func GenerateContainerTemplate() ([]byte, error) {
 			Namespace:    "{{.Namespace}}",
 			Ports:        []string{},
 			Pod:          "{{.Pod}}",
+			Init:         {{.Init}},
 			// Node:         "{{.Node}}",
 			Compromised:  0,
 		},{{ end }}
@@ -102,6 +102,8 @@ func (suite *DslTestSuite) TestTraversalSource_escapes() {
 		"path[sys-ptrace-pod, CE_SYS_PTRACE, Node]",
 		"path[priv-pod, CE_MODULE_LOAD, Node]",
 		"path[priv-pod, CE_PRIV_MOUNT, Node]",
+		"path[priv-init-container, CE_MODULE_LOAD, Node]",
+		"path[priv-init-container, CE_PRIV_MOUNT, Node]",
 		"path[nsenter-pod, CE_NSENTER, Node]",
 		"path[nsenter-pod, CE_MODULE_LOAD, Node]",
 		"path[nsenter-pod, CE_PRIV_MOUNT, Node]",
@@ -115,6 +117,14 @@ func (suite *DslTestSuite) TestTraversalSource_escapes() {
 		"path[kube-proxy, CE_PRIV_MOUNT, Node]",
 		"path[varlog-container, CE_VAR_LOG_SYMLINK, Node]",
 		"path[umh-core-container, CE_UMH_CORE_PATTERN, Node]",
+		"path[runtime-socket-pod, CE_RUNTIME_SOCKET, Node]",
+		"path[sys-ptrace-pod, CE_BPF, Node]",
+		"path[sys-ptrace-pod, CE_CGROUP_RELEASE_AGENT, Node]",
+		"path[dac-read-search-pod, CE_DAC_READ_SEARCH, Node]",
+		"path[release-agent-pod, CE_CGROUP_RELEASE_AGENT, Node]",
+		"path[release-agent-pod, CE_BPF, Node]",
+		"path[bpf-pod, CE_BPF, Node]",
+		"path[sys-rawio-pod, CE_SYS_RAWIO, Node]",
 	}
 
 	suite.ElementsMatch(escapes, expected)
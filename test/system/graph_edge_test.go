@@ -97,6 +97,30 @@ func (suite *EdgeTestSuite) _testContainerEscape(edgeLabel string, nodes map[str
 	suite.True(matched)
 }
 
+func (suite *EdgeTestSuite) TestEdge_CE_BPF() {
+	containers := map[string]bool{
+		"bpf-pod": true,
+	}
+
+	suite._testContainerEscape("CE_BPF", DefaultContainerEscapeNodes, containers)
+}
+
+func (suite *EdgeTestSuite) TestEdge_CE_CGROUP_RELEASE_AGENT() {
+	containers := map[string]bool{
+		"release-agent-pod": true,
+	}
+
+	suite._testContainerEscape("CE_CGROUP_RELEASE_AGENT", DefaultContainerEscapeNodes, containers)
+}
+
+func (suite *EdgeTestSuite) TestEdge_CE_DAC_READ_SEARCH() {
+	containers := map[string]bool{
+		"dac-read-search-pod": true,
+	}
+
+	suite._testContainerEscape("CE_DAC_READ_SEARCH", DefaultContainerEscapeNodes, containers)
+}
+
 func (suite *EdgeTestSuite) TestEdge_CE_MODULE_LOAD() {
 	containers := map[string]bool{
 		"modload-pod": true,
@@ -115,10 +139,42 @@ func (suite *EdgeTestSuite) TestEdge_CE_NSENTER() {
 
 func (suite *EdgeTestSuite) TestEdge_CE_PRIV_MOUNT() {
 	containers := map[string]bool{
-		"priv-pod": true,
+		"priv-pod":            true,
+		"priv-init-container": true,
 	}
 
 	suite._testContainerEscape("CE_PRIV_MOUNT", DefaultContainerEscapeNodes, containers)
+
+	// Init containers are included in the container escapes
+	results, err := suite.g.V().
+		Has("class", "Container").
+		Has("init", true).
+		OutE("CE_PRIV_MOUNT").
+		OutV().
+		Values("name").
+		ToList()
+
+	suite.NoError(err)
+	suite.Equal([]string{"priv-init-container"}, suite.resultsToStringArray(results))
+}
+
+func (suite *EdgeTestSuite) TestEdge_CE_RUNTIME_SOCKET() {
+	containers := map[string]bool{
+		"runtime-socket-pod": true,
+	}
+
+	suite._testContainerEscape("CE_RUNTIME_SOCKET", DefaultContainerEscapeNodes, containers)
+
+	// The socket is mounted read-only, which does not prevent the escape
+	results, err := suite.g.V().
+		Has("class", "Container").
+		Has("name", "runtime-socket-pod").
+		OutE().HasLabel("CE_RUNTIME_SOCKET").
+		Values("mountReason").
+		ToList()
+
+	suite.NoError(err)
+	suite.Equal([]string{"containerd socket"}, suite.resultsToStringArray(results))
 }
 
 func (suite *EdgeTestSuite) TestEdge_CE_SYS_PTRACE() {
@@ -129,6 +185,14 @@ func (suite *EdgeTestSuite) TestEdge_CE_SYS_PTRACE() {
 	suite._testContainerEscape("CE_SYS_PTRACE", DefaultContainerEscapeNodes, containers)
 }
 
+func (suite *EdgeTestSuite) TestEdge_CE_SYS_RAWIO() {
+	containers := map[string]bool{
+		"sys-rawio-pod": true,
+	}
+
+	suite._testContainerEscape("CE_SYS_RAWIO", DefaultContainerEscapeNodes, containers)
+}
+
 func (suite *EdgeTestSuite) TestEdge_CE_UMH_CORE_PATTERN() {
 	containers := map[string]bool{
 		"umh-core-container": true,
@@ -189,6 +253,7 @@ func (suite *EdgeTestSuite) TestEdge_IDENTITY_ASSUME_Container() {
 	expected := []string{
 		"path[map[name:[impersonate-pod]], map[], map[name:[impersonate-sa]",
 		"path[map[name:[pod-create-pod]], map[], map[name:[pod-create-sa]",
+		"path[map[name:[pod-debug-pod]], map[], map[name:[pod-debug-sa]",
 		"path[map[name:[pod-exec-pod]], map[], map[name:[pod-exec-sa]",
 		"path[map[name:[pod-patch-pod]], map[], map[name:[pod-patch-sa]",
 		"path[map[name:[rolebind-pod-crb-cr-crb-cr]], map[], map[name:[rolebind-sa-crb-cr-crb-cr]",
@@ -204,6 +269,7 @@ func (suite *EdgeTestSuite) TestEdge_IDENTITY_ASSUME_Container() {
 		"path[map[name:[tokenget-pod]], map[], map[name:[tokenget-sa]",
 		"path[map[name:[tokenlist-pod]], map[], map[name:[tokenlist-sa]",
 		"path[map[name:[varlog-container]], map[], map[name:[varlog-sa]",
+		"path[map[name:[webhook-inject-pod]], map[], map[name:[webhook-inject-sa]",
 	}
 	suite.Subset(paths, expected)
 }
@@ -270,8 +336,11 @@ func (suite *EdgeTestSuite) TestEdge_POD_PATCH() {
 
 	paths := suite.pathsToStringArray(results)
 	expected := []string{
+		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[bpf-pod]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[control-pod]",
+		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[dac-read-search-pod]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[endpoints-pod]",
+		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[host-pvc-write-pod]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[host-read-exploit-pod]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[host-write-exploit-pod]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[impersonate-pod]",
@@ -279,9 +348,11 @@ func (suite *EdgeTestSuite) TestEdge_POD_PATCH() {
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[netadmin-pod]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[nsenter-pod]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[pod-create-pod]",
+		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[pod-debug-pod]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[pod-exec-pod]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[pod-patch-pod]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[priv-pod]",
+		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[release-agent-pod]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[rolebind-pod-crb-cr-crb-cr]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[rolebind-pod-crb-cr-crb-r-fail]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[rolebind-pod-crb-cr-rb-cr]",
@@ -292,13 +363,21 @@ func (suite *EdgeTestSuite) TestEdge_POD_PATCH() {
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[rolebind-pod-rb-r-crb-cr-fail]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[rolebind-pod-rb-r-rb-crb]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[rolebind-pod-rb-r-rb-r]",
+		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[runtime-socket-pod]",
+		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[secretmount-pod]",
+		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[share-reader-pod]",
+		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[share-writer-pod]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[sharedps-pod1]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[sharedps-pod2]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[sys-ptrace-pod]",
+		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[sys-rawio-pod]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[tokenget-pod]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[tokenlist-pod]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[umh-core-pod]",
 		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[varlog-pod]",
+		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[webhook-inject-pod]",
+		"path[map[name:[patch-pods::pod-patch-pods]], map[], map[name:[webhook-target-pod]",
+		"path[map[name:[scoped-pods::pod-scoped-pods]], map[], map[name:[pod-patch-pod]",
 	}
 	suite.ElementsMatch(paths, expected)
 }
@@ -327,6 +406,66 @@ func (suite *EdgeTestSuite) TestEdge_POD_CREATE() {
 	suite.Subset(paths, expected)
 }
 
+func (suite *EdgeTestSuite) TestEdge_POD_DEBUG() {
+	// We have one bespoke container running with pods/ephemeralcontainers permissions which should reach all pods in the namespace
+	results, err := suite.g.V().
+		Has("class", "PermissionSet").
+		Has("namespace", "default").
+		OutE().HasLabel("POD_DEBUG").
+		InV().Has("class", "Pod").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[bpf-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[control-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[dac-read-search-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[endpoints-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[host-pvc-write-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[host-read-exploit-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[host-write-exploit-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[impersonate-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[modload-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[netadmin-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[nsenter-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[pod-create-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[pod-debug-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[pod-exec-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[pod-patch-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[priv-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[release-agent-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[rolebind-pod-crb-cr-crb-cr]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[rolebind-pod-crb-cr-crb-r-fail]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[rolebind-pod-crb-cr-rb-cr]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[rolebind-pod-crb-cr-rb-r]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[rolebind-pod-rb-cr-crb-cr-fail]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[rolebind-pod-rb-cr-rb-cr]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[rolebind-pod-rb-cr-rb-r]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[rolebind-pod-rb-r-crb-cr-fail]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[rolebind-pod-rb-r-rb-crb]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[rolebind-pod-rb-r-rb-r]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[runtime-socket-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[share-reader-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[share-writer-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[sharedps-pod1]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[sharedps-pod2]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[sys-ptrace-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[sys-rawio-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[tokenget-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[tokenlist-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[umh-core-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[varlog-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[webhook-inject-pod]",
+		"path[map[name:[debug-pods::pod-debug-pods]], map[], map[name:[webhook-target-pod]",
+	}
+	suite.ElementsMatch(paths, expected)
+}
+
 func (suite *EdgeTestSuite) TestEdge_POD_EXEC() {
 	// We have one bespoke container running with pod/exec permissions which should reach all pods in the namespace
 	results, err := suite.g.V().
@@ -343,8 +482,11 @@ func (suite *EdgeTestSuite) TestEdge_POD_EXEC() {
 
 	paths := suite.pathsToStringArray(results)
 	expected := []string{
+		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[bpf-pod]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[control-pod]",
+		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[dac-read-search-pod]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[endpoints-pod]",
+		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[host-pvc-write-pod]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[host-read-exploit-pod]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[host-write-exploit-pod]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[impersonate-pod]",
@@ -352,9 +494,11 @@ func (suite *EdgeTestSuite) TestEdge_POD_EXEC() {
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[netadmin-pod]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[nsenter-pod]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[pod-create-pod]",
+		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[pod-debug-pod]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[pod-exec-pod]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[pod-patch-pod]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[priv-pod]",
+		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[release-agent-pod]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[rolebind-pod-crb-cr-crb-cr]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[rolebind-pod-crb-cr-crb-r-fail]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[rolebind-pod-crb-cr-rb-cr]",
@@ -365,13 +509,20 @@ func (suite *EdgeTestSuite) TestEdge_POD_EXEC() {
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[rolebind-pod-rb-r-crb-cr-fail]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[rolebind-pod-rb-r-rb-crb]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[rolebind-pod-rb-r-rb-r]",
+		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[runtime-socket-pod]",
+		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[share-reader-pod]",
+		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[share-writer-pod]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[sharedps-pod1]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[sharedps-pod2]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[sys-ptrace-pod]",
+		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[sys-rawio-pod]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[tokenget-pod]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[tokenlist-pod]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[umh-core-pod]",
 		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[varlog-pod]",
+		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[webhook-inject-pod]",
+		"path[map[name:[exec-pods::pod-exec-pods]], map[], map[name:[webhook-target-pod]",
+		"path[map[name:[scoped-pods::pod-scoped-pods]], map[], map[name:[pod-exec-pod]",
 	}
 	suite.ElementsMatch(paths, expected)
 }
@@ -402,10 +553,23 @@ func (suite *EdgeTestSuite) TestEdge_PERMISSION_DISCOVER() {
 
 	paths := suite.pathsToStringArray(results)
 	expected := []string{
+		"path[map[name:[csr-sa]], map[], map[name:[csr-issue-client::pod-csr-issue-client]",
+		"path[map[name:[csr-sa]], map[], map[name:[csr-issue-fail::pod-csr-issue-fail]",
+		"path[map[name:[csr-sa]], map[], map[name:[csr-issue-kubelet::pod-csr-issue-kubelet]",
+		"path[map[name:[escalate-sa]], map[], map[name:[escalate-clusterroles-fail::pod-escalate-clusterroles-fail]",
+		"path[map[name:[escalate-sa]], map[], map[name:[escalate-clusterroles::pod-escalate-clusterroles]",
+		"path[map[name:[escalate-sa]], map[], map[name:[escalate-roles::pod-escalate-roles]",
+		"path[map[name:[impersonate-sa]], map[], map[name:[impersonate-masters::pod-impersonate-masters]",
 		"path[map[name:[impersonate-sa]], map[], map[name:[impersonate::pod-impersonate]",
+		"path[map[name:[nodeproxy-sa]], map[], map[name:[nodes-proxy-scoped::pod-nodes-proxy-scoped]",
+		"path[map[name:[nodeproxy-sa]], map[], map[name:[nodes-proxy::pod-nodes-proxy]",
 		"path[map[name:[pod-create-sa]], map[], map[name:[create-pods::pod-create-pods]",
+		"path[map[name:[pod-debug-sa]], map[], map[name:[debug-pods::pod-debug-pods]",
 		"path[map[name:[pod-exec-sa]], map[], map[name:[exec-pods::pod-exec-pods]",
 		"path[map[name:[pod-patch-sa]], map[], map[name:[patch-pods::pod-patch-pods]",
+		"path[map[name:[scoped-sa]], map[], map[name:[scoped-bind-clusterroles::pod-scoped-bind-clusterroles]",
+		"path[map[name:[scoped-sa]], map[], map[name:[scoped-bind-roles::pod-scoped-bind-roles]",
+		"path[map[name:[scoped-sa]], map[], map[name:[scoped-pods::pod-scoped-pods]",
 		"path[map[name:[rolebind-sa-crb-cr-crb-cr]], map[], map[name:[rolebind-crb-cr-crb-cr::pod-bind-role-crb-cr-crb-cr]",
 		"path[map[name:[rolebind-sa-crb-cr-crb-r-fail]], map[], map[name:[rolebind-crb-cr-crb-r-fail::pod-bind-role-crb-cr-crb-r-fail]",
 		"path[map[name:[rolebind-sa-crb-cr-rb-cr]], map[], map[name:[rolebind-crb-cr-rb-cr::pod-bind-role-crb-cr-rb-cr]",
@@ -419,6 +583,8 @@ func (suite *EdgeTestSuite) TestEdge_PERMISSION_DISCOVER() {
 		"path[map[name:[tokenget-sa]], map[], map[name:[read-secrets::pod-get-secrets]",
 		"path[map[name:[tokenlist-sa]], map[], map[name:[list-secrets::pod-list-secrets]",
 		"path[map[name:[varlog-sa]], map[], map[name:[read-logs::pod-read-logs]",
+		"path[map[name:[webhook-inject-sa]], map[], map[name:[patch-webhooks::webhook-inject-patch-webhooks]",
+		"path[map[name:[workload-patch-sa]], map[], map[name:[patch-deployments::workload-patch-deployments]",
 	}
 
 	suite.ElementsMatch(paths, expected)
@@ -474,6 +640,31 @@ func (suite *EdgeTestSuite) TestEdge_VOLUME_DISCOVER() {
 	suite.Equal(volumeCount, pathCount)
 }
 
+func (suite *EdgeTestSuite) TestEdge_VOLUME_SHARE_WRITE() {
+	results, err := suite.g.V().
+		Has("class", "Container").
+		OutE().HasLabel("VOLUME_SHARE_WRITE").
+		InV().Has("class", "Container").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[share-writer-pod]], map[], map[name:[share-reader-pod]",
+	}
+	suite.Subset(paths, expected)
+
+	// The reader container only has read-only access to the shared host directory
+	notExpected := []string{
+		"path[map[name:[share-reader-pod]], map[], map[name:[share-writer-pod]",
+	}
+	suite.NotSubset(paths, notExpected)
+}
+
 func (suite *EdgeTestSuite) TestEdge_TOKEN_BRUTEFORCE() {
 	results, err := suite.g.V().
 		Has("class", "PermissionSet").
@@ -489,8 +680,12 @@ func (suite *EdgeTestSuite) TestEdge_TOKEN_BRUTEFORCE() {
 
 	paths := suite.pathsToStringArray(results)
 	expected := []string{
+		"path[map[name:[read-secrets::pod-get-secrets]], map[], map[name:[csr-sa]",
+		"path[map[name:[read-secrets::pod-get-secrets]], map[], map[name:[escalate-sa]",
 		"path[map[name:[read-secrets::pod-get-secrets]], map[], map[name:[impersonate-sa]",
+		"path[map[name:[read-secrets::pod-get-secrets]], map[], map[name:[nodeproxy-sa]",
 		"path[map[name:[read-secrets::pod-get-secrets]], map[], map[name:[pod-create-sa]",
+		"path[map[name:[read-secrets::pod-get-secrets]], map[], map[name:[pod-debug-sa]",
 		"path[map[name:[read-secrets::pod-get-secrets]], map[], map[name:[pod-exec-sa]",
 		"path[map[name:[read-secrets::pod-get-secrets]], map[], map[name:[pod-patch-sa]",
 		"path[map[name:[read-secrets::pod-get-secrets]], map[], map[name:[rolebind-sa-crb-cr-crb-cr]",
@@ -503,9 +698,13 @@ func (suite *EdgeTestSuite) TestEdge_TOKEN_BRUTEFORCE() {
 		"path[map[name:[read-secrets::pod-get-secrets]], map[], map[name:[rolebind-sa-rb-r-crb-cr-fail]",
 		"path[map[name:[read-secrets::pod-get-secrets]], map[], map[name:[rolebind-sa-rb-r-rb-crb]",
 		"path[map[name:[read-secrets::pod-get-secrets]], map[], map[name:[rolebind-sa-rb-r-rb-r]",
+		"path[map[name:[read-secrets::pod-get-secrets]], map[], map[name:[scoped-sa]",
 		"path[map[name:[read-secrets::pod-get-secrets]], map[], map[name:[tokenget-sa]",
 		"path[map[name:[read-secrets::pod-get-secrets]], map[], map[name:[tokenlist-sa]",
 		"path[map[name:[read-secrets::pod-get-secrets]], map[], map[name:[varlog-sa]",
+		"path[map[name:[read-secrets::pod-get-secrets]], map[], map[name:[webhook-inject-sa]",
+		"path[map[name:[read-secrets::pod-get-secrets]], map[], map[name:[workload-patch-sa]",
+		"path[map[name:[scoped-pods::pod-scoped-pods]], map[], map[name:[tokenget-sa]",
 	}
 	suite.ElementsMatch(paths, expected)
 }
@@ -525,8 +724,12 @@ func (suite *EdgeTestSuite) TestEdge_TOKEN_LIST() {
 
 	paths := suite.pathsToStringArray(results)
 	expected := []string{
+		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[csr-sa]",
+		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[escalate-sa]",
 		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[impersonate-sa]",
+		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[nodeproxy-sa]",
 		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[pod-create-sa]",
+		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[pod-debug-sa]",
 		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[pod-exec-sa]",
 		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[pod-patch-sa]",
 		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[rolebind-sa-crb-cr-crb-cr]",
@@ -539,16 +742,20 @@ func (suite *EdgeTestSuite) TestEdge_TOKEN_LIST() {
 		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[rolebind-sa-rb-r-crb-cr-fail]",
 		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[rolebind-sa-rb-r-rb-crb]",
 		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[rolebind-sa-rb-r-rb-r]",
+		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[scoped-sa]",
 		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[tokenget-sa]",
 		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[tokenlist-sa]",
 		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[varlog-sa]",
+		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[webhook-inject-sa]",
+		"path[map[name:[list-secrets::pod-list-secrets]], map[], map[name:[workload-patch-sa]",
+		"path[map[name:[scoped-pods::pod-scoped-pods]], map[], map[name:[tokenget-sa]",
 	}
 	suite.ElementsMatch(paths, expected)
 }
 
 func (suite *EdgeTestSuite) TestEdge_TOKEN_STEAL() {
-	// Every pod in our test cluster should have projected volume holding a token. BUT we only
-	// save those with a non-default service account token as shown below.
+	// Every pod in our test cluster should have projected volume holding a token, except those with
+	// automount disabled (e.g the control pod). Many pods run under the default service account.
 	results, err := suite.g.V().
 		Has("class", "Volume").
 		OutE().
@@ -557,6 +764,7 @@ func (suite *EdgeTestSuite) TestEdge_TOKEN_STEAL() {
 		Has("class", "Identity").
 		Has("namespace", "default").
 		Values("name").
+		Dedup().
 		ToList()
 
 	suite.NoError(err)
@@ -564,8 +772,10 @@ func (suite *EdgeTestSuite) TestEdge_TOKEN_STEAL() {
 
 	identities := suite.resultsToStringArray(results)
 	expected := []string{
+		"default",
 		"impersonate-sa",
 		"pod-create-sa",
+		"pod-debug-sa",
 		"pod-exec-sa",
 		"pod-patch-sa",
 		"rolebind-sa-crb-cr-crb-cr",
@@ -581,6 +791,7 @@ func (suite *EdgeTestSuite) TestEdge_TOKEN_STEAL() {
 		"tokenget-sa",
 		"tokenlist-sa",
 		"varlog-sa",
+		"webhook-inject-sa",
 	}
 	suite.ElementsMatch(identities, expected)
 }
@@ -604,6 +815,18 @@ func (suite *EdgeTestSuite) TestEdge_EXPLOIT_HOST_READ() {
 		"path[map[name:[host-read-exploit-pod]], map[], map[name:[host-ssh]",
 	}
 	suite.Subset(paths, expected)
+
+	// The reason of the matching unsafe read rule is recorded on the edge
+	results, err = suite.g.V().
+		Has("class", "Volume").
+		Has("name", "host-ssh").
+		OutE().HasLabel("EXPLOIT_HOST_READ").
+		Values("mountReason").
+		Dedup().
+		ToList()
+
+	suite.NoError(err)
+	suite.Equal([]string{"root SSH keys"}, suite.resultsToStringArray(results))
 }
 
 func (suite *EdgeTestSuite) TestEdge_EXPLOIT_HOST_WRITE() {
@@ -622,6 +845,7 @@ func (suite *EdgeTestSuite) TestEdge_EXPLOIT_HOST_WRITE() {
 
 	paths := suite.pathsToStringArray(results)
 	expected := []string{
+		"path[map[name:[host-pvc-write-pod]], map[], map[name:[host-pvc]",
 		"path[map[name:[host-write-exploit-pod]], map[], map[name:[hostroot]",
 	}
 	suite.Subset(paths, expected)
@@ -689,6 +913,24 @@ func (suite *EdgeTestSuite) TestEdge_ENDPOINT_EXPLOIT_ContainerPort() {
 	suite.Subset(paths, expected)
 }
 
+func (suite *EdgeTestSuite) TestEdge_ENDPOINT_EXPLOIT_NetworkPolicy() {
+	// The webproxy-allow-http network policy only allows ingress traffic to the service port of the endpoints pod
+	results, err := suite.g.V().
+		Has("class", "Endpoint").
+		Has("exposure", P.Eq(int(shared.EndpointExposureClusterIP))).
+		Has("serviceEndpoint", "jmx").
+		OutE("ENDPOINT_EXPLOIT").
+		Values("networkPolicyBlocked").
+		ToList()
+
+	suite.NoError(err)
+	suite.Equal(1, len(results))
+
+	blocked, err := results[0].GetBool()
+	suite.NoError(err)
+	suite.True(blocked)
+}
+
 func (suite *EdgeTestSuite) TestEdge_ENDPOINT_EXPLOIT_NodePort() {
 	results, err := suite.g.V().
 		Has("class", "Endpoint").
@@ -762,6 +1004,158 @@ func (suite *EdgeTestSuite) TestEdge_SHARE_PS_NAMESPACE() {
 	suite.ElementsMatch(paths, expected)
 }
 
+func (suite *EdgeTestSuite) TestEdge_SECRET_MOUNT() {
+	results, err := suite.g.V().
+		Has("class", "Volume").
+		OutE().HasLabel("SECRET_MOUNT").
+		InV().Has("class", "Secret").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[secretmount-volume]], map[], map[name:[secretmount-secret]",
+	}
+	suite.Subset(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_WORKLOAD_SPAWN() {
+	// The kube-proxy daemonset spawns one pod on each of the test cluster nodes
+	results, err := suite.g.V().
+		Has("class", "Workload").
+		Has("kind", "DaemonSet").
+		Has("name", "kube-proxy").
+		OutE().HasLabel("WORKLOAD_SPAWN").
+		InV().Has("class", "Pod").
+		ToList()
+
+	suite.NoError(err)
+	suite.Equal(3, len(results))
+}
+
+func (suite *EdgeTestSuite) TestEdge_WORKLOAD_PATCH() {
+	// We have one bespoke service account with deployment/patch permissions in the default namespace
+	results, err := suite.g.V().
+		Has("class", "PermissionSet").
+		Has("namespace", "default").
+		OutE().HasLabel("WORKLOAD_PATCH").
+		InV().Has("class", "Workload").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[patch-deployments::workload-patch-deployments]], map[], map[name:[workload-patch-deployment]",
+	}
+	suite.ElementsMatch(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_WEBHOOK_INJECT_PermissionSet() {
+	// We have one bespoke service account with mutatingwebhookconfigurations/patch permissions which should reach
+	// the pods intercepted by the existing mutating webhooks
+	results, err := suite.g.V().
+		Has("class", "PermissionSet").
+		Has("name", "patch-webhooks::webhook-inject-patch-webhooks").
+		OutE().HasLabel("WEBHOOK_INJECT").
+		InV().Has("class", "Pod").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[patch-webhooks::webhook-inject-patch-webhooks]], map[], map[name:[webhook-target-pod]",
+	}
+	suite.ElementsMatch(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_WEBHOOK_INJECT_Webhook() {
+	// The test webhook only intercepts pods labelled with kubehound-webhook=target
+	results, err := suite.g.V().
+		Has("class", "Webhook").
+		OutE().HasLabel("WEBHOOK_INJECT").
+		InV().Has("class", "Pod").
+		Has("namespace", "default").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[inject.kubehound.example.com]], map[], map[name:[webhook-target-pod]",
+	}
+	suite.ElementsMatch(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_NAMESPACE_DISCOVER() {
+	results, err := suite.g.V().
+		Has("class", "Pod").
+		Has("name", gremlingo.P.Within("pod-create-pod", "webhook-target-pod")).
+		OutE().HasLabel("NAMESPACE_DISCOVER").
+		InV().Has("class", "Namespace").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[pod-create-pod]], map[], map[name:[default]",
+		"path[map[name:[webhook-target-pod]], map[], map[name:[default]",
+	}
+	suite.ElementsMatch(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_NAMESPACE_PATCH() {
+	// We have one bespoke role with namespaces/patch permissions in the psa-restricted namespace, which should only
+	// reach its own namespace
+	results, err := suite.g.V().
+		Has("class", "PermissionSet").
+		Has("namespace", "psa-restricted").
+		OutE().HasLabel("NAMESPACE_PATCH").
+		InV().Has("class", "Namespace").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[patch-namespace::namespace-patch-namespace]], map[], map[name:[psa-restricted]",
+	}
+	suite.ElementsMatch(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_POD_CREATE_PodSecurity() {
+	// The psa-restricted namespace enforces the restricted pod security level, hence the pods/create permission of
+	// the bespoke role cannot be used to create a privileged pod
+	results, err := suite.g.V().
+		Has("class", "PermissionSet").
+		Has("namespace", "psa-restricted").
+		OutE().HasLabel("POD_CREATE").
+		ToList()
+
+	suite.NoError(err)
+	suite.Equal(len(results), 0)
+}
+
 // Case 1 (cf docs)
 func (suite *EdgeTestSuite) TestEdge_ROLE_BIND_CASE_1() {
 	results, err := suite.g.V().
@@ -914,6 +1308,152 @@ func (suite *EdgeTestSuite) TestEdge_ROLE_BIND_CASE_4() {
 	suite.ElementsMatch(paths, expected)
 }
 
+func (suite *EdgeTestSuite) TestEdge_CSR_ISSUE() {
+	results, err := suite.g.V().
+		Has("class", "PermissionSet").
+		Has("name", gremlingo.TextP.StartingWith("csr-issue")).
+		OutE().HasLabel("CSR_ISSUE").
+		InV().Has("class", "Identity").
+		// Scoping only to the identities related to the attacks to avoid dependency on the Kind Cluster default roles
+		Has("name", gremlingo.P.Within("system:masters", "system:nodes")).
+		Path().
+		By(__.ValueMap("name")).
+		By(__.ValueMap("signerName")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	// Approving requests for a custom signer does not result in an issued certificate
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[csr-issue-client::pod-csr-issue-client]], map[signerName:kubernetes.io/kube-apiserver-client], map[name:[system:masters]",
+		"path[map[name:[csr-issue-client::pod-csr-issue-client]], map[signerName:kubernetes.io/kube-apiserver-client], map[name:[system:nodes]",
+		"path[map[name:[csr-issue-kubelet::pod-csr-issue-kubelet]], map[signerName:kubernetes.io/kube-apiserver-client-kubelet], map[name:[system:nodes]",
+	}
+	suite.ElementsMatch(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_NODE_PROXY_EXEC() {
+	results, err := suite.g.V().
+		Has("class", "PermissionSet").
+		Has("name", gremlingo.TextP.StartingWith("nodes-proxy")).
+		OutE().HasLabel("NODE_PROXY_EXEC").
+		InV().Has("class", "Node").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	// Access to the nodes/log and nodes/stats subresources does not allow to reach the kubelet exec endpoints
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[nodes-proxy::pod-nodes-proxy]], map[], map[name:[kubehound.test.local-control-plane]",
+		"path[map[name:[nodes-proxy::pod-nodes-proxy]], map[], map[name:[kubehound.test.local-worker]",
+		"path[map[name:[nodes-proxy::pod-nodes-proxy]], map[], map[name:[kubehound.test.local-worker2]",
+		"path[map[name:[nodes-proxy-scoped::pod-nodes-proxy-scoped]], map[], map[name:[kubehound.test.local-worker]",
+	}
+	suite.ElementsMatch(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_RESOURCE_SCOPED() {
+	results, err := suite.g.V().
+		Has("class", "PermissionSet").
+		Has("name", gremlingo.TextP.StartingWith("scoped-")).
+		OutE().Has("resourceScoped", true).
+		InV().
+		Path().
+		By(__.ValueMap("name")).
+		By(__.Label()).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	// Only the resources named in the rules are reachable, and the create verb is never restricted for bindings
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[scoped-bind-clusterroles::pod-scoped-bind-clusterroles]], ROLE_BIND, map[name:[nodes-proxy::pod-nodes-proxy]",
+		"path[map[name:[scoped-bind-roles::pod-scoped-bind-roles]], ROLE_BIND, map[name:[list-secrets::pod-list-secrets]",
+		"path[map[name:[scoped-pods::pod-scoped-pods]], POD_EXEC, map[name:[pod-exec-pod]",
+		"path[map[name:[scoped-pods::pod-scoped-pods]], POD_PATCH, map[name:[pod-patch-pod]",
+		"path[map[name:[scoped-pods::pod-scoped-pods]], TOKEN_BRUTEFORCE, map[name:[tokenget-sa]",
+		"path[map[name:[scoped-pods::pod-scoped-pods]], TOKEN_LIST, map[name:[tokenget-sa]",
+	}
+	suite.ElementsMatch(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_ROLE_ESCALATE_Cluster() {
+	results, err := suite.g.V().
+		Has("class", "PermissionSet").
+		Has("isNamespaced", false).
+		OutE().HasLabel("ROLE_ESCALATE").
+		InV().Has("class", "PermissionSet").
+		Has("isNamespaced", false).
+		// Scoping only to the roles related to the attacks to avoid dependency on the Kind Cluster default roles
+		Has("name", gremlingo.TextP.StartingWith("escalate")).
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[escalate-clusterroles::pod-escalate-clusterroles]], map[], map[name:[escalate-clusterroles-fail::pod-escalate-clusterroles-fail]",
+		"path[map[name:[escalate-clusterroles::pod-escalate-clusterroles]], map[], map[name:[escalate-clusterroles::pod-escalate-clusterroles]",
+	}
+	suite.ElementsMatch(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_ROLE_ESCALATE_Namespace() {
+	results, err := suite.g.V().
+		Has("class", "PermissionSet").
+		Has("isNamespaced", true).
+		OutE().HasLabel("ROLE_ESCALATE").
+		InV().Has("class", "PermissionSet").
+		// Permission sets binding a cluster role cannot be escalated via roles
+		Has("name", gremlingo.P.Within("impersonate::pod-impersonate", "rolebind-rb-cr-rb-r::pod-bind-role-rb-cr-rb-r")).
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[escalate-roles::pod-escalate-roles]], map[], map[name:[impersonate::pod-impersonate]",
+	}
+	suite.ElementsMatch(paths, expected)
+}
+
+func (suite *EdgeTestSuite) TestEdge_IDENTITY_IMPERSONATE() {
+	results, err := suite.g.V().
+		Has("class", "PermissionSet").
+		Has("name", gremlingo.TextP.StartingWith("impersonate")).
+		OutE().HasLabel("IDENTITY_IMPERSONATE").
+		Has("resourceScoped", true).
+		InV().Has("class", "Identity").
+		Path().
+		By(__.ValueMap("name")).
+		ToList()
+
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(results), 1)
+
+	// Users and groups cannot be impersonated through a namespaced role
+	paths := suite.pathsToStringArray(results)
+	expected := []string{
+		"path[map[name:[impersonate-masters::pod-impersonate-masters]], map[], map[name:[system:masters]",
+		"path[map[name:[impersonate::pod-impersonate]], map[], map[name:[tokenget-sa]",
+	}
+	suite.ElementsMatch(paths, expected)
+}
+
 func (suite *EdgeTestSuite) Test_NoEdgeCase() {
 	// The control pod has no interesting properties and therefore should have NO outgoing edges
 	results, err := suite.g.V().
@@ -154,6 +154,9 @@ func (suite *VertexTestSuite) TestVertexContainer() {
 		runAsUser, ok := converted["runAsUser"].(int64)
 		suite.True(ok, "failed to convert compromised field to CompromiseType")
 
+		isInit, ok := converted["init"].(bool)
+		suite.True(ok, "failed to convert init field to bool")
+
 		// We skip these because they are built by Kind itself
 		if slices.Contains(containerToSkip, containerName) {
 			continue
@@ -174,6 +177,7 @@ func (suite *VertexTestSuite) TestVertexContainer() {
 			RunAsUser:    runAsUser,
 			Ports:        []string{},
 			Pod:          podName,
+			Init:         isInit,
 			Namespace:    namespace,
 			// Node:         nodeName, // see comments for converted["node"].(string)
 			Compromised: shared.CompromiseType(compromised),
@@ -316,9 +320,9 @@ func (suite *VertexTestSuite) TestVertexVolume() {
 	// Note: the value differs between CI and local. I am not sure if the different is from the kind
 	// version (brew is 0.30, github action v1.12.0 is kind 0.26) or environment (macos arm64 vs ubuntu x64)
 	if runtime.GOOS == "darwin" {
-		suite.Equal(64, len(results))
+		suite.Equal(87, len(results))
 	} else {
-		suite.Equal(61, len(results))
+		suite.Equal(84, len(results))
 	}
 
 	results, err = suite.g.V().Has("class", vertex.VolumeLabel).Has("sourcePath", "/proc/sys/kernel").Has("name", "nodeproc").ElementMap().ToList()
@@ -332,6 +336,12 @@ func (suite *VertexTestSuite) TestVertexVolume() {
 	results, err = suite.g.V().Has("class", vertex.VolumeLabel).Has("sourcePath", "/var/log").Has("name", "nodelog").ElementMap().ToList()
 	suite.NoError(err)
 	suite.Equal(len(results), 1)
+
+	// Persistent volume claims are resolved to the backing persistent volume
+	results, err = suite.g.V().Has("class", vertex.VolumeLabel).Has("type", "PersistentVolumeClaim").Has("name", "host-pvc").
+		Has("claimName", "host-pvc-claim").Has("backingType", "HostPath").Has("sourcePath", "/etc").ElementMap().ToList()
+	suite.NoError(err)
+	suite.Equal(1, len(results))
 }
 
 func (suite *VertexTestSuite) TestVertexIdentity() {
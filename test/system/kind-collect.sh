@@ -21,12 +21,24 @@ RESOURCES_ALLOWLIST=(
     pods
     roles*
     rolebinding*
+    serviceaccounts
+    deployments.apps
+    daemonsets.apps
+    statefulsets.apps
+    jobs.batch
+    cronjobs.batch
+    networkpolicies.networking.k8s.io
+    persistentvolumeclaims
 )
 
 CLUSTER_RESOURCES=(
     nodes
     clusterroles.rbac.authorization.k8s.io
     clusterrolebindings.rbac.authorization.k8s.io
+    persistentvolumes
+    mutatingwebhookconfigurations.admissionregistration.k8s.io
+    validatingwebhookconfigurations.admissionregistration.k8s.io
+    namespaces
 )
 
 #
@@ -1,5 +1,5 @@
 // PLEASE DO NOT EDIT
-// THIS HAS BEEN GENERATED AUTOMATICALLY on 2025-09-09 16:04
+// THIS HAS BEEN GENERATED AUTOMATICALLY on 2026-10-17 11:09
 //
 // Generate it with "go generate ./..."
 //
@@ -19,6 +19,16 @@ import (
 )
 
 var expectedPods = map[string]graph.Pod{
+	"bpf-pod": {
+		StoreID:               "",
+		Name:                  "bpf-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
 	"control-pod": {
 		StoreID:               "",
 		Name:                  "control-pod",
@@ -29,6 +39,16 @@ var expectedPods = map[string]graph.Pod{
 		ShareProcessNamespace: false,
 		Critical:              false,
 	},
+	"dac-read-search-pod": {
+		StoreID:               "",
+		Name:                  "dac-read-search-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
 	"endpoints-pod": {
 		StoreID:               "",
 		Name:                  "endpoints-pod",
@@ -39,6 +59,16 @@ var expectedPods = map[string]graph.Pod{
 		ShareProcessNamespace: false,
 		Critical:              false,
 	},
+	"host-pvc-write-pod": {
+		StoreID:               "",
+		Name:                  "host-pvc-write-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
 	"host-read-exploit-pod": {
 		StoreID:               "",
 		Name:                  "host-read-exploit-pod",
@@ -109,6 +139,16 @@ var expectedPods = map[string]graph.Pod{
 		ShareProcessNamespace: false,
 		Critical:              false,
 	},
+	"pod-debug-pod": {
+		StoreID:               "",
+		Name:                  "pod-debug-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "pod-debug-sa",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
 	"pod-exec-pod": {
 		StoreID:               "",
 		Name:                  "pod-exec-pod",
@@ -139,6 +179,16 @@ var expectedPods = map[string]graph.Pod{
 		ShareProcessNamespace: false,
 		Critical:              false,
 	},
+	"release-agent-pod": {
+		StoreID:               "",
+		Name:                  "release-agent-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
 	"rolebind-pod-crb-cr-crb-cr": {
 		StoreID:               "",
 		Name:                  "rolebind-pod-crb-cr-crb-cr",
@@ -239,6 +289,46 @@ var expectedPods = map[string]graph.Pod{
 		ShareProcessNamespace: false,
 		Critical:              false,
 	},
+	"runtime-socket-pod": {
+		StoreID:               "",
+		Name:                  "runtime-socket-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
+	"secretmount-pod": {
+		StoreID:               "",
+		Name:                  "secretmount-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
+	"share-reader-pod": {
+		StoreID:               "",
+		Name:                  "share-reader-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
+	"share-writer-pod": {
+		StoreID:               "",
+		Name:                  "share-writer-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
 	"sharedps-pod1": {
 		StoreID:               "",
 		Name:                  "sharedps-pod1",
@@ -269,6 +359,16 @@ var expectedPods = map[string]graph.Pod{
 		ShareProcessNamespace: false,
 		Critical:              false,
 	},
+	"sys-rawio-pod": {
+		StoreID:               "",
+		Name:                  "sys-rawio-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
 	"tokenget-pod": {
 		StoreID:               "",
 		Name:                  "tokenget-pod",
@@ -319,6 +419,26 @@ var expectedPods = map[string]graph.Pod{
 		ShareProcessNamespace: false,
 		Critical:              false,
 	},
+	"webhook-inject-pod": {
+		StoreID:               "",
+		Name:                  "webhook-inject-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "webhook-inject-sa",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
+	"webhook-target-pod": {
+		StoreID:               "",
+		Name:                  "webhook-target-pod",
+		IsNamespaced:          true,
+		Namespace:             "default",
+		Compromised:           shared.CompromiseNone,
+		ServiceAccount:        "default",
+		ShareProcessNamespace: false,
+		Critical:              false,
+	},
 }
 
 var expectedNodes = map[string]graph.Node{
@@ -349,6 +469,15 @@ var expectedNodes = map[string]graph.Node{
 }
 
 var expectedVolumes = map[string]graph.Volume{
+	"containerd-sock": {
+		StoreID:    "",
+		Name:       "containerd-sock",
+		Type:       "",
+		SourcePath: "",
+		MountPath:  "/host/run/containerd/containerd.sock",
+		Readonly:   true,
+		Namespace:  "default",
+	},
 	"host-pod-dir": {
 		StoreID:    "",
 		Name:       "host-pod-dir",
@@ -358,6 +487,15 @@ var expectedVolumes = map[string]graph.Volume{
 		Readonly:   true,
 		Namespace:  "default",
 	},
+	"host-pvc": {
+		StoreID:    "",
+		Name:       "host-pvc",
+		Type:       "",
+		SourcePath: "",
+		MountPath:  "/host/etc",
+		Readonly:   false,
+		Namespace:  "default",
+	},
 	"host-ssh": {
 		StoreID:    "",
 		Name:       "host-ssh",
@@ -367,6 +505,15 @@ var expectedVolumes = map[string]graph.Volume{
 		Readonly:   true,
 		Namespace:  "default",
 	},
+	"hostproc": {
+		StoreID:    "",
+		Name:       "hostproc",
+		Type:       "",
+		SourcePath: "",
+		MountPath:  "/host/proc",
+		Readonly:   true,
+		Namespace:  "default",
+	},
 	"hostroot": {
 		StoreID:    "",
 		Name:       "hostroot",
@@ -394,9 +541,56 @@ var expectedVolumes = map[string]graph.Volume{
 		Readonly:   false,
 		Namespace:  "default",
 	},
+	"secretmount-volume": {
+		StoreID:    "",
+		Name:       "secretmount-volume",
+		Type:       "",
+		SourcePath: "",
+		MountPath:  "/secrets/",
+		Readonly:   true,
+		Namespace:  "default",
+	},
+	"shared-data": {
+		StoreID:    "",
+		Name:       "shared-data",
+		Type:       "",
+		SourcePath: "",
+		MountPath:  "/shared",
+		Readonly:   false,
+		Namespace:  "default",
+	},
+	"shared-data-ro": {
+		StoreID:    "",
+		Name:       "shared-data-ro",
+		Type:       "",
+		SourcePath: "",
+		MountPath:  "/shared",
+		Readonly:   true,
+		Namespace:  "default",
+	},
 }
 
 var expectedContainers = map[string]graph.Container{
+	"bpf-pod": {
+		StoreID:      "",
+		Name:         "bpf-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Namespace:    "default",
+		Ports:        []string{},
+		Pod:          "bpf-pod",
+		Init:         false,
+		// Node:         "",
+		Compromised: 0,
+	},
 	"control-pod": {
 		StoreID:      "",
 		Name:         "control-pod",
@@ -413,6 +607,27 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "control-pod",
+		Init:         false,
+		// Node:         "",
+		Compromised: 0,
+	},
+	"dac-read-search-pod": {
+		StoreID:      "",
+		Name:         "dac-read-search-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Namespace:    "default",
+		Ports:        []string{},
+		Pod:          "dac-read-search-pod",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -432,6 +647,27 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "endpoints-pod",
+		Init:         false,
+		// Node:         "",
+		Compromised: 0,
+	},
+	"host-pvc-write-pod": {
+		StoreID:      "",
+		Name:         "host-pvc-write-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Namespace:    "default",
+		Ports:        []string{},
+		Pod:          "host-pvc-write-pod",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -451,6 +687,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "host-read-exploit-pod",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -470,6 +707,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "host-write-exploit-pod",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -489,6 +727,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "impersonate-pod",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -508,6 +747,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "modload-pod",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -527,6 +767,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "netadmin-pod",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -546,6 +787,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "nsenter-pod",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -565,6 +807,27 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "pod-create-pod",
+		Init:         false,
+		// Node:         "",
+		Compromised: 0,
+	},
+	"pod-debug-pod": {
+		StoreID:      "",
+		Name:         "pod-debug-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Namespace:    "default",
+		Ports:        []string{},
+		Pod:          "pod-debug-pod",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -584,6 +847,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "pod-exec-pod",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -603,6 +867,27 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "pod-patch-pod",
+		Init:         false,
+		// Node:         "",
+		Compromised: 0,
+	},
+	"priv-init-container": {
+		StoreID:      "",
+		Name:         "priv-init-container",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   true,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Namespace:    "default",
+		Ports:        []string{},
+		Pod:          "priv-pod",
+		Init:         true,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -622,6 +907,27 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "priv-pod",
+		Init:         false,
+		// Node:         "",
+		Compromised: 0,
+	},
+	"release-agent-pod": {
+		StoreID:      "",
+		Name:         "release-agent-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Namespace:    "default",
+		Ports:        []string{},
+		Pod:          "release-agent-pod",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -641,6 +947,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "rolebind-pod-crb-cr-crb-cr",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -660,6 +967,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "rolebind-pod-crb-cr-crb-r-fail",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -679,6 +987,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "rolebind-pod-crb-cr-rb-cr",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -698,6 +1007,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "rolebind-pod-crb-cr-rb-r",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -717,6 +1027,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "rolebind-pod-rb-cr-crb-cr-fail",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -736,6 +1047,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "rolebind-pod-rb-cr-rb-cr",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -755,6 +1067,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "rolebind-pod-rb-cr-rb-r",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -774,6 +1087,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "rolebind-pod-rb-r-crb-cr-fail",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -793,6 +1107,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "rolebind-pod-rb-r-rb-crb",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -812,6 +1127,87 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "rolebind-pod-rb-r-rb-r",
+		Init:         false,
+		// Node:         "",
+		Compromised: 0,
+	},
+	"runtime-socket-pod": {
+		StoreID:      "",
+		Name:         "runtime-socket-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Namespace:    "default",
+		Ports:        []string{},
+		Pod:          "runtime-socket-pod",
+		Init:         false,
+		// Node:         "",
+		Compromised: 0,
+	},
+	"secretmount-pod": {
+		StoreID:      "",
+		Name:         "secretmount-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Namespace:    "default",
+		Ports:        []string{},
+		Pod:          "secretmount-pod",
+		Init:         false,
+		// Node:         "",
+		Compromised: 0,
+	},
+	"share-reader-pod": {
+		StoreID:      "",
+		Name:         "share-reader-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Namespace:    "default",
+		Ports:        []string{},
+		Pod:          "share-reader-pod",
+		Init:         false,
+		// Node:         "",
+		Compromised: 0,
+	},
+	"share-writer-pod": {
+		StoreID:      "",
+		Name:         "share-writer-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Namespace:    "default",
+		Ports:        []string{},
+		Pod:          "share-writer-pod",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -831,6 +1227,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "sharedps-pod1",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -850,6 +1247,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "sharedps-pod1",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -869,6 +1267,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "sharedps-pod1",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -888,6 +1287,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "sharedps-pod2",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -907,6 +1307,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "sharedps-pod2",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -926,6 +1327,27 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "sys-ptrace-pod",
+		Init:         false,
+		// Node:         "",
+		Compromised: 0,
+	},
+	"sys-rawio-pod": {
+		StoreID:      "",
+		Name:         "sys-rawio-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Namespace:    "default",
+		Ports:        []string{},
+		Pod:          "sys-rawio-pod",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -945,6 +1367,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "tokenget-pod",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -964,6 +1387,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "tokenlist-pod",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -983,6 +1407,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "umh-core-pod",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -1002,6 +1427,7 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "default",
 		Ports:        []string{},
 		Pod:          "varlog-pod",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -1021,6 +1447,47 @@ var expectedContainers = map[string]graph.Container{
 		Namespace:    "vault",
 		Ports:        []string{},
 		Pod:          "vault-pod",
+		Init:         false,
+		// Node:         "",
+		Compromised: 0,
+	},
+	"webhook-inject-pod": {
+		StoreID:      "",
+		Name:         "webhook-inject-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Namespace:    "default",
+		Ports:        []string{},
+		Pod:          "webhook-inject-pod",
+		Init:         false,
+		// Node:         "",
+		Compromised: 0,
+	},
+	"webhook-target-pod": {
+		StoreID:      "",
+		Name:         "webhook-target-pod",
+		Image:        "ubuntu",
+		Command:      []string{},
+		Args:         []string{},
+		Capabilities: []string{},
+		Privileged:   false,
+		PrivEsc:      false,
+		HostPID:      false,
+		HostIPC:      false,
+		HostNetwork:  false,
+		RunAsUser:    0,
+		Namespace:    "default",
+		Ports:        []string{},
+		Pod:          "webhook-target-pod",
+		Init:         false,
 		// Node:         "",
 		Compromised: 0,
 	},
@@ -1037,6 +1504,26 @@ var expectedPermissionSets = map[string]graph.PermissionSet{
 		RoleBinding:  "pod-create-pods",
 		Critical:     false,
 	},
+	"debug-pods::pod-debug-pods": {
+		StoreID:      "",
+		Name:         "debug-pods::pod-debug-pods",
+		IsNamespaced: true,
+		Namespace:    "default",
+		Role:         "debug-pods",
+		Rules:        []string{"API()::R(pods,pods/log)::N()::V(get,list)", "API()::R(pods/ephemeralcontainers)::N()::V(patch)"},
+		RoleBinding:  "pod-debug-pods",
+		Critical:     false,
+	},
+	"escalate-roles::pod-escalate-roles": {
+		StoreID:      "",
+		Name:         "escalate-roles::pod-escalate-roles",
+		IsNamespaced: true,
+		Namespace:    "default",
+		Role:         "escalate-roles",
+		Rules:        []string{"API(rbac.authorization.k8s.io)::R(roles)::N()::V(escalate,patch)"},
+		RoleBinding:  "pod-escalate-roles",
+		Critical:     false,
+	},
 	"exec-pods::pod-exec-pods": {
 		StoreID:      "",
 		Name:         "exec-pods::pod-exec-pods",
@@ -1053,7 +1540,7 @@ var expectedPermissionSets = map[string]graph.PermissionSet{
 		IsNamespaced: true,
 		Namespace:    "default",
 		Role:         "impersonate",
-		Rules:        []string{"API(*)::R(users,groups)::N()::V(impersonate)"},
+		Rules:        []string{"API(*)::R(users,groups)::N()::V(impersonate)", "API()::R(serviceaccounts)::N(tokenget-sa)::V(impersonate)"},
 		RoleBinding:  "pod-impersonate",
 		Critical:     false,
 	},
@@ -1067,6 +1554,26 @@ var expectedPermissionSets = map[string]graph.PermissionSet{
 		RoleBinding:  "pod-list-secrets",
 		Critical:     false,
 	},
+	"patch-deployments::workload-patch-deployments": {
+		StoreID:      "",
+		Name:         "patch-deployments::workload-patch-deployments",
+		IsNamespaced: true,
+		Namespace:    "default",
+		Role:         "patch-deployments",
+		Rules:        []string{"API(apps)::R(deployments)::N()::V(get,list,patch)"},
+		RoleBinding:  "workload-patch-deployments",
+		Critical:     false,
+	},
+	"patch-namespace::namespace-patch-namespace": {
+		StoreID:      "",
+		Name:         "patch-namespace::namespace-patch-namespace",
+		IsNamespaced: true,
+		Namespace:    "psa-restricted",
+		Role:         "patch-namespace",
+		Rules:        []string{"API()::R(namespaces)::N()::V(get,patch)", "API()::R(pods)::N()::V(create)"},
+		RoleBinding:  "namespace-patch-namespace",
+		Critical:     false,
+	},
 	"patch-pods::pod-patch-pods": {
 		StoreID:      "",
 		Name:         "patch-pods::pod-patch-pods",
@@ -1217,9 +1724,45 @@ var expectedPermissionSets = map[string]graph.PermissionSet{
 		RoleBinding:  "pod-bind-role-rb-r-rb-r-u",
 		Critical:     false,
 	},
+	"scoped-bind-roles::pod-scoped-bind-roles": {
+		StoreID:      "",
+		Name:         "scoped-bind-roles::pod-scoped-bind-roles",
+		IsNamespaced: true,
+		Namespace:    "default",
+		Role:         "scoped-bind-roles",
+		Rules:        []string{"API(rbac.authorization.k8s.io)::R(rolebindings)::N()::V(create)", "API(rbac.authorization.k8s.io)::R(roles)::N(list-secrets)::V(bind)"},
+		RoleBinding:  "pod-scoped-bind-roles",
+		Critical:     false,
+	},
+	"scoped-pods::pod-scoped-pods": {
+		StoreID:      "",
+		Name:         "scoped-pods::pod-scoped-pods",
+		IsNamespaced: true,
+		Namespace:    "default",
+		Role:         "scoped-pods",
+		Rules:        []string{"API()::R(pods/exec)::N(pod-exec-pod)::V(create)", "API()::R(pods)::N(pod-patch-pod)::V(patch)", "API()::R(secrets)::N(tokenget-sa-token-kubehound)::V(get,list)"},
+		RoleBinding:  "pod-scoped-pods",
+		Critical:     false,
+	},
 }
 
 var expectedIdentities = map[string]graph.Identity{
+	"csr-sa": {
+		StoreID:      "",
+		Name:         "csr-sa",
+		IsNamespaced: true,
+		Namespace:    "default",
+		Type:         "ServiceAccount",
+		Critical:     false,
+	},
+	"escalate-sa": {
+		StoreID:      "",
+		Name:         "escalate-sa",
+		IsNamespaced: true,
+		Namespace:    "default",
+		Type:         "ServiceAccount",
+		Critical:     false,
+	},
 	"group-rb-r-crb-cr-fail": {
 		StoreID:      "",
 		Name:         "group-rb-r-crb-cr-fail",
@@ -1252,6 +1795,22 @@ var expectedIdentities = map[string]graph.Identity{
 		Type:         "ServiceAccount",
 		Critical:     false,
 	},
+	"namespace-patch-sa": {
+		StoreID:      "",
+		Name:         "namespace-patch-sa",
+		IsNamespaced: true,
+		Namespace:    "psa-restricted",
+		Type:         "ServiceAccount",
+		Critical:     false,
+	},
+	"nodeproxy-sa": {
+		StoreID:      "",
+		Name:         "nodeproxy-sa",
+		IsNamespaced: true,
+		Namespace:    "default",
+		Type:         "ServiceAccount",
+		Critical:     false,
+	},
 	"pod-create-sa": {
 		StoreID:      "",
 		Name:         "pod-create-sa",
@@ -1260,6 +1819,14 @@ var expectedIdentities = map[string]graph.Identity{
 		Type:         "ServiceAccount",
 		Critical:     false,
 	},
+	"pod-debug-sa": {
+		StoreID:      "",
+		Name:         "pod-debug-sa",
+		IsNamespaced: true,
+		Namespace:    "default",
+		Type:         "ServiceAccount",
+		Critical:     false,
+	},
 	"pod-exec-sa": {
 		StoreID:      "",
 		Name:         "pod-exec-sa",
@@ -1356,6 +1923,14 @@ var expectedIdentities = map[string]graph.Identity{
 		Type:         "ServiceAccount",
 		Critical:     false,
 	},
+	"scoped-sa": {
+		StoreID:      "",
+		Name:         "scoped-sa",
+		IsNamespaced: true,
+		Namespace:    "default",
+		Type:         "ServiceAccount",
+		Critical:     false,
+	},
 	"system:nodes": {
 		StoreID:      "",
 		Name:         "system:nodes",
@@ -1412,4 +1987,20 @@ var expectedIdentities = map[string]graph.Identity{
 		Type:         "ServiceAccount",
 		Critical:     false,
 	},
+	"webhook-inject-sa": {
+		StoreID:      "",
+		Name:         "webhook-inject-sa",
+		IsNamespaced: true,
+		Namespace:    "default",
+		Type:         "ServiceAccount",
+		Critical:     false,
+	},
+	"workload-patch-sa": {
+		StoreID:      "",
+		Name:         "workload-patch-sa",
+		IsNamespaced: true,
+		Namespace:    "default",
+		Type:         "ServiceAccount",
+		Critical:     false,
+	},
 }